@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/app/server"
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/infra/database"
+	"zapcore/internal/infra/repository"
+	"zapcore/internal/infra/whatsapp"
+	"zapcore/pkg/logger"
+
+	"github.com/fatih/color"
+	"github.com/google/uuid"
+)
+
+// usage descreve os comandos disponíveis no binário
+const usage = `Uso: zapcore [comando] [argumentos]
+
+Sem comando, inicia o servidor HTTP (equivalente a "zapcore serve").
+
+Comandos:
+  serve                                   inicia o servidor HTTP
+  session list|create|connect|logout|delete
+                                          gerencia sessões do WhatsApp
+  migrate up|down|status                  gerencia as migrations do banco
+  webhook replay --url URL                reenvia eventos do log para um webhook
+  media gc|retention                      remove mídias sem referência ou vencidas pela retenção
+  export <sessão>                         exporta sessão, chats, contatos e mensagens em NDJSON
+  doctor                                  verifica banco, migrations, storage e store do WhatsApp
+
+Use "zapcore <comando> -h" para ver as opções de cada comando.`
+
+// commands mapeia os subcomandos administrativos para suas implementações
+var commands = map[string]func(cfg *config.Config, args []string) error{
+	"session": runSession,
+	"migrate": runMigrate,
+	"webhook": runWebhook,
+	"media":   runMedia,
+	"export":  runExport,
+	"doctor":  runDoctor,
+}
+
+// isHelp verifica se o argumento pede a ajuda
+func isHelp(arg string) bool {
+	return arg == "help" || arg == "-h" || arg == "--help"
+}
+
+// runCommand executa um subcomando administrativo
+func runCommand(cfg *config.Config, name string, args []string) error {
+	run, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("comando desconhecido: %s\n\n%s", name, usage)
+	}
+	return run(cfg, args)
+}
+
+// parseArgs interpreta flags em qualquer posição, retornando os argumentos posicionais
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// printError imprime um erro do comando no terminal
+func printError(err error) {
+	red := color.New(color.FgRed).SprintFunc()
+	fmt.Fprintf(os.Stderr, "%s %v\n", red("❌"), err)
+}
+
+// cliDeps agrupa as dependências compartilhadas pelos subcomandos, criadas sob demanda
+type cliDeps struct {
+	cfg            *config.Config
+	bunDB          *server.BunDB
+	sessionRepo    *repository.SessionRepository
+	storeManager   *whatsapp.StoreManager
+	whatsappClient *whatsapp.WhatsAppClient
+}
+
+// openDeps conecta ao banco e recusa operar sobre um esquema mais novo que o binário
+func openDeps(cfg *config.Config) (*cliDeps, error) {
+	bunDB, err := server.NewBunDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := database.NewMigrator(bunDB.GetDB()).EnsureCompatible(context.Background()); err != nil {
+		bunDB.Close()
+		return nil, err
+	}
+
+	return &cliDeps{
+		cfg:         cfg,
+		bunDB:       bunDB,
+		sessionRepo: repository.NewSessionRepository(bunDB.GetDB()),
+	}, nil
+}
+
+// Close libera as conexões abertas
+func (d *cliDeps) Close() {
+	if d.storeManager != nil {
+		d.storeManager.Close()
+	}
+	d.bunDB.Close()
+}
+
+// getStoreManager inicializa o store do whatsmeow no mesmo banco do servidor
+func (d *cliDeps) getStoreManager() (*whatsapp.StoreManager, error) {
+	if d.storeManager != nil {
+		return d.storeManager, nil
+	}
+
+	storeManager, err := whatsapp.NewStoreManager(d.bunDB.GetSQLDB(), logger.Get().GetZerolog())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao inicializar store manager do WhatsApp: %w", err)
+	}
+	d.storeManager = storeManager
+	return storeManager, nil
+}
+
+// getWhatsAppClient cria o cliente WhatsApp com os mesmos handlers de sessão e armazenamento do servidor
+func (d *cliDeps) getWhatsAppClient() (*whatsapp.WhatsAppClient, error) {
+	if d.whatsappClient != nil {
+		return d.whatsappClient, nil
+	}
+
+	storeManager, err := d.getStoreManager()
+	if err != nil {
+		return nil, err
+	}
+
+	db := d.bunDB.GetDB()
+	sessionHandler := whatsapp.NewSessionEventHandler(d.sessionRepo)
+	storageHandler := whatsapp.NewStorageHandler(
+		repository.NewMessageRepository(db),
+		repository.NewChatRepository(db),
+		repository.NewContactRepository(db),
+		nil,
+	)
+	readRule, err := message.NewReadRule(d.cfg.Receipts.ReadRule, d.cfg.Receipts.ReadThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao configurar confirmações de leitura: %w", err)
+	}
+	storageHandler.SetReceiptTracking(repository.NewReceiptRepository(db), readRule)
+	compositeHandler := whatsapp.NewCompositeEventHandler(sessionHandler, storageHandler)
+
+	d.whatsappClient = whatsapp.NewWhatsAppClient(storeManager.GetContainer(), d.sessionRepo, compositeHandler, nil)
+	return d.whatsappClient, nil
+}
+
+// resolveSession busca a sessão pelo ID ou pelo nome
+func (d *cliDeps) resolveSession(ctx context.Context, identifier string) (*session.Session, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return nil, fmt.Errorf("informe o ID ou o nome da sessão")
+	}
+
+	if id, err := uuid.Parse(identifier); err == nil {
+		return d.sessionRepo.GetByID(ctx, id)
+	}
+
+	sess, err := d.sessionRepo.GetByName(ctx, identifier)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, fmt.Errorf("sessão não encontrada com identificador '%s'", identifier)
+		}
+		return nil, err
+	}
+	return sess, nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/app/server"
+	"zapcore/internal/infra/database"
+	"zapcore/internal/infra/repository"
+	"zapcore/internal/infra/storage"
+	"zapcore/internal/infra/whatsapp"
+	"zapcore/pkg/logger"
+
+	"github.com/fatih/color"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// doctorTimeout limita cada verificação do doctor
+const doctorTimeout = 10 * time.Second
+
+// doctorReport acumula o resultado das verificações
+type doctorReport struct {
+	failures int
+}
+
+func (r *doctorReport) ok(check, detail string) {
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s %-18s %s\n", green("✅"), check, detail)
+}
+
+func (r *doctorReport) warn(check, detail string) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Printf("%s %-18s %s\n", yellow("⚠️ "), check, detail)
+}
+
+func (r *doctorReport) fail(check string, err error) {
+	red := color.New(color.FgRed).SprintFunc()
+	fmt.Printf("%s %-18s %v\n", red("❌"), check, err)
+	r.failures++
+}
+
+// runDoctor verifica banco, migrations, store do WhatsApp e armazenamento de mídias
+func runDoctor(cfg *config.Config, args []string) error {
+	report := &doctorReport{}
+
+	checkStorage(cfg, report)
+
+	bunDB, err := server.NewBunDB(cfg)
+	if err != nil {
+		report.fail("banco de dados", err)
+		return fmt.Errorf("%d verificação(ões) falharam", report.failures)
+	}
+	defer bunDB.Close()
+	report.ok("banco de dados", fmt.Sprintf("%s:%s/%s", cfg.Database.Host, cfg.Database.Port, cfg.Database.Name))
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	status, err := database.NewMigrator(bunDB.GetDB()).EnsureCompatible(ctx)
+	switch {
+	case err != nil:
+		report.fail("migrations", err)
+	case status.Pending > 0:
+		report.warn("migrations", fmt.Sprintf("%d pendente(s), execute \"zapcore migrate up\"", status.Pending))
+	default:
+		report.ok("migrations", fmt.Sprintf("%d aplicada(s)", len(status.Migrations)))
+	}
+
+	checkWhatsAppStore(ctx, bunDB, report)
+
+	if report.failures > 0 {
+		return fmt.Errorf("%d verificação(ões) falharam", report.failures)
+	}
+	return nil
+}
+
+// checkStorage verifica o acesso ao armazenamento de mídias do driver configurado
+func checkStorage(cfg *config.Config, report *doctorReport) {
+	driver := cfg.GetStorageDriver()
+	if driver == storage.DriverNone {
+		report.warn("storage", "desabilitado")
+		return
+	}
+
+	backend, err := storage.NewBackend(cfg)
+	if err != nil {
+		report.fail("storage", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	if err := backend.HealthCheck(ctx); err != nil {
+		report.fail("storage", err)
+		return
+	}
+
+	switch driver {
+	case storage.DriverMinIO:
+		report.ok("storage", fmt.Sprintf("minio %s/%s", cfg.MinIO.Endpoint, cfg.MinIO.DefaultBucket))
+	case storage.DriverS3:
+		report.ok("storage", fmt.Sprintf("s3 %s/%s", cfg.Storage.S3.Endpoint, cfg.Storage.S3.Bucket))
+	default:
+		report.ok("storage", fmt.Sprintf("local %s", cfg.WhatsApp.MediaPath))
+	}
+}
+
+// checkWhatsAppStore compara os dispositivos do store do whatsmeow com as sessões pareadas
+func checkWhatsAppStore(ctx context.Context, bunDB *server.BunDB, report *doctorReport) {
+	storeManager, err := whatsapp.NewStoreManager(bunDB.GetSQLDB(), logger.Get().GetZerolog())
+	if err != nil {
+		report.fail("whatsapp store", err)
+		return
+	}
+	defer storeManager.Close()
+
+	devices, err := storeManager.GetContainer().GetAllDevices(ctx)
+	if err != nil {
+		report.fail("whatsapp store", fmt.Errorf("erro ao listar dispositivos: %w", err))
+		return
+	}
+
+	users := make(map[string]struct{}, len(devices))
+	for _, device := range devices {
+		if device.ID != nil {
+			users[device.ID.User] = struct{}{}
+		}
+	}
+
+	sessions, err := repository.NewSessionRepository(bunDB.GetDB()).GetActiveSessions(ctx)
+	if err != nil {
+		report.fail("whatsapp store", fmt.Errorf("erro ao listar sessões: %w", err))
+		return
+	}
+
+	// Sessões com JID mas sem dispositivo no store precisarão de um novo pareamento
+	var orphans []string
+	for _, sess := range sessions {
+		if sess.JID == "" {
+			continue
+		}
+		jid, err := types.ParseJID(sess.JID)
+		if err != nil {
+			orphans = append(orphans, sess.Name)
+			continue
+		}
+		if _, ok := users[jid.User]; !ok {
+			orphans = append(orphans, sess.Name)
+		}
+	}
+
+	if len(orphans) > 0 {
+		report.warn("whatsapp store", fmt.Sprintf("%d dispositivo(s); sessões sem dispositivo: %v", len(devices), orphans))
+		return
+	}
+	report.ok("whatsapp store", fmt.Sprintf("%d dispositivo(s)", len(devices)))
+}
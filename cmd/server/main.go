@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"runtime"
 	"time"
 
 	"zapcore/internal/app/config"
 	"zapcore/internal/app/server"
 	"zapcore/pkg/logger"
+	"zapcore/pkg/tracing"
 
 	"github.com/fatih/color"
 )
@@ -27,14 +30,31 @@ func printStartupInfo() {
 }
 
 func main() {
+	// Sem argumentos o binário inicia o servidor; os demais comandos são administrativos
+	command := "serve"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	if isHelp(command) {
+		fmt.Println(usage)
+		return
+	}
+
 	// Exibir informações de inicialização
-	printStartupInfo()
+	if command == "serve" {
+		printStartupInfo()
+	}
 
 	// Carregar configurações
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Printf("❌ Erro ao carregar configurações: %v\n", err)
-		return
+		os.Exit(1)
+	}
+
+	// Comandos administrativos exibem apenas avisos e erros do logger
+	if command != "serve" {
+		cfg.Log.Level = "warn"
 	}
 
 	// Inicializar logger centralizado
@@ -65,6 +85,33 @@ func main() {
 		}).Fatal().Err(err).Msg("❌ Configuração inválida")
 	}
 
+	// Subcomandos administrativos: zapcore session|migrate|webhook|media|export|doctor
+	if command != "serve" {
+		if err := runCommand(cfg, command, os.Args[2:]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Inicializar tracing (propagação W3C sempre ativa; exportador configurável)
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.Endpoint,
+		Insecure:       cfg.Tracing.Insecure,
+		FilePath:       cfg.Tracing.FilePath,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: "1.0.0",
+		Environment:    cfg.Server.Env,
+	})
+	if err != nil {
+		logger.WithFields(map[string]interface{}{
+			"component": "main",
+			"phase":     "initialization",
+		}).Fatal().Err(err).Msg("❌ Erro ao inicializar tracing")
+	}
+
 	// Criar servidor
 	logger.WithFields(map[string]interface{}{
 		"component": "main",
@@ -89,4 +136,11 @@ func main() {
 			"phase":     "startup",
 		}).Fatal().Err(err).Msg("❌ Erro ao iniciar servidor")
 	}
+
+	// Descarregar spans pendentes
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout.Shutdown)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Get().Error().Err(err).Msg("Erro ao finalizar tracing")
+	}
 }
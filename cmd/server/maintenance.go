@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/domain/eventstream"
+	"zapcore/internal/domain/media"
+	"zapcore/internal/infra/repository"
+	"zapcore/internal/infra/storage"
+	mediaUseCase "zapcore/internal/usecases/media"
+	sessionUseCase "zapcore/internal/usecases/session"
+	webhookUseCase "zapcore/internal/usecases/webhook"
+
+	"github.com/fatih/color"
+	"github.com/google/uuid"
+)
+
+// runWebhook executa o subcomando "webhook replay"
+func runWebhook(cfg *config.Config, args []string) error {
+	const webhookUsage = `Uso: zapcore webhook replay --url URL [--session id|nome] [--type tipo,...] [--after seq] [--limit n] [--dry-run]`
+
+	if len(args) == 0 || args[0] != "replay" {
+		return fmt.Errorf("comando de webhook inválido\n\n%s", webhookUsage)
+	}
+
+	fs := flag.NewFlagSet("webhook replay", flag.ContinueOnError)
+	url := fs.String("url", "", "URL que receberá os eventos")
+	sessionArg := fs.String("session", "", "filtra eventos de uma sessão (ID ou nome)")
+	types := fs.String("type", "", "filtra tipos de evento, separados por vírgula")
+	after := fs.Int64("after", 0, "reenvia eventos com seq maior que este valor")
+	limit := fs.Int("limit", 0, "quantidade máxima de eventos (0 = todos)")
+	dryRun := fs.Bool("dry-run", false, "apenas conta os eventos, sem enviar")
+	if _, err := parseArgs(fs, args[1:]); err != nil {
+		return err
+	}
+	if *url == "" && !*dryRun {
+		return fmt.Errorf("informe --url\n\n%s", webhookUsage)
+	}
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+	if cfg.Events.LogBackend != "postgres" {
+		fmt.Println(yellow("⚠️  EVENTS_LOG_BACKEND não é postgres: o log persistido pode estar vazio ou desatualizado"))
+	}
+
+	deps, err := openDeps(cfg)
+	if err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	filter := eventstream.Filter{}
+	if *sessionArg != "" {
+		sess, err := deps.resolveSession(ctx, *sessionArg)
+		if err != nil {
+			return err
+		}
+		filter.SessionIDs = []uuid.UUID{sess.ID}
+	}
+	for _, t := range strings.Split(*types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			filter.Types = append(filter.Types, t)
+		}
+	}
+
+	eventLog := repository.NewEventLogRepository(deps.bunDB.GetDB(), cfg.Events.LogSize)
+	response, err := webhookUseCase.NewReplayUseCase(eventLog, nil).Execute(ctx, &webhookUseCase.ReplayRequest{
+		URL:      *url,
+		Filter:   filter,
+		AfterSeq: *after,
+		Limit:    *limit,
+		DryRun:   *dryRun,
+	})
+	if err != nil {
+		if response != nil && response.Sent > 0 {
+			fmt.Printf("%d evento(s) entregue(s); retome com --after %d\n", response.Sent, response.LastSeq)
+		}
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s %d evento(s), último seq %d\n", green("✅ "+response.Message+":"), response.Sent, response.LastSeq)
+	return nil
+}
+
+// runMedia executa os subcomandos "media gc" e "media retention"
+func runMedia(cfg *config.Config, args []string) error {
+	const mediaUsage = `Uso: zapcore media gc [--prefix caminho] [--min-age 24h] [--reconcile] [--dry-run]
+       zapcore media retention [--session id|nome] [--limit n] [--dry-run]`
+
+	if len(args) == 0 || (args[0] != "gc" && args[0] != "retention") {
+		return fmt.Errorf("comando de media inválido\n\n%s", mediaUsage)
+	}
+	if args[0] == "retention" {
+		return runMediaRetention(cfg, args[1:])
+	}
+
+	fs := flag.NewFlagSet("media gc", flag.ContinueOnError)
+	prefix := fs.String("prefix", "", "limita a coleta a um prefixo do bucket")
+	minAge := fs.Duration("min-age", mediaUseCase.DefaultGCMinAge, "idade mínima das mídias removidas")
+	reconcile := fs.Bool("reconcile", false, "procura também mensagens cujo objeto não existe mais no bucket")
+	dryRun := fs.Bool("dry-run", false, "apenas lista as mídias órfãs, sem remover")
+	if _, err := parseArgs(fs, args[1:]); err != nil {
+		return err
+	}
+
+	if cfg.GetStorageDriver() == storage.DriverNone {
+		return fmt.Errorf("armazenamento de mídias desabilitado (STORAGE_DRIVER=none)")
+	}
+
+	deps, err := openDeps(cfg)
+	if err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	backend, err := storage.NewBackend(cfg)
+	if err != nil {
+		return err
+	}
+
+	db := deps.bunDB.GetDB()
+	messageRepo := repository.NewMessageRepository(db)
+	gc := mediaUseCase.NewGCUseCase(storage.NewMediaStorage(backend, cfg.Storage.URLExpiry), messageRepo, repository.NewTemplateRepository(db))
+	gc.SetBlobRepository(repository.NewMediaBlobRepository(db))
+	gc.SetMissingMarker(messageRepo)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	response, err := gc.Execute(ctx, &mediaUseCase.GCRequest{
+		Prefix:    *prefix,
+		MinAge:    *minAge,
+		DryRun:    *dryRun,
+		Reconcile: *reconcile,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		for _, path := range response.Orphans {
+			fmt.Println(path)
+		}
+		for _, path := range response.Missing {
+			fmt.Println("ausente:", path)
+		}
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s %d objeto(s) verificado(s), %.2f MB liberado(s)\n",
+		green("✅ "+response.Message+":"), response.Scanned, float64(response.FreedBytes)/1024/1024)
+	return nil
+}
+
+// runMediaRetention executa o subcomando "media retention"
+func runMediaRetention(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("media retention", flag.ContinueOnError)
+	sessionArg := fs.String("session", "", "limita a retenção a uma sessão (ID ou nome)")
+	limit := fs.Int("limit", mediaUseCase.DefaultRetentionBatch, "quantidade máxima de mídias removidas")
+	dryRun := fs.Bool("dry-run", false, "apenas conta as mídias vencidas, sem remover")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if cfg.GetStorageDriver() == storage.DriverNone {
+		return fmt.Errorf("armazenamento de mídias desabilitado (STORAGE_DRIVER=none)")
+	}
+
+	defaults, err := media.ParseRetentionRules(cfg.Media.RetentionDefault)
+	if err != nil {
+		return fmt.Errorf("MEDIA_RETENTION_DEFAULT inválida: %w", err)
+	}
+
+	deps, err := openDeps(cfg)
+	if err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	backend, err := storage.NewBackend(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	req := &mediaUseCase.RetentionRequest{Limit: *limit, DryRun: *dryRun}
+	if *sessionArg != "" {
+		sess, err := deps.resolveSession(ctx, *sessionArg)
+		if err != nil {
+			return err
+		}
+		req.SessionID = &sess.ID
+	}
+
+	db := deps.bunDB.GetDB()
+	retention := mediaUseCase.NewRetentionUseCase(
+		repository.NewMessageRepository(db),
+		deps.sessionRepo,
+		repository.NewTenantRepository(db),
+		storage.NewMediaStorage(backend, cfg.Storage.URLExpiry),
+		defaults,
+	)
+	// Os conteúdos liberados são removidos pelo "media gc"
+	retention.SetBlobRepository(repository.NewMediaBlobRepository(db), nil)
+
+	response, err := retention.Execute(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s %d sessão(ões), %d falha(s), %.2f MB liberado(s)\n",
+		green("✅ "+response.Message+":"), response.Sessions, response.Failed, float64(response.FreedBytes)/1024/1024)
+	if response.Released > 0 {
+		fmt.Printf("%d conteúdo(s) deduplicado(s) liberado(s); execute \"zapcore media gc\" para removê-los\n", response.Released)
+	}
+	return nil
+}
+
+// runExport executa o subcomando "export"
+func runExport(cfg *config.Config, args []string) error {
+	const exportUsage = `Uso: zapcore export <id|nome> [--out arquivo.ndjson] [--skip-messages]`
+
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	out := fs.String("out", "", "arquivo de saída (padrão: stdout)")
+	skipMessages := fs.Bool("skip-messages", false, "exporta apenas sessão, chats e contatos")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("informe a sessão\n\n%s", exportUsage)
+	}
+
+	deps, err := openDeps(cfg)
+	if err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	ctx := context.Background()
+	sess, err := deps.resolveSession(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	output := os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("erro ao criar arquivo de exportação: %w", err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	writer := bufio.NewWriter(output)
+	db := deps.bunDB.GetDB()
+	export := sessionUseCase.NewExportUseCase(
+		deps.sessionRepo,
+		repository.NewChatRepository(db),
+		repository.NewContactRepository(db),
+		repository.NewMessageRepository(db),
+	)
+
+	start := time.Now()
+	response, err := export.Execute(ctx, &sessionUseCase.ExportRequest{
+		SessionID:    sess.ID,
+		SkipMessages: *skipMessages,
+		Writer:       writer,
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("erro ao gravar exportação: %w", err)
+	}
+
+	// Com saída em stdout o resumo vai para stderr para não corromper o NDJSON
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Fprintf(os.Stderr, "%s %s: %d chat(s), %d contato(s), %d mensagem(ns) em %s\n",
+		green("✅ Sessão exportada"), sess.Name, response.Chats, response.Contacts, response.Messages,
+		time.Since(start).Round(time.Millisecond))
+	return nil
+}
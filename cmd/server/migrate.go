@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/app/server"
+	"zapcore/internal/infra/database"
+
+	"github.com/fatih/color"
+)
+
+// migrateUsage descreve o subcomando migrate
+const migrateUsage = `Uso: zapcore migrate <comando>
+
+Comandos:
+  up      aplica todas as migrations pendentes
+  down    reverte o último grupo de migrations aplicado
+  status  lista as migrations e o estado de cada uma`
+
+// runMigrate executa o subcomando "migrate up|down|status"
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("comando de migrate inválido\n\n%s", migrateUsage)
+	}
+
+	bunDB, err := server.NewBunDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer bunDB.Close()
+
+	ctx := context.Background()
+	migrator := database.NewMigrator(bunDB.GetDB())
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	switch args[0] {
+	case "up":
+		group, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if group.IsZero() {
+			fmt.Println(green("✅ Nenhuma migration pendente"))
+			return nil
+		}
+		fmt.Printf("%s %s\n", green("✅ Migrations aplicadas:"), group.Migrations)
+
+	case "down":
+		group, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if group.IsZero() {
+			fmt.Println(yellow("Nenhuma migration para reverter"))
+			return nil
+		}
+		fmt.Printf("%s %s\n", yellow("↩️ Migrations revertidas:"), group.Migrations)
+
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(status)
+
+	default:
+		return fmt.Errorf("comando de migrate desconhecido: %s\n\n%s", args[0], migrateUsage)
+	}
+
+	return nil
+}
+
+// printMigrationStatus imprime a tabela de migrations
+func printMigrationStatus(status *database.SchemaStatus) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSÃO\tDESCRIÇÃO\tGRUPO\tAPLICADA EM")
+	for _, migration := range status.Migrations {
+		if !migration.Applied {
+			fmt.Fprintf(w, "%s\t%s\t-\t%s\n", migration.Name, migration.Comment, yellow("pendente"))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", migration.Name, migration.Comment, migration.GroupID,
+			green(migration.MigratedAt.Format("2006-01-02 15:04:05")))
+	}
+	for _, name := range status.Unknown {
+		fmt.Fprintf(w, "%s\t%s\t-\t%s\n", name, "?", red("desconhecida (versão mais nova)"))
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d pendente(s)\n", status.Pending)
+}
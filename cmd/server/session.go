@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"os/user"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/domain/audit"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
+	"zapcore/internal/infra/repository"
+	auditUseCase "zapcore/internal/usecases/audit"
+	sessionUseCase "zapcore/internal/usecases/session"
+	tenantUseCase "zapcore/internal/usecases/tenant"
+
+	"github.com/fatih/color"
+)
+
+// sessionUsage descreve o subcomando session
+const sessionUsage = `Uso: zapcore session <comando> [argumentos]
+
+Comandos:
+  list                                      lista as sessões cadastradas
+  create <nome> [--tenant ID]               cria uma nova sessão (tenant "default" se omitido)
+  connect <id|nome> [--timeout 2m]          conecta a sessão e exibe o QR Code no terminal
+  logout <id|nome>                          desvincula o dispositivo da conta do WhatsApp
+  delete <id|nome> --yes [--logout]         remove a sessão (--logout desvincula antes)`
+
+// runSession executa o subcomando "session"
+func runSession(cfg *config.Config, args []string) error {
+	if len(args) == 0 || isHelp(args[0]) {
+		fmt.Println(sessionUsage)
+		return nil
+	}
+
+	deps, err := openDeps(cfg)
+	if err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	switch args[0] {
+	case "list":
+		return sessionList(deps)
+	case "create":
+		return sessionCreate(deps, args[1:])
+	case "connect":
+		return sessionConnect(deps, args[1:])
+	case "logout":
+		return sessionLogout(deps, args[1:])
+	case "delete":
+		return sessionDelete(deps, args[1:])
+	default:
+		return fmt.Errorf("comando de session desconhecido: %s\n\n%s", args[0], sessionUsage)
+	}
+}
+
+// sessionList imprime a tabela de sessões
+func sessionList(deps *cliDeps) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	response, err := sessionUseCase.NewListUseCase(deps.sessionRepo).Execute(context.Background(), &sessionUseCase.ListRequest{Limit: 1000})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNOME\tSTATUS\tJID\tATIVA")
+	for _, sess := range response.Sessions {
+		status := yellow(string(sess.Status))
+		if sess.IsConnected() {
+			status = green(string(sess.Status))
+		}
+		jid := sess.JID
+		if jid == "" {
+			jid = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", sess.ID, sess.Name, status, jid, sess.IsActive)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d sessão(ões)\n", len(response.Sessions))
+	return nil
+}
+
+// sessionCreate cria uma nova sessão
+func sessionCreate(deps *cliDeps, args []string) error {
+	fs := flag.NewFlagSet("session create", flag.ContinueOnError)
+	webhook := fs.String("webhook", "", "URL de webhook da sessão")
+	tenantID := fs.String("tenant", tenant.DefaultTenantID, "tenant dono da sessão")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("uso: zapcore session create <nome> [--webhook URL] [--tenant ID]")
+	}
+
+	tenantRepo := repository.NewTenantRepository(deps.bunDB.GetDB())
+	quotas := tenantUseCase.NewQuotaUseCase(tenantRepo, tenantRepo, deps.sessionRepo)
+	response, err := sessionUseCase.NewCreateUseCase(deps.sessionRepo, quotas).Execute(context.Background(), &sessionUseCase.CreateRequest{
+		TenantID: *tenantID,
+		Name:     positional[0],
+		Webhook:  *webhook,
+	})
+	if err != nil {
+		return err
+	}
+	deps.recordAudit(audit.ActionSessionCreate, response.Session, nil)
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s %s (%s)\n", green("✅ Sessão criada:"), response.Session.Name, response.Session.ID)
+	return nil
+}
+
+// sessionConnect conecta a sessão e aguarda o pareamento pelo QR Code exibido no terminal
+func sessionConnect(deps *cliDeps, args []string) error {
+	fs := flag.NewFlagSet("session connect", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 2*time.Minute, "tempo máximo de espera pelo pareamento")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("uso: zapcore session connect <id|nome> [--timeout 2m]")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sess, err := deps.resolveSession(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := deps.getWhatsAppClient()
+	if err != nil {
+		return err
+	}
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	fmt.Println(yellow("⚠️  Não conecte a mesma sessão no servidor enquanto este comando estiver em execução"))
+
+	response, err := sessionUseCase.NewConnectUseCase(deps.sessionRepo, client).Execute(ctx, &sessionUseCase.ConnectRequest{SessionID: sess.ID})
+	deps.recordAudit(audit.ActionSessionConnect, sess, err)
+	if err != nil {
+		return err
+	}
+	if response.Message == "Sessão já está conectada" {
+		fmt.Println(green("✅ " + response.Message))
+		return nil
+	}
+
+	fmt.Println("Aguardando pareamento... escaneie o QR Code com o WhatsApp (Ctrl+C para cancelar)")
+
+	// A conexão é assíncrona: o QR Code é impresso pelo cliente e o pareamento é detectado por polling
+	defer client.Disconnect(context.Background(), sess.ID)
+
+	deadline := time.NewTimer(*timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("pareamento cancelado")
+		case <-deadline.C:
+			return fmt.Errorf("tempo de espera pelo pareamento esgotado (%s)", *timeout)
+		case <-ticker.C:
+			if !client.IsLoggedIn(ctx, sess.ID) || !client.IsConnected(ctx, sess.ID) {
+				continue
+			}
+
+			updated, err := deps.sessionRepo.GetByID(ctx, sess.ID)
+			if err != nil || updated.JID == "" {
+				continue
+			}
+
+			fmt.Printf("%s %s (%s)\n", green("✅ Sessão pareada:"), updated.Name, updated.JID)
+			fmt.Println("Inicie o servidor para manter a sessão conectada; ela será reconectada automaticamente.")
+			return nil
+		}
+	}
+}
+
+// sessionLogout desvincula o dispositivo da sessão
+func sessionLogout(deps *cliDeps, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("uso: zapcore session logout <id|nome>")
+	}
+
+	ctx := context.Background()
+	sess, err := deps.resolveSession(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := deps.getWhatsAppClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := sessionUseCase.NewLogoutUseCase(deps.sessionRepo, client).Execute(ctx, &sessionUseCase.LogoutRequest{SessionID: sess.ID})
+	deps.recordAudit(audit.ActionSessionLogout, sess, err)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Println(green("✅ " + response.Message))
+	return nil
+}
+
+// sessionDelete remove a sessão, exigindo confirmação explícita
+func sessionDelete(deps *cliDeps, args []string) error {
+	fs := flag.NewFlagSet("session delete", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "confirma a remoção")
+	logout := fs.Bool("logout", false, "desvincula o dispositivo antes de remover")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("uso: zapcore session delete <id|nome> --yes [--logout]")
+	}
+
+	ctx := context.Background()
+	sess, err := deps.resolveSession(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if !*yes {
+		return fmt.Errorf("a remoção da sessão '%s' é irreversível; repita o comando com --yes para confirmar", sess.Name)
+	}
+
+	client, err := deps.getWhatsAppClient()
+	if err != nil {
+		return err
+	}
+
+	err = sessionUseCase.NewDeleteUseCase(deps.sessionRepo, client).Execute(ctx, &sessionUseCase.DeleteRequest{
+		SessionID: sess.ID,
+		Logout:    *logout,
+	})
+	deps.recordAudit(audit.ActionSessionDelete, sess, err)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s %s (%s)\n", green("✅ Sessão removida:"), sess.Name, sess.ID)
+	return nil
+}
+
+// recordAudit registra na trilha de auditoria uma ação executada pela CLI, identificando
+// o usuário do sistema operacional e a máquina em que o comando rodou
+func (d *cliDeps) recordAudit(action audit.Action, sess *session.Session, err error) {
+	entry := audit.NewEntry(action, audit.ActorCLI, cliActor())
+	entry.TenantID = sess.TenantID
+	entry.SessionID = &sess.ID
+	entry.ResourceID = sess.ID.String()
+	entry.Details = map[string]any{"name": sess.Name}
+	entry.SetError(err)
+
+	recorder := auditUseCase.NewRecordUseCase(repository.NewAuditRepository(d.bunDB.GetDB()), d.sessionRepo)
+	recorder.Record(context.Background(), entry)
+}
+
+// cliActor identifica quem executou o comando, no formato cli:<usuário>@<máquina>
+func cliActor() string {
+	name := "desconhecido"
+	if current, err := user.Current(); err == nil {
+		name = current.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		name += "@" + host
+	}
+	return "cli:" + name
+}
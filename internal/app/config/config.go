@@ -17,8 +17,17 @@ type Config struct {
 	WhatsApp  WhatsAppConfig
 	CORS      CORSConfig
 	RateLimit RateLimitConfig
+	Redis     RedisConfig
+	SendLimit SendLimitConfig
+	Receipts  ReceiptsConfig
 	Timeout   TimeoutConfig
 	MinIO     MinIOConfig
+	Storage   StorageConfig
+	Media     MediaConfig
+	Events    EventsConfig
+	Sinks     SinksConfig
+	Metrics   MetricsConfig
+	Tracing   TracingConfig
 }
 
 // ServerConfig configurações do servidor HTTP
@@ -39,6 +48,7 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+	AutoMigrate     bool // aplica migrations pendentes na inicialização
 }
 
 // LogConfig configurações de logging
@@ -70,16 +80,49 @@ type CORSConfig struct {
 	AllowedHeaders []string
 }
 
-// RateLimitConfig configurações de rate limiting
+// RateLimitConfig configurações de rate limiting (token bucket); Requests zero desativa a política
 type RateLimitConfig struct {
-	Requests int
-	Window   time.Duration
+	Backend         string // memory, postgres ou redis
+	Requests        int    // por IP do cliente
+	Window          time.Duration
+	Burst           int // capacidade extra do bucket por IP (zero usa Requests)
+	KeyRequests     int // por chave de API
+	KeyWindow       time.Duration
+	SendRequests    int // envios de mensagens por chave de API
+	SendWindow      time.Duration
+	ReadRequests    int // leituras (GET) por chave de API
+	ReadWindow      time.Duration
+	SessionRequests int // envios de mensagens por sessão
+	SessionWindow   time.Duration
+}
+
+// SendLimitConfig limites de envio padrão de cada sessão, contra bloqueios do WhatsApp; zero desativa o limite
+type SendLimitConfig struct {
+	MessagesPerMinute int
+	NewChatsPerDay    int
+	RecipientGap      time.Duration // intervalo mínimo entre mensagens ao mesmo destinatário
+	MaxQueue          time.Duration // espera máxima na fila antes de recusar o envio
+}
+
+// ReceiptsConfig regra de agregação das confirmações de leitura em grupos e listas de transmissão
+type ReceiptsConfig struct {
+	ReadRule      string // any, all ou threshold
+	ReadThreshold int    // percentual de destinatários que precisam ler no modo threshold
+}
+
+// RedisConfig configurações do Redis
+type RedisConfig struct {
+	Host     string
+	Port     string
+	Password string
+	DB       int
 }
 
 // TimeoutConfig configurações de timeout
 type TimeoutConfig struct {
 	Request  time.Duration
 	Shutdown time.Duration
+	QRStuck  time.Duration // QR Code sem leitura é reportado como travado após esse tempo
 }
 
 // MinIOConfig configurações do MinIO
@@ -92,6 +135,105 @@ type MinIOConfig struct {
 	DefaultBucket   string
 }
 
+// StorageConfig configurações do armazenamento de mídias
+type StorageConfig struct {
+	Driver     string        // minio, s3, local ou none; vazio usa minio com MINIO_ENABLED=true
+	URLExpiry  time.Duration // validade das URLs de download das mídias
+	PublicURL  string        // URL do zapcore nos links assinados do driver local; vazio usa o endereço do servidor
+	SigningKey string        // chave dos links assinados do driver local; vazio deriva da API_KEY
+	S3         S3Config
+}
+
+// S3Config configurações do driver S3 (AWS, R2, GCS interop)
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	PathStyle       bool // endereça o bucket no caminho em vez do subdomínio
+}
+
+// MediaConfig configurações do processamento das mídias enviadas
+type MediaConfig struct {
+	Thumbnails   bool   // gera thumbnails embutidos e previews das mídias enviadas
+	PreviewSize  int    // lado máximo, em pixels, do preview armazenado junto à mídia
+	FFmpegPath   string // executável do ffmpeg; sem ele vídeos seguem sem thumbnail e áudios não viram mensagem de voz
+	PdftoppmPath string // executável do pdftoppm; sem ele PDFs usam a imagem embutida
+	Normalize    bool   // normaliza imagens, vídeos e stickers antes do envio (padrão das requisições)
+	ImageMaxSide int    // lado máximo, em pixels, das imagens enviadas
+
+	RetryTimeout        time.Duration // espera pelo reenvio da mídia expirada pelo aparelho do remetente
+	Backfill            bool          // baixa periodicamente as mídias recebidas que não foram armazenadas
+	BackfillInterval    time.Duration // intervalo entre as varreduras de mídias pendentes
+	BackfillBatch       int           // mensagens por varredura
+	BackfillConcurrency int           // downloads simultâneos em cada varredura
+	BackfillMaxAttempts int           // tentativas por mensagem antes de desistir
+	BackfillMaxAge      time.Duration // ignora mensagens mais antigas; zero não limita
+
+	Dedup       bool          // grava cada conteúdo uma única vez por tenant, endereçado pelo SHA-256
+	UploadReuse time.Duration // reaproveita uploads ao WhatsApp do mesmo conteúdo por este período; zero desativa
+
+	Retention         bool          // remove periodicamente as mídias vencidas pelas políticas de retenção
+	RetentionInterval time.Duration // intervalo entre as execuções da retenção
+	RetentionBatch    int           // mídias removidas por execução
+	RetentionDefault  string        // política das sessões e tenants sem a própria, ex.: "video:inbound=30,*=365"
+
+	Scan ScanConfig
+}
+
+// ScanConfig configurações da verificação de malware das mídias recebidas
+type ScanConfig struct {
+	Driver       string        // none, clamd ou http
+	Types        []string      // tipos de mídia verificados: image, video, audio, document, sticker
+	Timeout      time.Duration // tempo máximo de cada verificação
+	FailClosed   bool          // coloca em quarentena as mídias que não puderam ser verificadas
+	ClamdAddress string        // tcp://host:porta ou unix:///caminho/do/socket
+	HTTPURL      string        // endpoint que recebe o conteúdo e responde {"verdict","signature"}
+	HTTPToken    string        // enviado como Bearer ao endpoint HTTP
+	MaxSize      int64         // mídias maiores não são verificadas; zero não limita
+}
+
+// EventsConfig configurações do stream de eventos em tempo real
+type EventsConfig struct {
+	LogBackend       string // memory ou postgres
+	LogSize          int
+	SubscriberBuffer int
+}
+
+// MetricsConfig configurações do endpoint de métricas Prometheus
+type MetricsConfig struct {
+	Enabled bool
+	Token   string // token próprio, separado da API Key
+}
+
+// TracingConfig configurações do tracing OpenTelemetry
+type TracingConfig struct {
+	Exporter    string // none, stdout, file, otlp-grpc ou otlp-http
+	Endpoint    string // host:porta do coletor OTLP (vazio usa OTEL_EXPORTER_OTLP_ENDPOINT)
+	Insecure    bool
+	FilePath    string
+	SampleRatio float64
+	ServiceName string
+}
+
+// SinksConfig configurações dos sinks de eventos (NATS, RabbitMQ, Kafka)
+type SinksConfig struct {
+	Subject        string
+	Types          []string
+	NATSURL        string
+	NATSStream     string
+	AMQPURL        string
+	AMQPExchange   string
+	KafkaBrokers   string
+	KafkaTopic     string
+	BufferDir      string
+	BufferMaxBytes int64
+	QueueSize      int
+	EnqueueTimeout time.Duration
+}
+
 // Load carrega as configurações usando Viper
 func Load() (*Config, error) {
 	// Configurar Viper para ler arquivo .env
@@ -129,6 +271,7 @@ func Load() (*Config, error) {
 		MaxOpenConns:    viper.GetInt("DB_MAX_OPEN_CONNS"),
 		MaxIdleConns:    viper.GetInt("DB_MAX_IDLE_CONNS"),
 		ConnMaxLifetime: viper.GetDuration("DB_CONN_MAX_LIFETIME"),
+		AutoMigrate:     viper.GetBool("DB_AUTO_MIGRATE"),
 	}
 
 	// Configurações de log
@@ -162,14 +305,84 @@ func Load() (*Config, error) {
 
 	// Configurações de rate limiting
 	config.RateLimit = RateLimitConfig{
-		Requests: viper.GetInt("RATE_LIMIT_REQUESTS"),
-		Window:   viper.GetDuration("RATE_LIMIT_WINDOW"),
+		Backend:         viper.GetString("RATE_LIMIT_BACKEND"),
+		Requests:        viper.GetInt("RATE_LIMIT_REQUESTS"),
+		Window:          viper.GetDuration("RATE_LIMIT_WINDOW"),
+		Burst:           viper.GetInt("RATE_LIMIT_BURST"),
+		KeyRequests:     viper.GetInt("RATE_LIMIT_KEY_REQUESTS"),
+		KeyWindow:       viper.GetDuration("RATE_LIMIT_KEY_WINDOW"),
+		SendRequests:    viper.GetInt("RATE_LIMIT_SEND_REQUESTS"),
+		SendWindow:      viper.GetDuration("RATE_LIMIT_SEND_WINDOW"),
+		ReadRequests:    viper.GetInt("RATE_LIMIT_READ_REQUESTS"),
+		ReadWindow:      viper.GetDuration("RATE_LIMIT_READ_WINDOW"),
+		SessionRequests: viper.GetInt("RATE_LIMIT_SESSION_REQUESTS"),
+		SessionWindow:   viper.GetDuration("RATE_LIMIT_SESSION_WINDOW"),
+	}
+
+	// Configurações do Redis
+	config.Redis = RedisConfig{
+		Host:     viper.GetString("REDIS_HOST"),
+		Port:     viper.GetString("REDIS_PORT"),
+		Password: viper.GetString("REDIS_PASSWORD"),
+		DB:       viper.GetInt("REDIS_DB"),
+	}
+
+	// Configurações dos limites de envio
+	config.SendLimit = SendLimitConfig{
+		MessagesPerMinute: viper.GetInt("SEND_LIMIT_MESSAGES_PER_MINUTE"),
+		NewChatsPerDay:    viper.GetInt("SEND_LIMIT_NEW_CHATS_PER_DAY"),
+		RecipientGap:      viper.GetDuration("SEND_LIMIT_RECIPIENT_GAP"),
+		MaxQueue:          viper.GetDuration("SEND_LIMIT_MAX_QUEUE"),
+	}
+
+	// Configurações das confirmações de leitura
+	config.Receipts = ReceiptsConfig{
+		ReadRule:      viper.GetString("RECEIPT_READ_RULE"),
+		ReadThreshold: viper.GetInt("RECEIPT_READ_THRESHOLD"),
+	}
+
+	// Configurações do processamento de mídia
+	config.Media = MediaConfig{
+		Thumbnails:   viper.GetBool("MEDIA_THUMBNAILS_ENABLED"),
+		PreviewSize:  viper.GetInt("MEDIA_PREVIEW_SIZE"),
+		FFmpegPath:   viper.GetString("MEDIA_FFMPEG_PATH"),
+		PdftoppmPath: viper.GetString("MEDIA_PDFTOPPM_PATH"),
+		Normalize:    viper.GetBool("MEDIA_NORMALIZE_ENABLED"),
+		ImageMaxSide: viper.GetInt("MEDIA_IMAGE_MAX_SIDE"),
+
+		RetryTimeout:        viper.GetDuration("MEDIA_RETRY_TIMEOUT"),
+		Backfill:            viper.GetBool("MEDIA_BACKFILL_ENABLED"),
+		BackfillInterval:    viper.GetDuration("MEDIA_BACKFILL_INTERVAL"),
+		BackfillBatch:       viper.GetInt("MEDIA_BACKFILL_BATCH"),
+		BackfillConcurrency: viper.GetInt("MEDIA_BACKFILL_CONCURRENCY"),
+		BackfillMaxAttempts: viper.GetInt("MEDIA_BACKFILL_MAX_ATTEMPTS"),
+		BackfillMaxAge:      viper.GetDuration("MEDIA_BACKFILL_MAX_AGE"),
+
+		Dedup:       viper.GetBool("MEDIA_DEDUP_ENABLED"),
+		UploadReuse: viper.GetDuration("MEDIA_UPLOAD_REUSE_TTL"),
+
+		Retention:         viper.GetBool("MEDIA_RETENTION_ENABLED"),
+		RetentionInterval: viper.GetDuration("MEDIA_RETENTION_INTERVAL"),
+		RetentionBatch:    viper.GetInt("MEDIA_RETENTION_BATCH"),
+		RetentionDefault:  viper.GetString("MEDIA_RETENTION_DEFAULT"),
+
+		Scan: ScanConfig{
+			Driver:       viper.GetString("MEDIA_SCAN_DRIVER"),
+			Types:        splitList(viper.GetString("MEDIA_SCAN_TYPES")),
+			Timeout:      viper.GetDuration("MEDIA_SCAN_TIMEOUT"),
+			FailClosed:   viper.GetBool("MEDIA_SCAN_FAIL_CLOSED"),
+			ClamdAddress: viper.GetString("MEDIA_SCAN_CLAMD_ADDRESS"),
+			HTTPURL:      viper.GetString("MEDIA_SCAN_HTTP_URL"),
+			HTTPToken:    viper.GetString("MEDIA_SCAN_HTTP_TOKEN"),
+			MaxSize:      viper.GetInt64("MEDIA_SCAN_MAX_SIZE"),
+		},
 	}
 
 	// Configurações de timeout
 	config.Timeout = TimeoutConfig{
 		Request:  viper.GetDuration("REQUEST_TIMEOUT"),
 		Shutdown: viper.GetDuration("SHUTDOWN_TIMEOUT"),
+		QRStuck:  viper.GetDuration("WHATSAPP_QR_STUCK_TIMEOUT"),
 	}
 
 	// Configurações do MinIO
@@ -182,9 +395,76 @@ func Load() (*Config, error) {
 		DefaultBucket:   viper.GetString("MINIO_DEFAULT_BUCKET"),
 	}
 
+	// Configurações do armazenamento de mídias
+	config.Storage = StorageConfig{
+		Driver:     viper.GetString("STORAGE_DRIVER"),
+		URLExpiry:  viper.GetDuration("STORAGE_URL_EXPIRY"),
+		PublicURL:  viper.GetString("STORAGE_PUBLIC_URL"),
+		SigningKey: viper.GetString("STORAGE_SIGNING_KEY"),
+		S3: S3Config{
+			Endpoint:        viper.GetString("S3_ENDPOINT"),
+			Region:          viper.GetString("S3_REGION"),
+			Bucket:          viper.GetString("S3_BUCKET"),
+			AccessKeyID:     viper.GetString("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: viper.GetString("S3_SECRET_ACCESS_KEY"),
+			UseSSL:          viper.GetBool("S3_USE_SSL"),
+			PathStyle:       viper.GetBool("S3_PATH_STYLE"),
+		},
+	}
+
+	// Configurações do stream de eventos
+	config.Events = EventsConfig{
+		LogBackend:       viper.GetString("EVENTS_LOG_BACKEND"),
+		LogSize:          viper.GetInt("EVENTS_LOG_SIZE"),
+		SubscriberBuffer: viper.GetInt("EVENTS_SUBSCRIBER_BUFFER"),
+	}
+
+	// Configurações dos sinks de eventos
+	config.Sinks = SinksConfig{
+		Subject:        viper.GetString("SINK_SUBJECT"),
+		Types:          splitList(viper.GetString("SINK_TYPES")),
+		NATSURL:        viper.GetString("SINK_NATS_URL"),
+		NATSStream:     viper.GetString("SINK_NATS_STREAM"),
+		AMQPURL:        viper.GetString("SINK_AMQP_URL"),
+		AMQPExchange:   viper.GetString("SINK_AMQP_EXCHANGE"),
+		KafkaBrokers:   viper.GetString("SINK_KAFKA_BROKERS"),
+		KafkaTopic:     viper.GetString("SINK_KAFKA_TOPIC"),
+		BufferDir:      viper.GetString("SINK_BUFFER_DIR"),
+		BufferMaxBytes: viper.GetInt64("SINK_BUFFER_MAX_BYTES"),
+		QueueSize:      viper.GetInt("SINK_QUEUE_SIZE"),
+		EnqueueTimeout: viper.GetDuration("SINK_ENQUEUE_TIMEOUT"),
+	}
+
+	// Configurações de métricas
+	config.Metrics = MetricsConfig{
+		Enabled: viper.GetBool("METRICS_ENABLED"),
+		Token:   viper.GetString("METRICS_TOKEN"),
+	}
+
+	// Configurações de tracing
+	config.Tracing = TracingConfig{
+		Exporter:    viper.GetString("TRACING_EXPORTER"),
+		Endpoint:    viper.GetString("TRACING_ENDPOINT"),
+		Insecure:    viper.GetBool("TRACING_INSECURE"),
+		FilePath:    viper.GetString("TRACING_FILE_PATH"),
+		SampleRatio: viper.GetFloat64("TRACING_SAMPLE_RATIO"),
+		ServiceName: viper.GetString("TRACING_SERVICE_NAME"),
+	}
+
 	return config, nil
 }
 
+// splitList separa uma lista por vírgulas descartando itens vazios
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // setDefaults define os valores padrão para as configurações
 func setDefaults() {
 	// Servidor
@@ -202,6 +482,7 @@ func setDefaults() {
 	viper.SetDefault("DB_MAX_OPEN_CONNS", 25)
 	viper.SetDefault("DB_MAX_IDLE_CONNS", 5)
 	viper.SetDefault("DB_CONN_MAX_LIFETIME", "300s")
+	viper.SetDefault("DB_AUTO_MIGRATE", true)
 
 	// Log
 	viper.SetDefault("LOG_LEVEL", "info")
@@ -225,12 +506,65 @@ func setDefaults() {
 	viper.SetDefault("CORS_ALLOWED_HEADERS", "Content-Type,Authorization")
 
 	// Rate Limiting
+	viper.SetDefault("RATE_LIMIT_BACKEND", "memory")
 	viper.SetDefault("RATE_LIMIT_REQUESTS", 100)
 	viper.SetDefault("RATE_LIMIT_WINDOW", "60s")
+	viper.SetDefault("RATE_LIMIT_BURST", 0)
+	viper.SetDefault("RATE_LIMIT_KEY_REQUESTS", 0)
+	viper.SetDefault("RATE_LIMIT_KEY_WINDOW", "60s")
+	viper.SetDefault("RATE_LIMIT_SEND_REQUESTS", 0)
+	viper.SetDefault("RATE_LIMIT_SEND_WINDOW", "60s")
+	viper.SetDefault("RATE_LIMIT_READ_REQUESTS", 0)
+	viper.SetDefault("RATE_LIMIT_READ_WINDOW", "60s")
+	viper.SetDefault("RATE_LIMIT_SESSION_REQUESTS", 0)
+	viper.SetDefault("RATE_LIMIT_SESSION_WINDOW", "60s")
+
+	// Limites de envio por sessão
+	viper.SetDefault("SEND_LIMIT_MESSAGES_PER_MINUTE", 30)
+	viper.SetDefault("SEND_LIMIT_NEW_CHATS_PER_DAY", 0)
+	viper.SetDefault("SEND_LIMIT_RECIPIENT_GAP", "0s")
+	viper.SetDefault("SEND_LIMIT_MAX_QUEUE", "10s")
+
+	// Confirmações de leitura
+	viper.SetDefault("RECEIPT_READ_RULE", "all")
+	viper.SetDefault("RECEIPT_READ_THRESHOLD", 50)
+
+	// Processamento de mídia
+	viper.SetDefault("MEDIA_THUMBNAILS_ENABLED", true)
+	viper.SetDefault("MEDIA_PREVIEW_SIZE", 480)
+	viper.SetDefault("MEDIA_FFMPEG_PATH", "ffmpeg")
+	viper.SetDefault("MEDIA_PDFTOPPM_PATH", "pdftoppm")
+	viper.SetDefault("MEDIA_NORMALIZE_ENABLED", true)
+	viper.SetDefault("MEDIA_IMAGE_MAX_SIDE", 2560)
+	viper.SetDefault("MEDIA_RETRY_TIMEOUT", "30s")
+	viper.SetDefault("MEDIA_BACKFILL_ENABLED", true)
+	viper.SetDefault("MEDIA_BACKFILL_INTERVAL", "5m")
+	viper.SetDefault("MEDIA_BACKFILL_BATCH", 50)
+	viper.SetDefault("MEDIA_BACKFILL_CONCURRENCY", 2)
+	viper.SetDefault("MEDIA_BACKFILL_MAX_ATTEMPTS", 5)
+	viper.SetDefault("MEDIA_BACKFILL_MAX_AGE", "720h")
+	viper.SetDefault("MEDIA_DEDUP_ENABLED", true)
+	viper.SetDefault("MEDIA_UPLOAD_REUSE_TTL", "168h")
+	viper.SetDefault("MEDIA_RETENTION_ENABLED", true)
+	viper.SetDefault("MEDIA_RETENTION_INTERVAL", "1h")
+	viper.SetDefault("MEDIA_RETENTION_BATCH", 500)
+	viper.SetDefault("MEDIA_RETENTION_DEFAULT", "")
+	viper.SetDefault("MEDIA_SCAN_DRIVER", "none")
+	viper.SetDefault("MEDIA_SCAN_TYPES", "document")
+	viper.SetDefault("MEDIA_SCAN_TIMEOUT", "30s")
+	viper.SetDefault("MEDIA_SCAN_FAIL_CLOSED", false)
+	viper.SetDefault("MEDIA_SCAN_CLAMD_ADDRESS", "tcp://localhost:3310")
+	viper.SetDefault("MEDIA_SCAN_MAX_SIZE", 100*1024*1024)
+
+	// Redis
+	viper.SetDefault("REDIS_HOST", "localhost")
+	viper.SetDefault("REDIS_PORT", "6379")
+	viper.SetDefault("REDIS_DB", 0)
 
 	// Timeouts
 	viper.SetDefault("REQUEST_TIMEOUT", "30s")
 	viper.SetDefault("SHUTDOWN_TIMEOUT", "10s")
+	viper.SetDefault("WHATSAPP_QR_STUCK_TIMEOUT", "3m")
 
 	// MinIO
 	viper.SetDefault("MINIO_ENABLED", true)
@@ -239,6 +573,37 @@ func setDefaults() {
 	viper.SetDefault("MINIO_SECRET_ACCESS_KEY", "4xN4PEDyxijbN4gM")
 	viper.SetDefault("MINIO_USE_SSL", false)
 	viper.SetDefault("MINIO_DEFAULT_BUCKET", "zapcore-media")
+
+	// Armazenamento de mídias
+	viper.SetDefault("STORAGE_DRIVER", "")
+	viper.SetDefault("STORAGE_URL_EXPIRY", "24h")
+	viper.SetDefault("S3_ENDPOINT", "s3.amazonaws.com")
+	viper.SetDefault("S3_REGION", "us-east-1")
+	viper.SetDefault("S3_USE_SSL", true)
+	viper.SetDefault("S3_PATH_STYLE", false)
+
+	// Stream de eventos
+	viper.SetDefault("EVENTS_LOG_BACKEND", "memory")
+	viper.SetDefault("EVENTS_LOG_SIZE", 1000)
+	viper.SetDefault("EVENTS_SUBSCRIBER_BUFFER", 256)
+
+	// Sinks de eventos (globais desativados por padrão)
+	viper.SetDefault("SINK_SUBJECT", "zapcore.{session}.{type}")
+	viper.SetDefault("SINK_AMQP_EXCHANGE", "zapcore.events")
+	viper.SetDefault("SINK_BUFFER_DIR", "./data/sinks")
+	viper.SetDefault("SINK_BUFFER_MAX_BYTES", 512*1024*1024)
+	viper.SetDefault("SINK_QUEUE_SIZE", 1000)
+	viper.SetDefault("SINK_ENQUEUE_TIMEOUT", "100ms")
+
+	// Métricas (sem token padrão para forçar configuração)
+	viper.SetDefault("METRICS_ENABLED", false)
+
+	// Tracing (desativado por padrão)
+	viper.SetDefault("TRACING_EXPORTER", "none")
+	viper.SetDefault("TRACING_INSECURE", false)
+	viper.SetDefault("TRACING_FILE_PATH", "./logs/traces.json")
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("TRACING_SERVICE_NAME", "zapcore")
 }
 
 // GetDatabaseDSN retorna a string de conexão do banco de dados
@@ -254,11 +619,40 @@ func (c *Config) GetDatabaseDSN() string {
 	)
 }
 
+// GetRedisAddress retorna o endereço do Redis
+func (c *Config) GetRedisAddress() string {
+	return fmt.Sprintf("%s:%s", c.Redis.Host, c.Redis.Port)
+}
+
 // GetServerAddress retorna o endereço completo do servidor
 func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf("%s:%s", c.Server.Host, c.Server.Port)
 }
 
+// GetStorageDriver retorna o driver de armazenamento; sem STORAGE_DRIVER, usa o MinIO quando
+// habilitado, mantendo o comportamento das instalações anteriores
+func (c *Config) GetStorageDriver() string {
+	if c.Storage.Driver != "" {
+		return c.Storage.Driver
+	}
+	if c.MinIO.Enabled {
+		return "minio"
+	}
+	return "none"
+}
+
+// GetStoragePublicURL retorna a URL do zapcore usada nos links assinados do driver local
+func (c *Config) GetStoragePublicURL() string {
+	if c.Storage.PublicURL != "" {
+		return c.Storage.PublicURL
+	}
+	host := c.Server.Host
+	if host == "" || host == "0.0.0.0" {
+		host = "localhost"
+	}
+	return fmt.Sprintf("http://%s:%s", host, c.Server.Port)
+}
+
 // IsProduction verifica se está em ambiente de produção
 func (c *Config) IsProduction() bool {
 	return c.Server.Env == "production"
@@ -279,5 +673,128 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DB_PASSWORD deve ser configurada")
 	}
 
+	switch c.Tracing.Exporter {
+	case "", "none", "stdout", "file", "otlp-grpc", "otlp-http":
+	default:
+		return fmt.Errorf("TRACING_EXPORTER inválido: %s (use none, stdout, file, otlp-grpc ou otlp-http)", c.Tracing.Exporter)
+	}
+
+	switch c.RateLimit.Backend {
+	case "", "memory", "postgres", "redis":
+	default:
+		return fmt.Errorf("RATE_LIMIT_BACKEND inválido: %s (use memory, postgres ou redis)", c.RateLimit.Backend)
+	}
+
+	for name, window := range map[string]time.Duration{
+		"RATE_LIMIT_WINDOW":         c.RateLimit.Window,
+		"RATE_LIMIT_KEY_WINDOW":     c.RateLimit.KeyWindow,
+		"RATE_LIMIT_SEND_WINDOW":    c.RateLimit.SendWindow,
+		"RATE_LIMIT_READ_WINDOW":    c.RateLimit.ReadWindow,
+		"RATE_LIMIT_SESSION_WINDOW": c.RateLimit.SessionWindow,
+	} {
+		if window <= 0 {
+			return fmt.Errorf("%s deve ser uma duração positiva (ex.: 60s, 1m)", name)
+		}
+	}
+
+	if c.SendLimit.MessagesPerMinute < 0 || c.SendLimit.NewChatsPerDay < 0 || c.SendLimit.RecipientGap < 0 || c.SendLimit.MaxQueue < 0 {
+		return fmt.Errorf("SEND_LIMIT_* não podem ser negativos")
+	}
+
+	switch c.Receipts.ReadRule {
+	case "any", "all":
+	case "threshold":
+		if c.Receipts.ReadThreshold < 1 || c.Receipts.ReadThreshold > 100 {
+			return fmt.Errorf("RECEIPT_READ_THRESHOLD deve estar entre 1 e 100")
+		}
+	default:
+		return fmt.Errorf("RECEIPT_READ_RULE inválido: %s (use any, all ou threshold)", c.Receipts.ReadRule)
+	}
+
+	if c.Media.Thumbnails && c.Media.PreviewSize < 72 {
+		return fmt.Errorf("MEDIA_PREVIEW_SIZE deve ser de pelo menos 72 pixels")
+	}
+
+	if c.Media.ImageMaxSide < 512 {
+		return fmt.Errorf("MEDIA_IMAGE_MAX_SIDE deve ser de pelo menos 512 pixels")
+	}
+
+	if c.Media.RetryTimeout < time.Second {
+		return fmt.Errorf("MEDIA_RETRY_TIMEOUT deve ser de pelo menos 1s")
+	}
+
+	if c.Media.Backfill {
+		if c.Media.BackfillInterval < time.Minute {
+			return fmt.Errorf("MEDIA_BACKFILL_INTERVAL deve ser de pelo menos 1m")
+		}
+		if c.Media.BackfillBatch < 1 || c.Media.BackfillConcurrency < 1 || c.Media.BackfillMaxAttempts < 1 {
+			return fmt.Errorf("MEDIA_BACKFILL_BATCH, MEDIA_BACKFILL_CONCURRENCY e MEDIA_BACKFILL_MAX_ATTEMPTS devem ser maiores que zero")
+		}
+	}
+
+	// A CDN do WhatsApp descarta os arquivos em cerca de 30 dias
+	if c.Media.UploadReuse < 0 || c.Media.UploadReuse > 720*time.Hour {
+		return fmt.Errorf("MEDIA_UPLOAD_REUSE_TTL deve estar entre 0 e 720h")
+	}
+
+	if c.Media.Retention {
+		if c.Media.RetentionInterval < time.Minute {
+			return fmt.Errorf("MEDIA_RETENTION_INTERVAL deve ser de pelo menos 1m")
+		}
+		if c.Media.RetentionBatch < 1 {
+			return fmt.Errorf("MEDIA_RETENTION_BATCH deve ser maior que zero")
+		}
+	}
+
+	switch c.Media.Scan.Driver {
+	case "", "none":
+	case "clamd", "http":
+		if c.Media.Scan.Driver == "clamd" && c.Media.Scan.ClamdAddress == "" {
+			return fmt.Errorf("MEDIA_SCAN_CLAMD_ADDRESS deve ser configurado quando MEDIA_SCAN_DRIVER=clamd")
+		}
+		if c.Media.Scan.Driver == "http" && c.Media.Scan.HTTPURL == "" {
+			return fmt.Errorf("MEDIA_SCAN_HTTP_URL deve ser configurado quando MEDIA_SCAN_DRIVER=http")
+		}
+		if c.Media.Scan.Timeout < time.Second {
+			return fmt.Errorf("MEDIA_SCAN_TIMEOUT deve ser de pelo menos 1s")
+		}
+		if c.Media.Scan.MaxSize < 0 {
+			return fmt.Errorf("MEDIA_SCAN_MAX_SIZE não pode ser negativo")
+		}
+		for _, mediaType := range c.Media.Scan.Types {
+			switch mediaType {
+			case "image", "video", "audio", "document", "sticker":
+			default:
+				return fmt.Errorf("MEDIA_SCAN_TYPES inválido: %s (use image, video, audio, document ou sticker)", mediaType)
+			}
+		}
+	default:
+		return fmt.Errorf("MEDIA_SCAN_DRIVER inválido: %s (use none, clamd ou http)", c.Media.Scan.Driver)
+	}
+
+	switch c.GetStorageDriver() {
+	case "minio", "local", "none":
+	case "s3":
+		if c.Storage.S3.Bucket == "" {
+			return fmt.Errorf("S3_BUCKET deve ser configurado quando STORAGE_DRIVER=s3")
+		}
+	default:
+		return fmt.Errorf("STORAGE_DRIVER inválido: %s (use minio, s3, local ou none)", c.Storage.Driver)
+	}
+
+	// URLs pré-assinadas do S3 valem no máximo 7 dias
+	if c.Storage.URLExpiry < time.Minute || c.Storage.URLExpiry > 7*24*time.Hour {
+		return fmt.Errorf("STORAGE_URL_EXPIRY deve estar entre 1m e 168h")
+	}
+
+	if c.Metrics.Enabled {
+		if c.Metrics.Token == "" {
+			return fmt.Errorf("METRICS_TOKEN deve ser configurado quando METRICS_ENABLED=true")
+		}
+		if c.Metrics.Token == c.Auth.APIKey {
+			return fmt.Errorf("METRICS_TOKEN deve ser diferente da API_KEY")
+		}
+	}
+
 	return nil
 }
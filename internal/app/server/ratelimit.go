@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/domain/ratelimit"
+	"zapcore/internal/http/router"
+	rateLimitInfra "zapcore/internal/infra/ratelimit"
+	"zapcore/internal/infra/repository"
+	"zapcore/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/uptrace/bun"
+)
+
+// newRateLimiter cria o limitador do backend configurado (RATE_LIMIT_BACKEND). Com Redis, o
+// limitador também é retornado para verificação de saúde e encerramento.
+func newRateLimiter(cfg *config.Config, db *bun.DB) (ratelimit.Limiter, *rateLimitInfra.RedisLimiter) {
+	switch cfg.RateLimit.Backend {
+	case "postgres":
+		return repository.NewRateLimitRepository(db), nil
+	case "redis":
+		limiter := rateLimitInfra.NewRedisLimiter(redis.NewClient(&redis.Options{
+			Addr:     cfg.GetRedisAddress(),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		}))
+		// Redis indisponível não impede a inicialização: os limites ficam locais até reconectar
+		if err := limiter.Ping(context.Background()); err != nil {
+			logger.Get().Warn().Err(err).Str("addr", cfg.GetRedisAddress()).Msg("Redis do rate limiting indisponível, usando limites locais")
+		}
+		return limiter, limiter
+	default:
+		return rateLimitInfra.NewMemoryLimiter(), nil
+	}
+}
+
+// rateLimitPolicies converte as variáveis RATE_LIMIT_* nas políticas do router
+func rateLimitPolicies(cfg *config.RateLimitConfig) router.RateLimitPolicies {
+	return router.RateLimitPolicies{
+		IP:      ratelimit.Policy{Name: "ip", Requests: cfg.Requests, Window: cfg.Window, Burst: cfg.Burst},
+		Key:     ratelimit.Policy{Name: "key", Requests: cfg.KeyRequests, Window: cfg.KeyWindow},
+		Send:    ratelimit.Policy{Name: "send", Requests: cfg.SendRequests, Window: cfg.SendWindow},
+		Read:    ratelimit.Policy{Name: "read", Requests: cfg.ReadRequests, Window: cfg.ReadWindow},
+		Session: ratelimit.Policy{Name: "session", Requests: cfg.SessionRequests, Window: cfg.SessionWindow},
+	}
+}
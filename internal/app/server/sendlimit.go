@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/domain/chat"
+	"zapcore/internal/domain/sendlimit"
+	"zapcore/internal/domain/session"
+	sendLimitInfra "zapcore/internal/infra/sendlimit"
+
+	"github.com/google/uuid"
+)
+
+// newSendGovernor cria o governador de envio com os limites padrão (variáveis SEND_LIMIT_*),
+// os limites próprios de cada sessão e o histórico de conversas para identificar novos destinatários
+func newSendGovernor(cfg *config.Config, sessionRepo session.Repository, chatRepo chat.Repository) *sendLimitInfra.Governor {
+	defaults := sendlimit.Limits{
+		MessagesPerMinute:   cfg.SendLimit.MessagesPerMinute,
+		NewChatsPerDay:      cfg.SendLimit.NewChatsPerDay,
+		RecipientGapSeconds: int(cfg.SendLimit.RecipientGap.Seconds()),
+		MaxQueueSeconds:     int(cfg.SendLimit.MaxQueue.Seconds()),
+	}
+
+	resolve := func(ctx context.Context, sessionID uuid.UUID) (*sendlimit.Limits, error) {
+		sess, err := sessionRepo.GetByID(ctx, sessionID)
+		if err != nil {
+			if errors.Is(err, session.ErrSessionNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return sess.SendLimits, nil
+	}
+
+	isKnown := func(ctx context.Context, sessionID uuid.UUID, recipient string) (bool, error) {
+		if _, err := chatRepo.GetByJID(ctx, sessionID, recipient); err != nil {
+			if errors.Is(err, chat.ErrChatNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	return sendLimitInfra.NewGovernor(defaults, resolve, isKnown)
+}
@@ -12,24 +12,43 @@ import (
 	"time"
 
 	"zapcore/internal/app/config"
-	"zapcore/internal/domain/chat"
-	"zapcore/internal/domain/contact"
+	"zapcore/internal/domain/eventstream"
+	domainMedia "zapcore/internal/domain/media"
 	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/ratelimit"
 	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/template"
 	"zapcore/internal/http/handlers"
 	"zapcore/internal/http/router"
 	"zapcore/internal/infra/database"
+	eventSink "zapcore/internal/infra/eventsink"
+	eventStream "zapcore/internal/infra/eventstream"
+	"zapcore/internal/infra/metrics"
+	rateLimitInfra "zapcore/internal/infra/ratelimit"
 	"zapcore/internal/infra/repository"
+	"zapcore/internal/infra/scanner"
+	sendLimitInfra "zapcore/internal/infra/sendlimit"
 	"zapcore/internal/infra/storage"
 	"zapcore/internal/infra/whatsapp"
+	"zapcore/internal/shared/media"
+	apiKeyUseCase "zapcore/internal/usecases/apikey"
+	auditUseCase "zapcore/internal/usecases/audit"
+	autoReplyUseCase "zapcore/internal/usecases/autoreply"
+	businessHoursUseCase "zapcore/internal/usecases/businesshours"
+	eventSinkUseCase "zapcore/internal/usecases/eventsink"
+	mediaUseCase "zapcore/internal/usecases/media"
 	messageUseCase "zapcore/internal/usecases/message"
 	sessionUseCase "zapcore/internal/usecases/session"
+	templateUseCase "zapcore/internal/usecases/template"
+	tenantUseCase "zapcore/internal/usecases/tenant"
 	"zapcore/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/extra/bunotel"
 )
 
 // BunDB representa a conexão com o banco de dados usando Bun ORM
@@ -69,6 +88,9 @@ func NewBunDB(cfg *config.Config) (*BunDB, error) {
 	// Criar instância Bun
 	db := bun.NewDB(sqldb, pgdialect.New())
 
+	// Spans das queries como filhos do span presente no contexto (noop sem tracing configurado)
+	db.AddQueryHook(bunotel.NewQueryHook(bunotel.WithDBName(cfg.Database.Name)))
+
 	// Logs do Bun desabilitados - usando logger centralizado
 
 	// Testar conexão
@@ -85,12 +107,6 @@ func NewBunDB(cfg *config.Config) (*BunDB, error) {
 		logger: logger.Get(),
 	}
 
-	// Executar auto-migration
-	if err := bunDB.AutoMigrate(ctx); err != nil {
-		sqldb.Close()
-		return nil, fmt.Errorf("erro ao executar auto-migration: %w", err)
-	}
-
 	logger.WithFields(map[string]interface{}{
 		"component": "database",
 		"driver":    "postgresql",
@@ -116,6 +132,14 @@ func (d *BunDB) Ping() error {
 	return d.db.PingContext(ctx)
 }
 
+// HealthCheck verifica se o pool de conexões consegue alcançar o banco
+func (d *BunDB) HealthCheck(ctx context.Context) error {
+	if err := d.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("banco de dados não está acessível: %w", err)
+	}
+	return nil
+}
+
 // Close fecha a conexão com o banco
 func (d *BunDB) Close() error {
 	if d.db != nil {
@@ -125,34 +149,37 @@ func (d *BunDB) Close() error {
 	return nil
 }
 
-// AutoMigrate executa as migrations automáticas baseadas nas structs
-func (d *BunDB) AutoMigrate(ctx context.Context) error {
-	d.logger.WithFields(map[string]interface{}{
-		"component": "database",
-		"operation": "migration",
-	}).Info().Msg("🔄 Executando auto-migration")
+// Migrate verifica a versão do esquema e aplica as migrations pendentes.
+// Recusa iniciar contra um banco migrado por uma versão mais nova do ZapCore.
+func (d *BunDB) Migrate(ctx context.Context, autoMigrate bool) error {
+	migrator := database.NewMigrator(d.db)
 
-	// Registrar modelos
-	models := []interface{}{
-		(*session.Session)(nil),
-		(*message.Message)(nil),
-		(*chat.Chat)(nil),
-		(*contact.Contact)(nil),
+	status, err := migrator.EnsureCompatible(ctx)
+	if err != nil {
+		return err
 	}
 
-	// Criar tabelas para cada modelo usando apenas Bun ORM
-	for _, model := range models {
-		if _, err := d.db.NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
-			return fmt.Errorf("erro ao criar tabela para modelo %T: %w", model, err)
-		}
+	if status.Pending == 0 {
+		d.logger.WithFields(map[string]interface{}{
+			"component": "database",
+			"operation": "migration",
+			"status":    "up_to_date",
+		}).Info().Msg("✅ Esquema do banco atualizado")
+		return nil
+	}
+
+	if !autoMigrate {
+		return fmt.Errorf("%w (%d): execute \"zapcore migrate up\"", database.ErrPendingMigrations, status.Pending)
 	}
 
 	d.logger.WithFields(map[string]interface{}{
 		"component": "database",
 		"operation": "migration",
-		"status":    "completed",
-	}).Info().Msg("✅ Auto-migration concluída")
-	return nil
+		"pending":   status.Pending,
+	}).Info().Msg("🔄 Aplicando migrations")
+
+	_, err = migrator.Up(ctx)
+	return err
 }
 
 // Server representa o servidor HTTP da aplicação
@@ -163,6 +190,23 @@ type Server struct {
 	bunDB          *BunDB
 	storeManager   *whatsapp.StoreManager
 	whatsappClient *whatsapp.WhatsAppClient // Singleton instance
+	storageHandler *whatsapp.StorageHandler
+	eventBroker    *eventStream.Broker
+	sinkDispatcher *eventSink.Dispatcher
+	mediaStorage   *storage.MediaStorage
+	tenantRepo     *repository.TenantRepository
+	tenantQuotas   *tenantUseCase.QuotaUseCase
+	rateLimiter    ratelimit.Limiter
+	redisLimiter   *rateLimitInfra.RedisLimiter
+	sendGovernor   *sendLimitInfra.Governor
+	mediaBackfill  *mediaUseCase.BackfillUseCase
+	stopBackfill   context.CancelFunc
+	backfillDone   chan struct{}
+	mediaRetention *mediaUseCase.RetentionUseCase
+	retentionRules *domainMedia.RetentionPolicy
+	stopRetention  context.CancelFunc
+	retentionDone  chan struct{}
+	readRule       message.ReadRule
 }
 
 // New cria uma nova instância do servidor
@@ -183,6 +227,12 @@ func New(cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("erro ao conectar com banco de dados Bun: %w", err)
 	}
 
+	// Aplicar migrations versionadas
+	if err := bunDB.Migrate(context.Background(), cfg.Database.AutoMigrate); err != nil {
+		bunDB.Close()
+		return nil, fmt.Errorf("erro ao executar migrations: %w", err)
+	}
+
 	// Inicializar store manager do WhatsApp
 	storeManager, err := whatsapp.NewStoreManager(bunDB.GetSQLDB(), appLogger.GetZerolog())
 	if err != nil {
@@ -194,24 +244,131 @@ func New(cfg *config.Config) (*Server, error) {
 	messageRepo := repository.NewMessageRepository(bunDB.GetDB())
 	chatRepo := repository.NewChatRepository(bunDB.GetDB())
 	contactRepo := repository.NewContactRepository(bunDB.GetDB())
+	tenantRepo := repository.NewTenantRepository(bunDB.GetDB())
+	tenantQuotas := tenantUseCase.NewQuotaUseCase(tenantRepo, tenantRepo, sessionRepo)
 
-	// Criar cliente MinIO se habilitado
-	var minioClient *storage.MinIOClient
-	if cfg.MinIO.Enabled {
-		var err error
-		minioClient, err = storage.NewMinIOClient(&cfg.MinIO)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao criar cliente MinIO: %w", err)
-		}
+	// Criar armazenamento de mídias do driver configurado (STORAGE_DRIVER)
+	storageBackend, err := storage.NewBackend(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar armazenamento de mídias: %w", err)
+	}
+	retentionRules, err := domainMedia.ParseRetentionRules(cfg.Media.RetentionDefault)
+	if err != nil {
+		return nil, fmt.Errorf("MEDIA_RETENTION_DEFAULT inválida: %w", err)
+	}
+	var mediaStorage *storage.MediaStorage
+	if storageBackend != nil {
+		mediaStorage = storage.NewMediaStorage(storageBackend, cfg.Storage.URLExpiry)
+	} else {
+		appLogger.Warn().Msg("Armazenamento de mídias desativado: mídias recebidas não serão guardadas")
 	}
 
 	// Criar handlers de eventos (MediaDownloader será configurado dinamicamente)
 	sessionHandler := whatsapp.NewSessionEventHandler(sessionRepo)
 	storageHandler := whatsapp.NewStorageHandler(messageRepo, chatRepo, contactRepo, nil)
+
+	// Confirmações por destinatário, com o status agregado pela regra de leitura
+	readRule, err := message.NewReadRule(cfg.Receipts.ReadRule, cfg.Receipts.ReadThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao configurar confirmações de leitura: %w", err)
+	}
+	storageHandler.SetReceiptTracking(repository.NewReceiptRepository(bunDB.GetDB()), readRule)
 	compositeHandler := whatsapp.NewCompositeEventHandler(sessionHandler, storageHandler)
 
+	// Criar broker do stream de eventos (WebSocket/SSE)
+	var eventLog eventstream.Log
+	if cfg.Events.LogBackend == "postgres" {
+		eventLog = repository.NewEventLogRepository(bunDB.GetDB(), cfg.Events.LogSize)
+	} else {
+		eventLog = eventStream.NewMemoryLog(cfg.Events.LogSize)
+	}
+	eventBroker := eventStream.NewBroker(eventLog, cfg.Events.SubscriberBuffer)
+	compositeHandler.AddPublisher(eventBroker)
+
+	// Criar dispatcher dos sinks de eventos (NATS, RabbitMQ, Kafka)
+	sinkDispatcher, err := newSinkDispatcher(cfg, repository.NewEventSinkRepository(bunDB.GetDB()))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar sinks de eventos: %w", err)
+	}
+	compositeHandler.AddPublisher(sinkDispatcher)
+
+	// Criar limitador de requisições do backend configurado
+	rateLimiter, redisLimiter := newRateLimiter(cfg, bunDB.GetDB())
+
+	// Registrar coletores consultados a cada coleta de métricas
+	if cfg.Metrics.Enabled {
+		if err := metrics.RegisterDBStats(bunDB.GetSQLDB()); err != nil {
+			return nil, fmt.Errorf("erro ao registrar métricas do banco: %w", err)
+		}
+		if err := metrics.Register(metrics.NewSessionCollector(sessionRepo)); err != nil {
+			return nil, fmt.Errorf("erro ao registrar métricas de sessões: %w", err)
+		}
+	}
+
 	// Criar cliente WhatsApp (singleton)
-	whatsappClient := whatsapp.NewWhatsAppClient(storeManager.GetContainer(), sessionRepo, compositeHandler, minioClient)
+	whatsappClient := whatsapp.NewWhatsAppClient(storeManager.GetContainer(), sessionRepo, compositeHandler, mediaStorage)
+	whatsappClient.SetMediaQuota(tenantQuotas)
+	if mediaStorage != nil && cfg.Media.Dedup {
+		whatsappClient.SetMediaBlobs(repository.NewMediaBlobRepository(bunDB.GetDB()))
+	}
+	if cfg.Media.UploadReuse > 0 {
+		whatsappClient.SetUploadCache(repository.NewMediaUploadRepository(bunDB.GetDB()), cfg.Media.UploadReuse)
+	}
+
+	// Verificação de malware das mídias recebidas (MEDIA_SCAN_DRIVER)
+	mediaScanner, err := scanner.New(&cfg.Media.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar verificação de mídias: %w", err)
+	}
+	if mediaScanner != nil && mediaStorage != nil {
+		whatsappClient.SetMediaScanner(mediaScanner, whatsapp.MediaScanOptions{
+			Types:      cfg.Media.Scan.Types,
+			MaxSize:    cfg.Media.Scan.MaxSize,
+			FailClosed: cfg.Media.Scan.FailClosed,
+		})
+		appLogger.Info().
+			Str("driver", mediaScanner.Name()).
+			Strs("types", cfg.Media.Scan.Types).
+			Bool("fail_closed", cfg.Media.Scan.FailClosed).
+			Msg("Verificação de malware das mídias recebidas ativada")
+	}
+
+	// Limites de envio por sessão, aplicados a todos os caminhos de envio
+	sendGovernor := newSendGovernor(cfg, sessionRepo, chatRepo)
+	whatsappClient.SetSendGovernor(sendGovernor)
+
+	// Thumbnails e previews das mídias enviadas
+	if cfg.Media.Thumbnails {
+		thumbnailer := media.NewThumbnailer(media.ThumbnailOptions{
+			PreviewSize:  cfg.Media.PreviewSize,
+			FFmpegPath:   cfg.Media.FFmpegPath,
+			PdftoppmPath: cfg.Media.PdftoppmPath,
+		})
+		whatsappClient.SetThumbnailer(thumbnailer)
+		appLogger.Info().
+			Bool("ffmpeg", thumbnailer.HasFFmpeg()).
+			Bool("pdftoppm", thumbnailer.HasPdftoppm()).
+			Msg("Geração de thumbnails ativada")
+	}
+
+	// Conversão dos áudios enviados como mensagem de voz (PTT)
+	audioTranscoder := media.NewAudioTranscoder(cfg.Media.FFmpegPath)
+	if _, ok := audioTranscoder.(media.NoopTranscoder); ok {
+		appLogger.Warn().Msg("ffmpeg não encontrado: apenas áudios em OGG/Opus serão enviados como mensagem de voz")
+	}
+	whatsappClient.SetAudioTranscoder(audioTranscoder)
+
+	// Normalização das mídias enviadas; as requisições podem ativar ou desativar cada etapa
+	normalizeDefaults := media.NormalizeSteps{}
+	if cfg.Media.Normalize {
+		normalizeDefaults = media.AllNormalizeSteps()
+	}
+	whatsappClient.SetNormalizer(media.NewNormalizer(media.NormalizerOptions{
+		Defaults:     normalizeDefaults,
+		ImageMaxSide: cfg.Media.ImageMaxSide,
+		FFmpegPath:   cfg.Media.FFmpegPath,
+	}))
+	whatsappClient.SetMediaRetryTimeout(cfg.Media.RetryTimeout)
 
 	server := &Server{
 		config:         cfg,
@@ -219,6 +376,17 @@ func New(cfg *config.Config) (*Server, error) {
 		bunDB:          bunDB,
 		storeManager:   storeManager,
 		whatsappClient: whatsappClient,
+		storageHandler: storageHandler,
+		eventBroker:    eventBroker,
+		sinkDispatcher: sinkDispatcher,
+		mediaStorage:   mediaStorage,
+		tenantRepo:     tenantRepo,
+		tenantQuotas:   tenantQuotas,
+		rateLimiter:    rateLimiter,
+		redisLimiter:   redisLimiter,
+		sendGovernor:   sendGovernor,
+		retentionRules: retentionRules,
+		readRule:       readRule,
 	}
 
 	// Configurar rotas
@@ -241,42 +409,298 @@ func (s *Server) setupRoutes() *gin.Engine {
 	// Criar repositórios
 	sessionRepo := repository.NewSessionRepository(s.bunDB.GetDB())
 	messageRepo := repository.NewMessageRepository(s.bunDB.GetDB())
+	chatRepo := repository.NewChatRepository(s.bunDB.GetDB())
+	templateRepo := repository.NewTemplateRepository(s.bunDB.GetDB())
+	autoReplyRuleRepo := repository.NewAutoReplyRuleRepository(s.bunDB.GetDB())
+	conversationStateRepo := repository.NewConversationStateRepository(s.bunDB.GetDB())
+	businessHoursRepo := repository.NewBusinessHoursRepository(s.bunDB.GetDB())
+	awayNoticeRepo := repository.NewAwayNoticeRepository(s.bunDB.GetDB())
+	apiKeyRepo := repository.NewAPIKeyRepository(s.bunDB.GetDB())
+	eventSinkRepo := repository.NewEventSinkRepository(s.bunDB.GetDB())
+	auditRepo := repository.NewAuditRepository(s.bunDB.GetDB())
+
+	// Mídia de templates e downloads de mídia só estão disponíveis com o armazenamento habilitado
+	var templateMedia template.MediaStorage
+	var mediaReader domainMedia.Reader
+	if s.mediaStorage != nil {
+		templateMedia = s.mediaStorage
+		mediaReader = s.mediaStorage
+	}
 
 	// Criar use cases
-	sendTextUseCase := messageUseCase.NewSendTextUseCase(messageRepo, sessionRepo, s.whatsappClient)
-	sendMediaUseCase := messageUseCase.NewSendMediaUseCase(messageRepo, sessionRepo, s.whatsappClient)
+	renderTemplateUseCase := templateUseCase.NewRenderUseCase(templateRepo, templateMedia)
+	createTemplateUseCase := templateUseCase.NewCreateUseCase(templateRepo)
+	getTemplateUseCase := templateUseCase.NewGetUseCase(templateRepo)
+	listTemplateUseCase := templateUseCase.NewListUseCase(templateRepo)
+	updateTemplateUseCase := templateUseCase.NewUpdateUseCase(templateRepo)
+	deleteTemplateUseCase := templateUseCase.NewDeleteUseCase(templateRepo)
+
+	sendTextUseCase := messageUseCase.NewSendTextUseCase(messageRepo, sessionRepo, s.whatsappClient, renderTemplateUseCase, s.tenantQuotas)
+	sendMediaUseCase := messageUseCase.NewSendMediaUseCase(messageRepo, sessionRepo, s.whatsappClient, renderTemplateUseCase, s.tenantQuotas)
+	getReceiptsUseCase := messageUseCase.NewGetReceiptsUseCase(messageRepo, repository.NewReceiptRepository(s.bunDB.GetDB()), s.readRule)
+	downloadMediaUseCase := mediaUseCase.NewDownloadUseCase(messageRepo, mediaReader, s.whatsappClient)
+	retryMediaUseCase := mediaUseCase.NewRetryUseCase(messageRepo, mediaReader, s.whatsappClient)
+	if s.mediaStorage != nil && s.config.Media.Backfill {
+		s.mediaBackfill = mediaUseCase.NewBackfillUseCase(messageRepo, sessionRepo, s.whatsappClient, s.whatsappClient)
+	}
+	if s.mediaStorage != nil && s.config.Media.Retention {
+		s.mediaRetention = mediaUseCase.NewRetentionUseCase(messageRepo, sessionRepo, s.tenantRepo, s.mediaStorage, s.retentionRules)
+		if s.config.Media.Dedup {
+			blobRepo := repository.NewMediaBlobRepository(s.bunDB.GetDB())
+			collector := mediaUseCase.NewGCUseCase(s.mediaStorage)
+			collector.SetBlobRepository(blobRepo)
+			s.mediaRetention.SetBlobRepository(blobRepo, collector)
+		}
+	}
 
-	createSessionUseCase := sessionUseCase.NewCreateUseCase(sessionRepo)
+	createRuleUseCase := autoReplyUseCase.NewCreateRuleUseCase(autoReplyRuleRepo, sessionRepo)
+	listRulesUseCase := autoReplyUseCase.NewListRulesUseCase(autoReplyRuleRepo)
+	updateRuleUseCase := autoReplyUseCase.NewUpdateRuleUseCase(autoReplyRuleRepo)
+	deleteRuleUseCase := autoReplyUseCase.NewDeleteRuleUseCase(autoReplyRuleRepo)
+
+	getBusinessHoursUseCase := businessHoursUseCase.NewGetUseCase(businessHoursRepo)
+	setBusinessHoursUseCase := businessHoursUseCase.NewSetUseCase(businessHoursRepo, sessionRepo)
+	deleteBusinessHoursUseCase := businessHoursUseCase.NewDeleteUseCase(businessHoursRepo)
+	businessHoursStatusUseCase := businessHoursUseCase.NewStatusUseCase(businessHoursRepo)
+
+	// Registrar automações no pipeline de mensagens recebidas
+	businessHoursAutomation := businessHoursUseCase.NewAutomation(businessHoursRepo, awayNoticeRepo, s.whatsappClient)
+	businessHoursAutomation.SetQuotas(s.tenantQuotas)
+	s.storageHandler.AddInboundProcessor(businessHoursAutomation)
+
+	autoReplyEngine := autoReplyUseCase.NewEngine(
+		autoReplyRuleRepo,
+		conversationStateRepo,
+		chatRepo,
+		s.whatsappClient,
+		renderTemplateUseCase,
+		businessHoursStatusUseCase,
+	)
+	autoReplyEngine.SetQuotas(s.tenantQuotas)
+	s.storageHandler.AddInboundProcessor(autoReplyEngine)
+
+	createSinkUseCase := eventSinkUseCase.NewCreateSinkUseCase(eventSinkRepo, sessionRepo, s.sinkDispatcher)
+	listSinksUseCase := eventSinkUseCase.NewListSinksUseCase(eventSinkRepo)
+	updateSinkUseCase := eventSinkUseCase.NewUpdateSinkUseCase(eventSinkRepo, s.sinkDispatcher)
+	deleteSinkUseCase := eventSinkUseCase.NewDeleteSinkUseCase(eventSinkRepo, s.sinkDispatcher)
+
+	createSessionUseCase := sessionUseCase.NewCreateUseCase(sessionRepo, s.tenantQuotas)
 	connectSessionUseCase := sessionUseCase.NewConnectUseCase(sessionRepo, s.whatsappClient)
 	disconnectSessionUseCase := sessionUseCase.NewDisconnectUseCase(sessionRepo, s.whatsappClient)
 	listSessionUseCase := sessionUseCase.NewListUseCase(sessionRepo)
 	getStatusSessionUseCase := sessionUseCase.NewGetStatusUseCase(sessionRepo, s.whatsappClient)
+	sessionSendLimitsUseCase := sessionUseCase.NewSendLimitsUseCase(sessionRepo, s.sendGovernor)
+	sessionRetentionUseCase := sessionUseCase.NewMediaRetentionUseCase(sessionRepo, s.tenantRepo, s.retentionRules)
+	sessionsHealthUseCase := sessionUseCase.NewHealthCheckUseCase(sessionRepo, s.whatsappClient, s.config.Timeout.QRStuck)
+
+	createKeyUseCase := apiKeyUseCase.NewCreateKeyUseCase(apiKeyRepo, sessionRepo, s.tenantRepo)
+	listKeysUseCase := apiKeyUseCase.NewListKeysUseCase(apiKeyRepo)
+	rotateKeyUseCase := apiKeyUseCase.NewRotateKeyUseCase(apiKeyRepo)
+	revokeKeyUseCase := apiKeyUseCase.NewRevokeKeyUseCase(apiKeyRepo)
+	authenticateUseCase := apiKeyUseCase.NewAuthenticateUseCase(apiKeyRepo, s.tenantRepo)
+
+	createTenantUseCase := tenantUseCase.NewCreateTenantUseCase(s.tenantRepo)
+	listTenantsUseCase := tenantUseCase.NewListTenantsUseCase(s.tenantRepo)
+	updateTenantUseCase := tenantUseCase.NewUpdateTenantUseCase(s.tenantRepo)
+	deleteTenantUseCase := tenantUseCase.NewDeleteTenantUseCase(s.tenantRepo)
+
+	recordAuditUseCase := auditUseCase.NewRecordUseCase(auditRepo, sessionRepo)
+	listAuditUseCase := auditUseCase.NewListUseCase(auditRepo)
 
 	// Criar handlers
-	messageHandler := handlers.NewMessageHandler(sendTextUseCase, sendMediaUseCase)
+	messageHandler := handlers.NewMessageHandler(sendTextUseCase, sendMediaUseCase, getReceiptsUseCase)
 	sessionHandler := handlers.NewSessionHandler(
 		createSessionUseCase,
 		connectSessionUseCase,
 		disconnectSessionUseCase,
 		listSessionUseCase,
 		getStatusSessionUseCase,
+		sessionSendLimitsUseCase,
+		sessionRetentionUseCase,
+	)
+	templateHandler := handlers.NewTemplateHandler(
+		createTemplateUseCase,
+		getTemplateUseCase,
+		listTemplateUseCase,
+		updateTemplateUseCase,
+		deleteTemplateUseCase,
+		renderTemplateUseCase,
+	)
+	autoReplyHandler := handlers.NewAutoReplyHandler(
+		createRuleUseCase,
+		listRulesUseCase,
+		updateRuleUseCase,
+		deleteRuleUseCase,
+	)
+	businessHoursHandler := handlers.NewBusinessHoursHandler(
+		getBusinessHoursUseCase,
+		setBusinessHoursUseCase,
+		deleteBusinessHoursUseCase,
+		businessHoursStatusUseCase,
 	)
-	healthHandler := handlers.NewHealthHandler("1.0.0")
+	eventStreamHandler := handlers.NewEventStreamHandler(s.eventBroker, func(ctx context.Context) ([]uuid.UUID, error) {
+		sessions, err := sessionRepo.List(ctx, session.ListFilters{})
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]uuid.UUID, 0, len(sessions))
+		for _, sess := range sessions {
+			ids = append(ids, sess.ID)
+		}
+		return ids, nil
+	})
+	eventSinkHandler := handlers.NewEventSinkHandler(
+		createSinkUseCase,
+		listSinksUseCase,
+		updateSinkUseCase,
+		deleteSinkUseCase,
+	)
+	apiKeyHandler := handlers.NewAPIKeyHandler(
+		createKeyUseCase,
+		listKeysUseCase,
+		rotateKeyUseCase,
+		revokeKeyUseCase,
+	)
+	tenantHandler := handlers.NewTenantHandler(
+		createTenantUseCase,
+		listTenantsUseCase,
+		updateTenantUseCase,
+		deleteTenantUseCase,
+		s.tenantQuotas,
+	)
+	auditHandler := handlers.NewAuditHandler(listAuditUseCase)
+	mediaHandler := handlers.NewMediaHandler(downloadMediaUseCase, retryMediaUseCase)
+	healthHandler := handlers.NewHealthHandler("1.0.0", sessionsHealthUseCase, s.readinessChecks()...)
 
 	// Configurar router
 	routerConfig := router.Config{
-		APIKey:          s.config.Auth.APIKey,
-		RateLimitReqs:   s.config.RateLimit.Requests,
-		RateLimitWindow: s.config.RateLimit.Window.String(),
-		CORSOrigins:     s.config.CORS.AllowedOrigins,
-		CORSMethods:     s.config.CORS.AllowedMethods,
-		CORSHeaders:     s.config.CORS.AllowedHeaders,
+		APIKey:      s.config.Auth.APIKey,
+		CORSOrigins: s.config.CORS.AllowedOrigins,
+		CORSMethods: s.config.CORS.AllowedMethods,
+		CORSHeaders: s.config.CORS.AllowedHeaders,
+		RateLimiter: s.rateLimiter,
+		RateLimits:  rateLimitPolicies(&s.config.RateLimit),
+
+		KeyAuthenticator: authenticateUseCase,
+		AuditRecorder:    recordAuditUseCase,
+		SessionResolver: func(ctx context.Context, identifier string) (uuid.UUID, error) {
+			// O repositório filtra pelo tenant do contexto: sessões de outros tenants não são encontradas
+			if sessionID, err := uuid.Parse(identifier); err == nil {
+				sess, err := sessionRepo.GetByID(ctx, sessionID)
+				if err != nil {
+					return uuid.Nil, err
+				}
+				return sess.ID, nil
+			}
+			sess, err := getStatusSessionUseCase.GetByName(ctx, identifier)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return sess.ID, nil
+		},
+	}
+	if s.config.Metrics.Enabled {
+		routerConfig.MetricsToken = s.config.Metrics.Token
+	}
+	if s.mediaStorage != nil {
+		if local, ok := s.mediaStorage.Backend().(*storage.LocalBackend); ok {
+			routerConfig.FileServer = local.Handler()
+		}
 	}
 
-	appRouter := router.NewRouter(routerConfig, sessionHandler, messageHandler, healthHandler)
+	appRouter := router.NewRouter(routerConfig, sessionHandler, messageHandler, templateHandler, autoReplyHandler, businessHoursHandler, eventStreamHandler, eventSinkHandler, healthHandler, apiKeyHandler, tenantHandler, auditHandler, mediaHandler)
 	return appRouter.Setup()
 }
 
+// readinessChecks monta as verificações do /ready; sem banco ou store a instância não deve receber tráfego
+func (s *Server) readinessChecks() []handlers.ReadinessCheck {
+	checks := []handlers.ReadinessCheck{
+		{Name: "database", Critical: true, Check: s.bunDB.HealthCheck},
+		{Name: "whatsapp_store", Critical: true, Check: s.storeManager.HealthCheck},
+	}
+
+	// Sem armazenamento apenas a gravação das mídias recebidas fica indisponível
+	if s.mediaStorage != nil {
+		checks = append(checks, handlers.ReadinessCheck{Name: "storage", Critical: false, Check: s.mediaStorage.HealthCheck})
+	}
+
+	// Sem Redis o rate limiting continua com limites locais a cada réplica
+	if s.redisLimiter != nil {
+		checks = append(checks, handlers.ReadinessCheck{Name: "redis", Critical: false, Check: s.redisLimiter.Ping})
+	}
+
+	return checks
+}
+
+// startMediaBackfill inicia o download periódico das mídias recebidas que não foram armazenadas
+func (s *Server) startMediaBackfill() {
+	if s.mediaBackfill == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopBackfill = cancel
+	s.backfillDone = make(chan struct{})
+
+	go func() {
+		defer close(s.backfillDone)
+		s.mediaBackfill.Run(ctx, s.config.Media.BackfillInterval, mediaUseCase.BackfillRequest{
+			Limit:       s.config.Media.BackfillBatch,
+			Concurrency: s.config.Media.BackfillConcurrency,
+			MaxAttempts: s.config.Media.BackfillMaxAttempts,
+			MaxAge:      s.config.Media.BackfillMaxAge,
+		})
+	}()
+
+	s.logger.Info().
+		Dur("interval", s.config.Media.BackfillInterval).
+		Int("batch", s.config.Media.BackfillBatch).
+		Int("concurrency", s.config.Media.BackfillConcurrency).
+		Msg("Download periódico de mídias pendentes iniciado")
+}
+
+// stopMediaBackfill interrompe o download periódico e aguarda os downloads em andamento
+func (s *Server) stopMediaBackfill() {
+	if s.stopBackfill == nil {
+		return
+	}
+	s.stopBackfill()
+	<-s.backfillDone
+}
+
+// startMediaRetention inicia a remoção periódica das mídias vencidas pelas políticas de retenção
+func (s *Server) startMediaRetention() {
+	if s.mediaRetention == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopRetention = cancel
+	s.retentionDone = make(chan struct{})
+
+	go func() {
+		defer close(s.retentionDone)
+		s.mediaRetention.Run(ctx, s.config.Media.RetentionInterval, mediaUseCase.RetentionRequest{
+			Limit: s.config.Media.RetentionBatch,
+		})
+	}()
+
+	s.logger.Info().
+		Dur("interval", s.config.Media.RetentionInterval).
+		Int("batch", s.config.Media.RetentionBatch).
+		Str("default_policy", s.config.Media.RetentionDefault).
+		Msg("Retenção periódica de mídias iniciada")
+}
+
+// stopMediaRetention interrompe a retenção periódica e aguarda a execução em andamento
+func (s *Server) stopMediaRetention() {
+	if s.stopRetention == nil {
+		return
+	}
+	s.stopRetention()
+	<-s.retentionDone
+}
+
 // Start inicia o servidor HTTP
 func (s *Server) Start() error {
 	s.logger.WithFields(map[string]interface{}{
@@ -292,6 +716,9 @@ func (s *Server) Start() error {
 		// Não retornar erro aqui para não impedir o servidor de iniciar
 	}
 
+	s.startMediaBackfill()
+	s.startMediaRetention()
+
 	// Canal para capturar sinais do sistema
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -334,6 +761,22 @@ func (s *Server) Stop() error {
 		return err
 	}
 
+	// Interromper o download das mídias pendentes antes de desconectar as sessões
+	s.stopMediaBackfill()
+	s.stopMediaRetention()
+
+	// Parar sinks de eventos, persistindo em disco o que estiver na fila
+	if s.sinkDispatcher != nil {
+		s.sinkDispatcher.Close()
+	}
+
+	// Fechar conexão com o Redis do rate limiting
+	if s.redisLimiter != nil {
+		if err := s.redisLimiter.Close(); err != nil {
+			s.logger.Error().Err(err).Msg("Erro fechar Redis")
+		}
+	}
+
 	// Fechar store manager do WhatsApp
 	if s.storeManager != nil {
 		if err := s.storeManager.Close(); err != nil {
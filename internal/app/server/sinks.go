@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/domain/eventsink"
+	eventSink "zapcore/internal/infra/eventsink"
+
+	"github.com/google/uuid"
+)
+
+// newSinkDispatcher cria o dispatcher com os sinks globais (variáveis SINK_*) e os sinks por sessão
+func newSinkDispatcher(cfg *config.Config, sinkRepo eventsink.Repository) (*eventSink.Dispatcher, error) {
+	dispatcher := eventSink.NewDispatcher(eventSink.Options{
+		BufferDir:      cfg.Sinks.BufferDir,
+		BufferMaxBytes: cfg.Sinks.BufferMaxBytes,
+		QueueSize:      cfg.Sinks.QueueSize,
+		EnqueueTimeout: cfg.Sinks.EnqueueTimeout,
+	})
+
+	ctx := context.Background()
+
+	for _, sink := range globalSinks(&cfg.Sinks) {
+		if err := sink.Validate(); err != nil {
+			return nil, fmt.Errorf("sink global %s: %w", sink.Name, err)
+		}
+		if err := dispatcher.Apply(ctx, sink); err != nil {
+			return nil, fmt.Errorf("sink global %s: %w", sink.Name, err)
+		}
+	}
+
+	if err := dispatcher.Load(ctx, sinkRepo); err != nil {
+		return nil, err
+	}
+
+	return dispatcher, nil
+}
+
+// globalSinks monta os sinks configurados por ambiente; SessionID nulo recebe todas as sessões
+func globalSinks(cfg *config.SinksConfig) []*eventsink.Sink {
+	var sinks []*eventsink.Sink
+
+	add := func(driver eventsink.Driver, url string, options map[string]string) {
+		if url == "" {
+			return
+		}
+		sink := eventsink.NewSink(uuid.Nil, "global-"+string(driver), driver, url)
+		// ID estável para reaproveitar o buffer em disco entre reinícios
+		sink.ID = uuid.NewSHA1(uuid.NameSpaceOID, []byte("zapcore.sink."+string(driver)))
+		sink.Subject = cfg.Subject
+		sink.Types = cfg.Types
+		for key, value := range options {
+			if value != "" {
+				sink.Options[key] = value
+			}
+		}
+		sinks = append(sinks, sink)
+	}
+
+	add(eventsink.DriverNATS, cfg.NATSURL, map[string]string{eventsink.OptionNATSStream: cfg.NATSStream})
+	add(eventsink.DriverAMQP, cfg.AMQPURL, map[string]string{eventsink.OptionAMQPExchange: cfg.AMQPExchange})
+	add(eventsink.DriverKafka, cfg.KafkaBrokers, map[string]string{eventsink.OptionKafkaTopic: cfg.KafkaTopic})
+
+	return sinks
+}
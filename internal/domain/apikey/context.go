@@ -0,0 +1,16 @@
+package apikey
+
+import "context"
+
+type principalContextKey struct{}
+
+// WithPrincipal associa o principal autenticado ao contexto
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext retorna o principal autenticado, se houver
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,241 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/ratelimit"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Scope representa uma permissão concedida a uma chave de API
+type Scope string
+
+const (
+	ScopeSessionsRead   Scope = "sessions:read"
+	ScopeSessionsWrite  Scope = "sessions:write"
+	ScopeMessagesSend   Scope = "messages:send"
+	ScopeTemplatesRead  Scope = "templates:read"
+	ScopeTemplatesWrite Scope = "templates:write"
+	ScopeEventsRead     Scope = "events:read"
+	ScopeAdmin          Scope = "admin" // Todas as permissões, incluindo o gerenciamento de chaves
+)
+
+// KeyPrefix identifica as chaves geradas pelo ZapCore
+const KeyPrefix = "zc_"
+
+// displayPrefixLength é a quantidade de caracteres guardados em claro para identificar a chave
+const displayPrefixLength = 11
+
+// AllScopes lista os escopos suportados
+var AllScopes = []Scope{
+	ScopeSessionsRead,
+	ScopeSessionsWrite,
+	ScopeMessagesSend,
+	ScopeTemplatesRead,
+	ScopeTemplatesWrite,
+	ScopeEventsRead,
+	ScopeAdmin,
+}
+
+// IsValid verifica se o escopo é suportado
+func (s Scope) IsValid() bool {
+	for _, scope := range AllScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey representa uma chave de API emitida para um cliente
+type APIKey struct {
+	bun.BaseModel `bun:"table:zapcore_api_keys,alias:ak"`
+
+	ID         uuid.UUID   `bun:"id,pk,type:uuid" json:"id"`
+	TenantID   string      `bun:"tenantId,type:varchar(100),notnull" json:"tenantId"`
+	Name       string      `bun:"name,type:varchar(100),notnull" json:"name"`
+	Prefix     string      `bun:"prefix,type:varchar(16),notnull" json:"prefix"`
+	KeyHash    string      `bun:"keyHash,type:varchar(64),notnull,unique" json:"-"`
+	Scopes     []Scope     `bun:"scopes,type:jsonb,notnull" json:"scopes"`
+	SessionIDs []uuid.UUID `bun:"sessionIds,type:jsonb" json:"sessionIds,omitempty"` // Vazio libera todas as sessões
+	ExpiresAt  *time.Time  `bun:"expiresAt,type:timestamptz" json:"expiresAt,omitempty"`
+	RateLimit  *RateLimit  `bun:"rateLimit,type:jsonb" json:"rateLimit,omitempty"` // Vazio usa a política padrão por chave
+	LastUsedAt *time.Time  `bun:"lastUsedAt,type:timestamptz" json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time  `bun:"revokedAt,type:timestamptz" json:"revokedAt,omitempty"`
+	CreatedAt  time.Time   `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt  time.Time   `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+}
+
+// RateLimit é a política de rate limiting própria de uma chave, que substitui a padrão
+type RateLimit struct {
+	Requests      int `json:"requests"`
+	WindowSeconds int `json:"windowSeconds"`
+	Burst         int `json:"burst,omitempty"`
+}
+
+// Policy converte o limite da chave em uma política de token bucket
+func (r *RateLimit) Policy() ratelimit.Policy {
+	return ratelimit.Policy{
+		Name:     "key",
+		Requests: r.Requests,
+		Window:   time.Duration(r.WindowSeconds) * time.Second,
+		Burst:    r.Burst,
+	}
+}
+
+// NewAPIKey cria uma nova chave e retorna também o segredo em claro, que não é persistido
+func NewAPIKey(tenantID, name string, scopes []Scope, sessionIDs []uuid.UUID, expiresAt *time.Time) (*APIKey, string, error) {
+	now := time.Now()
+	key := &APIKey{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		Name:       strings.TrimSpace(name),
+		Scopes:     scopes,
+		SessionIDs: sessionIDs,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	secret, err := key.Rotate()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, secret, nil
+}
+
+// Rotate gera um novo segredo para a chave; o anterior deixa de valer imediatamente
+func (k *APIKey) Rotate() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("erro ao gerar chave de API: %w", err)
+	}
+
+	secret := KeyPrefix + hex.EncodeToString(buf)
+	k.Prefix = secret[:displayPrefixLength]
+	k.KeyHash = HashKey(secret)
+	k.UpdatedAt = time.Now()
+
+	return secret, nil
+}
+
+// Revoke revoga a chave
+func (k *APIKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// IsRevoked verifica se a chave foi revogada
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired verifica se a chave expirou
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Validate valida os dados da chave
+func (k *APIKey) Validate() error {
+	if k.Name == "" {
+		return NewKeyValidationError("name", "nome é obrigatório")
+	}
+	if len(k.Name) > 100 {
+		return NewKeyValidationError("name", "nome deve ter no máximo 100 caracteres")
+	}
+	if len(k.Scopes) == 0 {
+		return NewKeyValidationError("scopes", "informe ao menos um escopo")
+	}
+	for _, scope := range k.Scopes {
+		if !scope.IsValid() {
+			return NewKeyValidationError("scopes", "escopo desconhecido: "+string(scope))
+		}
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		return NewKeyValidationError("expiresAt", "data de expiração deve estar no futuro")
+	}
+	if k.RateLimit != nil {
+		if k.RateLimit.Requests <= 0 || k.RateLimit.WindowSeconds <= 0 {
+			return NewKeyValidationError("rateLimit", "requests e windowSeconds devem ser maiores que zero")
+		}
+		if k.RateLimit.Burst < 0 {
+			return NewKeyValidationError("rateLimit", "burst não pode ser negativo")
+		}
+	}
+	return nil
+}
+
+// HashKey calcula o hash SHA-256 de uma chave em claro
+func HashKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Principal representa quem está autenticado na requisição
+type Principal struct {
+	KeyID      uuid.UUID   `json:"keyId"`
+	TenantID   string      `json:"tenantId,omitempty"` // Vazio apenas na chave mestre, que enxerga todos os tenants
+	Name       string      `json:"name"`
+	Master     bool        `json:"master"`
+	Scopes     []Scope     `json:"scopes"`
+	SessionIDs []uuid.UUID `json:"sessionIds,omitempty"`
+	RateLimit  *RateLimit  `json:"rateLimit,omitempty"`
+}
+
+// MasterPrincipal representa a chave mestre da configuração, com acesso total
+func MasterPrincipal() *Principal {
+	return &Principal{
+		Name:   "master",
+		Master: true,
+		Scopes: []Scope{ScopeAdmin},
+	}
+}
+
+// NewPrincipal cria o principal de uma chave de API
+func NewPrincipal(key *APIKey) *Principal {
+	return &Principal{
+		KeyID:      key.ID,
+		TenantID:   key.TenantID,
+		Name:       key.Name,
+		Scopes:     key.Scopes,
+		SessionIDs: key.SessionIDs,
+		RateLimit:  key.RateLimit,
+	}
+}
+
+// HasScope verifica se o principal tem o escopo; admin concede todos
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRestricted indica se o acesso está limitado a uma lista de sessões
+func (p *Principal) IsRestricted() bool {
+	return len(p.SessionIDs) > 0
+}
+
+// CanAccessSession verifica se o principal pode operar a sessão
+func (p *Principal) CanAccessSession(sessionID uuid.UUID) bool {
+	if !p.IsRestricted() {
+		return true
+	}
+	for _, id := range p.SessionIDs {
+		if id == sessionID {
+			return true
+		}
+	}
+	return false
+}
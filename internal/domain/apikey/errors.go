@@ -0,0 +1,31 @@
+package apikey
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Erros específicos do domínio de chaves de API
+var (
+	ErrKeyNotFound = errors.New("chave de API não encontrada")
+	ErrKeyRevoked  = errors.New("chave de API revogada")
+	ErrKeyExpired  = errors.New("chave de API expirada")
+)
+
+// KeyValidationError representa um erro de validação de chave de API
+type KeyValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *KeyValidationError) Error() string {
+	return fmt.Sprintf("chave de API inválida no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewKeyValidationError cria um novo erro de validação de chave de API
+func NewKeyValidationError(field, message string) *KeyValidationError {
+	return &KeyValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
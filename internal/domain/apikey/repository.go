@@ -0,0 +1,18 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository define a interface para persistência das chaves de API
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*APIKey, error)
+	List(ctx context.Context) ([]*APIKey, error)
+	Update(ctx context.Context, key *APIKey) error
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
@@ -0,0 +1,18 @@
+package audit
+
+import "context"
+
+type entryContextKey struct{}
+
+// WithEntry associa ao contexto o registro de auditoria da requisição em andamento
+func WithEntry(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, entryContextKey{}, entry)
+}
+
+// SetResource informa o recurso afetado quando ele só é conhecido após a execução
+// (ex.: ID gerado na criação). Sem registro no contexto, não faz nada.
+func SetResource(ctx context.Context, resourceID string) {
+	if entry, ok := ctx.Value(entryContextKey{}).(*Entry); ok && entry != nil {
+		entry.ResourceID = resourceID
+	}
+}
@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Action identifica a operação registrada na trilha de auditoria, no formato <recurso>.<operação>
+type Action string
+
+const (
+	ActionSessionCreate  Action = "session.create"
+	ActionSessionConnect Action = "session.connect"
+	ActionSessionLogout  Action = "session.logout"
+	ActionSessionDelete  Action = "session.delete"
+
+	ActionSessionSendLimitsSet    Action = "session.send_limits.set"
+	ActionSessionSendLimitsDelete Action = "session.send_limits.delete"
+	ActionSessionRetentionSet     Action = "session.media_retention.set"
+	ActionSessionRetentionDelete  Action = "session.media_retention.delete"
+
+	ActionMessageSend       Action = "message.send"
+	ActionMessageMediaRetry Action = "message.media_retry"
+
+	ActionKeyCreate Action = "key.create"
+	ActionKeyRotate Action = "key.rotate"
+	ActionKeyRevoke Action = "key.revoke"
+
+	ActionTenantCreate Action = "tenant.create"
+	ActionTenantUpdate Action = "tenant.update"
+	ActionTenantDelete Action = "tenant.delete"
+
+	ActionTemplateCreate Action = "template.create"
+	ActionTemplateUpdate Action = "template.update"
+	ActionTemplateDelete Action = "template.delete"
+
+	ActionAutoReplyCreate Action = "autoreply.create"
+	ActionAutoReplyUpdate Action = "autoreply.update"
+	ActionAutoReplyDelete Action = "autoreply.delete"
+
+	ActionBusinessHoursSet    Action = "businesshours.set"
+	ActionBusinessHoursDelete Action = "businesshours.delete"
+
+	ActionSinkCreate Action = "sink.create"
+	ActionSinkUpdate Action = "sink.update"
+	ActionSinkDelete Action = "sink.delete"
+)
+
+// Resource retorna o tipo de recurso afetado pela ação (parte antes do ponto)
+func (a Action) Resource() string {
+	resource, _, _ := strings.Cut(string(a), ".")
+	return resource
+}
+
+// ActorType identifica o tipo de credencial que executou a ação
+type ActorType string
+
+const (
+	ActorMaster ActorType = "master" // Chave mestre da configuração
+	ActorKey    ActorType = "key"    // Chave emitida pelo /admin/keys
+	ActorCLI    ActorType = "cli"    // Comando administrativo executado no servidor
+)
+
+// Outcome indica se a ação foi concluída
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Entry representa um registro imutável da trilha de auditoria
+type Entry struct {
+	bun.BaseModel `bun:"table:zapcore_audit_log,alias:al"`
+
+	ID         uuid.UUID      `bun:"id,pk,type:uuid" json:"id"`
+	TenantID   string         `bun:"tenantId,type:varchar(100),nullzero" json:"tenantId,omitempty"`
+	ActorType  ActorType      `bun:"actorType,type:varchar(20),notnull" json:"actorType"`
+	KeyID      *uuid.UUID     `bun:"keyId,type:uuid" json:"keyId,omitempty"`
+	ActorName  string         `bun:"actorName,type:varchar(255),notnull" json:"actorName"`
+	IP         string         `bun:"ip,type:varchar(64),nullzero" json:"ip,omitempty"`
+	UserAgent  string         `bun:"userAgent,type:varchar(255),nullzero" json:"userAgent,omitempty"`
+	RequestID  string         `bun:"requestId,type:varchar(100),nullzero" json:"requestId,omitempty"`
+	Action     Action         `bun:"action,type:varchar(50),notnull" json:"action"`
+	ResourceID string         `bun:"resourceId,type:varchar(255),nullzero" json:"resourceId,omitempty"`
+	SessionID  *uuid.UUID     `bun:"sessionId,type:uuid" json:"sessionId,omitempty"`
+	Method     string         `bun:"method,type:varchar(10),nullzero" json:"method,omitempty"`
+	Path       string         `bun:"path,type:text,nullzero" json:"path,omitempty"`
+	StatusCode int            `bun:"statusCode,nullzero" json:"statusCode,omitempty"`
+	Outcome    Outcome        `bun:"outcome,type:varchar(20),notnull" json:"outcome"`
+	Details    map[string]any `bun:"details,type:jsonb,nullzero" json:"details,omitempty"`
+	CreatedAt  time.Time      `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+}
+
+// NewEntry cria um novo registro de auditoria para a ação informada
+func NewEntry(action Action, actorType ActorType, actorName string) *Entry {
+	return &Entry{
+		ID:        uuid.New(),
+		Action:    action,
+		ActorType: actorType,
+		ActorName: actorName,
+		Outcome:   OutcomeSuccess,
+		CreatedAt: time.Now(),
+	}
+}
+
+// SetOutcome registra o resultado a partir do status HTTP da resposta
+func (e *Entry) SetOutcome(statusCode int) {
+	e.StatusCode = statusCode
+	e.Outcome = OutcomeSuccess
+	if statusCode >= 400 {
+		e.Outcome = OutcomeFailure
+	}
+}
+
+// SetError registra o resultado de uma ação executada fora do HTTP
+func (e *Entry) SetError(err error) {
+	if err == nil {
+		e.Outcome = OutcomeSuccess
+		return
+	}
+	e.Outcome = OutcomeFailure
+	if e.Details == nil {
+		e.Details = make(map[string]any)
+	}
+	e.Details["error"] = err.Error()
+}
+
+// ListFilters define os filtros para consulta da trilha de auditoria
+type ListFilters struct {
+	TenantID   string     `json:"tenantId,omitempty"`
+	Action     Action     `json:"action,omitempty"`
+	ActorName  string     `json:"actor,omitempty"`
+	KeyID      *uuid.UUID `json:"keyId,omitempty"`
+	SessionID  *uuid.UUID `json:"sessionId,omitempty"`
+	ResourceID string     `json:"resourceId,omitempty"`
+	RequestID  string     `json:"requestId,omitempty"`
+	Outcome    Outcome    `json:"outcome,omitempty"`
+	From       *time.Time `json:"from,omitempty"`
+	To         *time.Time `json:"to,omitempty"`
+	Limit      int        `json:"limit,omitempty"`
+	Offset     int        `json:"offset,omitempty"`
+}
@@ -0,0 +1,21 @@
+package audit
+
+import "fmt"
+
+// FilterValidationError representa um filtro de consulta inválido
+type FilterValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *FilterValidationError) Error() string {
+	return fmt.Sprintf("filtro de auditoria inválido no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewFilterValidationError cria um novo erro de validação de filtro
+func NewFilterValidationError(field, message string) *FilterValidationError {
+	return &FilterValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
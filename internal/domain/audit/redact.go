@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Redacted substitui valores sensíveis nos detalhes da auditoria
+const Redacted = "[REDACTED]"
+
+// Truncated substitui valores grandes demais para a trilha (ex.: mídia em base64)
+const Truncated = "[TRUNCATED]"
+
+// maxValueBytes é o tamanho máximo, em JSON, de um valor guardado nos detalhes
+const maxValueBytes = 1024
+
+// sensitiveFields são removidos dos detalhes: credenciais e conteúdo das mensagens
+var sensitiveFields = map[string]bool{
+	"key":           true,
+	"apikey":        true,
+	"secret":        true,
+	"password":      true,
+	"token":         true,
+	"authorization": true,
+	"options":       true, // Opções dos sinks podem conter credenciais
+	"text":          true,
+	"caption":       true,
+	"base64":        true,
+	"base64_data":   true,
+	"variables":     true,
+}
+
+// urlFields têm usuário, senha e query string removidos, preservando o destino
+var urlFields = map[string]bool{
+	"url":       true,
+	"media_url": true,
+	"webhook":   true,
+}
+
+// RedactJSON converte o corpo JSON de uma requisição em detalhes seguros para a trilha.
+// Retorna nil quando o corpo não é um objeto JSON.
+func RedactJSON(body []byte) map[string]any {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil
+	}
+
+	redacted := make(map[string]any, len(fields))
+	for name, raw := range fields {
+		if len(raw) > maxValueBytes && !sensitiveFields[strings.ToLower(name)] {
+			redacted[name] = Truncated
+			continue
+		}
+
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			continue
+		}
+		redacted[name] = redactValue(name, value)
+	}
+
+	return redacted
+}
+
+// redactValue aplica as regras de redação ao campo e, recursivamente, aos seus filhos
+func redactValue(name string, value any) any {
+	field := strings.ToLower(name)
+	if sensitiveFields[field] {
+		return Redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		if urlFields[field] {
+			return sanitizeURL(v)
+		}
+		return v
+	case map[string]any:
+		for key, child := range v {
+			v[key] = redactValue(key, child)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(name, child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// sanitizeURL remove credenciais e parâmetros da URL
+func sanitizeURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return Redacted
+	}
+	parsed.User = nil
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
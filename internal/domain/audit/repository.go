@@ -0,0 +1,17 @@
+package audit
+
+import "context"
+
+// Repository define a interface para persistência da trilha de auditoria.
+// A trilha é append-only: não há operações de atualização ou remoção.
+type Repository interface {
+	// Create grava um novo registro
+	Create(ctx context.Context, entry *Entry) error
+
+	// List retorna registros com filtros opcionais, dos mais recentes para os mais antigos
+	List(ctx context.Context, filters ListFilters) ([]*Entry, error)
+
+	// Stream percorre todos os registros que atendem aos filtros, em ordem cronológica,
+	// sem carregá-los todos em memória. Limit e Offset são ignorados.
+	Stream(ctx context.Context, filters ListFilters, fn func(*Entry) error) error
+}
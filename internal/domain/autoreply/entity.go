@@ -0,0 +1,413 @@
+package autoreply
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// MatchType representa a forma de comparação do texto da mensagem
+type MatchType string
+
+const (
+	MatchTypeAny      MatchType = "any"
+	MatchTypeExact    MatchType = "exact"
+	MatchTypeContains MatchType = "contains"
+	MatchTypeRegex    MatchType = "regex"
+)
+
+// ChatType representa o tipo de chat aceito pela regra
+type ChatType string
+
+const (
+	ChatTypeAny        ChatType = "any"
+	ChatTypeIndividual ChatType = "individual"
+	ChatTypeGroup      ChatType = "group"
+)
+
+// HoursCondition indica se a regra vale dentro ou fora do horário comercial
+type HoursCondition string
+
+const (
+	HoursAny    HoursCondition = ""
+	HoursOpen   HoursCondition = "open"
+	HoursClosed HoursCondition = "closed"
+)
+
+// ActionType representa os tipos de ação executados por uma regra
+type ActionType string
+
+const (
+	ActionReplyText     ActionType = "reply_text"
+	ActionReplyMedia    ActionType = "reply_media"
+	ActionReplyTemplate ActionType = "reply_template"
+	ActionAddLabel      ActionType = "add_label"
+	ActionWebhook       ActionType = "webhook"
+	ActionSetStep       ActionType = "set_step"
+	ActionEndFlow       ActionType = "end_flow"
+	ActionStop          ActionType = "stop"
+)
+
+// DefaultStepTimeout é o tempo padrão de expiração de um passo de fluxo
+const DefaultStepTimeout = 10 * time.Minute
+
+// Schedule representa uma janela semanal de horário comercial
+type Schedule struct {
+	Timezone string `json:"timezone,omitempty"` // Ex: America/Sao_Paulo
+	Days     []int  `json:"days"`               // 0 = domingo ... 6 = sábado
+	Start    string `json:"start"`              // HH:MM
+	End      string `json:"end"`                // HH:MM
+}
+
+// Conditions define quando uma regra é aplicada
+type Conditions struct {
+	MatchType     MatchType      `json:"matchType"`
+	Pattern       string         `json:"pattern,omitempty"`
+	CaseSensitive bool           `json:"caseSensitive,omitempty"`
+	MessageTypes  []string       `json:"messageTypes,omitempty"`
+	ChatType      ChatType       `json:"chatType,omitempty"`
+	BusinessHours HoursCondition `json:"businessHours,omitempty"`
+	Schedule      *Schedule      `json:"schedule,omitempty"`
+	Step          string         `json:"step,omitempty"` // Passo de fluxo exigido
+}
+
+// Action define uma ação executada quando a regra casa
+type Action struct {
+	Type ActionType `json:"type"`
+
+	// reply_text / reply_media
+	Text      string `json:"text,omitempty"`
+	MediaURL  string `json:"mediaUrl,omitempty"`
+	MediaType string `json:"mediaType,omitempty"` // image, video, audio, document
+	Caption   string `json:"caption,omitempty"`
+	FileName  string `json:"fileName,omitempty"`
+
+	// reply_template
+	TemplateID *uuid.UUID        `json:"templateId,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	Language   string            `json:"language,omitempty"`
+
+	// add_label
+	Label string `json:"label,omitempty"`
+
+	// webhook
+	WebhookURL string `json:"webhookUrl,omitempty"`
+
+	// set_step
+	Step           string `json:"step,omitempty"`
+	TimeoutSeconds int    `json:"timeoutSeconds,omitempty"`
+}
+
+// Rule representa uma regra de resposta automática de uma sessão
+type Rule struct {
+	bun.BaseModel `bun:"table:zapcore_autoreply_rules,alias:ar"`
+
+	ID         uuid.UUID  `bun:"id,pk,type:uuid" json:"id"`
+	SessionID  uuid.UUID  `bun:"sessionId,type:uuid,notnull" json:"sessionId"`
+	Name       string     `bun:"name,type:varchar(100),notnull" json:"name"`
+	Priority   int        `bun:"priority,type:integer,notnull" json:"priority"`
+	IsActive   bool       `bun:"isActive,type:boolean" json:"isActive"`
+	Conditions Conditions `bun:"conditions,type:jsonb" json:"conditions"`
+	Actions    []Action   `bun:"actions,type:jsonb" json:"actions"`
+	CreatedAt  time.Time  `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt  time.Time  `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+}
+
+// NewRule cria uma nova instância de Rule
+func NewRule(sessionID uuid.UUID, name string) *Rule {
+	now := time.Now()
+	return &Rule{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		Name:      name,
+		IsActive:  true,
+		Conditions: Conditions{
+			MatchType: MatchTypeAny,
+			ChatType:  ChatTypeAny,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// ConversationState guarda o passo atual de um fluxo em um chat
+type ConversationState struct {
+	bun.BaseModel `bun:"table:zapcore_autoreply_states,alias:ast"`
+
+	ID        uuid.UUID      `bun:"id,pk,type:uuid" json:"id"`
+	SessionID uuid.UUID      `bun:"sessionId,type:uuid,notnull,unique:zapcore_autoreply_states_chat" json:"sessionId"`
+	ChatJID   string         `bun:"chatJid,type:varchar(100),notnull,unique:zapcore_autoreply_states_chat" json:"chatJid"`
+	Step      string         `bun:"step,type:varchar(100),notnull" json:"step"`
+	Data      map[string]any `bun:"data,type:jsonb" json:"data,omitempty"`
+	ExpiresAt time.Time      `bun:"expiresAt,type:timestamptz,notnull" json:"expiresAt"`
+	CreatedAt time.Time      `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt time.Time      `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+}
+
+// IsExpired verifica se o estado do fluxo expirou
+func (s *ConversationState) IsExpired(now time.Time) bool {
+	return now.After(s.ExpiresAt)
+}
+
+// MatchInput reúne os dados da mensagem avaliados pelas regras
+type MatchInput struct {
+	Text        string
+	MessageType string
+	IsGroup     bool
+	Now         time.Time
+	Step        string
+	IsOpen      *bool // Horário comercial da sessão, quando conhecido
+}
+
+// Validate valida a regra antes de persistir
+func (r *Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrInvalidRuleName
+	}
+	if len(r.Actions) == 0 {
+		return ErrRuleWithoutActions
+	}
+
+	switch r.Conditions.MatchType {
+	case "", MatchTypeAny:
+	case MatchTypeExact, MatchTypeContains:
+		if r.Conditions.Pattern == "" {
+			return NewRuleValidationError("conditions.pattern", "padrão obrigatório para este tipo de comparação")
+		}
+	case MatchTypeRegex:
+		if _, err := regexp.Compile(r.Conditions.Pattern); err != nil {
+			return NewRuleValidationError("conditions.pattern", fmt.Sprintf("regex inválida: %v", err))
+		}
+	default:
+		return NewRuleValidationError("conditions.matchType", fmt.Sprintf("tipo de comparação desconhecido: %s", r.Conditions.MatchType))
+	}
+
+	if r.Conditions.Schedule != nil {
+		if err := r.Conditions.Schedule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i, action := range r.Actions {
+		if err := action.Validate(); err != nil {
+			return NewRuleValidationError(fmt.Sprintf("actions[%d]", i), err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Validate valida os campos obrigatórios de cada tipo de ação
+func (a Action) Validate() error {
+	switch a.Type {
+	case ActionReplyText:
+		if a.Text == "" {
+			return fmt.Errorf("'text' obrigatório")
+		}
+	case ActionReplyMedia:
+		if a.MediaURL == "" || a.MediaType == "" {
+			return fmt.Errorf("'mediaUrl' e 'mediaType' obrigatórios")
+		}
+	case ActionReplyTemplate:
+		if a.TemplateID == nil {
+			return fmt.Errorf("'templateId' obrigatório")
+		}
+	case ActionAddLabel:
+		if a.Label == "" {
+			return fmt.Errorf("'label' obrigatório")
+		}
+	case ActionWebhook:
+		if !strings.HasPrefix(a.WebhookURL, "http://") && !strings.HasPrefix(a.WebhookURL, "https://") {
+			return fmt.Errorf("'webhookUrl' deve ser uma URL HTTP/HTTPS")
+		}
+	case ActionSetStep:
+		if a.Step == "" {
+			return fmt.Errorf("'step' obrigatório")
+		}
+	case ActionEndFlow, ActionStop:
+	default:
+		return fmt.Errorf("tipo de ação desconhecido: %s", a.Type)
+	}
+	return nil
+}
+
+// StepTimeout retorna a duração do passo configurado na ação
+func (a Action) StepTimeout() time.Duration {
+	if a.TimeoutSeconds <= 0 {
+		return DefaultStepTimeout
+	}
+	return time.Duration(a.TimeoutSeconds) * time.Second
+}
+
+// Matches verifica se a regra se aplica à mensagem
+func (r *Rule) Matches(in MatchInput) bool {
+	if !r.IsActive {
+		return false
+	}
+
+	cond := r.Conditions
+
+	// Passo do fluxo
+	if cond.Step != "" && cond.Step != in.Step {
+		return false
+	}
+
+	// Tipo de chat
+	switch cond.ChatType {
+	case ChatTypeGroup:
+		if !in.IsGroup {
+			return false
+		}
+	case ChatTypeIndividual:
+		if in.IsGroup {
+			return false
+		}
+	}
+
+	// Tipo de mensagem
+	if len(cond.MessageTypes) > 0 && !containsString(cond.MessageTypes, in.MessageType) {
+		return false
+	}
+
+	// Horário comercial
+	if cond.BusinessHours != HoursAny {
+		open, known := r.isOpen(in)
+		if !known {
+			return false
+		}
+		if (cond.BusinessHours == HoursOpen) != open {
+			return false
+		}
+	}
+
+	return cond.matchText(in.Text)
+}
+
+// isOpen resolve o horário comercial pela agenda da regra ou pelo valor informado
+func (r *Rule) isOpen(in MatchInput) (bool, bool) {
+	if r.Conditions.Schedule != nil {
+		return r.Conditions.Schedule.IsOpen(in.Now), true
+	}
+	if in.IsOpen != nil {
+		return *in.IsOpen, true
+	}
+	return false, false
+}
+
+// matchText compara o texto da mensagem com o padrão configurado
+func (c Conditions) matchText(text string) bool {
+	pattern := c.Pattern
+	if !c.CaseSensitive && c.MatchType != MatchTypeRegex {
+		text = strings.ToLower(text)
+		pattern = strings.ToLower(pattern)
+	}
+
+	switch c.MatchType {
+	case "", MatchTypeAny:
+		return true
+	case MatchTypeExact:
+		return strings.TrimSpace(text) == strings.TrimSpace(pattern)
+	case MatchTypeContains:
+		return strings.Contains(text, pattern)
+	case MatchTypeRegex:
+		if !c.CaseSensitive && !strings.HasPrefix(pattern, "(?i)") {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(text)
+	default:
+		return false
+	}
+}
+
+// Validate valida a agenda semanal
+func (s *Schedule) Validate() error {
+	if _, err := s.location(); err != nil {
+		return NewRuleValidationError("schedule.timezone", err.Error())
+	}
+	if _, err := parseClock(s.Start); err != nil {
+		return NewRuleValidationError("schedule.start", err.Error())
+	}
+	if _, err := parseClock(s.End); err != nil {
+		return NewRuleValidationError("schedule.end", err.Error())
+	}
+	for _, day := range s.Days {
+		if day < 0 || day > 6 {
+			return NewRuleValidationError("schedule.days", "dias devem estar entre 0 (domingo) e 6 (sábado)")
+		}
+	}
+	return nil
+}
+
+// IsOpen verifica se o instante informado está dentro da agenda
+func (s *Schedule) IsOpen(now time.Time) bool {
+	loc, err := s.location()
+	if err != nil {
+		return false
+	}
+	local := now.In(loc)
+
+	if len(s.Days) > 0 && !containsInt(s.Days, int(local.Weekday())) {
+		return false
+	}
+
+	start, err := parseClock(s.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(s.End)
+	if err != nil {
+		return false
+	}
+
+	minutes := local.Hour()*60 + local.Minute()
+	if start <= end {
+		return minutes >= start && minutes < end
+	}
+	// Janela que atravessa a meia-noite (ex: 22:00-06:00)
+	return minutes >= start || minutes < end
+}
+
+// location retorna o fuso horário da agenda
+func (s *Schedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// parseClock converte HH:MM em minutos desde a meia-noite
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido '%s', use HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// containsString verifica se a lista contém o valor
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsInt verifica se a lista contém o valor
+func containsInt(list []int, value int) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package autoreply
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Erros específicos do domínio de respostas automáticas
+var (
+	ErrRuleNotFound       = errors.New("regra de resposta automática não encontrada")
+	ErrStateNotFound      = errors.New("estado de conversa não encontrado")
+	ErrInvalidRuleName    = errors.New("nome da regra inválido")
+	ErrRuleWithoutActions = errors.New("regra deve possuir ao menos uma ação")
+)
+
+// RuleValidationError representa um erro de validação de regra
+type RuleValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *RuleValidationError) Error() string {
+	return fmt.Sprintf("regra inválida no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewRuleValidationError cria um novo erro de validação de regra
+func NewRuleValidationError(field, message string) *RuleValidationError {
+	return &RuleValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
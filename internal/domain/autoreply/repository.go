@@ -0,0 +1,47 @@
+package autoreply
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RuleRepository define a interface para persistência de regras
+type RuleRepository interface {
+	// Create cria uma nova regra
+	Create(ctx context.Context, rule *Rule) error
+
+	// GetByID busca uma regra pelo ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+
+	// ListBySession retorna as regras de uma sessão ordenadas por prioridade
+	ListBySession(ctx context.Context, sessionID uuid.UUID, onlyActive bool) ([]*Rule, error)
+
+	// Update atualiza uma regra existente
+	Update(ctx context.Context, rule *Rule) error
+
+	// Delete remove uma regra
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// StateRepository define a interface para persistência do estado dos fluxos
+type StateRepository interface {
+	// Get busca o estado do fluxo de um chat
+	Get(ctx context.Context, sessionID uuid.UUID, chatJID string) (*ConversationState, error)
+
+	// Save cria ou substitui o estado do fluxo de um chat
+	Save(ctx context.Context, state *ConversationState) error
+
+	// Delete remove o estado do fluxo de um chat
+	Delete(ctx context.Context, sessionID uuid.UUID, chatJID string) error
+
+	// DeleteExpired remove estados expirados
+	DeleteExpired(ctx context.Context) (int, error)
+}
+
+// BusinessHours informa se uma sessão está em horário de atendimento
+type BusinessHours interface {
+	// IsOpen retorna known = false quando a sessão não possui calendário
+	IsOpen(ctx context.Context, sessionID uuid.UUID, now time.Time) (open bool, known bool, err error)
+}
@@ -0,0 +1,298 @@
+package businesshours
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// dateLayout é o formato das datas de exceção (feriados)
+const dateLayout = "2006-01-02"
+
+// searchDays limita a busca pela próxima abertura/fechamento
+const searchDays = 400
+
+// TimeRange representa um intervalo de horário no formato HH:MM.
+// Quando End <= Start o intervalo termina no dia seguinte.
+type TimeRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// WeeklyRange representa um intervalo de atendimento em um dia da semana
+type WeeklyRange struct {
+	Day int `json:"day"` // 0 = domingo ... 6 = sábado
+	TimeRange
+}
+
+// Holiday representa uma exceção ao calendário semanal
+type Holiday struct {
+	Date  string      `json:"date"` // YYYY-MM-DD
+	Name  string      `json:"name,omitempty"`
+	Hours []TimeRange `json:"hours,omitempty"` // Vazio = fechado o dia todo
+}
+
+// Calendar representa o horário comercial de uma sessão e as mensagens automáticas associadas
+type Calendar struct {
+	bun.BaseModel `bun:"table:zapcore_business_hours,alias:bh"`
+
+	ID              uuid.UUID     `bun:"id,pk,type:uuid" json:"id"`
+	SessionID       uuid.UUID     `bun:"sessionId,type:uuid,notnull,unique" json:"sessionId"`
+	Timezone        string        `bun:"timezone,type:varchar(64),notnull" json:"timezone"`
+	Weekly          []WeeklyRange `bun:"weekly,type:jsonb" json:"weekly"`
+	Holidays        []Holiday     `bun:"holidays,type:jsonb" json:"holidays"`
+	AwayEnabled     bool          `bun:"awayEnabled,type:boolean" json:"awayEnabled"`
+	AwayMessage     string        `bun:"awayMessage,type:text" json:"awayMessage,omitempty"`
+	GreetingEnabled bool          `bun:"greetingEnabled,type:boolean" json:"greetingEnabled"`
+	GreetingMessage string        `bun:"greetingMessage,type:text" json:"greetingMessage,omitempty"`
+	CreatedAt       time.Time     `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt       time.Time     `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+}
+
+// NewCalendar cria uma nova instância de Calendar
+func NewCalendar(sessionID uuid.UUID, timezone string) *Calendar {
+	now := time.Now()
+	return &Calendar{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		Timezone:  timezone,
+		Weekly:    []WeeklyRange{},
+		Holidays:  []Holiday{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// AwayNotice registra a última janela fechada em que um chat recebeu a mensagem de ausência
+type AwayNotice struct {
+	bun.BaseModel `bun:"table:zapcore_away_notices,alias:an"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid" json:"id"`
+	SessionID uuid.UUID `bun:"sessionId,type:uuid,notnull,unique:zapcore_away_notices_chat" json:"sessionId"`
+	ChatJID   string    `bun:"chatJid,type:varchar(100),notnull,unique:zapcore_away_notices_chat" json:"chatJid"`
+	WindowKey string    `bun:"windowKey,type:varchar(64),notnull" json:"windowKey"`
+	SentAt    time.Time `bun:"sentAt,type:timestamptz,notnull" json:"sentAt"`
+}
+
+// Status representa a situação do horário comercial em um instante
+type Status struct {
+	Open        bool       `json:"open"`
+	Timezone    string     `json:"timezone"`
+	LocalTime   time.Time  `json:"localTime"`
+	Holiday     string     `json:"holiday,omitempty"`
+	NextOpening *time.Time `json:"nextOpening,omitempty"`
+	NextClosing *time.Time `json:"nextClosing,omitempty"`
+}
+
+// period é um intervalo concreto de atendimento
+type period struct {
+	start time.Time
+	end   time.Time
+}
+
+// Validate valida o calendário antes de persistir
+func (c *Calendar) Validate() error {
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return NewCalendarValidationError("timezone", fmt.Sprintf("fuso horário inválido: %s", c.Timezone))
+	}
+
+	for i, r := range c.Weekly {
+		if r.Day < 0 || r.Day > 6 {
+			return NewCalendarValidationError(fmt.Sprintf("weekly[%d].day", i), "dia deve estar entre 0 (domingo) e 6 (sábado)")
+		}
+		if err := r.TimeRange.validate(); err != nil {
+			return NewCalendarValidationError(fmt.Sprintf("weekly[%d]", i), err.Error())
+		}
+	}
+
+	for i, h := range c.Holidays {
+		if _, err := time.Parse(dateLayout, h.Date); err != nil {
+			return NewCalendarValidationError(fmt.Sprintf("holidays[%d].date", i), "data deve estar no formato YYYY-MM-DD")
+		}
+		for j, r := range h.Hours {
+			if err := r.validate(); err != nil {
+				return NewCalendarValidationError(fmt.Sprintf("holidays[%d].hours[%d]", i, j), err.Error())
+			}
+		}
+	}
+
+	if c.AwayEnabled && strings.TrimSpace(c.AwayMessage) == "" {
+		return NewCalendarValidationError("awayMessage", "mensagem de ausência obrigatória quando habilitada")
+	}
+	if c.GreetingEnabled && strings.TrimSpace(c.GreetingMessage) == "" {
+		return NewCalendarValidationError("greetingMessage", "mensagem de saudação obrigatória quando habilitada")
+	}
+
+	return nil
+}
+
+// IsOpen verifica se a sessão está em horário de atendimento
+func (c *Calendar) IsOpen(now time.Time) bool {
+	_, open := c.currentPeriod(now)
+	return open
+}
+
+// StatusAt calcula a situação do calendário no instante informado
+func (c *Calendar) StatusAt(now time.Time) Status {
+	loc := c.location()
+	local := now.In(loc)
+
+	status := Status{
+		Timezone:  loc.String(),
+		LocalTime: local,
+	}
+	if h := c.holiday(local); h != nil {
+		status.Holiday = h.Name
+		if status.Holiday == "" {
+			status.Holiday = h.Date
+		}
+	}
+
+	if p, open := c.currentPeriod(now); open {
+		status.Open = true
+		end := p.end
+		status.NextClosing = &end
+	} else if next, ok := c.NextOpening(now); ok {
+		status.NextOpening = &next
+	}
+
+	return status
+}
+
+// NextOpening retorna o início do próximo período de atendimento
+func (c *Calendar) NextOpening(now time.Time) (time.Time, bool) {
+	local := now.In(c.location())
+	for i := 0; i < searchDays; i++ {
+		for _, p := range c.periodsOn(local.AddDate(0, 0, i)) {
+			if p.start.After(now) {
+				return p.start, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// WindowKey identifica a janela fechada atual, usada para enviar a
+// mensagem de ausência no máximo uma vez por chat em cada janela
+func (c *Calendar) WindowKey(now time.Time) string {
+	if next, ok := c.NextOpening(now); ok {
+		return next.UTC().Format(time.RFC3339)
+	}
+	return "never"
+}
+
+// currentPeriod retorna o período de atendimento que contém o instante
+func (c *Calendar) currentPeriod(now time.Time) (period, bool) {
+	local := now.In(c.location())
+	// Períodos do dia anterior podem atravessar a meia-noite
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		for _, p := range c.periodsOn(day) {
+			if !now.Before(p.start) && now.Before(p.end) {
+				return p, true
+			}
+		}
+	}
+	return period{}, false
+}
+
+// periodsOn retorna os períodos de atendimento que começam na data local informada
+func (c *Calendar) periodsOn(day time.Time) []period {
+	ranges := c.rangesOn(day)
+	periods := make([]period, 0, len(ranges))
+
+	for _, r := range ranges {
+		start, err := parseClock(r.Start)
+		if err != nil {
+			continue
+		}
+		end, err := parseClock(r.End)
+		if err != nil {
+			continue
+		}
+
+		base := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		p := period{
+			start: base.Add(time.Duration(start) * time.Minute),
+			end:   base.Add(time.Duration(end) * time.Minute),
+		}
+		if end <= start {
+			p.end = p.end.AddDate(0, 0, 1)
+		}
+		periods = append(periods, p)
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].start.Before(periods[j].start)
+	})
+	return periods
+}
+
+// rangesOn retorna os intervalos configurados para a data, considerando feriados
+func (c *Calendar) rangesOn(day time.Time) []TimeRange {
+	if h := c.holiday(day); h != nil {
+		return h.Hours
+	}
+
+	var ranges []TimeRange
+	for _, r := range c.Weekly {
+		if r.Day == int(day.Weekday()) {
+			ranges = append(ranges, r.TimeRange)
+		}
+	}
+	return ranges
+}
+
+// holiday retorna a exceção cadastrada para a data local, se houver
+func (c *Calendar) holiday(day time.Time) *Holiday {
+	date := day.Format(dateLayout)
+	for i := range c.Holidays {
+		if c.Holidays[i].Date == date {
+			return &c.Holidays[i]
+		}
+	}
+	return nil
+}
+
+// location resolve o fuso horário do calendário, usando UTC como padrão
+func (c *Calendar) location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// validate valida o formato HH:MM do intervalo
+func (r TimeRange) validate() error {
+	if _, err := parseClock(r.Start); err != nil {
+		return err
+	}
+	if _, err := parseClock(r.End); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseClock converte HH:MM em minutos desde a meia-noite
+func parseClock(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("horário inválido: %s (use HH:MM)", value)
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, fmt.Errorf("horário inválido: %s (use HH:MM)", value)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("horário inválido: %s (use HH:MM)", value)
+	}
+
+	return hour*60 + minute, nil
+}
@@ -0,0 +1,29 @@
+package businesshours
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Erros específicos do domínio de horário comercial
+var (
+	ErrCalendarNotFound = errors.New("horário comercial não configurado")
+)
+
+// CalendarValidationError representa um erro de validação do calendário
+type CalendarValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *CalendarValidationError) Error() string {
+	return fmt.Sprintf("horário comercial inválido no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewCalendarValidationError cria um novo erro de validação do calendário
+func NewCalendarValidationError(field, message string) *CalendarValidationError {
+	return &CalendarValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
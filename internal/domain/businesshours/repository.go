@@ -0,0 +1,26 @@
+package businesshours
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository define a interface para persistência dos calendários
+type Repository interface {
+	// GetBySession busca o calendário de uma sessão
+	GetBySession(ctx context.Context, sessionID uuid.UUID) (*Calendar, error)
+
+	// Save cria ou substitui o calendário de uma sessão
+	Save(ctx context.Context, calendar *Calendar) error
+
+	// Delete remove o calendário de uma sessão
+	Delete(ctx context.Context, sessionID uuid.UUID) error
+}
+
+// NoticeRepository define a interface para controle das mensagens de ausência enviadas
+type NoticeRepository interface {
+	// MarkSent registra o envio para a janela informada. Retorna false
+	// se o chat já recebeu a mensagem de ausência nessa janela.
+	MarkSent(ctx context.Context, notice *AwayNotice) (bool, error)
+}
@@ -14,11 +14,11 @@ type Contact struct {
 	ID           uuid.UUID      `bun:"id,pk,type:uuid" json:"id"`
 	SessionID    uuid.UUID      `bun:"sessionId,type:uuid,notnull" json:"sessionId"`
 	JID          string         `bun:"jid,type:varchar(100),notnull" json:"jid"`
-	Name         string         `bun:"-" json:"name,omitempty"` // Não existe na migration atual
+	Name         string         `bun:"name,type:varchar(255)" json:"name,omitempty"`
 	PushName     string         `bun:"pushName,type:varchar(255)" json:"pushName,omitempty"`
 	BusinessName string         `bun:"businessName,type:varchar(255)" json:"businessName,omitempty"`
 	AvatarURL    string         `bun:"avatarUrl,type:varchar(500)" json:"avatarUrl,omitempty"`
-	IsBusiness   bool           `bun:"-" json:"isBusiness"` // Não existe na migration atual
+	IsBusiness   bool           `bun:"isBusiness,type:boolean,notnull,default:false" json:"isBusiness"`
 	IsGroup      bool           `bun:"isGroup,type:boolean" json:"isGroup"`
 	LastSeen     *time.Time     `bun:"lastSeen,type:timestamptz" json:"lastSeen,omitempty"`
 	Metadata     map[string]any `bun:"metadata,type:jsonb" json:"metadata,omitempty"`
@@ -56,6 +56,9 @@ func (c *Contact) SetPushName(pushName string) {
 // SetBusinessName define o nome do negócio
 func (c *Contact) SetBusinessName(businessName string) {
 	c.BusinessName = businessName
+	if businessName != "" {
+		c.IsBusiness = true
+	}
 	c.UpdatedAt = time.Now()
 }
 
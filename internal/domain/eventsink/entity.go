@@ -0,0 +1,214 @@
+package eventsink
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/eventstream"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Driver identifica o broker de destino de um sink
+type Driver string
+
+const (
+	DriverNATS  Driver = "nats"
+	DriverAMQP  Driver = "amqp"
+	DriverKafka Driver = "kafka"
+)
+
+const (
+	// DefaultSubjectPattern é o padrão de subject/routing key dos eventos
+	DefaultSubjectPattern = "zapcore.{session}.{type}"
+
+	// EnvelopeVersion é a versão do formato do envelope publicado
+	EnvelopeVersion = "1"
+
+	// EnvelopeSource identifica a origem dos eventos no envelope
+	EnvelopeSource = "zapcore"
+)
+
+// Opções específicas de cada driver
+const (
+	OptionNATSStream   = "stream"   // stream do JetStream criado sob demanda
+	OptionAMQPExchange = "exchange" // exchange do tipo topic
+	OptionKafkaTopic   = "topic"    // tópico fixo; sem ele o subject é usado como tópico
+)
+
+// IsValid verifica se o driver é suportado
+func (d Driver) IsValid() bool {
+	switch d {
+	case DriverNATS, DriverAMQP, DriverKafka:
+		return true
+	}
+	return false
+}
+
+// Sink representa a configuração de publicação de eventos de uma sessão
+type Sink struct {
+	bun.BaseModel `bun:"table:zapcore_event_sinks,alias:es"`
+
+	ID        uuid.UUID         `bun:"id,pk,type:uuid" json:"id"`
+	SessionID uuid.UUID         `bun:"sessionId,type:uuid,notnull" json:"sessionId"`
+	Name      string            `bun:"name,type:varchar(100),notnull" json:"name"`
+	Driver    Driver            `bun:"driver,type:varchar(20),notnull" json:"driver"`
+	URL       string            `bun:"url,type:text,notnull" json:"url"`
+	Subject   string            `bun:"subject,type:varchar(255),notnull" json:"subject"`
+	Types     []string          `bun:"types,type:jsonb" json:"types,omitempty"`
+	Options   map[string]string `bun:"options,type:jsonb" json:"options,omitempty"`
+	IsActive  bool              `bun:"isActive,notnull,default:true" json:"isActive"`
+	CreatedAt time.Time         `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt time.Time         `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+}
+
+// NewSink cria uma nova instância de Sink
+func NewSink(sessionID uuid.UUID, name string, driver Driver, url string) *Sink {
+	now := time.Now()
+	return &Sink{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		Name:      name,
+		Driver:    driver,
+		URL:       url,
+		Subject:   DefaultSubjectPattern,
+		Options:   make(map[string]string),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate valida a configuração do sink e normaliza os tipos de evento
+func (s *Sink) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return NewSinkValidationError("name", "nome é obrigatório")
+	}
+	if !s.Driver.IsValid() {
+		return NewSinkValidationError("driver", "driver deve ser nats, amqp ou kafka")
+	}
+	if err := validateURL(s.Driver, s.URL); err != nil {
+		return err
+	}
+	if strings.TrimSpace(s.Subject) == "" {
+		s.Subject = DefaultSubjectPattern
+	}
+
+	types := make([]string, 0, len(s.Types))
+	for _, value := range s.Types {
+		eventType, ok := eventstream.NormalizeType(value)
+		if !ok {
+			return NewSinkValidationError("types", "tipo de evento desconhecido: "+value)
+		}
+		types = append(types, eventType)
+	}
+	s.Types = types
+
+	return nil
+}
+
+// Accepts verifica se o sink deve receber o tipo de evento
+func (s *Sink) Accepts(eventType string) bool {
+	if len(s.Types) == 0 {
+		return true
+	}
+	for _, t := range s.Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Option retorna uma opção do driver ou o valor padrão
+func (s *Sink) Option(key, fallback string) string {
+	if value, ok := s.Options[key]; ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// validateURL confere o esquema da URL de conexão conforme o driver
+func validateURL(driver Driver, rawURL string) error {
+	if strings.TrimSpace(rawURL) == "" {
+		return NewSinkValidationError("url", "url é obrigatória")
+	}
+
+	// Kafka aceita uma lista de brokers host:porta separados por vírgula
+	if driver == DriverKafka {
+		for _, broker := range strings.Split(rawURL, ",") {
+			if !strings.Contains(strings.TrimSpace(broker), ":") {
+				return NewSinkValidationError("url", "brokers devem estar no formato host:porta")
+			}
+		}
+		return nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return NewSinkValidationError("url", "url inválida")
+	}
+
+	switch driver {
+	case DriverNATS:
+		if parsed.Scheme != "nats" && parsed.Scheme != "tls" {
+			return NewSinkValidationError("url", "url deve usar o esquema nats:// ou tls://")
+		}
+	case DriverAMQP:
+		if parsed.Scheme != "amqp" && parsed.Scheme != "amqps" {
+			return NewSinkValidationError("url", "url deve usar o esquema amqp:// ou amqps://")
+		}
+	}
+
+	return nil
+}
+
+// RenderSubject monta o subject/routing key a partir do padrão configurado
+func RenderSubject(pattern string, sessionID uuid.UUID, eventType string) string {
+	if pattern == "" {
+		pattern = DefaultSubjectPattern
+	}
+	return strings.NewReplacer(
+		"{session}", sessionID.String(),
+		"{type}", eventType,
+	).Replace(pattern)
+}
+
+// Envelope é o formato JSON publicado nos brokers
+type Envelope struct {
+	ID        uuid.UUID      `json:"id"`
+	Version   string         `json:"version"`
+	Source    string         `json:"source"`
+	Subject   string         `json:"subject"`
+	Type      string         `json:"type"`
+	SessionID uuid.UUID      `json:"sessionId"`
+	Sequence  int64          `json:"sequence,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      map[string]any `json:"data"`
+}
+
+// NewEnvelope cria o envelope de um evento do stream
+func NewEnvelope(event *eventstream.Event, subject string) *Envelope {
+	return &Envelope{
+		ID:        uuid.New(),
+		Version:   EnvelopeVersion,
+		Source:    EnvelopeSource,
+		Subject:   subject,
+		Type:      event.Type,
+		SessionID: event.SessionID,
+		Sequence:  event.Seq,
+		Timestamp: event.CreatedAt,
+		Data:      event.Payload,
+	}
+}
+
+// Record é uma mensagem pronta para envio, persistível no buffer em disco
+type Record struct {
+	ID        string `json:"id"`
+	Subject   string `json:"subject"`
+	SessionID string `json:"sessionId"`
+	Type      string `json:"type"`
+	Payload   []byte `json:"payload"`
+}
@@ -0,0 +1,32 @@
+package eventsink
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Erros específicos do domínio de sinks de eventos
+var (
+	ErrSinkNotFound  = errors.New("sink de eventos não encontrado")
+	ErrBufferFull    = errors.New("buffer em disco do sink está cheio")
+	ErrSinkClosed    = errors.New("sink de eventos encerrado")
+	ErrUnknownDriver = errors.New("driver de sink desconhecido")
+)
+
+// SinkValidationError representa um erro de validação de sink
+type SinkValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *SinkValidationError) Error() string {
+	return fmt.Sprintf("sink inválido no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewSinkValidationError cria um novo erro de validação de sink
+func NewSinkValidationError(field, message string) *SinkValidationError {
+	return &SinkValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
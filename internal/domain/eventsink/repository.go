@@ -0,0 +1,29 @@
+package eventsink
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository define a interface para persistência dos sinks por sessão
+type Repository interface {
+	Create(ctx context.Context, sink *Sink) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Sink, error)
+	ListBySession(ctx context.Context, sessionID uuid.UUID) ([]*Sink, error)
+	ListActive(ctx context.Context) ([]*Sink, error)
+	Update(ctx context.Context, sink *Sink) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// EventSink publica registros em um broker externo (NATS, AMQP, Kafka)
+type EventSink interface {
+	Publish(ctx context.Context, record *Record) error
+	Close() error
+}
+
+// Manager mantém os sinks ativos em execução
+type Manager interface {
+	Apply(ctx context.Context, sink *Sink) error
+	Remove(id uuid.UUID)
+}
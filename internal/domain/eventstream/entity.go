@@ -0,0 +1,118 @@
+package eventstream
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Tipos de evento publicados no stream
+const (
+	TypeMessage              = "Message"
+	TypeUndecryptableMessage = "UndecryptableMessage"
+	TypeReceipt              = "Receipt"
+	TypePresence             = "Presence"
+	TypeChatPresence         = "ChatPresence"
+	TypeConnected            = "Connected"
+	TypeDisconnected         = "Disconnected"
+	TypeLoggedOut            = "LoggedOut"
+	TypePairSuccess          = "PairSuccess"
+	TypeHistorySync          = "HistorySync"
+	TypeContact              = "Contact"
+	TypePushName             = "PushName"
+	TypeGroupInfo            = "GroupInfo"
+	TypePicture              = "Picture"
+	TypeMediaScan            = "MediaScan"
+)
+
+// knownTypes lista os tipos aceitos nos filtros
+var knownTypes = []string{
+	TypeMessage, TypeUndecryptableMessage, TypeReceipt, TypePresence, TypeChatPresence,
+	TypeConnected, TypeDisconnected, TypeLoggedOut, TypePairSuccess, TypeHistorySync,
+	TypeContact, TypePushName, TypeGroupInfo, TypePicture, TypeMediaScan,
+}
+
+// NormalizeType converte o tipo informado pelo cliente para o nome canônico,
+// ignorando maiúsculas/minúsculas. Retorna false para tipos desconhecidos.
+func NormalizeType(value string) (string, bool) {
+	for _, t := range knownTypes {
+		if strings.EqualFold(t, value) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// Event representa um evento de sessão publicado para os consumidores em tempo real.
+// Seq é crescente e funciona como ID para retomada (Last-Event-ID).
+type Event struct {
+	bun.BaseModel `bun:"table:zapcore_events,alias:ev"`
+
+	Seq       int64          `bun:"seq,pk,autoincrement" json:"id"`
+	SessionID uuid.UUID      `bun:"sessionId,type:uuid,notnull" json:"sessionId"`
+	Type      string         `bun:"type,type:varchar(50),notnull" json:"type"`
+	Payload   map[string]any `bun:"payload,type:jsonb" json:"payload"`
+	CreatedAt time.Time      `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+}
+
+// NewEvent cria uma nova instância de Event
+func NewEvent(sessionID uuid.UUID, eventType string, payload map[string]any) *Event {
+	return &Event{
+		SessionID: sessionID,
+		Type:      eventType,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Filter define quais eventos um consumidor deseja receber.
+// Listas vazias aceitam todos os valores.
+type Filter struct {
+	SessionIDs []uuid.UUID `json:"sessionIds,omitempty"`
+	Types      []string    `json:"types,omitempty"`
+}
+
+// Matches verifica se o evento atende ao filtro
+func (f Filter) Matches(e *Event) bool {
+	if len(f.SessionIDs) > 0 {
+		found := false
+		for _, id := range f.SessionIDs {
+			if id == e.SessionID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if t == e.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Subscription representa um consumidor conectado ao stream
+type Subscription struct {
+	Filter Filter
+
+	// Backlog contém os eventos perdidos desde o último ID informado
+	Backlog []*Event
+
+	// Events entrega os novos eventos; é fechado quando o consumidor
+	// fica para trás e precisa se reconectar usando o último ID recebido
+	Events chan *Event
+}
@@ -0,0 +1,28 @@
+package eventstream
+
+import "context"
+
+// Log define o armazenamento limitado de eventos usado para retomada
+type Log interface {
+	// Append grava o evento e define seu Seq
+	Append(ctx context.Context, event *Event) error
+
+	// Since retorna os eventos posteriores ao Seq informado, em ordem
+	Since(ctx context.Context, afterSeq int64, filter Filter, limit int) ([]*Event, error)
+}
+
+// Publisher recebe os eventos processados pelo handler de eventos do WhatsApp
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+}
+
+// Broker distribui os eventos publicados para os consumidores conectados
+type Broker interface {
+	Publisher
+
+	// Subscribe registra um consumidor, retomando a partir de lastEventID quando > 0
+	Subscribe(ctx context.Context, filter Filter, lastEventID int64) (*Subscription, error)
+
+	// Unsubscribe remove o consumidor
+	Unsubscribe(sub *Subscription)
+}
@@ -0,0 +1,67 @@
+package media
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BlobDeleting marca em RefCount o conteúdo reservado pela coleta: o registro não recebe novas
+// referências enquanto o objeto é removido
+const BlobDeleting = -1
+
+// Blob é um conteúdo de mídia armazenado uma única vez por tenant, endereçado pelo SHA-256. As
+// mensagens com o mesmo conteúdo apontam para o mesmo objeto e RefCount conta essas referências.
+type Blob struct {
+	bun.BaseModel `bun:"table:zapcore_media_blobs,alias:mb"`
+
+	ID          uuid.UUID `bun:"id,pk,type:uuid" json:"id"`
+	TenantID    string    `bun:"tenantId,type:varchar(100),notnull" json:"tenantId"`
+	SHA256      string    `bun:"sha256,type:char(64),notnull" json:"sha256"`
+	ObjectPath  string    `bun:"objectPath,type:varchar(500),notnull" json:"objectPath"`
+	Size        int64     `bun:"size,type:bigint,notnull" json:"size"`
+	ContentType string    `bun:"contentType,type:varchar(100)" json:"contentType,omitempty"`
+	RefCount    int       `bun:"refCount,type:integer,notnull,default:0" json:"refCount"`
+	CreatedAt   time.Time `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt   time.Time `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+}
+
+// NewBlob cria o registro de um conteúdo recém-gravado, já com a referência de quem o gravou
+func NewBlob(tenantID, sha256, objectPath string, size int64, contentType string) *Blob {
+	now := time.Now()
+	return &Blob{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		SHA256:      sha256,
+		ObjectPath:  objectPath,
+		Size:        size,
+		ContentType: contentType,
+		RefCount:    1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Upload guarda o resultado do envio de um conteúdo aos servidores do WhatsApp. Enquanto o
+// arquivo criptografado continua na CDN, envios do mesmo conteúdo reaproveitam o direct path e
+// a chave da mídia em vez de enviá-lo novamente. Como os conteúdos, os uploads são separados
+// por tenant.
+type Upload struct {
+	bun.BaseModel `bun:"table:zapcore_media_uploads,alias:mu"`
+
+	TenantID      string    `bun:"tenantId,pk,type:varchar(100)" json:"tenantId"`
+	SHA256        string    `bun:"sha256,pk,type:char(64)" json:"sha256"`
+	MediaType     string    `bun:"mediaType,pk,type:varchar(40)" json:"mediaType"` // Tipo de mídia do whatsmeow (chaves de criptografia)
+	URL           string    `bun:"url,type:text,notnull" json:"url"`
+	DirectPath    string    `bun:"directPath,type:text,notnull" json:"directPath"`
+	MediaKey      []byte    `bun:"mediaKey,type:bytea,notnull" json:"-"`
+	FileEncSHA256 []byte    `bun:"fileEncSha256,type:bytea,notnull" json:"-"`
+	FileLength    int64     `bun:"fileLength,type:bigint,notnull" json:"fileLength"`
+	UploadedAt    time.Time `bun:"uploadedAt,type:timestamptz,notnull" json:"uploadedAt"`
+}
+
+// IsReusable verifica se o upload ainda está dentro da janela em que a CDN mantém o arquivo
+func (u *Upload) IsReusable(validity time.Duration) bool {
+	return validity > 0 && time.Since(u.UploadedAt) < validity
+}
@@ -0,0 +1,12 @@
+package media
+
+import "time"
+
+// Object descreve um objeto de mídia armazenado
+type Object struct {
+	Path         string    `json:"path"`
+	Size         int64     `json:"size"`
+	ContentType  string    `json:"contentType,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified time.Time `json:"lastModified"`
+}
@@ -0,0 +1,15 @@
+package media
+
+import "errors"
+
+// Erros específicos do domínio de mídia
+var (
+	ErrObjectNotFound   = errors.New("objeto não encontrado no armazenamento")
+	ErrStorageDisabled  = errors.New("armazenamento de mídias não configurado")
+	ErrMediaUnavailable = errors.New("mídia indisponível no WhatsApp")
+	ErrMediaExpired     = errors.New("mídia removida pela política de retenção")
+	ErrMediaQuarantined = errors.New("mídia em quarentena pela verificação de malware")
+	ErrBlobNotFound     = errors.New("conteúdo de mídia não encontrado")
+	ErrBlobDeleting     = errors.New("conteúdo de mídia em remoção pela coleta")
+	ErrUploadNotFound   = errors.New("upload de mídia não encontrado")
+)
@@ -0,0 +1,93 @@
+package media
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"zapcore/internal/domain/message"
+
+	"github.com/google/uuid"
+)
+
+// Store define as operações do armazenamento de mídia usadas nas rotinas de manutenção
+type Store interface {
+	// WalkMedia percorre os objetos com o prefixo informado; vazio percorre todo o bucket
+	WalkMedia(ctx context.Context, prefix string, fn func(*Object) error) error
+
+	// DeleteMedia remove um objeto
+	DeleteMedia(ctx context.Context, objectPath string) error
+}
+
+// ReferenceSource lista os caminhos de mídia referenciados por registros do banco
+type ReferenceSource interface {
+	ListMediaPaths(ctx context.Context) ([]string, error)
+}
+
+// MissingMarker desvincula das mensagens os objetos que não existem mais no armazenamento
+type MissingMarker interface {
+	// ReferenceSource lista os caminhos referenciados pelas mensagens
+	ReferenceSource
+
+	// MarkMediaMissing limpa o caminho das mensagens que apontam para os objetos informados,
+	// devolvendo-as ao download das mídias pendentes
+	MarkMediaMissing(ctx context.Context, objectPaths []string) (int, error)
+}
+
+// Reader abre as mídias armazenadas para leitura
+type Reader interface {
+	// OpenMedia abre o objeto com suporte a Seek para leituras parciais; retorna
+	// ErrObjectNotFound quando o objeto não existe
+	OpenMedia(ctx context.Context, objectPath string) (io.ReadSeekCloser, *Object, error)
+}
+
+// Fetcher baixa do WhatsApp a mídia de uma mensagem que ainda não está no armazenamento
+type Fetcher interface {
+	// FetchMedia grava a mídia no armazenamento e preenche as informações de mídia da
+	// mensagem, sem persisti-la; falhas do WhatsApp retornam ErrMediaUnavailable
+	FetchMedia(ctx context.Context, msg *message.Message) error
+}
+
+// BlobRepository define a persistência dos conteúdos de mídia endereçados pelo SHA-256
+type BlobRepository interface {
+	// ListMediaPaths lista os caminhos dos conteúdos registrados, referenciados ou não
+	ReferenceSource
+
+	// AddReference registra mais uma referência ao conteúdo do tenant; retorna ErrBlobNotFound
+	// quando o conteúdo ainda não foi gravado e ErrBlobDeleting quando ele está em remoção
+	AddReference(ctx context.Context, tenantID, sha256 string) (*Blob, error)
+
+	// Create registra o conteúdo recém-gravado; se outro upload do mesmo conteúdo chegou antes,
+	// soma as referências ao registro existente e o retorna. Retorna ErrBlobDeleting quando o
+	// registro existente está reservado pela coleta.
+	Create(ctx context.Context, blob *Blob) (*Blob, error)
+
+	// ReleaseReference retira uma referência ao conteúdo, sem removê-lo
+	ReleaseReference(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByPaths remove os registros dos conteúdos cujos objetos não existem mais
+	DeleteByPaths(ctx context.Context, objectPaths []string) (int, error)
+
+	// RecountReferences recalcula as referências a partir das mensagens que apontam para cada conteúdo
+	RecountReferences(ctx context.Context) error
+
+	// ListUnreferenced retorna os conteúdos sem referências e sem alterações desde o instante informado
+	ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]*Blob, error)
+
+	// ClaimUnreferenced reserva o conteúdo para remoção se ele continua sem referências; a partir
+	// daí ele não recebe novas referências
+	ClaimUnreferenced(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// DeleteClaimed remove o registro do conteúdo reservado pela coleta
+	DeleteClaimed(ctx context.Context, id uuid.UUID) error
+}
+
+// UploadRepository define a persistência dos uploads de mídia feitos aos servidores do WhatsApp
+type UploadRepository interface {
+	// Get busca o upload do conteúdo do tenant para o tipo de mídia; retorna ErrUploadNotFound
+	// quando não há
+	Get(ctx context.Context, tenantID, sha256, mediaType string) (*Upload, error)
+
+	// Save grava o upload, substituindo o anterior do mesmo tenant, conteúdo e tipo de mídia
+	Save(ctx context.Context, upload *Upload) error
+}
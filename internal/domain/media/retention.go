@@ -0,0 +1,222 @@
+package media
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/message"
+)
+
+// Tipos de mídia das regras de retenção
+const (
+	RetentionTypeImage    = "image"
+	RetentionTypeVideo    = "video"
+	RetentionTypeAudio    = "audio"
+	RetentionTypeDocument = "document"
+	RetentionTypeSticker  = "sticker"
+)
+
+// retentionMessageTypes relaciona os tipos das regras aos tipos de mensagem; GIFs seguem os vídeos
+var retentionMessageTypes = map[string][]message.MessageType{
+	RetentionTypeImage:    {message.MessageTypeImage},
+	RetentionTypeVideo:    {message.MessageTypeVideo, message.MessageTypeGif},
+	RetentionTypeAudio:    {message.MessageTypeAudio},
+	RetentionTypeDocument: {message.MessageTypeDocument},
+	RetentionTypeSticker:  {message.MessageTypeSticker},
+}
+
+// RetentionTypes são os tipos de mídia aceitos nas regras de retenção
+var RetentionTypes = []string{
+	RetentionTypeImage,
+	RetentionTypeVideo,
+	RetentionTypeAudio,
+	RetentionTypeDocument,
+	RetentionTypeSticker,
+}
+
+// RetentionRule define por quantos dias as mídias de um tipo e direção ficam armazenadas
+type RetentionRule struct {
+	MediaType string                   `json:"mediaType,omitempty"` // image, video, audio, document ou sticker; vazio vale para todos
+	Direction message.MessageDirection `json:"direction,omitempty"` // inbound ou outbound; vazio vale para ambas
+	Days      int                      `json:"days"`                // 0 mantém as mídias indefinidamente
+}
+
+// specificity ordena as regras: tipo e direção vencem tipo, que vence direção, que vence a regra geral
+func (r RetentionRule) specificity() int {
+	score := 0
+	if r.MediaType != "" {
+		score += 2
+	}
+	if r.Direction != "" {
+		score++
+	}
+	return score
+}
+
+// matches verifica se a regra vale para o tipo e a direção informados
+func (r RetentionRule) matches(mediaType string, direction message.MessageDirection) bool {
+	return (r.MediaType == "" || r.MediaType == mediaType) && (r.Direction == "" || r.Direction == direction)
+}
+
+// RetentionPolicy reúne as regras de retenção de uma sessão, de um tenant ou os padrões da
+// configuração. Para cada tipo e direção vale a regra mais específica.
+type RetentionPolicy struct {
+	Rules []RetentionRule `json:"rules"`
+}
+
+// IsEmpty verifica se a política não tem regras, caso em que vale a política herdada
+func (p *RetentionPolicy) IsEmpty() bool {
+	return p == nil || len(p.Rules) == 0
+}
+
+// Validate valida as regras da política
+func (p *RetentionPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(p.Rules))
+	for i, rule := range p.Rules {
+		field := fmt.Sprintf("rules[%d]", i)
+		if rule.MediaType != "" && retentionMessageTypes[rule.MediaType] == nil {
+			return NewRetentionValidationError(field+".mediaType", "use image, video, audio, document ou sticker")
+		}
+		if rule.Direction != "" && rule.Direction != message.MessageDirectionInbound && rule.Direction != message.MessageDirectionOutbound {
+			return NewRetentionValidationError(field+".direction", "use inbound ou outbound")
+		}
+		if rule.Days < 0 {
+			return NewRetentionValidationError(field+".days", "não pode ser negativo")
+		}
+
+		key := rule.MediaType + ":" + string(rule.Direction)
+		if seen[key] {
+			return NewRetentionValidationError(field, "regra repetida para o mesmo tipo e direção")
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Days retorna os dias de retenção das mídias do tipo e direção; 0 mantém indefinidamente
+func (p *RetentionPolicy) Days(mediaType string, direction message.MessageDirection) int {
+	if p == nil {
+		return 0
+	}
+
+	best := -1
+	days := 0
+	for _, rule := range p.Rules {
+		if rule.matches(mediaType, direction) && rule.specificity() > best {
+			best = rule.specificity()
+			days = rule.Days
+		}
+	}
+	return days
+}
+
+// RetentionTarget é um conjunto de mídias com a mesma validade
+type RetentionTarget struct {
+	MessageTypes []message.MessageType
+	Direction    message.MessageDirection
+	MaxAge       time.Duration
+}
+
+// Targets expande a política em grupos de tipo e direção com a validade efetiva de cada um,
+// omitindo os mantidos indefinidamente
+func (p *RetentionPolicy) Targets() []RetentionTarget {
+	var targets []RetentionTarget
+	for _, mediaType := range RetentionTypes {
+		for _, direction := range []message.MessageDirection{message.MessageDirectionInbound, message.MessageDirectionOutbound} {
+			days := p.Days(mediaType, direction)
+			if days == 0 {
+				continue
+			}
+			targets = append(targets, RetentionTarget{
+				MessageTypes: retentionMessageTypes[mediaType],
+				Direction:    direction,
+				MaxAge:       time.Duration(days) * 24 * time.Hour,
+			})
+		}
+	}
+	return targets
+}
+
+// Origens da política de retenção efetiva de uma sessão
+const (
+	RetentionSourceSession = "session"
+	RetentionSourceTenant  = "tenant"
+	RetentionSourceDefault = "default"
+	RetentionSourceNone    = "none"
+)
+
+// ResolveRetention retorna a política efetiva e sua origem: a da sessão, a do tenant ou os padrões
+// da configuração, nessa ordem. Sem nenhuma delas as mídias são mantidas indefinidamente.
+func ResolveRetention(session, tenant, defaults *RetentionPolicy) (*RetentionPolicy, string) {
+	switch {
+	case !session.IsEmpty():
+		return session, RetentionSourceSession
+	case !tenant.IsEmpty():
+		return tenant, RetentionSourceTenant
+	case !defaults.IsEmpty():
+		return defaults, RetentionSourceDefault
+	default:
+		return nil, RetentionSourceNone
+	}
+}
+
+// ParseRetentionRules lê regras no formato "tipo[:direção]=dias", separadas por vírgula, com "*"
+// para todos os tipos. Exemplo: "video:inbound=30,document=90,*=365".
+func ParseRetentionRules(value string) (*RetentionPolicy, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	policy := &RetentionPolicy{}
+	for _, item := range strings.Split(value, ",") {
+		target, daysText, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return nil, fmt.Errorf("regra de retenção inválida: %q (use tipo[:direção]=dias)", item)
+		}
+
+		days, err := strconv.Atoi(strings.TrimSpace(daysText))
+		if err != nil {
+			return nil, fmt.Errorf("dias inválidos na regra de retenção %q", item)
+		}
+
+		mediaType, direction, _ := strings.Cut(strings.TrimSpace(target), ":")
+		if mediaType == "*" {
+			mediaType = ""
+		}
+		policy.Rules = append(policy.Rules, RetentionRule{
+			MediaType: strings.TrimSpace(mediaType),
+			Direction: message.MessageDirection(strings.TrimSpace(direction)),
+			Days:      days,
+		})
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// RetentionValidationError representa um erro de validação da política de retenção
+type RetentionValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *RetentionValidationError) Error() string {
+	return fmt.Sprintf("política de retenção inválida no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewRetentionValidationError cria um novo erro de validação da política de retenção
+func NewRetentionValidationError(field, message string) *RetentionValidationError {
+	return &RetentionValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
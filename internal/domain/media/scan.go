@@ -0,0 +1,31 @@
+package media
+
+import (
+	"context"
+	"io"
+)
+
+// ScanVerdict é o resultado da verificação de malware de uma mídia recebida
+type ScanVerdict string
+
+const (
+	ScanVerdictClean    ScanVerdict = "clean"
+	ScanVerdictInfected ScanVerdict = "infected"
+	ScanVerdictError    ScanVerdict = "error" // O scanner falhou ou não respondeu a tempo
+)
+
+// ScanResult representa o resultado da verificação de uma mídia
+type ScanResult struct {
+	Verdict   ScanVerdict `json:"verdict"`
+	Signature string      `json:"signature,omitempty"` // Assinatura encontrada, nas mídias infectadas
+	Scanner   string      `json:"scanner"`
+}
+
+// Scanner verifica o conteúdo das mídias recebidas antes do armazenamento
+type Scanner interface {
+	// Name identifica o driver nos logs, métricas e resultados
+	Name() string
+
+	// Scan verifica o conteúdo; falhas de comunicação com o scanner retornam erro
+	Scan(ctx context.Context, content io.Reader, size int64) (*ScanResult, error)
+}
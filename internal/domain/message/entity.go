@@ -1,6 +1,7 @@
 package message
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -73,34 +74,68 @@ const (
 	MessageStatusFailed    MessageStatus = "failed"
 )
 
+// statusRank ordena os status no ciclo de entrega; falhas podem ser superadas por confirmações
+var statusRank = map[MessageStatus]int{
+	MessageStatusPending:   0,
+	MessageStatusFailed:    0,
+	MessageStatusSent:      1,
+	MessageStatusDelivered: 2,
+	MessageStatusRead:      3,
+}
+
+// StatusesBefore retorna os status que podem avançar para o status informado. Confirmações
+// atrasadas de outros destinatários não fazem a mensagem voltar, por exemplo, de read para delivered.
+func StatusesBefore(status MessageStatus) []MessageStatus {
+	rank, ok := statusRank[status]
+	if !ok {
+		return nil
+	}
+	statuses := make([]MessageStatus, 0, len(statusRank))
+	for s, r := range statusRank {
+		if r < rank {
+			statuses = append(statuses, s)
+		}
+	}
+	return statuses
+}
+
 // Message representa uma mensagem do WhatsApp
 type Message struct {
 	bun.BaseModel `bun:"table:zapcore_messages,alias:m"`
 
-	ID              uuid.UUID        `bun:"id,pk,type:uuid" json:"id"`
-	SessionID       uuid.UUID        `bun:"sessionId,type:uuid,notnull" json:"sessionId"`
-	MsgID           string           `bun:"msgId,type:varchar(255),notnull" json:"msgId"` // ID único do WhatsApp
-	MessageType     MessageType      `bun:"messageType,type:varchar(50),notnull" json:"messageType"`
-	Direction       MessageDirection `bun:"direction,type:varchar(20),notnull" json:"direction"`
-	Status          MessageStatus    `bun:"status,type:varchar(20),notnull" json:"status"`
-	SenderJID       string           `bun:"senderJid,type:varchar(100),notnull" json:"senderJid"`
-	ChatJID         string           `bun:"chatJid,type:varchar(100),notnull" json:"chatJid"`
-	Content         string           `bun:"content,type:text" json:"content,omitempty"`
-	MediaID         *uuid.UUID       `bun:"mediaId,type:uuid" json:"mediaId,omitempty"`
-	MediaPath       string           `bun:"mediaPath,type:varchar(500)" json:"mediaPath,omitempty"`
-	MediaSize       int64            `bun:"mediaSize,type:bigint" json:"mediaSize,omitempty"`
-	MediaMimeType   string           `bun:"mediaMimeType,type:varchar(100)" json:"mediaMimeType,omitempty"`
-	MediaFileName   string           `bun:"mediaFileName,type:varchar(255)" json:"mediaFileName,omitempty"`
-	Caption         string           `bun:"caption,type:text" json:"caption,omitempty"`
-	Timestamp       time.Time        `bun:"timestamp,type:timestamptz,notnull" json:"timestamp"`
-	QuotedMessageID string           `bun:"quotedMessageId,type:varchar(255)" json:"quotedMessageId,omitempty"`
-	PushName        string           `bun:"pushName,type:varchar(255)" json:"pushName,omitempty"`
-	IsFromMe        bool             `bun:"isFromMe,type:boolean" json:"isFromMe"`
-	IsGroup         bool             `bun:"isGroup,type:boolean" json:"isGroup"`
-	MediaType       string           `bun:"mediaType,type:varchar(50)" json:"mediaType,omitempty"`
-	RawPayload      map[string]any   `bun:"rawPayload,type:jsonb" json:"rawPayload,omitempty"`
-	CreatedAt       time.Time        `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
-	UpdatedAt       time.Time        `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+	ID                 uuid.UUID        `bun:"id,pk,type:uuid" json:"id"`
+	SessionID          uuid.UUID        `bun:"sessionId,type:uuid,notnull" json:"sessionId"`
+	MsgID              string           `bun:"msgId,type:varchar(255),notnull" json:"msgId"` // ID único do WhatsApp
+	MessageType        MessageType      `bun:"messageType,type:varchar(50),notnull" json:"messageType"`
+	Direction          MessageDirection `bun:"direction,type:varchar(20),notnull" json:"direction"`
+	Status             MessageStatus    `bun:"status,type:varchar(20),notnull" json:"status"`
+	SenderJID          string           `bun:"senderJid,type:varchar(100),notnull" json:"senderJid"`
+	ChatJID            string           `bun:"chatJid,type:varchar(100),notnull" json:"chatJid"`
+	Content            string           `bun:"content,type:text" json:"content,omitempty"`
+	MediaID            *uuid.UUID       `bun:"mediaId,type:uuid" json:"mediaId,omitempty"`
+	MediaPath          string           `bun:"mediaPath,type:varchar(500)" json:"mediaPath,omitempty"`
+	MediaSize          int64            `bun:"mediaSize,type:bigint" json:"mediaSize,omitempty"`
+	MediaMimeType      string           `bun:"mediaMimeType,type:varchar(100)" json:"mediaMimeType,omitempty"`
+	MediaFileName      string           `bun:"mediaFileName,type:varchar(255)" json:"mediaFileName,omitempty"`
+	MediaAttempts      int              `bun:"mediaAttempts,type:integer,notnull,default:0" json:"mediaAttempts,omitempty"`
+	MediaAttemptAt     *time.Time       `bun:"mediaAttemptAt,type:timestamptz" json:"mediaAttemptAt,omitempty"`
+	MediaError         string           `bun:"mediaError,type:varchar(500)" json:"mediaError,omitempty"`
+	MediaExpiredAt     *time.Time       `bun:"mediaExpiredAt,type:timestamptz" json:"mediaExpiredAt,omitempty"`
+	MediaScanVerdict   string           `bun:"mediaScanVerdict,type:varchar(20)" json:"mediaScanVerdict,omitempty"`
+	MediaScanSignature string           `bun:"mediaScanSignature,type:varchar(255)" json:"mediaScanSignature,omitempty"`
+	MediaScannedAt     *time.Time       `bun:"mediaScannedAt,type:timestamptz" json:"mediaScannedAt,omitempty"`
+	MediaQuarantined   bool             `bun:"mediaQuarantined,type:boolean,notnull,default:false" json:"mediaQuarantined,omitempty"`
+	Caption            string           `bun:"caption,type:text" json:"caption,omitempty"`
+	Timestamp          time.Time        `bun:"timestamp,type:timestamptz,notnull" json:"timestamp"`
+	QuotedMessageID    string           `bun:"quotedMessageId,type:varchar(255)" json:"quotedMessageId,omitempty"`
+	PushName           string           `bun:"pushName,type:varchar(255)" json:"pushName,omitempty"`
+	IsFromMe           bool             `bun:"isFromMe,type:boolean" json:"isFromMe"`
+	IsGroup            bool             `bun:"isGroup,type:boolean" json:"isGroup"`
+	RecipientCount     int              `bun:"recipientCount,type:integer,notnull,default:0" json:"recipientCount,omitempty"`
+	MediaType          string           `bun:"mediaType,type:varchar(50)" json:"mediaType,omitempty"`
+	RawPayload         map[string]any   `bun:"rawPayload,type:jsonb" json:"rawPayload,omitempty"`
+	CreatedAt          time.Time        `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt          time.Time        `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
 }
 
 // NewMessage cria uma nova instância de Message
@@ -167,14 +202,19 @@ func (m *Message) GetRawPayloadField(key string) (any, bool) {
 	return value, exists
 }
 
+// MediaMessageTypes são os tipos de mensagem que carregam mídia
+var MediaMessageTypes = []MessageType{
+	MessageTypeImage,
+	MessageTypeVideo,
+	MessageTypeAudio,
+	MessageTypeDocument,
+	MessageTypeSticker,
+	MessageTypeGif,
+}
+
 // IsMediaMessage verifica se a mensagem contém mídia
 func (m *Message) IsMediaMessage() bool {
-	return m.MessageType == MessageTypeImage ||
-		m.MessageType == MessageTypeVideo ||
-		m.MessageType == MessageTypeAudio ||
-		m.MessageType == MessageTypeDocument ||
-		m.MessageType == MessageTypeSticker ||
-		m.MessageType == MessageTypeGif
+	return slices.Contains(MediaMessageTypes, m.MessageType)
 }
 
 // SetMediaInfo define as informações de mídia da mensagem
@@ -183,6 +223,49 @@ func (m *Message) SetMediaInfo(path string, size int64, mimeType, fileName strin
 	m.MediaSize = size
 	m.MediaMimeType = mimeType
 	m.MediaFileName = fileName
+	m.MediaError = ""
+	m.MediaExpiredAt = nil
+	m.MediaScanVerdict = ""
+	m.MediaScanSignature = ""
+	m.MediaScannedAt = nil
+	m.MediaQuarantined = false
+}
+
+// SetMediaScan registra o resultado da verificação de malware da mídia armazenada
+func (m *Message) SetMediaScan(verdict, signature string, quarantined bool) {
+	now := time.Now()
+	m.MediaScanVerdict = verdict
+	m.MediaScanSignature = signature
+	m.MediaScannedAt = &now
+	m.MediaQuarantined = quarantined
+}
+
+// ExpireMedia desvincula a mídia removida pela política de retenção, mantendo tipo, tamanho e
+// nome do arquivo. Mídias expiradas não são baixadas novamente de forma automática.
+func (m *Message) ExpireMedia() {
+	now := time.Now()
+	m.MediaID = nil
+	m.MediaPath = ""
+	m.MediaExpiredAt = &now
+}
+
+// IsMediaExpired verifica se a mídia foi removida pela política de retenção
+func (m *Message) IsMediaExpired() bool {
+	return m.MediaExpiredAt != nil
+}
+
+// maxMediaErrorLength limita o motivo da última falha de download ao tamanho da coluna
+const maxMediaErrorLength = 500
+
+// RecordMediaFailure registra uma tentativa de download da mídia que falhou
+func (m *Message) RecordMediaFailure(reason string) {
+	now := time.Now()
+	m.MediaAttempts++
+	m.MediaAttemptAt = &now
+	if len(reason) > maxMediaErrorLength {
+		reason = strings.ToValidUTF8(reason[:maxMediaErrorLength], "")
+	}
+	m.MediaError = reason
 }
 
 // HasMediaStored verifica se a mensagem tem mídia armazenada
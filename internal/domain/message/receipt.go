@@ -0,0 +1,152 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ReceiptType representa o tipo de confirmação enviada por um destinatário
+type ReceiptType string
+
+const (
+	ReceiptTypeDelivered ReceiptType = "delivered"
+	ReceiptTypeRead      ReceiptType = "read"
+	ReceiptTypePlayed    ReceiptType = "played" // Mensagem de voz ouvida
+)
+
+// Receipt representa a confirmação de um destinatário para uma mensagem. Em grupos e listas
+// de transmissão, cada participante gera suas próprias confirmações.
+type Receipt struct {
+	bun.BaseModel `bun:"table:zapcore_message_receipts,alias:mr"`
+
+	ID           uuid.UUID   `bun:"id,pk,type:uuid" json:"-"`
+	SessionID    uuid.UUID   `bun:"sessionId,type:uuid,notnull" json:"-"`
+	MsgID        string      `bun:"msgId,type:varchar(255),notnull" json:"-"`
+	ChatJID      string      `bun:"chatJid,type:varchar(100),notnull" json:"-"`
+	RecipientJID string      `bun:"recipientJid,type:varchar(100),notnull" json:"recipientJid"`
+	Type         ReceiptType `bun:"receiptType,type:varchar(20),notnull" json:"type"`
+	Timestamp    time.Time   `bun:"timestamp,type:timestamptz,notnull" json:"timestamp"`
+	CreatedAt    time.Time   `bun:"createdAt,type:timestamptz,notnull" json:"-"`
+}
+
+// NewReceipt cria uma nova confirmação de destinatário
+func NewReceipt(sessionID uuid.UUID, msgID, chatJID, recipientJID string, receiptType ReceiptType, timestamp time.Time) *Receipt {
+	return &Receipt{
+		ID:           uuid.New(),
+		SessionID:    sessionID,
+		MsgID:        msgID,
+		ChatJID:      chatJID,
+		RecipientJID: recipientJID,
+		Type:         receiptType,
+		Timestamp:    timestamp,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// ReceiptSummary conta os destinatários distintos de uma mensagem por confirmação
+type ReceiptSummary struct {
+	Expected   int `json:"expected"`   // Destinatários da mensagem no envio; zero quando desconhecido
+	Recipients int `json:"recipients"` // Destinatários com qualquer confirmação
+	Delivered  int `json:"delivered"`  // Destinatários que receberam (inclui os que leram)
+	Read       int `json:"read"`       // Destinatários que leram ou ouviram
+	Played     int `json:"played"`     // Destinatários que ouviram a mensagem de voz
+}
+
+// ReadRuleMode define quando a mensagem passa a ser considerada lida
+type ReadRuleMode string
+
+const (
+	ReadRuleAny       ReadRuleMode = "any"       // Primeira leitura de qualquer destinatário
+	ReadRuleAll       ReadRuleMode = "all"       // Todos os destinatários da mensagem leram
+	ReadRuleThreshold ReadRuleMode = "threshold" // Percentual mínimo dos destinatários leu
+)
+
+// ReadRule regra de agregação do status a partir das confirmações dos destinatários
+type ReadRule struct {
+	Mode    ReadRuleMode
+	Percent int // Percentual de leitura exigido no modo threshold (1-100)
+}
+
+// NewReadRule cria e valida a regra de leitura
+func NewReadRule(mode string, percent int) (ReadRule, error) {
+	rule := ReadRule{Mode: ReadRuleMode(mode), Percent: percent}
+	switch rule.Mode {
+	case ReadRuleAny, ReadRuleAll:
+	case ReadRuleThreshold:
+		if percent < 1 || percent > 100 {
+			return ReadRule{}, fmt.Errorf("percentual de leitura deve estar entre 1 e 100: %d", percent)
+		}
+	default:
+		return ReadRule{}, fmt.Errorf("regra de leitura inválida: %s (use any, all ou threshold)", mode)
+	}
+	return rule, nil
+}
+
+// String descreve a regra (ex.: all, threshold:50)
+func (r ReadRule) String() string {
+	if r.Mode == ReadRuleThreshold {
+		return fmt.Sprintf("%s:%d", r.Mode, r.Percent)
+	}
+	return string(r.Mode)
+}
+
+// Status agrega as confirmações em um status da mensagem; vazio quando ainda não há confirmações
+func (r ReadRule) Status(summary ReceiptSummary) MessageStatus {
+	if summary.Recipients == 0 {
+		return ""
+	}
+	if summary.Read > 0 && r.satisfied(summary) {
+		return MessageStatusRead
+	}
+	return MessageStatusDelivered
+}
+
+// satisfied verifica se as leituras atingem a regra. As regras all e threshold contam sobre os
+// destinatários da mensagem no envio, e não apenas sobre os que já confirmaram; sem essa contagem
+// elas não são atingidas.
+func (r ReadRule) satisfied(summary ReceiptSummary) bool {
+	total := summary.Expected
+	if summary.Recipients > total && total > 0 {
+		total = summary.Recipients // Membros que entraram no grupo depois do envio
+	}
+
+	switch r.Mode {
+	case ReadRuleAll:
+		return total > 0 && summary.Read >= total
+	case ReadRuleThreshold:
+		return total > 0 && summary.Read*100 >= total*r.Percent
+	default:
+		return true
+	}
+}
+
+// ExpectedRecipients retorna os destinatários esperados de uma mensagem da conversa: a contagem
+// registrada no envio ou, nas conversas individuais, o próprio contato. Zero quando desconhecida,
+// como em grupos de mensagens gravadas antes da contagem.
+func ExpectedRecipients(chatJID string, recipientCount int) int {
+	if recipientCount > 0 {
+		return recipientCount
+	}
+	if strings.HasSuffix(chatJID, "@g.us") || strings.HasSuffix(chatJID, "@broadcast") {
+		return 0
+	}
+	return 1
+}
+
+// ReceiptRepository define a interface para persistência das confirmações por destinatário
+type ReceiptRepository interface {
+	// Record grava as confirmações, ignorando as já registradas para o mesmo destinatário e tipo
+	Record(ctx context.Context, receipts []*Receipt) error
+
+	// ListByMessage retorna as confirmações de uma mensagem da sessão, da mais antiga para a mais recente
+	ListByMessage(ctx context.Context, sessionID uuid.UUID, msgID string) ([]*Receipt, error)
+
+	// Summarize conta os destinatários distintos de uma mensagem por confirmação, com a
+	// contagem de destinatários registrada na mensagem
+	Summarize(ctx context.Context, sessionID uuid.UUID, msgID string) (*ReceiptSummary, error)
+}
@@ -18,6 +18,9 @@ type Repository interface {
 	// GetByMessageID busca uma mensagem pelo MessageID do WhatsApp
 	GetByMessageID(ctx context.Context, messageID string) (*Message, error)
 
+	// GetBySessionMsgID busca uma mensagem da sessão pelo MessageID do WhatsApp
+	GetBySessionMsgID(ctx context.Context, sessionID uuid.UUID, msgID string) (*Message, error)
+
 	// ExistsByMsgID verifica se uma mensagem já existe pelo msgId
 	ExistsByMsgID(ctx context.Context, msgID string) (bool, error)
 
@@ -30,12 +33,27 @@ type Repository interface {
 	// Update atualiza uma mensagem existente
 	Update(ctx context.Context, message *Message) error
 
+	// UpdateMediaInfo atualiza apenas as informações de mídia de uma mensagem
+	UpdateMediaInfo(ctx context.Context, message *Message) error
+
+	// RecordMediaAttempt grava as tentativas de download da mídia de uma mensagem
+	RecordMediaAttempt(ctx context.Context, message *Message) error
+
+	// ListMissingMedia retorna as mensagens de mídia que ainda não foram armazenadas
+	ListMissingMedia(ctx context.Context, filter MissingMediaFilter) ([]*Message, error)
+
+	// ListStoredMedia retorna as mensagens com mídia armazenada, das mais antigas para as mais recentes
+	ListStoredMedia(ctx context.Context, filter StoredMediaFilter) ([]*Message, error)
+
 	// Delete remove uma mensagem
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// UpdateStatus atualiza apenas o status de uma mensagem
 	UpdateStatus(ctx context.Context, messageID string, status MessageStatus) error
 
+	// UpdateSessionStatus atualiza o status de uma mensagem da sessão pelo MessageID do WhatsApp
+	UpdateSessionStatus(ctx context.Context, sessionID uuid.UUID, msgID string, status MessageStatus) error
+
 	// GetBySessionID retorna mensagens de uma sessão específica
 	GetBySessionID(ctx context.Context, sessionID uuid.UUID, filters ListFilters) ([]*Message, error)
 
@@ -65,6 +83,25 @@ type ListFilters struct {
 	OrderDir  string            `json:"order_dir,omitempty"`
 }
 
+// MissingMediaFilter seleciona as mensagens de mídia pendentes de download, das mais recentes
+// para as mais antigas
+type MissingMediaFilter struct {
+	SessionIDs    []uuid.UUID // Sessões consideradas; vazio considera todas
+	MaxAttempts   int         // Ignora mensagens que já atingiram o limite de tentativas; zero não limita
+	AttemptBefore time.Time   // Ignora mensagens com tentativa posterior a este instante
+	Since         time.Time   // Ignora mensagens anteriores a este instante
+	Limit         int
+}
+
+// StoredMediaFilter seleciona as mensagens de uma sessão cuja mídia continua armazenada
+type StoredMediaFilter struct {
+	SessionID    uuid.UUID
+	MessageTypes []MessageType
+	Direction    MessageDirection // Vazio considera ambas
+	Before       time.Time        // Apenas mensagens anteriores a este instante
+	Limit        int
+}
+
 // DefaultListFilters retorna os filtros padrão para listagem
 func DefaultListFilters() ListFilters {
 	return ListFilters{
@@ -57,9 +57,6 @@ type MediaManager interface {
 
 	// GetInfo obtém informações de um arquivo de mídia
 	GetInfo(ctx context.Context, mediaID uuid.UUID) (*MediaInfo, error)
-
-	// GenerateThumbnail gera thumbnail para imagens/vídeos
-	GenerateThumbnail(ctx context.Context, mediaID uuid.UUID) (*MediaInfo, error)
 }
 
 // MediaInfo representa informações de um arquivo de mídia
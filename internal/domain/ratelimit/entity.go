@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Policy define um token bucket: Requests fichas repostas continuamente ao longo de Window,
+// com capacidade Burst (igual a Requests quando zero)
+type Policy struct {
+	Name     string        `json:"name,omitempty"`
+	Requests int           `json:"requests"`
+	Window   time.Duration `json:"-"`
+	Burst    int           `json:"burst,omitempty"`
+}
+
+// Enabled indica se a política limita algo; políticas zeradas são ignoradas
+func (p Policy) Enabled() bool {
+	return p.Requests > 0 && p.Window > 0
+}
+
+// Capacity retorna o número máximo de fichas acumuladas no bucket
+func (p Policy) Capacity() float64 {
+	if p.Burst > 0 {
+		return float64(p.Burst)
+	}
+	return float64(p.Requests)
+}
+
+// Rate retorna a reposição de fichas por segundo
+func (p Policy) Rate() float64 {
+	return float64(p.Requests) / p.Window.Seconds()
+}
+
+// TimeToFull retorna quanto tempo um bucket com as fichas informadas leva para encher
+func (p Policy) TimeToFull(tokens float64) time.Duration {
+	missing := p.Capacity() - tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / p.Rate() * float64(time.Second))
+}
+
+// String descreve a política no formato do header RateLimit-Policy (ex.: 100;w=60)
+func (p Policy) String() string {
+	policy := fmt.Sprintf("%d;w=%d", p.Requests, int(math.Ceil(p.Window.Seconds())))
+	if p.Burst > 0 {
+		policy += fmt.Sprintf(";burst=%d", p.Burst)
+	}
+	return policy
+}
+
+// Decision é o resultado da consulta ao limitador
+type Decision struct {
+	Allowed    bool
+	Limit      int           // Capacidade do bucket
+	Remaining  int           // Fichas restantes após a requisição
+	Reset      time.Duration // Tempo até o bucket encher novamente
+	RetryAfter time.Duration // Tempo até haver uma ficha, quando negado
+}
+
+// NewDecision calcula a decisão a partir das fichas restantes no bucket
+func NewDecision(policy Policy, tokens float64, allowed bool) *Decision {
+	decision := &Decision{
+		Allowed:   allowed,
+		Limit:     int(policy.Capacity()),
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     policy.TimeToFull(tokens),
+	}
+	if !allowed {
+		decision.RetryAfter = time.Duration((1 - tokens) / policy.Rate() * float64(time.Second))
+	}
+	return decision
+}
+
+// Bucket é o estado de um token bucket, usado pelo limitador em memória
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewBucket cria um bucket cheio
+func NewBucket(policy Policy, now time.Time) *Bucket {
+	return &Bucket{Tokens: policy.Capacity(), UpdatedAt: now}
+}
+
+// Take repõe as fichas acumuladas desde a última consulta e consome uma, se houver
+func (b *Bucket) Take(policy Policy, now time.Time) *Decision {
+	if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(policy.Capacity(), b.Tokens+elapsed*policy.Rate())
+	}
+	b.UpdatedAt = now
+
+	allowed := b.Tokens >= 1
+	if allowed {
+		b.Tokens--
+	}
+	return NewDecision(policy, b.Tokens, allowed)
+}
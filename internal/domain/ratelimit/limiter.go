@@ -0,0 +1,10 @@
+package ratelimit
+
+import "context"
+
+// Limiter consome fichas dos buckets identificados por chave. Implementações compartilhadas
+// (Postgres, Redis) mantêm o limite entre réplicas e reinícios.
+type Limiter interface {
+	// Allow consome uma ficha do bucket da chave segundo a política
+	Allow(ctx context.Context, key string, policy Policy) (*Decision, error)
+}
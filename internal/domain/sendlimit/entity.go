@@ -0,0 +1,54 @@
+package sendlimit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits define o ritmo de envio de uma sessão; valores zero desativam o limite correspondente
+type Limits struct {
+	MessagesPerMinute   int `json:"messagesPerMinute"`   // Mensagens por minuto (janela deslizante)
+	NewChatsPerDay      int `json:"newChatsPerDay"`      // Conversas iniciadas com destinatários novos por dia (UTC)
+	RecipientGapSeconds int `json:"recipientGapSeconds"` // Intervalo mínimo entre mensagens ao mesmo destinatário
+	MaxQueueSeconds     int `json:"maxQueueSeconds"`     // Espera máxima na fila antes de recusar o envio
+}
+
+// RecipientGap retorna o intervalo mínimo entre mensagens ao mesmo destinatário
+func (l Limits) RecipientGap() time.Duration {
+	return time.Duration(l.RecipientGapSeconds) * time.Second
+}
+
+// MaxQueue retorna quanto um envio pode aguardar na fila
+func (l Limits) MaxQueue() time.Duration {
+	return time.Duration(l.MaxQueueSeconds) * time.Second
+}
+
+// Validate valida os limites
+func (l Limits) Validate() error {
+	switch {
+	case l.MessagesPerMinute < 0:
+		return NewLimitsValidationError("messagesPerMinute", "não pode ser negativo")
+	case l.NewChatsPerDay < 0:
+		return NewLimitsValidationError("newChatsPerDay", "não pode ser negativo")
+	case l.RecipientGapSeconds < 0:
+		return NewLimitsValidationError("recipientGapSeconds", "não pode ser negativo")
+	case l.MaxQueueSeconds < 0:
+		return NewLimitsValidationError("maxQueueSeconds", "não pode ser negativo")
+	}
+	return nil
+}
+
+// Usage representa o consumo dos limites de envio de uma sessão nesta instância
+type Usage struct {
+	SessionID        uuid.UUID  `json:"sessionId"`
+	Limits           Limits     `json:"limits"`
+	Custom           bool       `json:"custom"`           // Limites próprios da sessão em vez dos padrões
+	MessagesInWindow int        `json:"messagesInWindow"` // Envios no último minuto, incluindo os da fila
+	NewChatsToday    int        `json:"newChatsToday"`    // Destinatários novos no dia (UTC)
+	Queued           int        `json:"queued"`           // Envios aguardando na fila agora
+	Delayed          int64      `json:"delayed"`          // Envios que aguardaram na fila desde o início
+	Rejected         int64      `json:"rejected"`         // Envios recusados desde o início
+	Day              string     `json:"day"`              // Dia (UTC) dos contadores diários
+	LastSentAt       *time.Time `json:"lastSentAt,omitempty"`
+}
@@ -0,0 +1,66 @@
+package sendlimit
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Motivos de recusa de um envio
+const (
+	ReasonMessagesPerMinute = "messages_per_minute"
+	ReasonNewChatsPerDay    = "new_chats_per_day"
+	ReasonRecipientGap      = "recipient_gap"
+)
+
+// ThrottledError indica que o envio foi recusado para proteger o número de bloqueios
+type ThrottledError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	var limit string
+	switch e.Reason {
+	case ReasonMessagesPerMinute:
+		limit = "limite de mensagens por minuto da sessão atingido"
+	case ReasonNewChatsPerDay:
+		limit = "limite diário de novas conversas da sessão atingido"
+	case ReasonRecipientGap:
+		limit = "intervalo mínimo entre mensagens ao mesmo destinatário não respeitado"
+	default:
+		limit = "limite de envio da sessão atingido"
+	}
+	return fmt.Sprintf("%s, tente novamente em %ds", limit, e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds retorna a espera sugerida em segundos inteiros, arredondada para cima
+func (e *ThrottledError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// NewThrottledError cria um novo erro de envio recusado
+func NewThrottledError(reason string, retryAfter time.Duration) *ThrottledError {
+	return &ThrottledError{
+		Reason:     reason,
+		RetryAfter: retryAfter,
+	}
+}
+
+// LimitsValidationError representa um erro de validação dos limites de envio
+type LimitsValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *LimitsValidationError) Error() string {
+	return fmt.Sprintf("limites de envio inválidos no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewLimitsValidationError cria um novo erro de validação dos limites de envio
+func NewLimitsValidationError(field, message string) *LimitsValidationError {
+	return &LimitsValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
@@ -0,0 +1,19 @@
+package sendlimit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Governor controla o ritmo de envio de cada sessão, em todos os caminhos de envio
+type Governor interface {
+	// Acquire reserva o envio ao destinatário, aguardando na fila quando a espera cabe no limite
+	Acquire(ctx context.Context, sessionID uuid.UUID, recipient string) error
+
+	// Usage retorna o consumo atual dos limites da sessão
+	Usage(ctx context.Context, sessionID uuid.UUID) (*Usage, error)
+
+	// Reload descarta os limites em cache da sessão após alteração
+	Reload(sessionID uuid.UUID)
+}
@@ -3,6 +3,10 @@ package session
 import (
 	"time"
 
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/sendlimit"
+	"zapcore/internal/domain/tenant"
+
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -21,6 +25,7 @@ type Session struct {
 	bun.BaseModel `bun:"table:zapcore_sessions,alias:s"`
 
 	ID        uuid.UUID             `bun:"id,pk,type:uuid" json:"id"`
+	TenantID  string                `bun:"tenantId,type:varchar(100),notnull" json:"tenantId"`
 	Name      string                `bun:"name,type:varchar(100),notnull,unique" json:"name"`
 	Status    WhatsAppSessionStatus `bun:"status,type:varchar(20),notnull" json:"status"`
 	JID       string                `bun:"jid,type:varchar(100)" json:"jid,omitempty"`
@@ -32,6 +37,12 @@ type Session struct {
 	CreatedAt time.Time             `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
 	UpdatedAt time.Time             `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
 	Metadata  map[string]any        `bun:"-" json:"metadata,omitempty"` // Não persistir no banco por enquanto
+
+	// Limites de envio próprios da sessão; vazio usa os padrões da configuração
+	SendLimits *sendlimit.Limits `bun:"sendLimits,type:jsonb" json:"sendLimits,omitempty"`
+
+	// Retenção das mídias própria da sessão; vazio usa a do tenant ou os padrões da configuração
+	MediaRetention *media.RetentionPolicy `bun:"mediaRetention,type:jsonb" json:"mediaRetention,omitempty"`
 }
 
 // NewSession cria uma nova instância de Session
@@ -39,6 +50,7 @@ func NewSession(name string) *Session {
 	now := time.Now()
 	return &Session{
 		ID:        uuid.New(),
+		TenantID:  tenant.DefaultTenantID,
 		Name:      name,
 		Status:    WhatsAppStatusDisconnected,
 		IsActive:  true,
@@ -78,6 +90,21 @@ func (s *Session) SetWebhook(webhook string) {
 	s.UpdatedAt = time.Now()
 }
 
+// SetSendLimits define os limites de envio próprios da sessão; nil volta aos padrões
+func (s *Session) SetSendLimits(limits *sendlimit.Limits) {
+	s.SendLimits = limits
+	s.UpdatedAt = time.Now()
+}
+
+// SetMediaRetention define a retenção das mídias própria da sessão; nil volta à política herdada
+func (s *Session) SetMediaRetention(policy *media.RetentionPolicy) {
+	if policy.IsEmpty() {
+		policy = nil
+	}
+	s.MediaRetention = policy
+	s.UpdatedAt = time.Now()
+}
+
 // Activate ativa a sessão
 func (s *Session) Activate() {
 	s.IsActive = true
@@ -17,6 +17,9 @@ type Repository interface {
 	// GetByName busca uma sessão pelo nome
 	GetByName(ctx context.Context, name string) (*Session, error)
 
+	// ExistsByName verifica se o nome já está em uso; nomes são únicos entre todos os tenants
+	ExistsByName(ctx context.Context, name string) (bool, error)
+
 	// List retorna todas as sessões com filtros opcionais
 	List(ctx context.Context, filters ListFilters) ([]*Session, error)
 
@@ -41,6 +44,7 @@ type Repository interface {
 
 // ListFilters define os filtros para listagem de sessões
 type ListFilters struct {
+	IDs      []uuid.UUID            `json:"ids,omitempty"` // Vazio não restringe
 	Status   *WhatsAppSessionStatus `json:"status,omitempty"`
 	IsActive *bool                  `json:"isActive,omitempty"`
 	Limit    int                    `json:"limit,omitempty"`
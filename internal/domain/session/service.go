@@ -53,6 +53,7 @@ type Service interface {
 
 // HealthStatus representa o status de saúde das sessões
 type HealthStatus struct {
+	Status            string                      `json:"status"`
 	TotalSessions     int                         `json:"total_sessions"`
 	ActiveSessions    int                         `json:"active_sessions"`
 	ConnectedSessions int                         `json:"connected_sessions"`
@@ -62,10 +63,17 @@ type HealthStatus struct {
 
 // SessionHealth representa a saúde de uma sessão específica
 type SessionHealth struct {
-	ID           uuid.UUID             `json:"id"`
-	Name         string                `json:"name"`
-	Status       WhatsAppSessionStatus `json:"status"`
-	IsHealthy    bool                  `json:"is_healthy"`
-	LastSeen     string                `json:"last_seen,omitempty"`
-	ErrorMessage string                `json:"error_message,omitempty"`
+	ID             uuid.UUID             `json:"id"`
+	Name           string                `json:"name"`
+	Status         WhatsAppSessionStatus `json:"status"`
+	IsHealthy      bool                  `json:"is_healthy"`
+	LastSeen       string                `json:"last_seen,omitempty"`
+	ErrorMessage   string                `json:"error_message,omitempty"`
+	Connected      bool                  `json:"connected"`
+	LoggedIn       bool                  `json:"logged_in"`
+	LastEventAt    string                `json:"last_event_at,omitempty"`
+	LastEventType  string                `json:"last_event_type,omitempty"`
+	QRPendingSince string                `json:"qr_pending_since,omitempty"`
+	QRCodesIssued  int                   `json:"qr_codes_issued,omitempty"`
+	QRStuck        bool                  `json:"qr_stuck"`
 }
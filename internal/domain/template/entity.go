@@ -0,0 +1,163 @@
+package template
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/tenant"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// variablePattern identifica placeholders no formato {{variavel}}
+var variablePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_.-]+)\s*\}\}`)
+
+// Variant representa uma variante de idioma do template
+type Variant struct {
+	Body      string `json:"body"`
+	MediaPath string `json:"mediaPath,omitempty"`
+}
+
+// Template representa um template de mensagem reutilizável
+type Template struct {
+	bun.BaseModel `bun:"table:zapcore_templates,alias:t"`
+
+	ID            uuid.UUID          `bun:"id,pk,type:uuid" json:"id"`
+	TenantID      string             `bun:"tenantId,type:varchar(100),notnull,unique:zapcore_templates_tenant_name" json:"tenantId"`
+	Name          string             `bun:"name,type:varchar(100),notnull,unique:zapcore_templates_tenant_name" json:"name"`
+	Language      string             `bun:"language,type:varchar(20),notnull" json:"language"`
+	Body          string             `bun:"body,type:text,notnull" json:"body"`
+	MediaPath     string             `bun:"mediaPath,type:text" json:"mediaPath,omitempty"`
+	MediaType     string             `bun:"mediaType,type:varchar(50)" json:"mediaType,omitempty"`
+	MediaMimeType string             `bun:"mediaMimeType,type:varchar(100)" json:"mediaMimeType,omitempty"`
+	MediaFileName string             `bun:"mediaFileName,type:varchar(255)" json:"mediaFileName,omitempty"`
+	Variants      map[string]Variant `bun:"variants,type:jsonb" json:"variants,omitempty"`
+	CreatedAt     time.Time          `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt     time.Time          `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+}
+
+// NewTemplate cria uma nova instância de Template
+func NewTemplate(tenantID, name, language, body string) *Template {
+	now := time.Now()
+	return &Template{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		Language:  language,
+		Body:      body,
+		Variants:  make(map[string]Variant),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// HasMedia verifica se o template possui mídia anexada
+func (t *Template) HasMedia() bool {
+	return t.MediaPath != ""
+}
+
+// ValidateMediaPaths garante que as mídias do template e das variantes pertencem ao tenant do
+// template, para que um tenant não envie mídias armazenadas por outro
+func (t *Template) ValidateMediaPaths() error {
+	if t.MediaPath != "" && !tenant.OwnsObjectPath(t.TenantID, t.MediaPath) {
+		return ErrInvalidMediaPath
+	}
+	for _, variant := range t.Variants {
+		if variant.MediaPath != "" && !tenant.OwnsObjectPath(t.TenantID, variant.MediaPath) {
+			return ErrInvalidMediaPath
+		}
+	}
+	return nil
+}
+
+// Resolve retorna o corpo e o path de mídia para o idioma solicitado,
+// caindo para o idioma padrão do template quando não há variante
+func (t *Template) Resolve(language string) (string, string) {
+	body, mediaPath := t.Body, t.MediaPath
+
+	if language == "" || strings.EqualFold(language, t.Language) {
+		return body, mediaPath
+	}
+
+	variant, ok := t.Variants[language]
+	if !ok {
+		// Tentar o idioma base (ex: "pt" para "pt-BR")
+		base := strings.SplitN(language, "-", 2)[0]
+		variant, ok = t.Variants[base]
+	}
+	if !ok {
+		return body, mediaPath
+	}
+
+	if variant.Body != "" {
+		body = variant.Body
+	}
+	if variant.MediaPath != "" {
+		mediaPath = variant.MediaPath
+	}
+
+	return body, mediaPath
+}
+
+// RequiredVariables retorna as variáveis referenciadas no template em todos os idiomas
+func (t *Template) RequiredVariables() []string {
+	seen := make(map[string]struct{})
+	for _, name := range ExtractVariables(t.Body) {
+		seen[name] = struct{}{}
+	}
+	for _, variant := range t.Variants {
+		for _, name := range ExtractVariables(variant.Body) {
+			seen[name] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Render aplica as variáveis ao corpo do template no idioma solicitado
+func (t *Template) Render(language string, variables map[string]string) (string, string, error) {
+	body, mediaPath := t.Resolve(language)
+
+	var missing []string
+	for _, name := range ExtractVariables(body) {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", "", NewMissingVariablesError(t.ID, missing)
+	}
+
+	rendered := variablePattern.ReplaceAllStringFunc(body, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		return variables[name]
+	})
+
+	return rendered, mediaPath, nil
+}
+
+// ExtractVariables retorna os nomes das variáveis presentes em um texto, sem repetição
+func ExtractVariables(body string) []string {
+	matches := variablePattern.FindAllStringSubmatch(body, -1)
+
+	seen := make(map[string]struct{}, len(matches))
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if _, ok := seen[match[1]]; ok {
+			continue
+		}
+		seen[match[1]] = struct{}{}
+		names = append(names, match[1])
+	}
+
+	return names
+}
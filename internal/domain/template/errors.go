@@ -0,0 +1,38 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Erros específicos do domínio de templates
+var (
+	ErrTemplateNotFound      = errors.New("template não encontrado")
+	ErrTemplateAlreadyExists = errors.New("template já existe")
+	ErrInvalidTemplateName   = errors.New("nome do template inválido")
+	ErrEmptyTemplateBody     = errors.New("corpo do template não pode estar vazio")
+	ErrTemplateHasNoMedia    = errors.New("template não possui mídia anexada")
+	ErrMediaStorageDisabled  = errors.New("armazenamento de mídia não configurado")
+	ErrInvalidMediaPath      = errors.New("path de mídia fora do armazenamento do tenant")
+)
+
+// MissingVariablesError indica que variáveis obrigatórias não foram informadas
+type MissingVariablesError struct {
+	TemplateID uuid.UUID
+	Missing    []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("variáveis obrigatórias ausentes no template %s: %s", e.TemplateID, strings.Join(e.Missing, ", "))
+}
+
+// NewMissingVariablesError cria um novo erro de variáveis ausentes
+func NewMissingVariablesError(templateID uuid.UUID, missing []string) *MissingVariablesError {
+	return &MissingVariablesError{
+		TemplateID: templateID,
+		Missing:    missing,
+	}
+}
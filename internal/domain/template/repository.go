@@ -0,0 +1,43 @@
+package template
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Repository define a interface para persistência de templates
+type Repository interface {
+	// Create cria um novo template
+	Create(ctx context.Context, tmpl *Template) error
+
+	// GetByID busca um template pelo ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Template, error)
+
+	// GetByName busca um template pelo nome dentro de um tenant
+	GetByName(ctx context.Context, tenantID, name string) (*Template, error)
+
+	// List retorna templates com filtros opcionais
+	List(ctx context.Context, filters ListFilters) ([]*Template, error)
+
+	// Update atualiza um template existente
+	Update(ctx context.Context, tmpl *Template) error
+
+	// Delete remove um template
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// MediaStorage define a interface para leitura das mídias anexadas aos templates
+type MediaStorage interface {
+	// GetMedia abre a mídia armazenada no path informado
+	GetMedia(ctx context.Context, objectPath string) (io.ReadCloser, error)
+}
+
+// ListFilters define os filtros para listagem de templates
+type ListFilters struct {
+	TenantID string `json:"tenant_id,omitempty"`
+	Language string `json:"language,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+	Offset   int    `json:"offset,omitempty"`
+}
@@ -0,0 +1,17 @@
+package tenant
+
+import "context"
+
+type tenantContextKey struct{}
+
+// WithID associa o tenant da requisição ao contexto; os repositórios passam a filtrar por ele
+func WithID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// IDFromContext retorna o tenant do contexto. Sem tenant (chave mestre ou processos internos)
+// as consultas não são restringidas.
+func IDFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
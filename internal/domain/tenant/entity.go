@@ -0,0 +1,107 @@
+package tenant
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/media"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultTenantID é o tenant que recebe as sessões e chaves criadas sem tenant explícito
+const DefaultTenantID = "default"
+
+// idPattern restringe o ID do tenant a um slug, pois ele compõe os paths de mídia no armazenamento
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,99}$`)
+
+// Tenant representa um cliente isolado que possui sessões, chaves de API, webhooks e templates
+type Tenant struct {
+	bun.BaseModel `bun:"table:zapcore_tenants,alias:tn"`
+
+	ID                string    `bun:"id,pk,type:varchar(100)" json:"id"`
+	Name              string    `bun:"name,type:varchar(100),notnull" json:"name"`
+	MaxSessions       int       `bun:"maxSessions,type:integer,notnull" json:"maxSessions"`             // 0 = ilimitado
+	MaxMessagesPerDay int       `bun:"maxMessagesPerDay,type:integer,notnull" json:"maxMessagesPerDay"` // 0 = ilimitado
+	MaxStorageBytes   int64     `bun:"maxStorageBytes,type:bigint,notnull" json:"maxStorageBytes"`      // 0 = ilimitado
+	IsActive          bool      `bun:"isActive,type:boolean,notnull" json:"isActive"`
+	CreatedAt         time.Time `bun:"createdAt,type:timestamptz,notnull" json:"createdAt"`
+	UpdatedAt         time.Time `bun:"updatedAt,type:timestamptz,notnull" json:"updatedAt"`
+
+	// Retenção das mídias das sessões do tenant que não definem a própria; vazio usa os padrões
+	MediaRetention *media.RetentionPolicy `bun:"mediaRetention,type:jsonb" json:"mediaRetention,omitempty"`
+}
+
+// StorageDir retorna o diretório raiz das mídias do tenant no armazenamento; sem tenant usa o
+// tenant padrão
+func StorageDir(tenantID string) string {
+	if tenantID == "" {
+		return DefaultTenantID
+	}
+	return tenantID
+}
+
+// OwnsObjectPath verifica se o caminho aponta para um objeto dentro do diretório do tenant.
+// Caminhos não normalizados, como os com ".." ou barras repetidas, são recusados.
+func OwnsObjectPath(tenantID, objectPath string) bool {
+	if objectPath == "" || path.Clean(objectPath) != objectPath {
+		return false
+	}
+	return strings.HasPrefix(objectPath, StorageDir(tenantID)+"/")
+}
+
+// NewTenant cria uma nova instância de Tenant, ativa e sem limites
+func NewTenant(id, name string) *Tenant {
+	now := time.Now()
+	return &Tenant{
+		ID:        strings.TrimSpace(id),
+		Name:      strings.TrimSpace(name),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate valida os dados do tenant
+func (t *Tenant) Validate() error {
+	if !idPattern.MatchString(t.ID) {
+		return NewTenantValidationError("id", "use de 1 a 100 caracteres entre letras minúsculas, números, '-' e '_'")
+	}
+	if t.Name == "" {
+		return NewTenantValidationError("name", "nome é obrigatório")
+	}
+	if len(t.Name) > 100 {
+		return NewTenantValidationError("name", "nome deve ter no máximo 100 caracteres")
+	}
+	if t.MaxSessions < 0 {
+		return NewTenantValidationError("maxSessions", "limite não pode ser negativo")
+	}
+	if t.MaxMessagesPerDay < 0 {
+		return NewTenantValidationError("maxMessagesPerDay", "limite não pode ser negativo")
+	}
+	if t.MaxStorageBytes < 0 {
+		return NewTenantValidationError("maxStorageBytes", "limite não pode ser negativo")
+	}
+	if err := t.MediaRetention.Validate(); err != nil {
+		return NewTenantValidationError("mediaRetention", err.Error())
+	}
+	return nil
+}
+
+// Usage representa o consumo atual de um tenant frente aos seus limites
+type Usage struct {
+	TenantID          string `json:"tenantId"`
+	Sessions          int    `json:"sessions"`
+	MaxSessions       int    `json:"maxSessions"`
+	MessagesToday     int    `json:"messagesToday"`
+	MaxMessagesPerDay int    `json:"maxMessagesPerDay"`
+	StorageBytes      int64  `json:"storageBytes"`
+	MaxStorageBytes   int64  `json:"maxStorageBytes"`
+}
+
+// UsageDay retorna o dia (UTC) usado na contagem diária de mensagens
+func UsageDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
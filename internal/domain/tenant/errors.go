@@ -0,0 +1,38 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Erros específicos do domínio de tenants
+var (
+	ErrTenantNotFound      = errors.New("tenant não encontrado")
+	ErrTenantAlreadyExists = errors.New("tenant já existe")
+	ErrTenantInactive      = errors.New("tenant desativado")
+	ErrTenantNotEmpty      = errors.New("tenant ainda possui sessões ou chaves de API")
+	ErrDefaultTenant       = errors.New("o tenant padrão não pode ser removido")
+
+	// Cotas
+	ErrSessionLimitReached  = errors.New("limite de sessões do tenant atingido")
+	ErrMessageQuotaExceeded = errors.New("cota diária de mensagens do tenant esgotada")
+	ErrStorageQuotaExceeded = errors.New("cota de armazenamento do tenant esgotada")
+)
+
+// TenantValidationError representa um erro de validação de tenant
+type TenantValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *TenantValidationError) Error() string {
+	return fmt.Sprintf("tenant inválido no campo '%s': %s", e.Field, e.Message)
+}
+
+// NewTenantValidationError cria um novo erro de validação de tenant
+func NewTenantValidationError(field, message string) *TenantValidationError {
+	return &TenantValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
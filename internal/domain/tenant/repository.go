@@ -0,0 +1,39 @@
+package tenant
+
+import (
+	"context"
+	"time"
+)
+
+// Repository define a interface para persistência de tenants
+type Repository interface {
+	// Create cria um novo tenant
+	Create(ctx context.Context, tenant *Tenant) error
+
+	// GetByID busca um tenant pelo ID
+	GetByID(ctx context.Context, id string) (*Tenant, error)
+
+	// List retorna todos os tenants
+	List(ctx context.Context) ([]*Tenant, error)
+
+	// Update atualiza um tenant existente
+	Update(ctx context.Context, tenant *Tenant) error
+
+	// Delete remove um tenant sem sessões nem chaves de API
+	Delete(ctx context.Context, id string) error
+}
+
+// UsageRepository define a interface para consulta e contabilização do consumo dos tenants
+type UsageRepository interface {
+	// CountSessions retorna o número de sessões do tenant
+	CountSessions(ctx context.Context, tenantID string) (int, error)
+
+	// StorageBytes soma o tamanho das mídias armazenadas das sessões do tenant
+	StorageBytes(ctx context.Context, tenantID string) (int64, error)
+
+	// MessagesSent retorna quantas mensagens o tenant enviou no dia
+	MessagesSent(ctx context.Context, tenantID string, day time.Time) (int, error)
+
+	// IncrementMessages soma delta ao contador diário e retorna o novo total
+	IncrementMessages(ctx context.Context, tenantID string, day time.Time, delta int) (int, error)
+}
@@ -19,6 +19,7 @@ const (
 	EventTypeDisconnected EventType = "Disconnected"
 	EventTypeQRCode       EventType = "QRCode"
 	EventTypePairSuccess  EventType = "PairSuccess"
+	EventTypeMediaScan    EventType = "MediaScan"
 	EventTypeAll          EventType = "All"
 )
 
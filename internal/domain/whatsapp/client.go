@@ -14,6 +14,9 @@ type Client interface {
 	// Disconnect encerra a conexão
 	Disconnect(ctx context.Context, sessionID uuid.UUID) error
 
+	// Logout desvincula o dispositivo da conta e remove as credenciais armazenadas
+	Logout(ctx context.Context, sessionID uuid.UUID) error
+
 	// GetQRCode gera QR Code para autenticação
 	GetQRCode(ctx context.Context, sessionID uuid.UUID) (string, error)
 
@@ -29,6 +32,9 @@ type Client interface {
 	// IsLoggedIn verifica se está logado
 	IsLoggedIn(ctx context.Context, sessionID uuid.UUID) bool
 
+	// GetActivity retorna o último evento recebido e o estado do QR Code da sessão
+	GetActivity(ctx context.Context, sessionID uuid.UUID) *SessionActivity
+
 	// SendTextMessage envia mensagem de texto
 	SendTextMessage(ctx context.Context, req *SendTextRequest) (*MessageResponse, error)
 
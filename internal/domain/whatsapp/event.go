@@ -16,6 +16,12 @@ type EventHandler interface {
 	HandleQRCode(ctx context.Context, event *QRCodeEvent) error
 }
 
+// InboundProcessor processa mensagens recebidas depois de persistidas
+// (respostas automáticas, mensagens de ausência, etc.)
+type InboundProcessor interface {
+	ProcessInbound(ctx context.Context, event *MessageEvent) error
+}
+
 // MessageEvent representa um evento de mensagem recebida
 type MessageEvent struct {
 	SessionID uuid.UUID      `json:"sessionId"`
@@ -33,6 +39,9 @@ type MessageEvent struct {
 	PushName  string         `json:"push_name,omitempty"`
 	ReplyToID string         `json:"reply_to_id,omitempty"`
 	Metadata  map[string]any `json:"metadata,omitempty"`
+
+	// IsFirstMessage indica a primeira mensagem do contato (chat ainda inexistente)
+	IsFirstMessage bool `json:"isFirstMessage,omitempty"`
 }
 
 // ReceiptEvent representa um evento de confirmação de leitura
@@ -15,17 +15,28 @@ type SendTextRequest struct {
 	ReplyToID string    `json:"reply_to_id,omitempty"`
 }
 
+// NormalizeOptions ajusta a normalização da mídia em uma requisição; campos nulos seguem o
+// padrão do servidor
+type NormalizeOptions struct {
+	Enabled        *bool `json:"enabled,omitempty"`        // true ativa e false desativa todas as etapas
+	TranscodeVideo *bool `json:"transcodeVideo,omitempty"` // Converter vídeos para H.264/AAC MP4
+	ResizeImage    *bool `json:"resizeImage,omitempty"`    // Reduzir e recomprimir imagens grandes
+	ConvertSticker *bool `json:"convertSticker,omitempty"` // Converter PNG/JPEG em sticker WebP 512x512
+	StripGPS       *bool `json:"stripGps,omitempty"`       // Remover a localização do EXIF
+}
+
 // SendImageRequest representa uma requisição de envio de imagem
 type SendImageRequest struct {
-	SessionID  uuid.UUID `json:"sessionId" validate:"required"`
-	ToJID      string    `json:"to_jid" validate:"required"`
-	ImageData  io.Reader `json:"-"`
-	ImageURL   string    `json:"image_url,omitempty"`
-	Base64Data string    `json:"base64_data,omitempty"` // Dados em base64
-	Caption    string    `json:"caption,omitempty"`
-	ReplyToID  string    `json:"reply_to_id,omitempty"`
-	MimeType   string    `json:"mime_type,omitempty"`
-	FileName   string    `json:"file_name,omitempty"`
+	SessionID  uuid.UUID         `json:"sessionId" validate:"required"`
+	ToJID      string            `json:"to_jid" validate:"required"`
+	ImageData  io.Reader         `json:"-"`
+	ImageURL   string            `json:"image_url,omitempty"`
+	Base64Data string            `json:"base64_data,omitempty"` // Dados em base64
+	Caption    string            `json:"caption,omitempty"`
+	ReplyToID  string            `json:"reply_to_id,omitempty"`
+	MimeType   string            `json:"mime_type,omitempty"`
+	FileName   string            `json:"file_name,omitempty"`
+	Normalize  *NormalizeOptions `json:"normalize,omitempty"`
 }
 
 // SendAudioRequest representa uma requisição de envio de áudio
@@ -38,20 +49,21 @@ type SendAudioRequest struct {
 	ReplyToID  string    `json:"reply_to_id,omitempty"`
 	MimeType   string    `json:"mime_type,omitempty"`
 	FileName   string    `json:"file_name,omitempty"`
-	IsVoice    bool      `json:"is_voice,omitempty"`
+	PTT        bool      `json:"ptt,omitempty"` // Enviar como mensagem de voz, convertendo para OGG/Opus
 }
 
 // SendVideoRequest representa uma requisição de envio de vídeo
 type SendVideoRequest struct {
-	SessionID  uuid.UUID `json:"sessionId" validate:"required"`
-	ToJID      string    `json:"to_jid" validate:"required"`
-	VideoData  io.Reader `json:"-"`
-	VideoURL   string    `json:"video_url,omitempty"`
-	Base64Data string    `json:"base64_data,omitempty"` // Dados em base64
-	Caption    string    `json:"caption,omitempty"`
-	ReplyToID  string    `json:"reply_to_id,omitempty"`
-	MimeType   string    `json:"mime_type,omitempty"`
-	FileName   string    `json:"file_name,omitempty"`
+	SessionID  uuid.UUID         `json:"sessionId" validate:"required"`
+	ToJID      string            `json:"to_jid" validate:"required"`
+	VideoData  io.Reader         `json:"-"`
+	VideoURL   string            `json:"video_url,omitempty"`
+	Base64Data string            `json:"base64_data,omitempty"` // Dados em base64
+	Caption    string            `json:"caption,omitempty"`
+	ReplyToID  string            `json:"reply_to_id,omitempty"`
+	MimeType   string            `json:"mime_type,omitempty"`
+	FileName   string            `json:"file_name,omitempty"`
+	Normalize  *NormalizeOptions `json:"normalize,omitempty"`
 }
 
 // SendDocumentRequest representa uma requisição de envio de documento
@@ -69,13 +81,14 @@ type SendDocumentRequest struct {
 
 // SendStickerRequest representa uma requisição de envio de sticker
 type SendStickerRequest struct {
-	SessionID   uuid.UUID `json:"sessionId" validate:"required"`
-	ToJID       string    `json:"to_jid" validate:"required"`
-	StickerData io.Reader `json:"-"`
-	StickerURL  string    `json:"sticker_url,omitempty"`
-	Base64Data  string    `json:"base64_data,omitempty"` // Dados em base64
-	ReplyToID   string    `json:"reply_to_id,omitempty"`
-	MimeType    string    `json:"mime_type,omitempty"`
+	SessionID   uuid.UUID         `json:"sessionId" validate:"required"`
+	ToJID       string            `json:"to_jid" validate:"required"`
+	StickerData io.Reader         `json:"-"`
+	StickerURL  string            `json:"sticker_url,omitempty"`
+	Base64Data  string            `json:"base64_data,omitempty"` // Dados em base64
+	ReplyToID   string            `json:"reply_to_id,omitempty"`
+	MimeType    string            `json:"mime_type,omitempty"`
+	Normalize   *NormalizeOptions `json:"normalize,omitempty"`
 }
 
 // SendLocationRequest representa uma requisição de envio de localização
@@ -190,8 +203,15 @@ type DownloadMediaRequest struct {
 	MediaKey      []byte    `json:"media_key" validate:"required"`
 	FileEncSHA256 []byte    `json:"file_enc_sha256" validate:"required"`
 	FileSHA256    []byte    `json:"file_sha256" validate:"required"`
-	FileLength    uint64    `json:"file_length" validate:"required"`
+	FileLength    uint64    `json:"file_length"` // Zero dispensa a conferência do tamanho
 	MediaType     string    `json:"media_type" validate:"required"`
+
+	// Mensagem de origem; quando informada, a mídia expirada na CDN é pedida novamente ao
+	// aparelho do remetente
+	ChatJID   string `json:"chat_jid,omitempty"`
+	SenderJID string `json:"sender_jid,omitempty"`
+	MessageID string `json:"message_id,omitempty"`
+	IsFromMe  bool   `json:"is_from_me,omitempty"`
 }
 
 // UploadMediaRequest representa uma requisição para upload de mídia
@@ -318,10 +338,13 @@ type UpdateGroupParticipantsRequest struct {
 
 // MessageResponse representa a resposta de envio de mensagem
 type MessageResponse struct {
-	MessageID string `json:"messageId"`
-	Status    string `json:"status"`
-	Timestamp int64  `json:"timestamp"`
-	Error     string `json:"error,omitempty"`
+	MessageID  string   `json:"messageId"`
+	Status     string   `json:"status"`
+	Timestamp  int64    `json:"timestamp"`
+	PreviewURL string   `json:"previewUrl,omitempty"` // Preview da mídia no armazenamento, quando gerado
+	PTT        bool     `json:"ptt,omitempty"`        // Áudio enviado como mensagem de voz
+	Normalized []string `json:"normalized,omitempty"` // Alterações feitas na mídia antes do envio
+	Error      string   `json:"error,omitempty"`
 }
 
 // Contact representa um contato
@@ -358,6 +381,14 @@ const (
 	StatusLoggedOut    ConnectionStatus = "logged_out"
 )
 
+// SessionActivity representa a atividade recente de uma sessão, mantida em memória pelo cliente
+type SessionActivity struct {
+	LastEventAt    *time.Time `json:"lastEventAt,omitempty"`
+	LastEventType  string     `json:"lastEventType,omitempty"`
+	QRPendingSince *time.Time `json:"qrPendingSince,omitempty"` // Primeiro QR Code ainda não escaneado
+	QRCodesIssued  int        `json:"qrCodesIssued"`
+}
+
 // PresenceType representa o tipo de presença
 type PresenceType string
 
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	apikeyEntity "zapcore/internal/domain/apikey"
+	auditEntity "zapcore/internal/domain/audit"
+	"zapcore/internal/usecases/apikey"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// APIKeyHandler gerencia as requisições HTTP para chaves de API
+type APIKeyHandler struct {
+	createUseCase *apikey.CreateKeyUseCase
+	listUseCase   *apikey.ListKeysUseCase
+	rotateUseCase *apikey.RotateKeyUseCase
+	revokeUseCase *apikey.RevokeKeyUseCase
+	logger        *logger.Logger
+}
+
+// NewAPIKeyHandler cria uma nova instância do handler
+func NewAPIKeyHandler(
+	createUseCase *apikey.CreateKeyUseCase,
+	listUseCase *apikey.ListKeysUseCase,
+	rotateUseCase *apikey.RotateKeyUseCase,
+	revokeUseCase *apikey.RevokeKeyUseCase,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		rotateUseCase: rotateUseCase,
+		revokeUseCase: revokeUseCase,
+		logger:        logger.Get(),
+	}
+}
+
+// Create emite uma nova chave de API
+// @Summary Criar chave de API
+// @Description Emite uma chave com escopos, sessões permitidas e expiração. O segredo só é retornado nesta resposta
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body apikey.CreateKeyRequest true "Dados da chave"
+// @Success 201 {object} apikey.KeyWithSecretResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var req apikey.CreateKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.createUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	auditEntity.SetResource(c.Request.Context(), response.APIKey.ID.String())
+	c.JSON(http.StatusCreated, response)
+}
+
+// List lista as chaves de API
+// @Summary Listar chaves de API
+// @Description Lista todas as chaves, incluindo revogadas e expiradas. Os segredos nunca são retornados
+// @Tags admin
+// @Produce json
+// @Success 200 {object} apikey.ListKeysResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/keys [get]
+func (h *APIKeyHandler) List(c *gin.Context) {
+	response, err := h.listUseCase.Execute(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Get retorna uma chave de API
+// @Summary Obter chave de API
+// @Tags admin
+// @Produce json
+// @Param keyID path string true "ID da chave"
+// @Success 200 {object} apikeyEntity.APIKey
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/keys/{keyID} [get]
+func (h *APIKeyHandler) Get(c *gin.Context) {
+	keyID, ok := h.parseKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.listUseCase.Get(c.Request.Context(), keyID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// Rotate gera um novo segredo para a chave
+// @Summary Rotacionar chave de API
+// @Description Gera um novo segredo mantendo escopos, sessões e expiração. O segredo anterior deixa de valer imediatamente
+// @Tags admin
+// @Produce json
+// @Param keyID path string true "ID da chave"
+// @Success 200 {object} apikey.KeyWithSecretResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/keys/{keyID}/rotate [post]
+func (h *APIKeyHandler) Rotate(c *gin.Context) {
+	keyID, ok := h.parseKeyID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.rotateUseCase.Execute(c.Request.Context(), keyID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Revoke revoga a chave
+// @Summary Revogar chave de API
+// @Description Revoga a chave imediatamente. O registro é mantido para consulta
+// @Tags admin
+// @Produce json
+// @Param keyID path string true "ID da chave"
+// @Success 200 {object} apikeyEntity.APIKey
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/keys/{keyID} [delete]
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	keyID, ok := h.parseKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.revokeUseCase.Execute(c.Request.Context(), keyID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// parseKeyID extrai e valida o ID da chave do path
+func (h *APIKeyHandler) parseKeyID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("keyID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "ID da chave inválido",
+			Message: "O identificador deve ser um UUID válido",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// handleError trata erros de forma centralizada
+func (h *APIKeyHandler) handleError(c *gin.Context, err error) {
+	// Tenant inexistente ou desativado ao emitir a chave
+	if writeTenantError(c, err) {
+		return
+	}
+
+	var validationErr *apikeyEntity.KeyValidationError
+
+	switch {
+	case errors.Is(err, apikeyEntity.ErrKeyNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "KEY_NOT_FOUND",
+			Message: "Chave de API não encontrada",
+		})
+	case errors.Is(err, apikeyEntity.ErrKeyRevoked):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "KEY_REVOKED",
+			Message: "Chave de API revogada não pode ser rotacionada",
+		})
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_KEY",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error().Err(err).Msg("Erro interno do servidor")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Erro interno do servidor",
+			Message: "Ocorreu um erro inesperado",
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	auditEntity "zapcore/internal/domain/audit"
+	"zapcore/internal/usecases/audit"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportFlushEvery define a cada quantos registros a exportação é enviada ao cliente
+const exportFlushEvery = 100
+
+// AuditHandler gerencia as requisições HTTP da trilha de auditoria
+type AuditHandler struct {
+	listUseCase *audit.ListUseCase
+	logger      *logger.Logger
+}
+
+// NewAuditHandler cria uma nova instância do handler
+func NewAuditHandler(listUseCase *audit.ListUseCase) *AuditHandler {
+	return &AuditHandler{
+		listUseCase: listUseCase,
+		logger:      logger.Get(),
+	}
+}
+
+// List consulta a trilha de auditoria
+// @Summary Consultar trilha de auditoria
+// @Description Lista as ações registradas, das mais recentes para as mais antigas. Chaves de um tenant veem apenas os registros do próprio tenant
+// @Tags admin
+// @Produce json
+// @Param action query string false "Ação (ex.: session.delete) ou recurso (ex.: session)"
+// @Param actor query string false "Nome da chave que executou a ação"
+// @Param keyId query string false "ID da chave que executou a ação"
+// @Param sessionId query string false "ID da sessão afetada"
+// @Param resourceId query string false "ID do recurso afetado"
+// @Param requestId query string false "Request ID (X-Request-ID)"
+// @Param outcome query string false "success ou failure"
+// @Param from query string false "Início do período (RFC 3339)"
+// @Param to query string false "Fim do período, exclusivo (RFC 3339)"
+// @Param tenantId query string false "Filtrar por tenant (chave mestre)"
+// @Param limit query int false "Limite de resultados (máximo 500)"
+// @Param offset query int false "Offset para paginação"
+// @Success 200 {object} audit.ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/audit [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	var req audit.ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Parâmetros inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.listUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Export exporta a trilha de auditoria em NDJSON
+// @Summary Exportar trilha de auditoria
+// @Description Exporta em ordem cronológica todos os registros que atendem aos filtros, um objeto JSON por linha. Aceita os mesmos filtros da consulta, exceto limit e offset
+// @Tags admin
+// @Produce application/x-ndjson
+// @Success 200 {string} string "Registros em NDJSON"
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/audit/export [get]
+func (h *AuditHandler) Export(c *gin.Context) {
+	var req audit.ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Parâmetros inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Os cabeçalhos só são enviados no primeiro registro, para que filtros inválidos
+	// ainda possam ser respondidos com erro
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-%s.ndjson"`, time.Now().UTC().Format("20060102-150405")))
+		c.Status(http.StatusOK)
+	}
+
+	encoder := json.NewEncoder(c.Writer)
+	exported := 0
+	err := h.listUseCase.Export(c.Request.Context(), &req, func(entry *auditEntity.Entry) error {
+		start()
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+		exported++
+		if exported%exportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+
+	if err != nil {
+		if !started {
+			h.handleError(c, err)
+			return
+		}
+		// A resposta já começou: o cliente recebe um arquivo incompleto
+		h.logger.Error().Err(err).Int("exported", exported).Msg("Exportação da trilha de auditoria interrompida")
+		return
+	}
+
+	start()
+	c.Writer.Flush()
+}
+
+// handleError trata erros de forma centralizada
+func (h *AuditHandler) handleError(c *gin.Context, err error) {
+	var validationErr *auditEntity.FilterValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_FILTER",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.logger.Error().Err(err).Msg("Erro interno do servidor")
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Erro interno do servidor",
+		Message: "Ocorreu um erro inesperado",
+	})
+}
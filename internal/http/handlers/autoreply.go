@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	auditEntity "zapcore/internal/domain/audit"
+	autoreplyEntity "zapcore/internal/domain/autoreply"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/usecases/autoreply"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AutoReplyHandler gerencia as requisições HTTP para regras de resposta automática
+type AutoReplyHandler struct {
+	createUseCase *autoreply.CreateRuleUseCase
+	listUseCase   *autoreply.ListRulesUseCase
+	updateUseCase *autoreply.UpdateRuleUseCase
+	deleteUseCase *autoreply.DeleteRuleUseCase
+	logger        *logger.Logger
+}
+
+// NewAutoReplyHandler cria uma nova instância do handler
+func NewAutoReplyHandler(
+	createUseCase *autoreply.CreateRuleUseCase,
+	listUseCase *autoreply.ListRulesUseCase,
+	updateUseCase *autoreply.UpdateRuleUseCase,
+	deleteUseCase *autoreply.DeleteRuleUseCase,
+) *AutoReplyHandler {
+	return &AutoReplyHandler{
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		logger:        logger.Get(),
+	}
+}
+
+// Create cria uma nova regra de resposta automática
+// @Summary Criar regra de resposta automática
+// @Description Cria uma regra com condições (texto, tipo de mensagem, tipo de chat, horário) e ações
+// @Tags autoreply
+// @Accept json
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param request body autoreply.CreateRuleRequest true "Dados da regra"
+// @Success 201 {object} autoreply.CreateRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/autoreply/rules [post]
+func (h *AutoReplyHandler) Create(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+
+	var req autoreply.CreateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.SessionID = sessionID
+
+	response, err := h.createUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	auditEntity.SetResource(c.Request.Context(), response.Rule.ID.String())
+	c.JSON(http.StatusCreated, response)
+}
+
+// List lista as regras de uma sessão
+// @Summary Listar regras de resposta automática
+// @Description Lista as regras da sessão em ordem de prioridade
+// @Tags autoreply
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Success 200 {object} autoreply.ListRulesResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /sessions/{sessionID}/autoreply/rules [get]
+func (h *AutoReplyHandler) List(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+
+	response, err := h.listUseCase.Execute(c.Request.Context(), sessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Update atualiza uma regra
+// @Summary Atualizar regra de resposta automática
+// @Description Atualiza os campos informados de uma regra
+// @Tags autoreply
+// @Accept json
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param ruleID path string true "ID da regra"
+// @Param request body autoreply.UpdateRuleRequest true "Campos a atualizar"
+// @Success 200 {object} autoreply.UpdateRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/autoreply/rules/{ruleID} [put]
+func (h *AutoReplyHandler) Update(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+	ruleID, ok := h.parseUUIDParam(c, "ruleID", "ID da regra inválido")
+	if !ok {
+		return
+	}
+
+	var req autoreply.UpdateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.SessionID = sessionID
+	req.RuleID = ruleID
+
+	response, err := h.updateUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete remove uma regra
+// @Summary Remover regra de resposta automática
+// @Description Remove uma regra da sessão
+// @Tags autoreply
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param ruleID path string true "ID da regra"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/autoreply/rules/{ruleID} [delete]
+func (h *AutoReplyHandler) Delete(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+	ruleID, ok := h.parseUUIDParam(c, "ruleID", "ID da regra inválido")
+	if !ok {
+		return
+	}
+
+	if err := h.deleteUseCase.Execute(c.Request.Context(), sessionID, ruleID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "Regra removida com sucesso",
+	})
+}
+
+// parseUUIDParam extrai e valida um UUID do path
+func (h *AutoReplyHandler) parseUUIDParam(c *gin.Context, name, errorMessage string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   errorMessage,
+			Message: "O identificador deve ser um UUID válido",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// handleError trata erros de forma centralizada
+func (h *AutoReplyHandler) handleError(c *gin.Context, err error) {
+	var validationErr *autoreplyEntity.RuleValidationError
+
+	switch {
+	case errors.Is(err, session.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "SESSION_NOT_FOUND",
+			Message: "Sessão não encontrada",
+		})
+	case errors.Is(err, autoreplyEntity.ErrRuleNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "RULE_NOT_FOUND",
+			Message: "Regra não encontrada",
+		})
+	case errors.As(err, &validationErr),
+		errors.Is(err, autoreplyEntity.ErrInvalidRuleName),
+		errors.Is(err, autoreplyEntity.ErrRuleWithoutActions):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_RULE",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error().Err(err).Msg("Erro interno do servidor")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Erro interno do servidor",
+			Message: "Ocorreu um erro inesperado",
+		})
+	}
+}
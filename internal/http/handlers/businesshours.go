@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	businessHoursEntity "zapcore/internal/domain/businesshours"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/usecases/businesshours"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BusinessHoursHandler gerencia as requisições HTTP de horário comercial
+type BusinessHoursHandler struct {
+	getUseCase    *businesshours.GetUseCase
+	setUseCase    *businesshours.SetUseCase
+	deleteUseCase *businesshours.DeleteUseCase
+	statusUseCase *businesshours.StatusUseCase
+	logger        *logger.Logger
+}
+
+// NewBusinessHoursHandler cria uma nova instância do handler
+func NewBusinessHoursHandler(
+	getUseCase *businesshours.GetUseCase,
+	setUseCase *businesshours.SetUseCase,
+	deleteUseCase *businesshours.DeleteUseCase,
+	statusUseCase *businesshours.StatusUseCase,
+) *BusinessHoursHandler {
+	return &BusinessHoursHandler{
+		getUseCase:    getUseCase,
+		setUseCase:    setUseCase,
+		deleteUseCase: deleteUseCase,
+		statusUseCase: statusUseCase,
+		logger:        logger.Get(),
+	}
+}
+
+// Get obtém o horário comercial da sessão
+// @Summary Obter horário comercial
+// @Description Retorna o calendário semanal, feriados e mensagens automáticas da sessão
+// @Tags business-hours
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Success 200 {object} businesshours.Calendar
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/business-hours [get]
+func (h *BusinessHoursHandler) Get(c *gin.Context) {
+	sessionID, ok := h.parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.getUseCase.Execute(c.Request.Context(), sessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// Set configura o horário comercial da sessão
+// @Summary Configurar horário comercial
+// @Description Define fuso horário, agenda semanal, feriados e mensagens de ausência e saudação
+// @Tags business-hours
+// @Accept json
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param request body businesshours.SetRequest true "Calendário"
+// @Success 200 {object} businesshours.SetResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/business-hours [put]
+func (h *BusinessHoursHandler) Set(c *gin.Context) {
+	sessionID, ok := h.parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	var req businesshours.SetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.SessionID = sessionID
+
+	response, err := h.setUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete remove o horário comercial da sessão
+// @Summary Remover horário comercial
+// @Description Remove o calendário e desativa as mensagens de ausência e saudação
+// @Tags business-hours
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/business-hours [delete]
+func (h *BusinessHoursHandler) Delete(c *gin.Context) {
+	sessionID, ok := h.parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteUseCase.Execute(c.Request.Context(), sessionID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "Horário comercial removido com sucesso",
+	})
+}
+
+// Status informa se a sessão está em horário de atendimento
+// @Summary Status do horário comercial
+// @Description Informa se a sessão está aberta agora e quando abre ou fecha
+// @Tags business-hours
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Success 200 {object} businesshours.StatusResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/business-hours/status [get]
+func (h *BusinessHoursHandler) Status(c *gin.Context) {
+	sessionID, ok := h.parseSessionID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.statusUseCase.Execute(c.Request.Context(), sessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseSessionID extrai e valida o ID da sessão do path
+func (h *BusinessHoursHandler) parseSessionID(c *gin.Context) (uuid.UUID, bool) {
+	sessionID, err := uuid.Parse(c.Param("sessionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "ID da sessão inválido",
+			Message: "O ID da sessão deve ser um UUID válido",
+		})
+		return uuid.Nil, false
+	}
+	return sessionID, true
+}
+
+// handleError trata erros de forma centralizada
+func (h *BusinessHoursHandler) handleError(c *gin.Context, err error) {
+	var validationErr *businessHoursEntity.CalendarValidationError
+
+	switch {
+	case errors.Is(err, session.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "SESSION_NOT_FOUND",
+			Message: "Sessão não encontrada",
+		})
+	case errors.Is(err, businessHoursEntity.ErrCalendarNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "BUSINESS_HOURS_NOT_FOUND",
+			Message: "Horário comercial não configurado para esta sessão",
+		})
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_BUSINESS_HOURS",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error().Err(err).Msg("Erro interno do servidor")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Erro interno do servidor",
+			Message: "Ocorreu um erro inesperado",
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/domain/eventstream"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// eventsHeartbeatInterval define o intervalo de keep-alive dos streams
+	eventsHeartbeatInterval = 25 * time.Second
+
+	// eventsWriteTimeout limita o tempo de escrita de cada frame/evento
+	eventsWriteTimeout = 10 * time.Second
+)
+
+// TenantSessionsFunc lista os IDs das sessões visíveis no contexto (tenant da chave autenticada)
+type TenantSessionsFunc func(ctx context.Context) ([]uuid.UUID, error)
+
+// EventStreamHandler transmite os eventos das sessões via WebSocket e SSE
+type EventStreamHandler struct {
+	broker         eventstream.Broker
+	tenantSessions TenantSessionsFunc
+	upgrader       websocket.Upgrader
+	logger         *logger.Logger
+}
+
+// NewEventStreamHandler cria uma nova instância do handler
+func NewEventStreamHandler(broker eventstream.Broker, tenantSessions TenantSessionsFunc) *EventStreamHandler {
+	return &EventStreamHandler{
+		broker:         broker,
+		tenantSessions: tenantSessions,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			// A autenticação é feita pela API Key; a origem não é restringida
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		logger: logger.Get(),
+	}
+}
+
+// WebSocket transmite eventos via WebSocket
+// @Summary Stream de eventos via WebSocket
+// @Description Transmite os eventos das sessões em tempo real. Use api_key na query quando o cliente não puder enviar headers.
+// @Tags events
+// @Param sessionId query string false "IDs de sessão separados por vírgula"
+// @Param type query string false "Tipos de evento separados por vírgula"
+// @Param lastEventId query int false "Retomar após este ID"
+// @Success 101
+// @Failure 400 {object} ErrorResponse
+// @Router /events/ws [get]
+func (h *EventStreamHandler) WebSocket(c *gin.Context) {
+	filter, lastEventID, ok := h.parseStreamParams(c)
+	if !ok {
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("Erro ao iniciar conexão WebSocket")
+		return
+	}
+	defer conn.Close()
+
+	sub, err := h.broker.Subscribe(c.Request.Context(), filter, lastEventID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Erro ao registrar consumidor de eventos")
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "erro ao registrar consumidor"),
+			time.Now().Add(eventsWriteTimeout))
+		return
+	}
+	defer h.broker.Unsubscribe(sub)
+
+	// O deadline herdado do servidor HTTP é substituído pelo controle via ping/pong
+	_ = conn.SetReadDeadline(time.Now().Add(2 * eventsHeartbeatInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * eventsHeartbeatInterval))
+	})
+
+	// Ler mensagens do cliente apenas para detectar o fechamento
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(event *eventstream.Event) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(eventsWriteTimeout))
+		return conn.WriteJSON(event)
+	}
+
+	h.stream(sub, closed, write, func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventsWriteTimeout))
+	})
+}
+
+// SSE transmite eventos via Server-Sent Events
+// @Summary Stream de eventos via SSE
+// @Description Transmite os eventos das sessões em tempo real. Suporta o header Last-Event-ID para retomada.
+// @Tags events
+// @Produce text/event-stream
+// @Param sessionId query string false "IDs de sessão separados por vírgula"
+// @Param type query string false "Tipos de evento separados por vírgula"
+// @Param lastEventId query int false "Retomar após este ID"
+// @Success 200
+// @Failure 400 {object} ErrorResponse
+// @Router /events/sse [get]
+func (h *EventStreamHandler) SSE(c *gin.Context) {
+	filter, lastEventID, ok := h.parseStreamParams(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.broker.Subscribe(c.Request.Context(), filter, lastEventID)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Erro ao registrar consumidor de eventos")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Erro interno do servidor",
+			Message: "Não foi possível registrar o consumidor de eventos",
+		})
+		return
+	}
+	defer h.broker.Unsubscribe(sub)
+
+	// O stream é de longa duração: remover o timeout de escrita do servidor
+	controller := http.NewResponseController(c.Writer)
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	write := func(event *eventstream.Event) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	h.stream(sub, c.Request.Context().Done(), write, func() error {
+		if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+}
+
+// stream entrega o backlog e os novos eventos até o cliente desconectar
+func (h *EventStreamHandler) stream(
+	sub *eventstream.Subscription,
+	done <-chan struct{},
+	write func(*eventstream.Event) error,
+	heartbeat func() error,
+) {
+	var lastSeq int64
+	for _, event := range sub.Backlog {
+		if err := write(event); err != nil {
+			return
+		}
+		lastSeq = event.Seq
+	}
+
+	ticker := time.NewTicker(eventsHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				// Consumidor atrasado: o cliente deve reconectar com o último ID
+				return
+			}
+			if event.Seq <= lastSeq {
+				continue
+			}
+			if err := write(event); err != nil {
+				return
+			}
+			lastSeq = event.Seq
+		case <-ticker.C:
+			if err := heartbeat(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseStreamParams extrai filtros e o último ID recebido da requisição
+func (h *EventStreamHandler) parseStreamParams(c *gin.Context) (eventstream.Filter, int64, bool) {
+	var filter eventstream.Filter
+
+	for _, value := range splitQueryValues(c.QueryArray("sessionId")) {
+		sessionID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "ID da sessão inválido",
+				Message: fmt.Sprintf("'%s' não é um UUID válido", value),
+			})
+			return filter, 0, false
+		}
+		filter.SessionIDs = append(filter.SessionIDs, sessionID)
+	}
+
+	// Chaves restritas só recebem eventos das próprias sessões
+	if principal, ok := apikey.PrincipalFromContext(c.Request.Context()); ok && principal.IsRestricted() {
+		for _, sessionID := range filter.SessionIDs {
+			if !principal.CanAccessSession(sessionID) {
+				c.JSON(http.StatusForbidden, ErrorResponse{
+					Error:   "Forbidden",
+					Message: fmt.Sprintf("API Key sem acesso à sessão %s", sessionID),
+				})
+				return filter, 0, false
+			}
+		}
+		if len(filter.SessionIDs) == 0 {
+			filter.SessionIDs = principal.SessionIDs
+		}
+	}
+
+	// Chaves de tenant recebem apenas eventos das sessões existentes no tenant ao abrir o stream
+	if principal, ok := apikey.PrincipalFromContext(c.Request.Context()); ok && principal.TenantID != "" && !principal.IsRestricted() && h.tenantSessions != nil {
+		allowed, err := h.tenantSessions(c.Request.Context())
+		if err != nil {
+			h.logger.Error().Err(err).Str("tenant_id", principal.TenantID).Msg("Erro ao listar sessões do tenant")
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "Erro interno do servidor",
+				Message: "Ocorreu um erro inesperado",
+			})
+			return filter, 0, false
+		}
+
+		for _, sessionID := range filter.SessionIDs {
+			if !slices.Contains(allowed, sessionID) {
+				c.JSON(http.StatusForbidden, ErrorResponse{
+					Error:   "Forbidden",
+					Message: fmt.Sprintf("API Key sem acesso à sessão %s", sessionID),
+				})
+				return filter, 0, false
+			}
+		}
+		if len(filter.SessionIDs) == 0 {
+			filter.SessionIDs = allowed
+		}
+		// Filtro vazio libera todas as sessões: um tenant sem sessões não deve receber nada
+		if len(filter.SessionIDs) == 0 {
+			filter.SessionIDs = []uuid.UUID{uuid.Nil}
+		}
+	}
+
+	for _, value := range splitQueryValues(c.QueryArray("type")) {
+		eventType, ok := eventstream.NormalizeType(value)
+		if !ok {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Tipo de evento inválido",
+				Message: fmt.Sprintf("tipo de evento desconhecido: %s", value),
+			})
+			return filter, 0, false
+		}
+		filter.Types = append(filter.Types, eventType)
+	}
+
+	// Last-Event-ID é enviado automaticamente pelo EventSource ao reconectar
+	rawLastID := c.GetHeader("Last-Event-ID")
+	if rawLastID == "" {
+		rawLastID = c.Query("lastEventId")
+	}
+
+	var lastEventID int64
+	if rawLastID != "" {
+		id, err := strconv.ParseInt(rawLastID, 10, 64)
+		if err != nil || id < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "ID de evento inválido",
+				Message: "lastEventId deve ser um número inteiro",
+			})
+			return filter, 0, false
+		}
+		lastEventID = id
+	}
+
+	return filter, lastEventID, true
+}
+
+// splitQueryValues aceita valores repetidos e separados por vírgula
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	auditEntity "zapcore/internal/domain/audit"
+	eventsinkEntity "zapcore/internal/domain/eventsink"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/usecases/eventsink"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EventSinkHandler gerencia as requisições HTTP para sinks de eventos
+type EventSinkHandler struct {
+	createUseCase *eventsink.CreateSinkUseCase
+	listUseCase   *eventsink.ListSinksUseCase
+	updateUseCase *eventsink.UpdateSinkUseCase
+	deleteUseCase *eventsink.DeleteSinkUseCase
+	logger        *logger.Logger
+}
+
+// NewEventSinkHandler cria uma nova instância do handler
+func NewEventSinkHandler(
+	createUseCase *eventsink.CreateSinkUseCase,
+	listUseCase *eventsink.ListSinksUseCase,
+	updateUseCase *eventsink.UpdateSinkUseCase,
+	deleteUseCase *eventsink.DeleteSinkUseCase,
+) *EventSinkHandler {
+	return &EventSinkHandler{
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		logger:        logger.Get(),
+	}
+}
+
+// Create cria um novo sink de eventos
+// @Summary Criar sink de eventos
+// @Description Publica os eventos da sessão em NATS JetStream, RabbitMQ ou Kafka
+// @Tags sinks
+// @Accept json
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param request body eventsink.CreateSinkRequest true "Dados do sink"
+// @Success 201 {object} eventsink.CreateSinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/sinks [post]
+func (h *EventSinkHandler) Create(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+
+	var req eventsink.CreateSinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.SessionID = sessionID
+
+	response, err := h.createUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	auditEntity.SetResource(c.Request.Context(), response.Sink.ID.String())
+	c.JSON(http.StatusCreated, response)
+}
+
+// List lista os sinks de uma sessão
+// @Summary Listar sinks de eventos
+// @Description Lista os sinks configurados para a sessão
+// @Tags sinks
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Success 200 {object} eventsink.ListSinksResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /sessions/{sessionID}/sinks [get]
+func (h *EventSinkHandler) List(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+
+	response, err := h.listUseCase.Execute(c.Request.Context(), sessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Update atualiza um sink
+// @Summary Atualizar sink de eventos
+// @Description Atualiza os campos informados e reinicia o sink
+// @Tags sinks
+// @Accept json
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param sinkID path string true "ID do sink"
+// @Param request body eventsink.UpdateSinkRequest true "Campos a atualizar"
+// @Success 200 {object} eventsink.UpdateSinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/sinks/{sinkID} [put]
+func (h *EventSinkHandler) Update(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+	sinkID, ok := h.parseUUIDParam(c, "sinkID", "ID do sink inválido")
+	if !ok {
+		return
+	}
+
+	var req eventsink.UpdateSinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.SessionID = sessionID
+	req.SinkID = sinkID
+
+	response, err := h.updateUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete remove um sink
+// @Summary Remover sink de eventos
+// @Description Remove o sink da sessão; registros pendentes em disco são mantidos
+// @Tags sinks
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param sinkID path string true "ID do sink"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/sinks/{sinkID} [delete]
+func (h *EventSinkHandler) Delete(c *gin.Context) {
+	sessionID, ok := h.parseUUIDParam(c, "sessionID", "ID da sessão inválido")
+	if !ok {
+		return
+	}
+	sinkID, ok := h.parseUUIDParam(c, "sinkID", "ID do sink inválido")
+	if !ok {
+		return
+	}
+
+	if err := h.deleteUseCase.Execute(c.Request.Context(), sessionID, sinkID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "Sink removido com sucesso",
+	})
+}
+
+// parseUUIDParam extrai e valida um UUID do path
+func (h *EventSinkHandler) parseUUIDParam(c *gin.Context, name, errorMessage string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   errorMessage,
+			Message: "O identificador deve ser um UUID válido",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// handleError trata erros de forma centralizada
+func (h *EventSinkHandler) handleError(c *gin.Context, err error) {
+	var validationErr *eventsinkEntity.SinkValidationError
+
+	switch {
+	case errors.Is(err, session.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "SESSION_NOT_FOUND",
+			Message: "Sessão não encontrada",
+		})
+	case errors.Is(err, eventsinkEntity.ErrSinkNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "SINK_NOT_FOUND",
+			Message: "Sink não encontrado",
+		})
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_SINK",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.Error().Err(err).Msg("Erro interno do servidor")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Erro interno do servidor",
+			Message: "Ocorreu um erro inesperado",
+		})
+	}
+}
@@ -1,27 +1,46 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"sync"
 	"time"
 
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/usecases/session"
 	"zapcore/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
+// readinessCheckTimeout limita cada verificação do readiness check
+const readinessCheckTimeout = 3 * time.Second
+
+// ReadinessCheck verifica um componente necessário para receber tráfego.
+// Falhas em componentes críticos tornam a aplicação "not_ready" (503); nos demais, "degraded".
+type ReadinessCheck struct {
+	Name     string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
 // HealthHandler gerencia as requisições de health check
 type HealthHandler struct {
-	logger    *logger.Logger
-	startTime time.Time
-	version   string
+	logger             *logger.Logger
+	startTime          time.Time
+	version            string
+	checks             []ReadinessCheck
+	sessionsHealthCase *session.HealthCheckUseCase
 }
 
 // NewHealthHandler cria uma nova instância do handler
-func NewHealthHandler(version string) *HealthHandler {
+func NewHealthHandler(version string, sessionsHealthCase *session.HealthCheckUseCase, checks ...ReadinessCheck) *HealthHandler {
 	return &HealthHandler{
-		logger:    logger.Get(),
-		startTime: time.Now(),
-		version:   version,
+		logger:             logger.Get(),
+		startTime:          time.Now(),
+		version:            version,
+		checks:             checks,
+		sessionsHealthCase: sessionsHealthCase,
 	}
 }
 
@@ -47,23 +66,104 @@ func (h *HealthHandler) Check(c *gin.Context) {
 
 // Ready verifica se a aplicação está pronta para receber tráfego
 // @Summary Readiness Check
-// @Description Verifica se a aplicação está pronta para receber requisições
+// @Description Verifica banco de dados, store do WhatsApp e armazenamento de mídias, com status e latência de cada componente
 // @Tags health
 // @Produce json
-// @Success 200 {object} HealthResponse
-// @Failure 503 {object} ErrorResponse
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse
 // @Router /ready [get]
 func (h *HealthHandler) Ready(c *gin.Context) {
-	// Aqui você pode adicionar verificações específicas:
-	// - Conexão com banco de dados
-	// - Conexões com serviços externos
-	// - Verificação de recursos necessários
+	components := make(map[string]ComponentStatus, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
 
-	response := HealthResponse{
-		Status:    "ready",
-		Timestamp: time.Now().Format(time.RFC3339),
-		Version:   h.version,
-		Uptime:    time.Since(h.startTime).String(),
+	// As verificações rodam em paralelo para que um componente lento não some latência aos demais
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func(check ReadinessCheck) {
+			defer wg.Done()
+			result := h.runCheck(c.Request.Context(), check)
+
+			mu.Lock()
+			components[check.Name] = result
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	status := "ready"
+	httpStatus := http.StatusOK
+	for name, component := range components {
+		if component.Status == "up" {
+			continue
+		}
+		if component.Critical {
+			status = "not_ready"
+			httpStatus = http.StatusServiceUnavailable
+		} else if status == "ready" {
+			status = "degraded"
+		}
+		h.logger.Warn().
+			Str("component", name).
+			Str("error", component.Error).
+			Bool("critical", component.Critical).
+			Msg("Readiness check falhou")
+	}
+
+	c.JSON(httpStatus, ReadinessResponse{
+		Status:     status,
+		Timestamp:  time.Now().Format(time.RFC3339),
+		Version:    h.version,
+		Uptime:     time.Since(h.startTime).String(),
+		Components: components,
+	})
+}
+
+// runCheck executa uma verificação com timeout e mede sua latência
+func (h *HealthHandler) runCheck(ctx context.Context, check ReadinessCheck) ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+
+	result := ComponentStatus{
+		Status:    "up",
+		Critical:  check.Critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = "down"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Sessions retorna o estado de conexão de cada sessão ativa
+// @Summary Health das sessões
+// @Description Estado de conexão, último evento recebido e detecção de QR Code travado de cada sessão ativa
+// @Tags health
+// @Security ApiKeyAuth
+// @Produce json
+// @Param unhealthy query bool false "Retornar apenas sessões com problema"
+// @Success 200 {object} session.HealthStatus
+// @Failure 500 {object} ErrorResponse
+// @Router /health/sessions [get]
+func (h *HealthHandler) Sessions(c *gin.Context) {
+	req := &session.HealthCheckRequest{
+		OnlyUnhealthy: c.Query("unhealthy") == "true",
+	}
+	if principal, ok := apikey.PrincipalFromContext(c.Request.Context()); ok {
+		req.SessionIDs = principal.SessionIDs
+	}
+
+	response, err := h.sessionsHealthCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Erro interno do servidor",
+			Message: "Ocorreu um erro inesperado",
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	mediaEntity "zapcore/internal/domain/media"
+	messageEntity "zapcore/internal/domain/message"
+	"zapcore/internal/usecases/media"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// mediaCacheControl permite o cache apenas no cliente: a mídia de uma mensagem não muda, mas
+// a resposta depende da chave de API
+const mediaCacheControl = "private, max-age=86400"
+
+// MediaHandler gerencia os downloads das mídias das mensagens
+type MediaHandler struct {
+	downloadUseCase *media.DownloadUseCase
+	retryUseCase    *media.RetryUseCase
+	logger          *logger.Logger
+}
+
+// NewMediaHandler cria uma nova instância do handler
+func NewMediaHandler(downloadUseCase *media.DownloadUseCase, retryUseCase *media.RetryUseCase) *MediaHandler {
+	return &MediaHandler{
+		downloadUseCase: downloadUseCase,
+		retryUseCase:    retryUseCase,
+		logger:          logger.Get(),
+	}
+}
+
+// Download envia a mídia da mensagem
+// @Summary Baixar mídia da mensagem
+// @Description Envia a mídia armazenada da mensagem, com suporte a Range e requisições condicionais (ETag). Mídias ainda não armazenadas são baixadas do WhatsApp na primeira requisição.
+// @Tags media
+// @Produce octet-stream
+// @Param sessionID path string true "ID da sessão"
+// @Param msgID path string true "ID da mensagem no WhatsApp"
+// @Param download query bool false "Força o download (Content-Disposition: attachment); tipos que não são imagem, vídeo ou áudio são sempre baixados"
+// @Success 200 {file} binary
+// @Success 206 {file} binary
+// @Success 304
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /media/{sessionID}/{msgID} [get]
+func (h *MediaHandler) Download(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("sessionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "ID da sessão inválido",
+			Message: "O ID da sessão deve ser um UUID válido",
+		})
+		return
+	}
+
+	response, err := h.downloadUseCase.Execute(c.Request.Context(), sessionID, c.Param("msgID"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer response.Content.Close()
+
+	// O tipo vem do remetente: só imagens, vídeos e áudios são exibidos no navegador, para que um
+	// HTML ou SVG enviado na conversa não rode na origem da API
+	disposition := "inline"
+	if download, _ := strconv.ParseBool(c.Query("download")); download || !inlineMediaType(response.ContentType) {
+		disposition = "attachment"
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", response.ContentType)
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": response.FileName}))
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy", "sandbox")
+	header.Set("Cache-Control", mediaCacheControl)
+	if response.ETag != "" {
+		header.Set("ETag", quoteETag(response.ETag))
+	}
+
+	// ServeContent trata Range, If-None-Match, If-Modified-Since e HEAD
+	http.ServeContent(c.Writer, c.Request, response.FileName, response.LastModified, response.Content)
+}
+
+// inlineMediaType indica se o tipo pode ser exibido no navegador: imagens, vídeos e áudios,
+// exceto SVG, que executa scripts
+func inlineMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if mediaType == "image/svg+xml" {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "image/") ||
+		strings.HasPrefix(mediaType, "video/") ||
+		strings.HasPrefix(mediaType, "audio/")
+}
+
+// Retry baixa novamente a mídia da mensagem do WhatsApp
+// @Summary Baixar novamente mídia da mensagem
+// @Description Baixa do WhatsApp a mídia que não está no armazenamento, pedindo o reenvio ao aparelho do remetente quando o arquivo expirou na CDN. Mídias já armazenadas só são baixadas novamente com force=true.
+// @Tags media
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param msgID path string true "ID da mensagem no WhatsApp"
+// @Param force query bool false "Baixa mesmo que a mídia já esteja armazenada"
+// @Success 200 {object} media.RetryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /messages/{sessionID}/{msgID}/media/retry [post]
+func (h *MediaHandler) Retry(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("sessionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "ID da sessão inválido",
+			Message: "O ID da sessão deve ser um UUID válido",
+		})
+		return
+	}
+
+	force, _ := strconv.ParseBool(c.Query("force"))
+	response, err := h.retryUseCase.Execute(c.Request.Context(), &media.RetryRequest{
+		SessionID: sessionID,
+		MsgID:     c.Param("msgID"),
+		Force:     force,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// handleError converte os erros do download em respostas HTTP
+func (h *MediaHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, messageEntity.ErrMessageNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "MESSAGE_NOT_FOUND",
+			Message: "Mensagem não encontrada na sessão",
+		})
+	case errors.Is(err, messageEntity.ErrMediaNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "MEDIA_NOT_FOUND",
+			Message: "A mensagem não possui mídia",
+		})
+	case errors.Is(err, mediaEntity.ErrStorageDisabled):
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "STORAGE_DISABLED",
+			Message: "Armazenamento de mídias não configurado",
+		})
+	case errors.Is(err, mediaEntity.ErrMediaExpired):
+		c.JSON(http.StatusGone, ErrorResponse{
+			Error:   "MEDIA_EXPIRED",
+			Message: "A mídia foi removida pela política de retenção; use o reenvio para baixá-la novamente",
+		})
+	case errors.Is(err, mediaEntity.ErrMediaQuarantined):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "MEDIA_QUARANTINED",
+			Message: "A mídia foi reprovada pela verificação de malware e está em quarentena",
+		})
+	case errors.Is(err, mediaEntity.ErrMediaUnavailable):
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "MEDIA_UNAVAILABLE",
+			Message: "Não foi possível baixar a mídia do WhatsApp; verifique se a sessão está conectada",
+		})
+	default:
+		h.logger.Error().Err(err).Msg("Erro interno do servidor")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Erro interno do servidor",
+			Message: "Ocorreu um erro inesperado",
+		})
+	}
+}
+
+// quoteETag garante as aspas exigidas no cabeçalho ETag; o S3 retorna o valor sem elas
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"strings"
 
+	auditEntity "zapcore/internal/domain/audit"
 	messageEntity "zapcore/internal/domain/message"
+	whatsappEntity "zapcore/internal/domain/whatsapp"
 	"zapcore/internal/shared/media"
 	"zapcore/internal/usecases/message"
 	"zapcore/pkg/logger"
@@ -18,20 +22,23 @@ import (
 
 // MessageHandler gerencia as requisições HTTP para mensagens
 type MessageHandler struct {
-	sendTextUseCase  *message.SendTextUseCase
-	sendMediaUseCase *message.SendMediaUseCase
-	logger           *logger.Logger
+	sendTextUseCase    *message.SendTextUseCase
+	sendMediaUseCase   *message.SendMediaUseCase
+	getReceiptsUseCase *message.GetReceiptsUseCase
+	logger             *logger.Logger
 }
 
 // NewMessageHandler cria uma nova instância do handler
 func NewMessageHandler(
 	sendTextUseCase *message.SendTextUseCase,
 	sendMediaUseCase *message.SendMediaUseCase,
+	getReceiptsUseCase *message.GetReceiptsUseCase,
 ) *MessageHandler {
 	return &MessageHandler{
-		sendTextUseCase:  sendTextUseCase,
-		sendMediaUseCase: sendMediaUseCase,
-		logger:           logger.Get(),
+		sendTextUseCase:    sendTextUseCase,
+		sendMediaUseCase:   sendMediaUseCase,
+		getReceiptsUseCase: getReceiptsUseCase,
+		logger:             logger.Get(),
 	}
 }
 
@@ -90,6 +97,7 @@ func (h *MessageHandler) SendText(c *gin.Context) {
 		return
 	}
 
+	auditEntity.SetResource(c.Request.Context(), response.WhatsAppID)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -187,6 +195,15 @@ type MediaRequest struct {
 	FileName string `json:"fileName,omitempty"` // Nome do arquivo
 	Caption  string `json:"caption,omitempty"`
 	ReplyID  string `json:"replyId,omitempty"`
+	PTT      bool   `json:"ptt,omitempty"` // Áudio como mensagem de voz (apenas /send/audio)
+
+	// Normalização de imagens, vídeos e stickers antes do envio; ausente segue o padrão do servidor
+	Normalize *whatsappEntity.NormalizeOptions `json:"normalize,omitempty"`
+
+	// Envio via template: a legenda (e a mídia, se ausente) vêm do template
+	TemplateID *uuid.UUID        `json:"templateId,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	Language   string            `json:"language,omitempty"`
 }
 
 // sendMediaHandler é um método auxiliar para envio de mídia
@@ -220,6 +237,42 @@ func (h *MessageHandler) sendMediaHandler(c *gin.Context, mediaType string) {
 		req.To = c.PostForm("to")
 		req.Caption = c.PostForm("caption")
 		req.ReplyID = c.PostForm("replyId")
+		req.Language = c.PostForm("language")
+		req.PTT = c.PostForm("ptt") == "true"
+
+		if templateID := c.PostForm("templateId"); templateID != "" {
+			parsedID, err := uuid.Parse(templateID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{
+					Error:   "ID do template inválido",
+					Message: "O campo 'templateId' deve ser um UUID válido",
+				})
+				return
+			}
+			req.TemplateID = &parsedID
+		}
+
+		// Opções de normalização chegam como JSON no form-data
+		if normalize := c.PostForm("normalize"); normalize != "" {
+			if err := json.Unmarshal([]byte(normalize), &req.Normalize); err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{
+					Error:   "Opções de normalização inválidas",
+					Message: "O campo 'normalize' deve ser um objeto JSON: " + err.Error(),
+				})
+				return
+			}
+		}
+
+		// Variáveis chegam como JSON no form-data
+		if variables := c.PostForm("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{
+					Error:   "Variáveis inválidas",
+					Message: "O campo 'variables' deve ser um objeto JSON: " + err.Error(),
+				})
+				return
+			}
+		}
 
 		h.logger.Debug().
 			Str("to", req.To).
@@ -235,9 +288,9 @@ func (h *MessageHandler) sendMediaHandler(c *gin.Context, mediaType string) {
 			return
 		}
 
-		// Processar arquivo enviado
+		// Processar arquivo enviado (opcional quando o template fornece a mídia)
 		file, header, err := c.Request.FormFile("media")
-		if err != nil {
+		if err != nil && req.TemplateID == nil {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Error:   "Arquivo de mídia obrigatório",
 				Message: "Erro ao processar arquivo: " + err.Error(),
@@ -245,13 +298,15 @@ func (h *MessageHandler) sendMediaHandler(c *gin.Context, mediaType string) {
 			return
 		}
 
-		// Armazenar para uso posterior
-		formFile = file
-		formHeader = header
-		req.FileName = header.Filename
+		if err == nil {
+			// Armazenar para uso posterior
+			formFile = file
+			formHeader = header
+			req.FileName = header.Filename
 
-		// Marcar que temos dados de arquivo (não base64)
-		req.File = "form-data-file" // Marcador especial para indicar que temos arquivo
+			// Marcar que temos dados de arquivo (não base64)
+			req.File = "form-data-file" // Marcador especial para indicar que temos arquivo
+		}
 
 	} else {
 		// Parse JSON
@@ -286,8 +341,8 @@ func (h *MessageHandler) sendMediaHandler(c *gin.Context, mediaType string) {
 		}
 	}
 
-	// Validar que apenas um campo de mídia está presente
-	if mediaFieldsCount == 0 {
+	// Validar que apenas um campo de mídia está presente (o template pode fornecer a mídia)
+	if mediaFieldsCount == 0 && req.TemplateID == nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "MEDIA_REQUIRED",
 			Message: "É obrigatório fornecer um dos campos: 'base64', 'url' ou usar form-data",
@@ -426,6 +481,11 @@ func (h *MessageHandler) sendMediaHandler(c *gin.Context, mediaType string) {
 		FileName:   fileName,
 		MimeType:   mimeType,
 		ReplyToID:  req.ReplyID,
+		PTT:        req.PTT && mediaType == "audio",
+		Normalize:  req.Normalize,
+		TemplateID: req.TemplateID,
+		Variables:  req.Variables,
+		Language:   req.Language,
 	}
 
 	response, err := h.sendMediaUseCase.Execute(c.Request.Context(), useCaseReq)
@@ -434,11 +494,73 @@ func (h *MessageHandler) sendMediaHandler(c *gin.Context, mediaType string) {
 		return
 	}
 
+	auditEntity.SetResource(c.Request.Context(), response.WhatsAppID)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetReceipts lista as confirmações de entrega, leitura e reprodução de cada destinatário
+// @Summary Confirmações da mensagem
+// @Description Lista quem recebeu, leu ou ouviu a mensagem e o status agregado pela regra de leitura
+// @Tags messages
+// @Produce json
+// @Param sessionID path string true "ID da sessão"
+// @Param msgID path string true "ID da mensagem no WhatsApp"
+// @Success 200 {object} message.ReceiptsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /messages/{sessionID}/{msgID}/receipts [get]
+func (h *MessageHandler) GetReceipts(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("sessionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "ID da sessão inválido",
+			Message: "O ID da sessão deve ser um UUID válido",
+		})
+		return
+	}
+
+	response, err := h.getReceiptsUseCase.Execute(c.Request.Context(), sessionID, c.Param("msgID"))
+	if err != nil {
+		if errors.Is(err, messageEntity.ErrMessageNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "MESSAGE_NOT_FOUND",
+				Message: "Mensagem não encontrada na sessão",
+			})
+			return
+		}
+		h.handleError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
 // handleError trata erros de forma centralizada
 func (h *MessageHandler) handleError(c *gin.Context, err error) {
+	// Erros de template (variáveis ausentes, template inexistente etc.)
+	if writeTemplateError(c, err) {
+		return
+	}
+
+	// Cota diária de mensagens do tenant
+	if writeTenantError(c, err) {
+		return
+	}
+
+	// Limites de envio da sessão
+	if writeSendLimitError(c, err) {
+		return
+	}
+
+	if errors.Is(err, messageEntity.ErrInvalidContent) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "CONTENT_REQUIRED",
+			Message: "É obrigatório fornecer 'text' ou 'templateId'",
+		})
+		return
+	}
+
 	// Aqui você pode adicionar a lógica de tratamento de erros específicos
 	h.logger.Error().Err(err).Msg("Erro interno do servidor")
 	c.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -501,6 +623,15 @@ func (h *MessageHandler) detectMimeTypeFromFilename(filename string) string {
 	if strings.HasSuffix(ext, ".mov") {
 		return "video/mov"
 	}
+	if strings.HasSuffix(ext, ".3gp") {
+		return "video/3gpp"
+	}
+	if strings.HasSuffix(ext, ".mkv") {
+		return "video/mkv"
+	}
+	if strings.HasSuffix(ext, ".webm") {
+		return "video/webm"
+	}
 
 	// Áudios
 	if strings.HasSuffix(ext, ".mp3") {
@@ -509,9 +640,12 @@ func (h *MessageHandler) detectMimeTypeFromFilename(filename string) string {
 	if strings.HasSuffix(ext, ".wav") {
 		return "audio/wav"
 	}
-	if strings.HasSuffix(ext, ".ogg") {
+	if strings.HasSuffix(ext, ".ogg") || strings.HasSuffix(ext, ".opus") {
 		return "audio/ogg"
 	}
+	if strings.HasSuffix(ext, ".m4a") {
+		return "audio/mp4"
+	}
 
 	// Documentos
 	if strings.HasSuffix(ext, ".pdf") {
@@ -555,6 +689,16 @@ func (h *MessageHandler) handleMediaError(c *gin.Context, err error) {
 		return
 	}
 
+	// Erros de template (variáveis ausentes, template inexistente etc.)
+	if writeTemplateError(c, err) {
+		return
+	}
+
+	// Limites de envio da sessão
+	if writeSendLimitError(c, err) {
+		return
+	}
+
 	// Verificar erros de validação de mídia
 	errMsg := err.Error()
 	switch {
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"zapcore/internal/domain/sendlimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeSendLimitError responde erros dos limites de envio da sessão; retorna false se o erro não for desse domínio
+func writeSendLimitError(c *gin.Context, err error) bool {
+	var throttled *sendlimit.ThrottledError
+	var validationErr *sendlimit.LimitsValidationError
+	switch {
+	case errors.As(err, &throttled):
+		c.Header("Retry-After", strconv.Itoa(throttled.RetryAfterSeconds()))
+		c.JSON(http.StatusTooManyRequests, ErrorResponse{
+			Error:   "SEND_THROTTLED",
+			Message: throttled.Error(),
+			Code:    throttled.Reason,
+		})
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_SEND_LIMITS",
+			Message: err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
@@ -1,10 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
 
+	"zapcore/internal/domain/apikey"
+	auditEntity "zapcore/internal/domain/audit"
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/sendlimit"
+	sessionEntity "zapcore/internal/domain/session"
 	"zapcore/internal/usecases/session"
 	"zapcore/pkg/logger"
 
@@ -22,6 +28,8 @@ type SessionHandler struct {
 	disconnectUseCase *session.DisconnectUseCase
 	listUseCase       *session.ListUseCase
 	getStatusUseCase  *session.GetStatusUseCase
+	sendLimitsUseCase *session.SendLimitsUseCase
+	retentionUseCase  *session.MediaRetentionUseCase
 	logger            *logger.Logger
 }
 
@@ -32,6 +40,8 @@ func NewSessionHandler(
 	disconnectUseCase *session.DisconnectUseCase,
 	listUseCase *session.ListUseCase,
 	getStatusUseCase *session.GetStatusUseCase,
+	sendLimitsUseCase *session.SendLimitsUseCase,
+	retentionUseCase *session.MediaRetentionUseCase,
 ) *SessionHandler {
 	return &SessionHandler{
 		createUseCase:     createUseCase,
@@ -39,6 +49,8 @@ func NewSessionHandler(
 		disconnectUseCase: disconnectUseCase,
 		listUseCase:       listUseCase,
 		getStatusUseCase:  getStatusUseCase,
+		sendLimitsUseCase: sendLimitsUseCase,
+		retentionUseCase:  retentionUseCase,
 		logger:            logger.Get(),
 	}
 }
@@ -119,6 +131,7 @@ func (h *SessionHandler) Create(c *gin.Context) {
 		return
 	}
 
+	auditEntity.SetResource(c.Request.Context(), response.Session.ID.String())
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -146,6 +159,11 @@ func (h *SessionHandler) List(c *gin.Context) {
 		return
 	}
 
+	// Chaves restritas enxergam apenas as próprias sessões
+	if principal, ok := apikey.PrincipalFromContext(c.Request.Context()); ok {
+		req.SessionIDs = principal.SessionIDs
+	}
+
 	response, err := h.listUseCase.Execute(c.Request.Context(), &req)
 	if err != nil {
 		h.handleError(c, err)
@@ -263,8 +281,234 @@ func (h *SessionHandler) Disconnect(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetSendLimits retorna os limites de envio da sessão e o consumo atual
+// @Summary Consultar limites de envio
+// @Description Retorna os limites de envio efetivos da sessão (próprios ou padrões) e o consumo nesta instância
+// @Tags sessions
+// @Produce json
+// @Param sessionID path string true "ID ou nome da sessão"
+// @Success 200 {object} sendlimit.Usage
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/send-limits [get]
+func (h *SessionHandler) GetSendLimits(c *gin.Context) {
+	identifier := c.Param("sessionID")
+	sessionID, err := h.resolveSessionIdentifier(c, identifier)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Sessão não encontrada",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	usage, err := h.sendLimitsUseCase.Usage(c.Request.Context(), sessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// SetSendLimits define os limites de envio próprios da sessão
+// @Summary Definir limites de envio
+// @Description Substitui os limites de envio padrão da sessão; campos zerados desativam o limite correspondente
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path string true "ID ou nome da sessão"
+// @Param request body sendlimit.Limits true "Limites de envio"
+// @Success 200 {object} sendlimit.Usage
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/send-limits [put]
+func (h *SessionHandler) SetSendLimits(c *gin.Context) {
+	identifier := c.Param("sessionID")
+	sessionID, err := h.resolveSessionIdentifier(c, identifier)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Sessão não encontrada",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var limits sendlimit.Limits
+	if err := c.ShouldBindJSON(&limits); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	usage, err := h.sendLimitsUseCase.Set(c.Request.Context(), sessionID, &limits)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// ResetSendLimits remove os limites de envio próprios da sessão
+// @Summary Restaurar limites de envio padrão
+// @Description Remove os limites próprios da sessão, que volta a usar os limites padrão da configuração
+// @Tags sessions
+// @Produce json
+// @Param sessionID path string true "ID ou nome da sessão"
+// @Success 200 {object} sendlimit.Usage
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/send-limits [delete]
+func (h *SessionHandler) ResetSendLimits(c *gin.Context) {
+	identifier := c.Param("sessionID")
+	sessionID, err := h.resolveSessionIdentifier(c, identifier)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Sessão não encontrada",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	usage, err := h.sendLimitsUseCase.Set(c.Request.Context(), sessionID, nil)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// GetMediaRetention retorna a retenção das mídias da sessão
+// @Summary Consultar retenção das mídias
+// @Description Retorna a política de retenção própria da sessão e a efetiva (da sessão, do tenant ou da configuração)
+// @Tags sessions
+// @Produce json
+// @Param sessionID path string true "ID ou nome da sessão"
+// @Success 200 {object} session.MediaRetentionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/media-retention [get]
+func (h *SessionHandler) GetMediaRetention(c *gin.Context) {
+	identifier := c.Param("sessionID")
+	sessionID, err := h.resolveSessionIdentifier(c, identifier)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Sessão não encontrada",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.retentionUseCase.Get(c.Request.Context(), sessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetMediaRetention define a retenção das mídias própria da sessão
+// @Summary Definir retenção das mídias
+// @Description Substitui a política de retenção herdada pela sessão; para cada tipo e direção vale a regra mais específica e 0 dias mantém as mídias
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path string true "ID ou nome da sessão"
+// @Param request body media.RetentionPolicy true "Política de retenção"
+// @Success 200 {object} session.MediaRetentionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/media-retention [put]
+func (h *SessionHandler) SetMediaRetention(c *gin.Context) {
+	identifier := c.Param("sessionID")
+	sessionID, err := h.resolveSessionIdentifier(c, identifier)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Sessão não encontrada",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var policy media.RetentionPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.retentionUseCase.Set(c.Request.Context(), sessionID, &policy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResetMediaRetention remove a retenção das mídias própria da sessão
+// @Summary Restaurar retenção das mídias herdada
+// @Description Remove a política própria da sessão, que volta a usar a do tenant ou a da configuração
+// @Tags sessions
+// @Produce json
+// @Param sessionID path string true "ID ou nome da sessão"
+// @Success 200 {object} session.MediaRetentionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sessions/{sessionID}/media-retention [delete]
+func (h *SessionHandler) ResetMediaRetention(c *gin.Context) {
+	identifier := c.Param("sessionID")
+	sessionID, err := h.resolveSessionIdentifier(c, identifier)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Sessão não encontrada",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.retentionUseCase.Set(c.Request.Context(), sessionID, nil)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // handleError trata erros de forma centralizada
 func (h *SessionHandler) handleError(c *gin.Context, err error) {
+	// Limites e status do tenant dono da sessão
+	if writeTenantError(c, err) {
+		return
+	}
+
+	// Limites de envio da sessão
+	if writeSendLimitError(c, err) {
+		return
+	}
+
+	// Política de retenção das mídias
+	var retentionErr *media.RetentionValidationError
+	if errors.As(err, &retentionErr) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_MEDIA_RETENTION",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, sessionEntity.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "SESSION_NOT_FOUND",
+			Message: "Sessão não encontrada",
+		})
+		return
+	}
+
 	// Aqui você pode adicionar a lógica de tratamento de erros específicos
 	// baseado nos erros do domain
 	h.logger.Error().Err(err).Msg("Erro interno do servidor")
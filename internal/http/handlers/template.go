@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	auditEntity "zapcore/internal/domain/audit"
+	templateEntity "zapcore/internal/domain/template"
+	tenantEntity "zapcore/internal/domain/tenant"
+	"zapcore/internal/usecases/template"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TemplateHandler gerencia as requisições HTTP para templates de mensagem
+type TemplateHandler struct {
+	createUseCase *template.CreateUseCase
+	getUseCase    *template.GetUseCase
+	listUseCase   *template.ListUseCase
+	updateUseCase *template.UpdateUseCase
+	deleteUseCase *template.DeleteUseCase
+	renderUseCase *template.RenderUseCase
+	logger        *logger.Logger
+}
+
+// NewTemplateHandler cria uma nova instância do handler
+func NewTemplateHandler(
+	createUseCase *template.CreateUseCase,
+	getUseCase *template.GetUseCase,
+	listUseCase *template.ListUseCase,
+	updateUseCase *template.UpdateUseCase,
+	deleteUseCase *template.DeleteUseCase,
+	renderUseCase *template.RenderUseCase,
+) *TemplateHandler {
+	return &TemplateHandler{
+		createUseCase: createUseCase,
+		getUseCase:    getUseCase,
+		listUseCase:   listUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		renderUseCase: renderUseCase,
+		logger:        logger.Get(),
+	}
+}
+
+// Create cria um novo template
+// @Summary Criar template
+// @Description Cria um template de mensagem com variáveis {{nome}}, mídia opcional e variantes por idioma
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param request body template.CreateRequest true "Dados do template"
+// @Success 201 {object} template.CreateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /templates [post]
+func (h *TemplateHandler) Create(c *gin.Context) {
+	var req template.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// Chaves vinculadas a um tenant sempre criam templates no próprio tenant
+	if tenantID, ok := tenantEntity.IDFromContext(c.Request.Context()); ok {
+		req.TenantID = tenantID
+	}
+
+	if req.TenantID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Campo 'tenantId' obrigatório",
+			Message: "O campo 'tenantId' deve ser fornecido",
+		})
+		return
+	}
+
+	response, err := h.createUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	auditEntity.SetResource(c.Request.Context(), response.Template.ID.String())
+	c.JSON(http.StatusCreated, response)
+}
+
+// List lista os templates
+// @Summary Listar templates
+// @Description Lista os templates de mensagem, opcionalmente filtrando por tenant e idioma
+// @Tags templates
+// @Produce json
+// @Param tenantId query string false "Filtrar por tenant"
+// @Param language query string false "Filtrar por idioma padrão"
+// @Param limit query int false "Limite de resultados"
+// @Param offset query int false "Offset para paginação"
+// @Success 200 {object} template.ListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /templates [get]
+func (h *TemplateHandler) List(c *gin.Context) {
+	var req template.ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Parâmetros inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.listUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Get obtém um template
+// @Summary Obter template
+// @Description Retorna um template e as variáveis que ele exige
+// @Tags templates
+// @Produce json
+// @Param templateID path string true "ID do template"
+// @Success 200 {object} template.GetResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{templateID} [get]
+func (h *TemplateHandler) Get(c *gin.Context) {
+	templateID, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.getUseCase.Execute(c.Request.Context(), templateID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Update atualiza um template
+// @Summary Atualizar template
+// @Description Atualiza os campos informados de um template
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param templateID path string true "ID do template"
+// @Param request body template.UpdateRequest true "Campos a atualizar"
+// @Success 200 {object} template.UpdateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{templateID} [put]
+func (h *TemplateHandler) Update(c *gin.Context) {
+	templateID, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req template.UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.TemplateID = templateID
+
+	response, err := h.updateUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete remove um template
+// @Summary Remover template
+// @Description Remove um template de mensagem
+// @Tags templates
+// @Produce json
+// @Param templateID path string true "ID do template"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{templateID} [delete]
+func (h *TemplateHandler) Delete(c *gin.Context) {
+	templateID, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteUseCase.Execute(c.Request.Context(), templateID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Success: true,
+		Message: "Template removido com sucesso",
+	})
+}
+
+// Render renderiza um template sem enviá-lo
+// @Summary Pré-visualizar template
+// @Description Renderiza o template com as variáveis informadas, validando as obrigatórias
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param templateID path string true "ID do template"
+// @Param request body template.RenderRequest true "Variáveis e idioma"
+// @Success 200 {object} template.RenderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{templateID}/render [post]
+func (h *TemplateHandler) Render(c *gin.Context) {
+	templateID, ok := h.parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req template.RenderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.TemplateID = templateID
+
+	response, err := h.renderUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseTemplateID extrai e valida o ID do template do path
+func (h *TemplateHandler) parseTemplateID(c *gin.Context) (uuid.UUID, bool) {
+	templateID, err := uuid.Parse(c.Param("templateID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "ID do template inválido",
+			Message: "O ID do template deve ser um UUID válido",
+		})
+		return uuid.Nil, false
+	}
+	return templateID, true
+}
+
+// handleError trata erros de forma centralizada
+func (h *TemplateHandler) handleError(c *gin.Context, err error) {
+	if writeTemplateError(c, err) {
+		return
+	}
+
+	h.logger.Error().Err(err).Msg("Erro interno do servidor")
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Erro interno do servidor",
+		Message: "Ocorreu um erro inesperado",
+	})
+}
+
+// writeTemplateError escreve a resposta para erros do domínio de templates.
+// Retorna false quando o erro não pertence ao domínio.
+func writeTemplateError(c *gin.Context, err error) bool {
+	var missingErr *templateEntity.MissingVariablesError
+	switch {
+	case errors.As(err, &missingErr):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "TEMPLATE_MISSING_VARIABLES",
+			"message": missingErr.Error(),
+			"missing": missingErr.Missing,
+		})
+	case errors.Is(err, templateEntity.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "TEMPLATE_NOT_FOUND",
+			Message: "Template não encontrado",
+		})
+	case errors.Is(err, templateEntity.ErrTemplateAlreadyExists):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "TEMPLATE_ALREADY_EXISTS",
+			Message: "Já existe um template com este nome para o tenant",
+		})
+	case errors.Is(err, templateEntity.ErrInvalidTemplateName),
+		errors.Is(err, templateEntity.ErrEmptyTemplateBody),
+		errors.Is(err, templateEntity.ErrTemplateHasNoMedia),
+		errors.Is(err, templateEntity.ErrInvalidMediaPath):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_TEMPLATE",
+			Message: err.Error(),
+		})
+	case errors.Is(err, templateEntity.ErrMediaStorageDisabled):
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "MEDIA_STORAGE_DISABLED",
+			Message: err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	auditEntity "zapcore/internal/domain/audit"
+	tenantEntity "zapcore/internal/domain/tenant"
+	"zapcore/internal/usecases/tenant"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantHandler gerencia as requisições HTTP para tenants
+type TenantHandler struct {
+	createUseCase *tenant.CreateTenantUseCase
+	listUseCase   *tenant.ListTenantsUseCase
+	updateUseCase *tenant.UpdateTenantUseCase
+	deleteUseCase *tenant.DeleteTenantUseCase
+	quotaUseCase  *tenant.QuotaUseCase
+	logger        *logger.Logger
+}
+
+// NewTenantHandler cria uma nova instância do handler
+func NewTenantHandler(
+	createUseCase *tenant.CreateTenantUseCase,
+	listUseCase *tenant.ListTenantsUseCase,
+	updateUseCase *tenant.UpdateTenantUseCase,
+	deleteUseCase *tenant.DeleteTenantUseCase,
+	quotaUseCase *tenant.QuotaUseCase,
+) *TenantHandler {
+	return &TenantHandler{
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		quotaUseCase:  quotaUseCase,
+		logger:        logger.Get(),
+	}
+}
+
+// Create cria um novo tenant
+// @Summary Criar tenant
+// @Description Cria um tenant com limites de sessões, mensagens diárias e armazenamento (0 = ilimitado)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body tenant.CreateTenantRequest true "Dados do tenant"
+// @Success 201 {object} tenant.TenantResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/tenants [post]
+func (h *TenantHandler) Create(c *gin.Context) {
+	var req tenant.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := h.createUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	auditEntity.SetResource(c.Request.Context(), response.Tenant.ID)
+	c.JSON(http.StatusCreated, response)
+}
+
+// List lista os tenants
+// @Summary Listar tenants
+// @Tags admin
+// @Produce json
+// @Success 200 {object} tenant.ListTenantsResponse
+// @Router /admin/tenants [get]
+func (h *TenantHandler) List(c *gin.Context) {
+	response, err := h.listUseCase.Execute(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Get retorna um tenant
+// @Summary Consultar tenant
+// @Tags admin
+// @Produce json
+// @Param tenantID path string true "ID do tenant"
+// @Success 200 {object} tenantEntity.Tenant
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tenants/{tenantID} [get]
+func (h *TenantHandler) Get(c *gin.Context) {
+	t, err := h.listUseCase.Get(c.Request.Context(), c.Param("tenantID"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// Update atualiza nome, limites ou status de um tenant
+// @Summary Atualizar tenant
+// @Description Atualiza apenas os campos informados. Desativar o tenant suspende todas as suas chaves de API
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param tenantID path string true "ID do tenant"
+// @Param request body tenant.UpdateTenantRequest true "Campos a atualizar"
+// @Success 200 {object} tenant.TenantResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tenants/{tenantID} [put]
+func (h *TenantHandler) Update(c *gin.Context) {
+	var req tenant.UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Dados inválidos",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.TenantID = c.Param("tenantID")
+
+	response, err := h.updateUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete remove um tenant sem sessões nem chaves de API
+// @Summary Remover tenant
+// @Tags admin
+// @Param tenantID path string true "ID do tenant"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/tenants/{tenantID} [delete]
+func (h *TenantHandler) Delete(c *gin.Context) {
+	if err := h.deleteUseCase.Execute(c.Request.Context(), c.Param("tenantID")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Usage retorna o consumo do tenant frente aos seus limites
+// @Summary Consumo do tenant
+// @Tags admin
+// @Produce json
+// @Param tenantID path string true "ID do tenant"
+// @Success 200 {object} tenantEntity.Usage
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/tenants/{tenantID}/usage [get]
+func (h *TenantHandler) Usage(c *gin.Context) {
+	usage, err := h.quotaUseCase.Usage(c.Request.Context(), c.Param("tenantID"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// handleError trata erros de forma centralizada
+func (h *TenantHandler) handleError(c *gin.Context, err error) {
+	if writeTenantError(c, err) {
+		return
+	}
+
+	h.logger.Error().Err(err).Msg("Erro interno do servidor")
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Erro interno do servidor",
+		Message: "Ocorreu um erro inesperado",
+	})
+}
+
+// writeTenantError escreve a resposta dos erros de tenant e de cota; retorna false para outros erros
+func writeTenantError(c *gin.Context, err error) bool {
+	var validationErr *tenantEntity.TenantValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_TENANT",
+			Message: err.Error(),
+		})
+	case errors.Is(err, tenantEntity.ErrTenantNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "TENANT_NOT_FOUND",
+			Message: "Tenant não encontrado",
+		})
+	case errors.Is(err, tenantEntity.ErrTenantAlreadyExists):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "TENANT_ALREADY_EXISTS",
+			Message: "Já existe um tenant com este ID",
+		})
+	case errors.Is(err, tenantEntity.ErrTenantNotEmpty):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "TENANT_NOT_EMPTY",
+			Message: "Remova as sessões e chaves de API do tenant antes de excluí-lo",
+		})
+	case errors.Is(err, tenantEntity.ErrDefaultTenant):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "DEFAULT_TENANT",
+			Message: "O tenant padrão não pode ser removido",
+		})
+	case errors.Is(err, tenantEntity.ErrTenantInactive):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "TENANT_INACTIVE",
+			Message: "Tenant desativado",
+		})
+	case errors.Is(err, tenantEntity.ErrSessionLimitReached):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "TENANT_SESSION_LIMIT_REACHED",
+			Message: "Limite de sessões do tenant atingido",
+		})
+	case errors.Is(err, tenantEntity.ErrMessageQuotaExceeded):
+		c.JSON(http.StatusTooManyRequests, ErrorResponse{
+			Error:   "TENANT_MESSAGE_QUOTA_EXCEEDED",
+			Message: "Cota diária de mensagens do tenant esgotada",
+		})
+	case errors.Is(err, tenantEntity.ErrStorageQuotaExceeded):
+		c.JSON(http.StatusInsufficientStorage, ErrorResponse{
+			Error:   "TENANT_STORAGE_QUOTA_EXCEEDED",
+			Message: "Cota de armazenamento do tenant esgotada",
+		})
+	default:
+		return false
+	}
+	return true
+}
@@ -31,6 +31,23 @@ type HealthResponse struct {
 	Uptime    string `json:"uptime"`
 }
 
+// ReadinessResponse representa a resposta do readiness check com o status de cada componente
+type ReadinessResponse struct {
+	Status     string                     `json:"status"`
+	Timestamp  string                     `json:"timestamp"`
+	Version    string                     `json:"version"`
+	Uptime     string                     `json:"uptime"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+// ComponentStatus representa o resultado da verificação de um componente
+type ComponentStatus struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
 // SendImageHTTPRequest representa uma requisição HTTP para envio de imagem
 type SendImageHTTPRequest struct {
 	ToJID     string `json:"to_jid" form:"to_jid" binding:"required"`
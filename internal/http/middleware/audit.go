@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/domain/audit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxAuditBodyBytes limita o corpo lido para os detalhes da auditoria. O handler lê o corpo
+// inteiro de qualquer forma; valores grandes (mídia em base64) são truncados na redação.
+const maxAuditBodyBytes = 32 << 20
+
+// AuditRecorder grava os registros da trilha de auditoria
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *audit.Entry)
+}
+
+// Audit registra a ação na trilha de auditoria com o autor (API Key), o IP, o request ID,
+// o recurso afetado, o status da resposta e o corpo da requisição sem dados sensíveis.
+// Deve ficar após a autenticação e as verificações de escopo e de acesso à sessão.
+func Audit(recorder AuditRecorder, resolver SessionResolver, action audit.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if recorder == nil {
+			c.Next()
+			return
+		}
+
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthenticated(c)
+			return
+		}
+
+		entry := newAuditEntry(c, principal, action)
+		entry.Details = readAuditBody(c)
+
+		// Resolver a sessão antes da execução: após uma remoção, o nome não seria mais encontrado
+		if identifier := c.Param("sessionID"); identifier != "" {
+			entry.SessionID = resolvePathSession(c.Request.Context(), resolver, identifier)
+			if entry.SessionID != nil && action.Resource() == "session" {
+				entry.ResourceID = entry.SessionID.String()
+			}
+		}
+
+		c.Request = c.Request.WithContext(audit.WithEntry(c.Request.Context(), entry))
+		c.Next()
+
+		entry.SetOutcome(c.Writer.Status())
+		if entry.SessionID == nil && action.Resource() == "session" {
+			if sessionID, err := uuid.Parse(entry.ResourceID); err == nil {
+				entry.SessionID = &sessionID
+			}
+		}
+
+		recorder.Record(c.Request.Context(), entry)
+	}
+}
+
+// newAuditEntry cria o registro com os dados do autor e da requisição
+func newAuditEntry(c *gin.Context, principal *apikey.Principal, action audit.Action) *audit.Entry {
+	actorType := audit.ActorKey
+	if principal.Master {
+		actorType = audit.ActorMaster
+	}
+
+	entry := audit.NewEntry(action, actorType, principal.Name)
+	entry.TenantID = principal.TenantID
+	if !principal.Master {
+		keyID := principal.KeyID
+		entry.KeyID = &keyID
+	}
+	entry.IP = c.ClientIP()
+	entry.UserAgent = truncate(c.Request.UserAgent(), 255)
+	entry.RequestID = c.GetString("request_id")
+	entry.Method = c.Request.Method
+	entry.Path = c.Request.URL.Path
+
+	// O recurso padrão é o último parâmetro do path (ex.: :keyID, :sinkID, :sessionID);
+	// criações informam o ID gerado com audit.SetResource
+	if len(c.Params) > 0 {
+		entry.ResourceID = c.Params[len(c.Params)-1].Value
+	}
+
+	return entry
+}
+
+// readAuditBody lê o corpo JSON da requisição, devolvendo-o intacto para o handler
+func readAuditBody(c *gin.Context) map[string]any {
+	if c.Request.Body == nil || !strings.Contains(c.ContentType(), "json") {
+		return nil
+	}
+
+	head, err := io.ReadAll(io.LimitReader(c.Request.Body, maxAuditBodyBytes+1))
+	c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(head), c.Request.Body), c.Request.Body}
+	if err != nil || len(head) == 0 {
+		return nil
+	}
+
+	if len(head) > maxAuditBodyBytes {
+		return map[string]any{"request": audit.Truncated}
+	}
+
+	if fields := audit.RedactJSON(head); fields != nil {
+		return map[string]any{"request": fields}
+	}
+	return nil
+}
+
+// resolvePathSession converte o identificador de sessão do path no ID da sessão, quando possível
+func resolvePathSession(ctx context.Context, resolver SessionResolver, identifier string) *uuid.UUID {
+	sessionID, err := uuid.Parse(identifier)
+	if err != nil {
+		if resolver == nil {
+			return nil
+		}
+		if sessionID, err = resolver(ctx, identifier); err != nil {
+			return nil
+		}
+	}
+	return &sessionID
+}
+
+// readCloser combina o leitor do corpo já consumido com o Close do corpo original
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// truncate limita o texto ao tamanho da coluna
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
@@ -1,20 +1,33 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/domain/tenant"
 	"zapcore/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
+// PrincipalKey é a chave do principal autenticado no contexto do Gin
+const PrincipalKey = "principal"
+
+// KeyAuthenticator valida chaves de API emitidas pelo /admin/keys
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (*apikey.Principal, error)
+}
+
 // AuthConfig representa a configuração do middleware de autenticação
 type AuthConfig struct {
-	APIKey     string
-	HeaderName string
-	QueryParam string
-	SkipPaths  []string
-	Logger     *logger.Logger
+	APIKey        string // Chave mestre, com acesso total
+	Authenticator KeyAuthenticator
+	HeaderName    string
+	QueryParam    string
+	SkipPaths     []string
+	Logger        *logger.Logger
 }
 
 // DefaultAuthConfig retorna a configuração padrão de autenticação
@@ -58,18 +71,19 @@ func APIKeyAuth(config AuthConfig) gin.HandlerFunc {
 			return
 		}
 
-		// Validar API Key
-		if !isValidAPIKey(apiKey, config.APIKey) {
+		// Validar API Key: chave mestre ou chave emitida
+		principal, status, message := authenticate(c.Request.Context(), apiKey, config)
+		if principal == nil {
 			config.Logger.Warn().
 				Str("path", path).
 				Str("method", c.Request.Method).
 				Str("ip", c.ClientIP()).
 				Str("api_key", maskAPIKey(apiKey)).
-				Msg("API Key inválida")
+				Msg(message)
 
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "API Key inválida",
+			c.JSON(status, gin.H{
+				"error":   http.StatusText(status),
+				"message": message,
 			})
 			c.Abort()
 			return
@@ -80,12 +94,49 @@ func APIKeyAuth(config AuthConfig) gin.HandlerFunc {
 			Str("path", path).
 			Str("method", c.Request.Method).
 			Str("ip", c.ClientIP()).
+			Str("key_name", principal.Name).
 			Msg("Autenticação bem-sucedida")
 
+		// Chaves vinculadas a um tenant só enxergam os dados dele nos repositórios
+		ctx := apikey.WithPrincipal(c.Request.Context(), principal)
+		if principal.TenantID != "" {
+			ctx = tenant.WithID(ctx, principal.TenantID)
+		}
+
+		c.Set(PrincipalKey, principal)
+		c.Request = c.Request.WithContext(ctx)
+
 		c.Next()
 	}
 }
 
+// authenticate resolve o principal da chave; sem principal, retorna o status e a mensagem de erro
+func authenticate(ctx context.Context, key string, config AuthConfig) (*apikey.Principal, int, string) {
+	if isValidAPIKey(key, config.APIKey) {
+		return apikey.MasterPrincipal(), http.StatusOK, ""
+	}
+
+	if config.Authenticator == nil {
+		return nil, http.StatusUnauthorized, "API Key inválida"
+	}
+
+	principal, err := config.Authenticator.Authenticate(ctx, key)
+	switch {
+	case err == nil:
+		return principal, http.StatusOK, ""
+	case errors.Is(err, apikey.ErrKeyNotFound):
+		return nil, http.StatusUnauthorized, "API Key inválida"
+	case errors.Is(err, apikey.ErrKeyRevoked):
+		return nil, http.StatusUnauthorized, "API Key revogada"
+	case errors.Is(err, apikey.ErrKeyExpired):
+		return nil, http.StatusUnauthorized, "API Key expirada"
+	case errors.Is(err, tenant.ErrTenantInactive):
+		return nil, http.StatusForbidden, "Tenant da API Key desativado"
+	default:
+		return nil, http.StatusServiceUnavailable, "Não foi possível validar a API Key"
+	}
+}
+
 // extractAPIKey extrai a API Key da requisição
 func extractAPIKey(c *gin.Context, config AuthConfig) string {
 	// Tentar extrair do header Authorization
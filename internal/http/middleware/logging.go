@@ -1,10 +1,17 @@
 package middleware
 
 import (
+	"net/http"
 	"time"
 	"zapcore/pkg/logger"
+	"zapcore/pkg/tracing"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // LoggingConfig representa a configuração do middleware de logging
@@ -18,7 +25,7 @@ type LoggingConfig struct {
 func DefaultLoggingConfig() LoggingConfig {
 	return LoggingConfig{
 		Logger:     logger.Get(),
-		SkipPaths:  []string{"/health", "/ready", "/live"},
+		SkipPaths:  []string{"/health", "/ready", "/live", "/metrics"},
 		TimeFormat: time.RFC3339,
 	}
 }
@@ -48,7 +55,7 @@ func Logging(config LoggingConfig) gin.HandlerFunc {
 		status := c.Writer.Status()
 
 		// Preparar log event usando o logger centralizado
-		logEvent := config.Logger.WithFields(map[string]interface{}{
+		logEvent := config.Logger.WithTrace(c.Request.Context()).WithFields(map[string]interface{}{
 			"method":        c.Request.Method,
 			"path":          path,
 			"status":        status,
@@ -81,7 +88,8 @@ func Logging(config LoggingConfig) gin.HandlerFunc {
 	}
 }
 
-// RequestID middleware para adicionar ID único a cada requisição
+// RequestID middleware para adicionar ID único a cada requisição.
+// Também continua o trace W3C recebido em traceparent (ou inicia um novo) e devolve o traceparent na resposta.
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
@@ -89,9 +97,39 @@ func RequestID() gin.HandlerFunc {
 			requestID = generateRequestID()
 		}
 
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracing.Tracer().Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", c.ClientIP()),
+				attribute.String("http.request_id", requestID),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+
 		c.Header("X-Request-ID", requestID)
 		c.Set("request_id", requestID)
 		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
 	}
 }
 
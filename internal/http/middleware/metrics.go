@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+	"zapcore/internal/infra/metrics"
+	"zapcore/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics middleware que registra latência e status das requisições HTTP
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// Usar o padrão da rota evita uma série por ID de sessão ou mensagem
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// MetricsAuth protege o endpoint de métricas com um token próprio, separado da API Key
+func MetricsAuth(token string) gin.HandlerFunc {
+	config := AuthConfig{
+		HeaderName: "Authorization",
+		QueryParam: "token",
+		Logger:     logger.Get(),
+	}
+
+	return func(c *gin.Context) {
+		providedToken := extractAPIKey(c, config)
+		if providedToken == "" || !isValidAPIKey(providedToken, token) {
+			config.Logger.Warn().
+				Str("path", c.Request.URL.Path).
+				Str("ip", c.ClientIP()).
+				Msg("Token de métricas inválido ou não fornecido")
+
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "Token de métricas inválido ou não fornecido",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,124 +1,45 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
-	"sync"
+	"strconv"
 	"time"
+
+	"zapcore/internal/domain/ratelimit"
+	"zapcore/internal/infra/metrics"
 	"zapcore/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
+// rateLimitRemainingKey guarda no contexto do Gin o menor saldo entre as políticas já
+// aplicadas, para que os headers reflitam a mais restritiva
+const rateLimitRemainingKey = "ratelimit_remaining"
+
 // RateLimitConfig representa a configuração do rate limiting
 type RateLimitConfig struct {
-	Requests  int                       // Número de requests permitidos
-	Window    time.Duration             // Janela de tempo
-	KeyFunc   func(*gin.Context) string // Função para extrair a chave (IP, API Key, etc)
-	SkipPaths []string                  // Paths que devem ser ignorados
+	Limiter   ratelimit.Limiter
+	Policy    ratelimit.Policy                                      // Política padrão
+	KeyFunc   func(*gin.Context) (string, bool)                     // Chave do bucket; false dispensa o limite
+	PolicyFor func(*gin.Context, ratelimit.Policy) ratelimit.Policy // Política da requisição (opcional)
+	SkipPaths []string                                              // Paths que devem ser ignorados
 	Logger    *logger.Logger
 }
 
-// DefaultRateLimitConfig retorna a configuração padrão do rate limiting
-func DefaultRateLimitConfig(requests int, window time.Duration) RateLimitConfig {
+// DefaultRateLimitConfig retorna a configuração padrão do rate limiting, por IP do cliente
+func DefaultRateLimitConfig(limiter ratelimit.Limiter, policy ratelimit.Policy) RateLimitConfig {
 	return RateLimitConfig{
-		Requests:  requests,
-		Window:    window,
-		KeyFunc:   func(c *gin.Context) string { return c.ClientIP() },
-		SkipPaths: []string{"/health", "/ready", "/live"},
+		Limiter:   limiter,
+		Policy:    policy,
+		KeyFunc:   func(c *gin.Context) (string, bool) { return c.ClientIP(), true },
+		SkipPaths: []string{"/health", "/ready", "/live", "/metrics"},
 		Logger:    logger.Get(),
 	}
 }
 
-// rateLimitEntry representa uma entrada no rate limiter
-type rateLimitEntry struct {
-	count     int
-	resetTime time.Time
-	mutex     sync.Mutex
-}
-
-// rateLimiter implementa um rate limiter em memória
-type rateLimiter struct {
-	entries map[string]*rateLimitEntry
-	mutex   sync.RWMutex
-	config  RateLimitConfig
-}
-
-// newRateLimiter cria um novo rate limiter
-func newRateLimiter(config RateLimitConfig) *rateLimiter {
-	rl := &rateLimiter{
-		entries: make(map[string]*rateLimitEntry),
-		config:  config,
-	}
-
-	// Iniciar limpeza periódica
-	go rl.cleanup()
-
-	return rl
-}
-
-// isAllowed verifica se a requisição é permitida
-func (rl *rateLimiter) isAllowed(key string) bool {
-	now := time.Now()
-
-	rl.mutex.RLock()
-	entry, exists := rl.entries[key]
-	rl.mutex.RUnlock()
-
-	if !exists {
-		// Primeira requisição para esta chave
-		rl.mutex.Lock()
-		rl.entries[key] = &rateLimitEntry{
-			count:     1,
-			resetTime: now.Add(rl.config.Window),
-		}
-		rl.mutex.Unlock()
-		return true
-	}
-
-	entry.mutex.Lock()
-	defer entry.mutex.Unlock()
-
-	// Verificar se a janela expirou
-	if now.After(entry.resetTime) {
-		entry.count = 1
-		entry.resetTime = now.Add(rl.config.Window)
-		return true
-	}
-
-	// Verificar se ainda há requests disponíveis
-	if entry.count >= rl.config.Requests {
-		return false
-	}
-
-	entry.count++
-	return true
-}
-
-// cleanup remove entradas expiradas periodicamente
-func (rl *rateLimiter) cleanup() {
-	ticker := time.NewTicker(rl.config.Window)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		now := time.Now()
-		rl.mutex.Lock()
-
-		for key, entry := range rl.entries {
-			entry.mutex.Lock()
-			if now.After(entry.resetTime) {
-				delete(rl.entries, key)
-			}
-			entry.mutex.Unlock()
-		}
-
-		rl.mutex.Unlock()
-	}
-}
-
-// RateLimit middleware para rate limiting
+// RateLimit middleware para rate limiting com token bucket
 func RateLimit(config RateLimitConfig) gin.HandlerFunc {
-	limiter := newRateLimiter(config)
-
 	return func(c *gin.Context) {
 		// Verificar se deve pular o rate limiting para este path
 		path := c.Request.URL.Path
@@ -129,25 +50,48 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 			}
 		}
 
+		policy := config.Policy
+		if config.PolicyFor != nil {
+			policy = config.PolicyFor(c, policy)
+		}
+		if config.Limiter == nil || !policy.Enabled() {
+			c.Next()
+			return
+		}
+
 		// Extrair chave para rate limiting
-		key := config.KeyFunc(c)
+		key, ok := config.KeyFunc(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		decision, err := config.Limiter.Allow(c.Request.Context(), policy.Name+":"+key, policy)
+		if err != nil {
+			// Falha do backend não deve derrubar a API: a requisição segue sem limite
+			config.Logger.Warn().Err(err).Str("policy", policy.Name).Msg("Erro ao consultar rate limiting")
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, policy, decision)
 
 		// Verificar se a requisição é permitida
-		if !limiter.isAllowed(key) {
+		if !decision.Allowed {
 			config.Logger.Warn().
+				Str("policy", policy.Name).
 				Str("key", key).
 				Str("path", path).
 				Str("method", c.Request.Method).
-				Int("requests", config.Requests).
-				Str("window", config.Window.String()).
+				Str("limit", policy.String()).
 				Msg("Rate limit excedido")
 
-			c.Header("X-RateLimit-Limit", string(rune(config.Requests)))
-			c.Header("X-RateLimit-Window", config.Window.String())
-
+			metrics.IncRateLimited(policy.Name)
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(decision.RetryAfter)))
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error":   "Rate limit exceeded",
 				"message": "Muitas requisições. Tente novamente mais tarde.",
+				"policy":  policy.Name,
 			})
 			c.Abort()
 			return
@@ -157,31 +101,83 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 	}
 }
 
-// APIKeyRateLimit cria um rate limiter baseado em API Key
-func APIKeyRateLimit(requests int, window time.Duration) gin.HandlerFunc {
-	config := RateLimitConfig{
-		Requests: requests,
-		Window:   window,
-		KeyFunc: func(c *gin.Context) string {
-			// Tentar extrair API Key
-			apiKey := c.GetHeader("X-API-Key")
-			if apiKey == "" {
-				apiKey = c.GetHeader("Authorization")
-			}
-			if apiKey == "" {
-				apiKey = c.Query("api_key")
-			}
+// APIKeyRateLimit limita as requisições de cada chave de API; chaves com limite próprio
+// substituem a política padrão
+func APIKeyRateLimit(limiter ratelimit.Limiter, policy ratelimit.Policy) gin.HandlerFunc {
+	config := principalRateLimitConfig(limiter, policy)
+	config.PolicyFor = func(c *gin.Context, policy ratelimit.Policy) ratelimit.Policy {
+		if principal, ok := GetPrincipal(c); ok && principal.RateLimit != nil {
+			return principal.RateLimit.Policy()
+		}
+		return policy
+	}
+	return RateLimit(config)
+}
 
-			// Se não tiver API Key, usar IP
-			if apiKey == "" {
-				return c.ClientIP()
-			}
+// RouteRateLimit limita as requisições de cada chave de API a um grupo de rotas (ex.: envios)
+func RouteRateLimit(limiter ratelimit.Limiter, policy ratelimit.Policy) gin.HandlerFunc {
+	return RateLimit(principalRateLimitConfig(limiter, policy))
+}
 
-			return "api:" + apiKey
-		},
-		SkipPaths: []string{"/health", "/ready", "/live"},
-		Logger:    logger.Get(),
+// ReadRateLimit limita as leituras (GET e HEAD) de cada chave de API
+func ReadRateLimit(limiter ratelimit.Limiter, policy ratelimit.Policy) gin.HandlerFunc {
+	config := principalRateLimitConfig(limiter, policy)
+	byPrincipal := config.KeyFunc
+	config.KeyFunc = func(c *gin.Context) (string, bool) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			return "", false
+		}
+		return byPrincipal(c)
 	}
+	return RateLimit(config)
+}
 
+// SessionRateLimit limita as requisições por sessão do path, somando todas as chaves que a operam
+func SessionRateLimit(limiter ratelimit.Limiter, policy ratelimit.Policy, resolver SessionResolver) gin.HandlerFunc {
+	config := principalRateLimitConfig(limiter, policy)
+	config.KeyFunc = func(c *gin.Context) (string, bool) {
+		sessionID := resolvePathSession(c.Request.Context(), resolver, c.Param("sessionID"))
+		if sessionID == nil {
+			return "", false
+		}
+		return sessionID.String(), true
+	}
 	return RateLimit(config)
 }
+
+// principalRateLimitConfig cria a configuração com buckets por chave de API autenticada
+func principalRateLimitConfig(limiter ratelimit.Limiter, policy ratelimit.Policy) RateLimitConfig {
+	return RateLimitConfig{
+		Limiter: limiter,
+		Policy:  policy,
+		KeyFunc: func(c *gin.Context) (string, bool) {
+			principal, ok := GetPrincipal(c)
+			if !ok {
+				return "", false
+			}
+			if principal.Master {
+				return "master", true
+			}
+			return principal.KeyID.String(), true
+		},
+		Logger: logger.Get(),
+	}
+}
+
+// setRateLimitHeaders escreve os headers RateLimit-* quando a política é a mais restritiva até aqui
+func setRateLimitHeaders(c *gin.Context, policy ratelimit.Policy, decision *ratelimit.Decision) {
+	if remaining, exists := c.Get(rateLimitRemainingKey); exists && decision.Allowed && remaining.(int) <= decision.Remaining {
+		return
+	}
+	c.Set(rateLimitRemainingKey, decision.Remaining)
+
+	c.Header("RateLimit-Limit", strconv.Itoa(decision.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+	c.Header("RateLimit-Policy", policy.String())
+}
+
+// ceilSeconds arredonda a duração para cima em segundos inteiros
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
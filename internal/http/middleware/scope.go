@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"zapcore/internal/domain/apikey"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SessionResolver converte o ID ou o nome de uma sessão em seu ID, considerando apenas
+// as sessões visíveis no contexto (tenant da chave autenticada)
+type SessionResolver func(ctx context.Context, identifier string) (uuid.UUID, error)
+
+// GetPrincipal retorna o principal autenticado na requisição
+func GetPrincipal(c *gin.Context) (*apikey.Principal, bool) {
+	return apikey.PrincipalFromContext(c.Request.Context())
+}
+
+// RequireScope exige que a chave autenticada tenha o escopo informado
+func RequireScope(scope apikey.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthenticated(c)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			abortForbidden(c, "API Key sem o escopo "+string(scope))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSessionAccess exige que a sessão do parâmetro :sessionID pertença ao tenant da chave
+// e esteja entre as sessões permitidas para ela. Sessões inexistentes e não permitidas recebem
+// a mesma resposta para não revelar quais existem.
+func RequireSessionAccess(resolver SessionResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthenticated(c)
+			return
+		}
+
+		if !principal.IsRestricted() && principal.TenantID == "" {
+			c.Next()
+			return
+		}
+
+		identifier := c.Param("sessionID")
+		var sessionID uuid.UUID
+		var err error
+		if resolver != nil {
+			sessionID, err = resolver(c.Request.Context(), identifier)
+		} else {
+			sessionID, err = uuid.Parse(identifier)
+		}
+
+		if err != nil || !principal.CanAccessSession(sessionID) {
+			abortForbidden(c, "API Key sem acesso a esta sessão")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAllSessions exige uma chave sem restrição de sessões
+func RequireAllSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthenticated(c)
+			return
+		}
+
+		if principal.IsRestricted() {
+			abortForbidden(c, "Operação disponível apenas para API Keys sem restrição de sessões")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireMaster exige a chave mestre da configuração, a única que enxerga todos os tenants
+func RequireMaster() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthenticated(c)
+			return
+		}
+
+		if !principal.Master {
+			abortForbidden(c, "Operação disponível apenas para a chave mestre")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// abortUnauthenticated encerra requisições que chegaram sem passar pela autenticação
+func abortUnauthenticated(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, gin.H{
+		"error":   "Unauthorized",
+		"message": "API Key é obrigatória",
+	})
+	c.Abort()
+}
+
+// abortForbidden encerra a requisição por falta de permissão
+func abortForbidden(c *gin.Context, message string) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":   "Forbidden",
+		"message": message,
+	})
+	c.Abort()
+}
@@ -1,10 +1,15 @@
 package router
 
 import (
-	"time"
+	"net/http"
 
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/domain/audit"
+	"zapcore/internal/domain/ratelimit"
 	"zapcore/internal/http/handlers"
 	"zapcore/internal/http/middleware"
+	"zapcore/internal/infra/metrics"
+	"zapcore/internal/infra/storage"
 	"zapcore/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -12,20 +17,51 @@ import (
 
 // Config representa a configuração do router
 type Config struct {
-	APIKey          string
-	RateLimitReqs   int
-	RateLimitWindow string
-	CORSOrigins     []string
-	CORSMethods     []string
-	CORSHeaders     []string
+	APIKey       string
+	CORSOrigins  []string
+	CORSMethods  []string
+	CORSHeaders  []string
+	MetricsToken string // vazio desativa o endpoint /metrics
+
+	// Downloads assinados do armazenamento local de mídias; nil desativa o /files
+	FileServer http.Handler
+
+	// Rate limiting com token bucket; nil desativa todos os limites
+	RateLimiter ratelimit.Limiter
+	RateLimits  RateLimitPolicies
+
+	// Chaves de API emitidas pelo /admin/keys, além da chave mestre
+	KeyAuthenticator middleware.KeyAuthenticator
+	SessionResolver  middleware.SessionResolver
+
+	// Trilha de auditoria das ações administrativas e de envio; nil desativa o registro
+	AuditRecorder middleware.AuditRecorder
+}
+
+// RateLimitPolicies agrupa as políticas de rate limiting; políticas sem requisições ficam desativadas
+type RateLimitPolicies struct {
+	IP      ratelimit.Policy // Por IP do cliente, em todas as rotas
+	Key     ratelimit.Policy // Por chave de API, substituída pelo limite próprio da chave
+	Send    ratelimit.Policy // Por chave de API, nas rotas de envio de mensagens
+	Read    ratelimit.Policy // Por chave de API, nas leituras (GET)
+	Session ratelimit.Policy // Por sessão, nas rotas de envio de mensagens
 }
 
 // Router representa o router principal da aplicação
 type Router struct {
-	config         Config
-	sessionHandler *handlers.SessionHandler
-	messageHandler *handlers.MessageHandler
-	healthHandler  *handlers.HealthHandler
+	config               Config
+	sessionHandler       *handlers.SessionHandler
+	messageHandler       *handlers.MessageHandler
+	templateHandler      *handlers.TemplateHandler
+	autoReplyHandler     *handlers.AutoReplyHandler
+	businessHoursHandler *handlers.BusinessHoursHandler
+	eventStreamHandler   *handlers.EventStreamHandler
+	eventSinkHandler     *handlers.EventSinkHandler
+	healthHandler        *handlers.HealthHandler
+	apiKeyHandler        *handlers.APIKeyHandler
+	tenantHandler        *handlers.TenantHandler
+	auditHandler         *handlers.AuditHandler
+	mediaHandler         *handlers.MediaHandler
 }
 
 // NewRouter cria uma nova instância do router
@@ -33,13 +69,31 @@ func NewRouter(
 	config Config,
 	sessionHandler *handlers.SessionHandler,
 	messageHandler *handlers.MessageHandler,
+	templateHandler *handlers.TemplateHandler,
+	autoReplyHandler *handlers.AutoReplyHandler,
+	businessHoursHandler *handlers.BusinessHoursHandler,
+	eventStreamHandler *handlers.EventStreamHandler,
+	eventSinkHandler *handlers.EventSinkHandler,
 	healthHandler *handlers.HealthHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	tenantHandler *handlers.TenantHandler,
+	auditHandler *handlers.AuditHandler,
+	mediaHandler *handlers.MediaHandler,
 ) *Router {
 	return &Router{
-		config:         config,
-		sessionHandler: sessionHandler,
-		messageHandler: messageHandler,
-		healthHandler:  healthHandler,
+		config:               config,
+		sessionHandler:       sessionHandler,
+		messageHandler:       messageHandler,
+		templateHandler:      templateHandler,
+		autoReplyHandler:     autoReplyHandler,
+		businessHoursHandler: businessHoursHandler,
+		eventStreamHandler:   eventStreamHandler,
+		eventSinkHandler:     eventSinkHandler,
+		healthHandler:        healthHandler,
+		apiKeyHandler:        apiKeyHandler,
+		tenantHandler:        tenantHandler,
+		auditHandler:         auditHandler,
+		mediaHandler:         mediaHandler,
 	}
 }
 
@@ -71,6 +125,9 @@ func (r *Router) setupMiddlewares(engine *gin.Engine) {
 	// Request ID middleware
 	engine.Use(middleware.RequestID())
 
+	// Métricas HTTP
+	engine.Use(middleware.Metrics())
+
 	// Logging middleware
 	loggingConfig := middleware.DefaultLoggingConfig()
 	engine.Use(middleware.Logging(loggingConfig))
@@ -84,11 +141,8 @@ func (r *Router) setupMiddlewares(engine *gin.Engine) {
 	}
 	engine.Use(middleware.CORS(corsConfig))
 
-	// Rate limiting middleware
-	rateLimitConfig := middleware.DefaultRateLimitConfig(
-		r.config.RateLimitReqs,
-		parseDuration(r.config.RateLimitWindow),
-	)
+	// Rate limiting por IP, antes da autenticação
+	rateLimitConfig := middleware.DefaultRateLimitConfig(r.config.RateLimiter, r.config.RateLimits.IP)
 	engine.Use(middleware.RateLimit(rateLimitConfig))
 }
 
@@ -99,6 +153,18 @@ func (r *Router) setupPublicRoutes(engine *gin.Engine) {
 	engine.GET("/ready", r.healthHandler.Ready)
 	engine.GET("/live", r.healthHandler.Live)
 
+	// Métricas Prometheus, protegidas por token próprio
+	if r.config.MetricsToken != "" {
+		engine.GET("/metrics", middleware.MetricsAuth(r.config.MetricsToken), gin.WrapH(metrics.Handler()))
+	}
+
+	// Downloads do armazenamento local, autorizados pela assinatura do link
+	if r.config.FileServer != nil {
+		files := gin.WrapH(r.config.FileServer)
+		engine.GET(storage.LocalFilesPath+"/*key", files)
+		engine.HEAD(storage.LocalFilesPath+"/*key", files)
+	}
+
 	// Root route
 	engine.GET("/", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -113,13 +179,44 @@ func (r *Router) setupPublicRoutes(engine *gin.Engine) {
 func (r *Router) setupProtectedRoutes(engine *gin.Engine) {
 	// Middleware de autenticação para rotas protegidas
 	authConfig := middleware.DefaultAuthConfig(r.config.APIKey)
+	authConfig.Authenticator = r.config.KeyAuthenticator
 	protected := engine.Group("/", middleware.APIKeyAuth(authConfig))
 
+	// Rate limiting por chave de API e das leituras de cada chave
+	protected.Use(
+		middleware.APIKeyRateLimit(r.config.RateLimiter, r.config.RateLimits.Key),
+		middleware.ReadRateLimit(r.config.RateLimiter, r.config.RateLimits.Read),
+	)
+
+	// Saúde das sessões (expõe nomes e IDs, por isso fica atrás da autenticação)
+	protected.GET("/health/sessions", r.scope(apikey.ScopeSessionsRead), r.healthHandler.Sessions)
+
+	// Gerenciamento de chaves de API, tenants e trilha de auditoria
+	r.setupAdminRoutes(protected)
+
 	// Rotas de sessões
 	r.setupSessionRoutes(protected)
 
 	// Rotas de mensagens
 	r.setupMessageRoutes(protected)
+
+	// Download das mídias das mensagens
+	r.setupMediaRoutes(protected)
+
+	// Rotas de templates
+	r.setupTemplateRoutes(protected)
+
+	// Rotas de respostas automáticas
+	r.setupAutoReplyRoutes(protected)
+
+	// Rotas de horário comercial
+	r.setupBusinessHoursRoutes(protected)
+
+	// Rotas de eventos em tempo real
+	r.setupEventRoutes(protected)
+
+	// Rotas de sinks de eventos
+	r.setupEventSinkRoutes(protected)
 }
 
 // setupSessionRoutes configura as rotas de sessões
@@ -127,15 +224,24 @@ func (r *Router) setupSessionRoutes(group *gin.RouterGroup) {
 	sessions := group.Group("/sessions")
 	{
 		// Gerenciamento de sessões
-		sessions.POST("/add", r.sessionHandler.Create)
-		sessions.GET("/list", r.sessionHandler.List)
-		sessions.GET("/:sessionID", r.sessionHandler.GetStatus)
+		// Chaves restritas não criam sessões: a nova sessão ficaria fora da própria lista
+		sessions.POST("/add", r.scope(apikey.ScopeSessionsWrite), middleware.RequireAllSessions(), r.audit(audit.ActionSessionCreate), r.sessionHandler.Create)
+		sessions.GET("/list", r.scope(apikey.ScopeSessionsRead), r.sessionHandler.List)
+		sessions.GET("/:sessionID", r.scope(apikey.ScopeSessionsRead), r.sessionAccess(), r.sessionHandler.GetStatus)
 		// sessions.DELETE("/:sessionID", r.sessionHandler.Delete) // TODO: Implementar
 
 		// Controle de conexão (aceita UUID ou nome da sessão)
-		sessions.POST("/:sessionID/connect", r.sessionHandler.Connect)
-		sessions.POST("/:sessionID/logout", r.sessionHandler.Disconnect)
-		sessions.GET("/:sessionID/status", r.sessionHandler.GetStatus)
+		sessions.POST("/:sessionID/connect", r.scope(apikey.ScopeSessionsWrite), r.sessionAccess(), r.audit(audit.ActionSessionConnect), r.sessionHandler.Connect)
+		sessions.POST("/:sessionID/logout", r.scope(apikey.ScopeSessionsWrite), r.sessionAccess(), r.audit(audit.ActionSessionLogout), r.sessionHandler.Disconnect)
+		sessions.GET("/:sessionID/status", r.scope(apikey.ScopeSessionsRead), r.sessionAccess(), r.sessionHandler.GetStatus)
+
+		// Limites de envio da sessão (mensagens por minuto, novas conversas por dia etc.)
+		sessions.GET("/:sessionID/send-limits", r.scope(apikey.ScopeSessionsRead), r.sessionAccess(), r.sessionHandler.GetSendLimits)
+		sessions.PUT("/:sessionID/send-limits", r.scope(apikey.ScopeSessionsWrite), r.sessionAccess(), r.audit(audit.ActionSessionSendLimitsSet), r.sessionHandler.SetSendLimits)
+		sessions.DELETE("/:sessionID/send-limits", r.scope(apikey.ScopeSessionsWrite), r.sessionAccess(), r.audit(audit.ActionSessionSendLimitsDelete), r.sessionHandler.ResetSendLimits)
+		sessions.GET("/:sessionID/media-retention", r.scope(apikey.ScopeSessionsRead), r.sessionAccess(), r.sessionHandler.GetMediaRetention)
+		sessions.PUT("/:sessionID/media-retention", r.scope(apikey.ScopeSessionsWrite), r.sessionAccess(), r.audit(audit.ActionSessionRetentionSet), r.sessionHandler.SetMediaRetention)
+		sessions.DELETE("/:sessionID/media-retention", r.scope(apikey.ScopeSessionsWrite), r.sessionAccess(), r.audit(audit.ActionSessionRetentionDelete), r.sessionHandler.ResetMediaRetention)
 
 		// QR Code e emparelhamento - TODO: Implementar
 		// sessions.GET("/:sessionID/qr", r.sessionHandler.GetQRCode)
@@ -154,7 +260,7 @@ func (r *Router) setupMessageRoutes(group *gin.RouterGroup) {
 	messages := group.Group("/messages")
 	{
 		// Rotas de envio de mensagens por sessão
-		sessionMessages := messages.Group("/:sessionID/send")
+		sessionMessages := messages.Group("/:sessionID/send", r.scope(apikey.ScopeMessagesSend), r.sessionAccess(), r.sendRateLimit(), r.sessionRateLimit(), r.audit(audit.ActionMessageSend))
 		{
 			// Mensagem de texto
 			logger.Debug().Msg("Registrando rota POST /messages/:sessionID/send/text")
@@ -175,6 +281,12 @@ func (r *Router) setupMessageRoutes(group *gin.RouterGroup) {
 			// sessionMessages.POST("/poll", r.messageHandler.SendPoll)
 		}
 
+		// Confirmações de entrega, leitura e reprodução por destinatário
+		messages.GET("/:sessionID/:msgID/receipts", r.scope(apikey.ScopeSessionsRead), r.sessionAccess(), r.messageHandler.GetReceipts)
+
+		// Novo download da mídia, com pedido de reenvio ao aparelho do remetente
+		messages.POST("/:sessionID/:msgID/media/retry", r.scope(apikey.ScopeSessionsWrite), r.sessionAccess(), r.audit(audit.ActionMessageMediaRetry), r.mediaHandler.Retry)
+
 		// TODO: Implementar gerenciamento de mensagens
 		// sessionMessages.GET("/", r.messageHandler.GetMessages)
 		// sessionMessages.GET("/:messageID", r.messageHandler.GetMessage)
@@ -183,17 +295,126 @@ func (r *Router) setupMessageRoutes(group *gin.RouterGroup) {
 	}
 }
 
-// parseDuration converte string de duração para time.Duration
-func parseDuration(duration string) time.Duration {
-	// Implementação simples - em produção usar time.ParseDuration
-	switch duration {
-	case "60s":
-		return 60 * time.Second
-	case "1m":
-		return 1 * time.Minute
-	case "5m":
-		return 5 * time.Minute
-	default:
-		return 60 * time.Second
+// setupMediaRoutes configura as rotas de download das mídias
+func (r *Router) setupMediaRoutes(group *gin.RouterGroup) {
+	media := group.Group("/media/:sessionID", r.scope(apikey.ScopeSessionsRead), r.sessionAccess())
+	{
+		media.GET("/:msgID", r.mediaHandler.Download)
+		media.HEAD("/:msgID", r.mediaHandler.Download)
+	}
+}
+
+// setupTemplateRoutes configura as rotas de templates de mensagem
+func (r *Router) setupTemplateRoutes(group *gin.RouterGroup) {
+	templates := group.Group("/templates")
+	{
+		// Templates são compartilhados entre as sessões: alterações exigem chave sem restrição de sessões
+		templates.POST("", r.scope(apikey.ScopeTemplatesWrite), middleware.RequireAllSessions(), r.audit(audit.ActionTemplateCreate), r.templateHandler.Create)
+		templates.GET("", r.scope(apikey.ScopeTemplatesRead), r.templateHandler.List)
+		templates.GET("/:templateID", r.scope(apikey.ScopeTemplatesRead), r.templateHandler.Get)
+		templates.PUT("/:templateID", r.scope(apikey.ScopeTemplatesWrite), middleware.RequireAllSessions(), r.audit(audit.ActionTemplateUpdate), r.templateHandler.Update)
+		templates.DELETE("/:templateID", r.scope(apikey.ScopeTemplatesWrite), middleware.RequireAllSessions(), r.audit(audit.ActionTemplateDelete), r.templateHandler.Delete)
+		templates.POST("/:templateID/render", r.scope(apikey.ScopeTemplatesRead), r.templateHandler.Render)
+	}
+}
+
+// setupAutoReplyRoutes configura as rotas de regras de resposta automática
+func (r *Router) setupAutoReplyRoutes(group *gin.RouterGroup) {
+	rules := group.Group("/sessions/:sessionID/autoreply/rules", r.sessionAccess())
+	{
+		rules.GET("", r.scope(apikey.ScopeSessionsRead), r.autoReplyHandler.List)
+		rules.POST("", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionAutoReplyCreate), r.autoReplyHandler.Create)
+		rules.PUT("/:ruleID", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionAutoReplyUpdate), r.autoReplyHandler.Update)
+		rules.DELETE("/:ruleID", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionAutoReplyDelete), r.autoReplyHandler.Delete)
 	}
 }
+
+// setupBusinessHoursRoutes configura as rotas de horário comercial
+func (r *Router) setupBusinessHoursRoutes(group *gin.RouterGroup) {
+	hours := group.Group("/sessions/:sessionID/business-hours", r.sessionAccess())
+	{
+		hours.GET("", r.scope(apikey.ScopeSessionsRead), r.businessHoursHandler.Get)
+		hours.PUT("", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionBusinessHoursSet), r.businessHoursHandler.Set)
+		hours.DELETE("", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionBusinessHoursDelete), r.businessHoursHandler.Delete)
+		hours.GET("/status", r.scope(apikey.ScopeSessionsRead), r.businessHoursHandler.Status)
+	}
+}
+
+// setupEventRoutes configura as rotas de stream de eventos
+func (r *Router) setupEventRoutes(group *gin.RouterGroup) {
+	// Chaves restritas recebem apenas eventos das próprias sessões (filtro aplicado no handler)
+	events := group.Group("/events", r.scope(apikey.ScopeEventsRead))
+	{
+		events.GET("/ws", r.eventStreamHandler.WebSocket)
+		events.GET("/sse", r.eventStreamHandler.SSE)
+	}
+}
+
+// setupEventSinkRoutes configura as rotas de sinks de eventos por sessão
+func (r *Router) setupEventSinkRoutes(group *gin.RouterGroup) {
+	sinks := group.Group("/sessions/:sessionID/sinks", r.sessionAccess())
+	{
+		sinks.GET("", r.scope(apikey.ScopeSessionsRead), r.eventSinkHandler.List)
+		sinks.POST("", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionSinkCreate), r.eventSinkHandler.Create)
+		sinks.PUT("/:sinkID", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionSinkUpdate), r.eventSinkHandler.Update)
+		sinks.DELETE("/:sinkID", r.scope(apikey.ScopeSessionsWrite), r.audit(audit.ActionSinkDelete), r.eventSinkHandler.Delete)
+	}
+}
+
+// setupAdminRoutes configura as rotas administrativas, restritas ao escopo admin
+func (r *Router) setupAdminRoutes(group *gin.RouterGroup) {
+	// Chaves admin de um tenant gerenciam apenas as chaves do próprio tenant. Chaves restritas a
+	// algumas sessões não gerenciam chaves: emitiriam ou rotacionariam chaves com acesso maior
+	keys := group.Group("/admin/keys", r.scope(apikey.ScopeAdmin), middleware.RequireAllSessions())
+	{
+		keys.POST("", r.audit(audit.ActionKeyCreate), r.apiKeyHandler.Create)
+		keys.GET("", r.apiKeyHandler.List)
+		keys.GET("/:keyID", r.apiKeyHandler.Get)
+		keys.POST("/:keyID/rotate", r.audit(audit.ActionKeyRotate), r.apiKeyHandler.Rotate)
+		keys.DELETE("/:keyID", r.audit(audit.ActionKeyRevoke), r.apiKeyHandler.Revoke)
+	}
+
+	// Tenants e seus limites só podem ser geridos pela chave mestre
+	tenants := group.Group("/admin/tenants", middleware.RequireMaster())
+	{
+		tenants.POST("", r.audit(audit.ActionTenantCreate), r.tenantHandler.Create)
+		tenants.GET("", r.tenantHandler.List)
+		tenants.GET("/:tenantID", r.tenantHandler.Get)
+		tenants.PUT("/:tenantID", r.audit(audit.ActionTenantUpdate), r.tenantHandler.Update)
+		tenants.DELETE("/:tenantID", r.audit(audit.ActionTenantDelete), r.tenantHandler.Delete)
+		tenants.GET("/:tenantID/usage", r.tenantHandler.Usage)
+	}
+
+	// Trilha de auditoria; chaves admin de um tenant consultam apenas os registros do próprio tenant
+	auditLog := group.Group("/admin/audit", r.scope(apikey.ScopeAdmin))
+	{
+		auditLog.GET("", r.auditHandler.List)
+		auditLog.GET("/export", r.auditHandler.Export)
+	}
+}
+
+// scope exige o escopo informado da chave autenticada
+func (r *Router) scope(scope apikey.Scope) gin.HandlerFunc {
+	return middleware.RequireScope(scope)
+}
+
+// sessionAccess exige que a chave autenticada tenha acesso à sessão do path
+func (r *Router) sessionAccess() gin.HandlerFunc {
+	return middleware.RequireSessionAccess(r.config.SessionResolver)
+}
+
+// audit registra a ação na trilha de auditoria; fica logo antes do handler para registrar
+// apenas requisições autorizadas
+func (r *Router) audit(action audit.Action) gin.HandlerFunc {
+	return middleware.Audit(r.config.AuditRecorder, r.config.SessionResolver, action)
+}
+
+// sendRateLimit aplica o limite de envios de cada chave de API
+func (r *Router) sendRateLimit() gin.HandlerFunc {
+	return middleware.RouteRateLimit(r.config.RateLimiter, r.config.RateLimits.Send)
+}
+
+// sessionRateLimit aplica o limite de envios da sessão do path, somando todas as chaves
+func (r *Router) sessionRateLimit() gin.HandlerFunc {
+	return middleware.SessionRateLimit(r.config.RateLimiter, r.config.RateLimits.Session, r.config.SessionResolver)
+}
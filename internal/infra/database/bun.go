@@ -6,10 +6,6 @@ import (
 	"fmt"
 	"time"
 
-	"zapcore/internal/domain/chat"
-	"zapcore/internal/domain/contact"
-	"zapcore/internal/domain/message"
-	"zapcore/internal/domain/session"
 	"zapcore/pkg/logger"
 
 	"github.com/uptrace/bun"
@@ -144,10 +140,10 @@ func NewBunDB(config *Config) (*BunDB, error) {
 		logger: logger.Get(),
 	}
 
-	// Executar auto-migration
-	if err := bunDB.AutoMigrate(ctx); err != nil {
+	// Aplicar migrations versionadas
+	if _, err := NewMigrator(db).Up(ctx); err != nil {
 		sqldb.Close()
-		return nil, fmt.Errorf("erro ao executar auto-migration: %w", err)
+		return nil, fmt.Errorf("erro ao executar migrations: %w", err)
 	}
 
 	bunDB.logger.Info().Msg("Conexão com banco de dados Bun estabelecida com sucesso")
@@ -190,29 +186,6 @@ func (d *BunDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (bun.Tx, error
 	return d.db.BeginTx(ctx, opts)
 }
 
-// AutoMigrate executa as migrations automáticas baseadas nas structs
-func (d *BunDB) AutoMigrate(ctx context.Context) error {
-	d.logger.Info().Msg("Iniciando auto-migration do banco de dados")
-
-	// Registrar modelos
-	models := []interface{}{
-		(*session.Session)(nil),
-		(*message.Message)(nil),
-		(*chat.Chat)(nil),
-		(*contact.Contact)(nil),
-	}
-
-	// Criar tabelas para cada modelo usando apenas Bun ORM
-	for _, model := range models {
-		if _, err := d.db.NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
-			return fmt.Errorf("erro ao criar tabela para modelo %T: %w", model, err)
-		}
-	}
-
-	d.logger.Info().Msg("Auto-migration concluída com sucesso")
-	return nil
-}
-
 // GetConfig retorna a configuração do banco
 func (d *BunDB) GetConfig() *Config {
 	return d.config
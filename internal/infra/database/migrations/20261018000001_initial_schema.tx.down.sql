@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS "zapcore_event_sinks";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_events";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_away_notices";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_business_hours";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_autoreply_states";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_autoreply_rules";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_templates";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_contacts";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_chats";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_messages";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_sessions";
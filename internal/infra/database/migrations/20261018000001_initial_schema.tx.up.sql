@@ -0,0 +1,189 @@
+-- Esquema inicial: equivalente às tabelas criadas pelo antigo AutoMigrate.
+-- IF NOT EXISTS permite adotar bancos que já foram criados pelo AutoMigrate.
+
+CREATE TABLE IF NOT EXISTS "zapcore_sessions" (
+    "id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "jid" varchar(100),
+    "isActive" boolean,
+    "lastSeen" timestamptz,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("name")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_messages" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "msgId" varchar(255) NOT NULL,
+    "messageType" varchar(50) NOT NULL,
+    "direction" varchar(20) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "senderJid" varchar(100) NOT NULL,
+    "chatJid" varchar(100) NOT NULL,
+    "content" text,
+    "mediaId" uuid,
+    "mediaPath" varchar(500),
+    "mediaSize" bigint,
+    "mediaMimeType" varchar(100),
+    "mediaFileName" varchar(255),
+    "caption" text,
+    "timestamp" timestamptz NOT NULL,
+    "quotedMessageId" varchar(255),
+    "pushName" varchar(255),
+    "isFromMe" boolean,
+    "isGroup" boolean,
+    "mediaType" varchar(50),
+    "rawPayload" jsonb,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_chats" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "jid" varchar(100) NOT NULL,
+    "name" varchar(255),
+    "chatType" varchar(20) NOT NULL,
+    "lastMessageTime" timestamptz,
+    "messageCount" integer,
+    "unreadCount" integer,
+    "isMuted" boolean,
+    "isPinned" boolean,
+    "isArchived" boolean,
+    "metadata" jsonb,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_contacts" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "jid" varchar(100) NOT NULL,
+    "pushName" varchar(255),
+    "businessName" varchar(255),
+    "avatarUrl" varchar(500),
+    "isGroup" boolean,
+    "lastSeen" timestamptz,
+    "metadata" jsonb,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_templates" (
+    "id" uuid NOT NULL,
+    "tenantId" varchar(100) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "language" varchar(20) NOT NULL,
+    "body" text NOT NULL,
+    "mediaPath" text,
+    "mediaType" varchar(50),
+    "mediaMimeType" varchar(100),
+    "mediaFileName" varchar(255),
+    "variants" jsonb,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "zapcore_templates_tenant_name" UNIQUE ("tenantId", "name")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_autoreply_rules" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "priority" integer NOT NULL,
+    "isActive" boolean,
+    "conditions" jsonb,
+    "actions" jsonb,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_autoreply_states" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "chatJid" varchar(100) NOT NULL,
+    "step" varchar(100) NOT NULL,
+    "data" jsonb,
+    "expiresAt" timestamptz NOT NULL,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "zapcore_autoreply_states_chat" UNIQUE ("sessionId", "chatJid")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_business_hours" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "timezone" varchar(64) NOT NULL,
+    "weekly" jsonb,
+    "holidays" jsonb,
+    "awayEnabled" boolean,
+    "awayMessage" text,
+    "greetingEnabled" boolean,
+    "greetingMessage" text,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("sessionId")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_away_notices" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "chatJid" varchar(100) NOT NULL,
+    "windowKey" varchar(64) NOT NULL,
+    "sentAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "zapcore_away_notices_chat" UNIQUE ("sessionId", "chatJid")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_events" (
+    "seq" bigserial NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "payload" jsonb,
+    "createdAt" timestamptz NOT NULL,
+    PRIMARY KEY ("seq")
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS "zapcore_event_sinks" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "driver" varchar(20) NOT NULL,
+    "url" text NOT NULL,
+    "subject" varchar(255) NOT NULL,
+    "types" jsonb,
+    "options" jsonb,
+    "isActive" boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
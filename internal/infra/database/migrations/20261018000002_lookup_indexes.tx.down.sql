@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "zapcore_event_sinks_session_idx";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_events_session_seq_idx";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_autoreply_rules_session_idx";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_contacts_session_jid_idx";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_chats_session_jid_idx";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_messages_session_chat_time_idx";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_messages_session_msg_idx";
@@ -0,0 +1,15 @@
+-- Índices para as consultas mais frequentes dos repositórios
+
+CREATE INDEX IF NOT EXISTS "zapcore_messages_session_msg_idx" ON "zapcore_messages" ("sessionId", "msgId");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_messages_session_chat_time_idx" ON "zapcore_messages" ("sessionId", "chatJid", "timestamp" DESC);
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_chats_session_jid_idx" ON "zapcore_chats" ("sessionId", "jid");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_contacts_session_jid_idx" ON "zapcore_contacts" ("sessionId", "jid");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_autoreply_rules_session_idx" ON "zapcore_autoreply_rules" ("sessionId", "priority");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_events_session_seq_idx" ON "zapcore_events" ("sessionId", "seq");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_event_sinks_session_idx" ON "zapcore_event_sinks" ("sessionId");
@@ -0,0 +1,3 @@
+ALTER TABLE "zapcore_contacts" DROP COLUMN IF EXISTS "isBusiness";
+--bun:split
+ALTER TABLE "zapcore_contacts" DROP COLUMN IF EXISTS "name";
@@ -0,0 +1,7 @@
+-- Persistir nome e flag business dos contatos (antes ignorados pelo ORM)
+
+ALTER TABLE "zapcore_contacts" ADD COLUMN IF NOT EXISTS "name" varchar(255);
+--bun:split
+ALTER TABLE "zapcore_contacts" ADD COLUMN IF NOT EXISTS "isBusiness" boolean NOT NULL DEFAULT false;
+--bun:split
+UPDATE "zapcore_contacts" SET "isBusiness" = true WHERE COALESCE("businessName", '') <> '';
@@ -0,0 +1,18 @@
+-- Chaves de API com escopos, sessões permitidas e expiração; apenas o hash SHA-256 é persistido
+
+CREATE TABLE IF NOT EXISTS "zapcore_api_keys" (
+    "id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "prefix" varchar(16) NOT NULL,
+    "keyHash" varchar(64) NOT NULL,
+    "scopes" jsonb NOT NULL,
+    "sessionIds" jsonb,
+    "expiresAt" timestamptz,
+    "lastUsedAt" timestamptz,
+    "revokedAt" timestamptz,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+--bun:split
+CREATE UNIQUE INDEX IF NOT EXISTS "zapcore_api_keys_hash_idx" ON "zapcore_api_keys" ("keyHash");
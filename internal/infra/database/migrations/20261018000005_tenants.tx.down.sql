@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "zapcore_api_keys_tenant_idx";
+--bun:split
+ALTER TABLE "zapcore_api_keys" DROP COLUMN IF EXISTS "tenantId";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_sessions_tenant_idx";
+--bun:split
+ALTER TABLE "zapcore_sessions" DROP COLUMN IF EXISTS "tenantId";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_tenant_usage";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_tenants";
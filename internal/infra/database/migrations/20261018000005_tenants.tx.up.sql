@@ -0,0 +1,38 @@
+-- Tenants isolam sessões, chaves de API e templates; dados existentes ficam no tenant "default"
+
+CREATE TABLE IF NOT EXISTS "zapcore_tenants" (
+    "id" varchar(100) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "maxSessions" integer NOT NULL DEFAULT 0,
+    "maxMessagesPerDay" integer NOT NULL DEFAULT 0,
+    "maxStorageBytes" bigint NOT NULL DEFAULT 0,
+    "isActive" boolean NOT NULL DEFAULT true,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+--bun:split
+INSERT INTO "zapcore_tenants" ("id", "name", "createdAt", "updatedAt")
+VALUES ('default', 'Default', now(), now())
+ON CONFLICT ("id") DO NOTHING;
+--bun:split
+-- Contador diário de mensagens enviadas, usado na cota maxMessagesPerDay
+CREATE TABLE IF NOT EXISTS "zapcore_tenant_usage" (
+    "tenantId" varchar(100) NOT NULL,
+    "day" date NOT NULL,
+    "messagesSent" integer NOT NULL DEFAULT 0,
+    PRIMARY KEY ("tenantId", "day")
+);
+--bun:split
+ALTER TABLE "zapcore_sessions" ADD COLUMN IF NOT EXISTS "tenantId" varchar(100) NOT NULL DEFAULT 'default';
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_sessions_tenant_idx" ON "zapcore_sessions" ("tenantId");
+--bun:split
+ALTER TABLE "zapcore_api_keys" ADD COLUMN IF NOT EXISTS "tenantId" varchar(100) NOT NULL DEFAULT 'default';
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_api_keys_tenant_idx" ON "zapcore_api_keys" ("tenantId");
+--bun:split
+-- Templates já eram agrupados por tenantId; garantir que o tenant de cada um exista
+INSERT INTO "zapcore_tenants" ("id", "name", "createdAt", "updatedAt")
+SELECT DISTINCT "tenantId", "tenantId", now(), now() FROM "zapcore_templates"
+ON CONFLICT ("id") DO NOTHING;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "zapcore_audit_log";
+--bun:split
+DROP FUNCTION IF EXISTS "zapcore_audit_log_append_only"();
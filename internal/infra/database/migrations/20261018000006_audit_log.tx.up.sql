@@ -0,0 +1,47 @@
+-- Trilha de auditoria append-only das ações administrativas e de envio
+
+CREATE TABLE IF NOT EXISTS "zapcore_audit_log" (
+    "id" uuid NOT NULL,
+    "tenantId" varchar(100),
+    "actorType" varchar(20) NOT NULL,
+    "keyId" uuid,
+    "actorName" varchar(255) NOT NULL,
+    "ip" varchar(64),
+    "userAgent" varchar(255),
+    "requestId" varchar(100),
+    "action" varchar(50) NOT NULL,
+    "resourceId" varchar(255),
+    "sessionId" uuid,
+    "method" varchar(10),
+    "path" text,
+    "statusCode" integer,
+    "outcome" varchar(20) NOT NULL,
+    "details" jsonb,
+    "createdAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_audit_log_created_idx" ON "zapcore_audit_log" ("createdAt");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_audit_log_tenant_idx" ON "zapcore_audit_log" ("tenantId", "createdAt");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_audit_log_session_idx" ON "zapcore_audit_log" ("sessionId", "createdAt");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_audit_log_key_idx" ON "zapcore_audit_log" ("keyId", "createdAt");
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_audit_log_action_idx" ON "zapcore_audit_log" ("action", "createdAt");
+--bun:split
+-- Registros não podem ser alterados nem removidos, mesmo com acesso direto ao banco
+CREATE OR REPLACE FUNCTION "zapcore_audit_log_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'zapcore_audit_log é append-only: % não é permitido', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+--bun:split
+CREATE TRIGGER "zapcore_audit_log_no_update_delete"
+BEFORE UPDATE OR DELETE ON "zapcore_audit_log"
+FOR EACH ROW EXECUTE FUNCTION "zapcore_audit_log_append_only"();
+--bun:split
+CREATE TRIGGER "zapcore_audit_log_no_truncate"
+BEFORE TRUNCATE ON "zapcore_audit_log"
+FOR EACH STATEMENT EXECUTE FUNCTION "zapcore_audit_log_append_only"();
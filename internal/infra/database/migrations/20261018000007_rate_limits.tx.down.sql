@@ -0,0 +1,3 @@
+ALTER TABLE "zapcore_api_keys" DROP COLUMN IF EXISTS "rateLimit";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_rate_limits";
@@ -0,0 +1,14 @@
+-- Buckets do rate limiting com RATE_LIMIT_BACKEND=postgres e políticas próprias por chave de API
+
+CREATE TABLE IF NOT EXISTS "zapcore_rate_limits" (
+    "key" varchar(255) NOT NULL,
+    "tokens" double precision NOT NULL,
+    "allowed" boolean NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    "expiresAt" timestamptz NOT NULL,
+    PRIMARY KEY ("key")
+);
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_rate_limits_expires_idx" ON "zapcore_rate_limits" ("expiresAt");
+--bun:split
+ALTER TABLE "zapcore_api_keys" ADD COLUMN IF NOT EXISTS "rateLimit" jsonb;
@@ -0,0 +1 @@
+ALTER TABLE "zapcore_sessions" DROP COLUMN IF EXISTS "sendLimits";
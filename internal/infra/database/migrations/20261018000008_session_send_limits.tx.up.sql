@@ -0,0 +1,3 @@
+-- Limites de envio próprios de cada sessão (mensagens por minuto, novas conversas por dia etc.)
+
+ALTER TABLE "zapcore_sessions" ADD COLUMN IF NOT EXISTS "sendLimits" jsonb;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "zapcore_message_receipts";
@@ -0,0 +1,16 @@
+-- Confirmações de entrega, leitura e reprodução por destinatário (grupos e listas de transmissão)
+
+CREATE TABLE IF NOT EXISTS "zapcore_message_receipts" (
+    "id" uuid NOT NULL,
+    "sessionId" uuid NOT NULL,
+    "msgId" varchar(255) NOT NULL,
+    "chatJid" varchar(100) NOT NULL,
+    "recipientJid" varchar(100) NOT NULL,
+    "receiptType" varchar(20) NOT NULL,
+    "timestamp" timestamptz NOT NULL,
+    "createdAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+--bun:split
+-- Uma confirmação por destinatário e tipo; também atende à consulta por mensagem da sessão
+CREATE UNIQUE INDEX IF NOT EXISTS "zapcore_message_receipts_unique_idx" ON "zapcore_message_receipts" ("sessionId", "msgId", "recipientJid", "receiptType");
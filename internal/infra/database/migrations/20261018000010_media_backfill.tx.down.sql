@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "zapcore_messages_media_pending_idx";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaError";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaAttemptAt";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaAttempts";
@@ -0,0 +1,12 @@
+-- Controle das tentativas de download das mídias que ainda não foram armazenadas
+
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaAttempts" integer NOT NULL DEFAULT 0;
+--bun:split
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaAttemptAt" timestamptz;
+--bun:split
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaError" varchar(500);
+--bun:split
+-- Atende à varredura das mídias pendentes sem percorrer as mensagens já resolvidas
+CREATE INDEX IF NOT EXISTS "zapcore_messages_media_pending_idx" ON "zapcore_messages" ("timestamp" DESC)
+    WHERE COALESCE("mediaPath", '') = ''
+      AND "messageType" IN ('imageMessage', 'videoMessage', 'audioMessage', 'documentMessage', 'stickerMessage', 'gifMessage');
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "zapcore_media_uploads";
+--bun:split
+DROP INDEX IF EXISTS "zapcore_messages_media_id_idx";
+--bun:split
+DROP TABLE IF EXISTS "zapcore_media_blobs";
@@ -0,0 +1,37 @@
+-- Mídias endereçadas pelo SHA-256 do conteúdo: cada conteúdo é gravado uma vez por tenant e as
+-- mensagens o referenciam em "mediaId"
+
+CREATE TABLE IF NOT EXISTS "zapcore_media_blobs" (
+    "id" uuid NOT NULL,
+    "tenantId" varchar(100) NOT NULL,
+    "sha256" char(64) NOT NULL,
+    "objectPath" varchar(500) NOT NULL,
+    "size" bigint NOT NULL,
+    "contentType" varchar(100),
+    "refCount" integer NOT NULL DEFAULT 0,
+    "createdAt" timestamptz NOT NULL,
+    "updatedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+--bun:split
+CREATE UNIQUE INDEX IF NOT EXISTS "zapcore_media_blobs_content_idx" ON "zapcore_media_blobs" ("tenantId", "sha256");
+--bun:split
+-- Atende à coleta dos conteúdos que nenhuma mensagem referencia mais
+CREATE INDEX IF NOT EXISTS "zapcore_media_blobs_unreferenced_idx" ON "zapcore_media_blobs" ("updatedAt")
+    WHERE "refCount" <= 0;
+--bun:split
+CREATE INDEX IF NOT EXISTS "zapcore_messages_media_id_idx" ON "zapcore_messages" ("mediaId")
+    WHERE "mediaId" IS NOT NULL;
+--bun:split
+-- Uploads das mídias enviadas aos servidores do WhatsApp, reaproveitados por envios do mesmo conteúdo
+CREATE TABLE IF NOT EXISTS "zapcore_media_uploads" (
+    "sha256" char(64) NOT NULL,
+    "mediaType" varchar(40) NOT NULL,
+    "url" text NOT NULL,
+    "directPath" text NOT NULL,
+    "mediaKey" bytea NOT NULL,
+    "fileEncSha256" bytea NOT NULL,
+    "fileLength" bigint NOT NULL,
+    "uploadedAt" timestamptz NOT NULL,
+    PRIMARY KEY ("sha256", "mediaType")
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "zapcore_messages_media_stored_idx";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaExpiredAt";
+--bun:split
+ALTER TABLE "zapcore_tenants" DROP COLUMN IF EXISTS "mediaRetention";
+--bun:split
+ALTER TABLE "zapcore_sessions" DROP COLUMN IF EXISTS "mediaRetention";
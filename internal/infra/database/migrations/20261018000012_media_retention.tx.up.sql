@@ -0,0 +1,12 @@
+-- Políticas de retenção das mídias por sessão e por tenant; vazio usa a política herdada
+
+ALTER TABLE "zapcore_sessions" ADD COLUMN IF NOT EXISTS "mediaRetention" jsonb;
+--bun:split
+ALTER TABLE "zapcore_tenants" ADD COLUMN IF NOT EXISTS "mediaRetention" jsonb;
+--bun:split
+-- Mensagens cuja mídia foi removida pela política de retenção não voltam ao download pendente
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaExpiredAt" timestamptz;
+--bun:split
+-- Atende à varredura das mídias armazenadas vencidas de cada sessão
+CREATE INDEX IF NOT EXISTS "zapcore_messages_media_stored_idx" ON "zapcore_messages" ("sessionId", "timestamp")
+    WHERE COALESCE("mediaPath", '') <> '';
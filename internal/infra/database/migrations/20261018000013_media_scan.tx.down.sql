@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "zapcore_messages_media_quarantined_idx";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaQuarantined";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaScannedAt";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaScanSignature";
+--bun:split
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "mediaScanVerdict";
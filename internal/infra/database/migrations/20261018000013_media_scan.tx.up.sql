@@ -0,0 +1,13 @@
+-- Resultado da verificação de malware das mídias recebidas
+
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaScanVerdict" varchar(20);
+--bun:split
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaScanSignature" varchar(255);
+--bun:split
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaScannedAt" timestamptz;
+--bun:split
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "mediaQuarantined" boolean NOT NULL DEFAULT false;
+--bun:split
+-- Atende à consulta das mídias em quarentena
+CREATE INDEX IF NOT EXISTS "zapcore_messages_media_quarantined_idx" ON "zapcore_messages" ("sessionId", "timestamp" DESC)
+    WHERE "mediaQuarantined";
@@ -0,0 +1 @@
+ALTER TABLE "zapcore_messages" DROP COLUMN IF EXISTS "recipientCount";
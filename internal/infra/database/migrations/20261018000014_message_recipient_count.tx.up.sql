@@ -0,0 +1,3 @@
+-- Destinatários de cada mensagem enviada, base das regras de leitura all e threshold
+
+ALTER TABLE "zapcore_messages" ADD COLUMN IF NOT EXISTS "recipientCount" integer NOT NULL DEFAULT 0;
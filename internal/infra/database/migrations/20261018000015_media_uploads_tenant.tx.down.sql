@@ -0,0 +1,7 @@
+DELETE FROM "zapcore_media_uploads";
+--bun:split
+ALTER TABLE "zapcore_media_uploads" DROP CONSTRAINT IF EXISTS "zapcore_media_uploads_pkey";
+--bun:split
+ALTER TABLE "zapcore_media_uploads" DROP COLUMN IF EXISTS "tenantId";
+--bun:split
+ALTER TABLE "zapcore_media_uploads" ADD PRIMARY KEY ("sha256", "mediaType");
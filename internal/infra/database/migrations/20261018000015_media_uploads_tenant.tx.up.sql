@@ -0,0 +1,11 @@
+-- Uploads ao WhatsApp separados por tenant, como os conteúdos deduplicados: a chave e o direct
+-- path de uma mídia não são reaproveitados por outro tenant. Os uploads existentes são apenas um
+-- cache e são descartados.
+
+DELETE FROM "zapcore_media_uploads";
+--bun:split
+ALTER TABLE "zapcore_media_uploads" ADD COLUMN IF NOT EXISTS "tenantId" varchar(100) NOT NULL;
+--bun:split
+ALTER TABLE "zapcore_media_uploads" DROP CONSTRAINT IF EXISTS "zapcore_media_uploads_pkey";
+--bun:split
+ALTER TABLE "zapcore_media_uploads" ADD PRIMARY KEY ("tenantId", "sha256", "mediaType");
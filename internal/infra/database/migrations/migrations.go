@@ -0,0 +1,24 @@
+// Package migrations contém as migrations SQL versionadas do ZapCore, embutidas no binário.
+//
+// Cada migration tem um arquivo <versão>_<descrição>.tx.up.sql e o respectivo .tx.down.sql;
+// comandos múltiplos são separados por "--bun:split". Migrations já aplicadas nunca devem
+// ser editadas: alterações de esquema entram sempre em um novo arquivo.
+package migrations
+
+import (
+	"embed"
+
+	"github.com/uptrace/bun/migrate"
+)
+
+//go:embed *.sql
+var sqlMigrations embed.FS
+
+// Migrations é o conjunto de migrations conhecido por esta versão do binário
+var Migrations = migrate.NewMigrations()
+
+func init() {
+	if err := Migrations.Discover(sqlMigrations); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"zapcore/internal/infra/database/migrations"
+	"zapcore/pkg/logger"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+const (
+	// MigrationsTable registra as migrations aplicadas
+	MigrationsTable = "zapcore_migrations"
+
+	// MigrationLocksTable é exigida pelo bun/migrate; o bloqueio efetivo usa advisory lock
+	MigrationLocksTable = "zapcore_migration_locks"
+
+	// migrationLockKey identifica o advisory lock das migrations ("zapcore" em hexadecimal)
+	migrationLockKey int64 = 0x7a6170636f7265
+)
+
+var (
+	// ErrSchemaNewer indica que o banco tem migrations desconhecidas por este binário
+	ErrSchemaNewer = errors.New("esquema do banco é mais novo que esta versão do ZapCore")
+
+	// ErrPendingMigrations indica que há migrations ainda não aplicadas
+	ErrPendingMigrations = errors.New("existem migrations pendentes")
+)
+
+// MigrationStatus representa o estado de uma migration
+type MigrationStatus struct {
+	Name       string    `json:"name"`
+	Comment    string    `json:"comment"`
+	Applied    bool      `json:"applied"`
+	GroupID    int64     `json:"groupId,omitempty"`
+	MigratedAt time.Time `json:"migratedAt,omitempty"`
+}
+
+// SchemaStatus resume o estado do esquema em relação às migrations embutidas
+type SchemaStatus struct {
+	Migrations []MigrationStatus `json:"migrations"`
+	Pending    int               `json:"pending"`
+	Unknown    []string          `json:"unknown,omitempty"`
+}
+
+// Migrator aplica e reverte as migrations SQL versionadas
+type Migrator struct {
+	db       *bun.DB
+	migrator *migrate.Migrator
+	logger   *logger.Logger
+}
+
+// NewMigrator cria um migrator com as migrations embutidas no binário
+func NewMigrator(db *bun.DB) *Migrator {
+	return &Migrator{
+		db: db,
+		migrator: migrate.NewMigrator(db, migrations.Migrations,
+			migrate.WithTableName(MigrationsTable),
+			migrate.WithLocksTableName(MigrationLocksTable),
+			migrate.WithMarkAppliedOnSuccess(true),
+		),
+		logger: logger.Get(),
+	}
+}
+
+// Status retorna as migrations conhecidas, as pendentes e as aplicadas que este binário não conhece
+func (m *Migrator) Status(ctx context.Context) (*SchemaStatus, error) {
+	if err := m.migrator.Init(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao criar tabela de migrations: %w", err)
+	}
+
+	ms, err := m.migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar migrations: %w", err)
+	}
+
+	missing, err := m.migrator.MissingMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar migrations: %w", err)
+	}
+
+	status := &SchemaStatus{}
+	for _, migration := range ms {
+		status.Migrations = append(status.Migrations, MigrationStatus{
+			Name:       migration.Name,
+			Comment:    migration.Comment,
+			Applied:    migration.IsApplied(),
+			GroupID:    migration.GroupID,
+			MigratedAt: migration.MigratedAt,
+		})
+		if !migration.IsApplied() {
+			status.Pending++
+		}
+	}
+	for _, migration := range missing {
+		status.Unknown = append(status.Unknown, migration.Name)
+	}
+
+	return status, nil
+}
+
+// EnsureCompatible falha se o banco já foi migrado por uma versão mais nova do ZapCore
+func (m *Migrator) EnsureCompatible(ctx context.Context) (*SchemaStatus, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(status.Unknown) > 0 {
+		return status, fmt.Errorf("%w: migrations desconhecidas %s", ErrSchemaNewer, strings.Join(status.Unknown, ", "))
+	}
+
+	return status, nil
+}
+
+// Up aplica todas as migrations pendentes em um único grupo
+func (m *Migrator) Up(ctx context.Context) (*migrate.MigrationGroup, error) {
+	var group *migrate.MigrationGroup
+
+	err := m.withLock(ctx, func() error {
+		if _, err := m.EnsureCompatible(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		group, err = m.migrator.Migrate(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao aplicar migrations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !group.IsZero() {
+		m.logger.WithFields(map[string]interface{}{
+			"component":  "database",
+			"operation":  "migration",
+			"group_id":   group.ID,
+			"migrations": group.Migrations.String(),
+		}).Info().Msg("✅ Migrations aplicadas")
+	}
+
+	return group, nil
+}
+
+// Down reverte o último grupo de migrations aplicado
+func (m *Migrator) Down(ctx context.Context) (*migrate.MigrationGroup, error) {
+	var group *migrate.MigrationGroup
+
+	err := m.withLock(ctx, func() error {
+		// Sem o SQL de down das migrations desconhecidas, o rollback apenas as desmarcaria
+		if _, err := m.EnsureCompatible(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		group, err = m.migrator.Rollback(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao reverter migrations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !group.IsZero() {
+		m.logger.WithFields(map[string]interface{}{
+			"component":  "database",
+			"operation":  "migration",
+			"group_id":   group.ID,
+			"migrations": group.Migrations.String(),
+		}).Warn().Msg("↩️ Migrations revertidas")
+	}
+
+	return group, nil
+}
+
+// withLock serializa migrations entre instâncias usando um advisory lock do PostgreSQL,
+// liberado automaticamente se o processo morrer no meio da execução
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao obter conexão para migrations: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", migrationLockKey); err != nil {
+		return fmt.Errorf("erro ao obter lock de migrations: %w", err)
+	}
+	defer func() {
+		// Usar contexto próprio para liberar o lock mesmo com ctx cancelado
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(unlockCtx, "SELECT pg_advisory_unlock(?)", migrationLockKey); err != nil {
+			m.logger.Warn().Err(err).Msg("Erro ao liberar lock de migrations")
+		}
+	}()
+
+	return fn()
+}
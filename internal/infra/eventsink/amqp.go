@@ -0,0 +1,115 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"zapcore/internal/domain/eventsink"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DefaultAMQPExchange é a exchange usada quando nenhuma é configurada
+const DefaultAMQPExchange = "zapcore.events"
+
+// AMQPSink publica eventos em uma exchange topic do RabbitMQ
+type AMQPSink struct {
+	url      string
+	exchange string
+	mu       sync.Mutex
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+}
+
+// NewAMQPSink cria um sink para RabbitMQ; a conexão é aberta no primeiro envio
+func NewAMQPSink(cfg *eventsink.Sink) *AMQPSink {
+	return &AMQPSink{
+		url:      cfg.URL,
+		exchange: cfg.Option(eventsink.OptionAMQPExchange, DefaultAMQPExchange),
+	}
+}
+
+// Publish publica o registro usando o subject como routing key e aguarda o confirm
+func (s *AMQPSink) Publish(ctx context.Context, record *eventsink.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, err := s.openChannel()
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, s.exchange, record.Subject, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    record.ID,
+		Type:         record.Type,
+		Timestamp:    time.Now(),
+		Body:         record.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao publicar no RabbitMQ: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao aguardar confirmação do RabbitMQ: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("RabbitMQ rejeitou a mensagem")
+	}
+
+	return nil
+}
+
+// Close encerra o canal e a conexão
+func (s *AMQPSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.channel != nil {
+		_ = s.channel.Close()
+		s.channel = nil
+	}
+	if s.conn != nil {
+		err := s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// openChannel reabre a conexão e o canal em modo confirm quando necessário
+func (s *AMQPSink) openChannel() (*amqp.Channel, error) {
+	if s.channel != nil && !s.channel.IsClosed() {
+		return s.channel, nil
+	}
+
+	if s.conn == nil || s.conn.IsClosed() {
+		conn, err := amqp.Dial(s.url)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao conectar no RabbitMQ: %w", err)
+		}
+		s.conn = conn
+	}
+
+	channel, err := s.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir canal: %w", err)
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		_ = channel.Close()
+		return nil, fmt.Errorf("erro ao ativar confirmações: %w", err)
+	}
+
+	if err := channel.ExchangeDeclare(s.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		_ = channel.Close()
+		return nil, fmt.Errorf("erro ao declarar exchange %s: %w", s.exchange, err)
+	}
+
+	s.channel = channel
+	return channel, nil
+}
@@ -0,0 +1,163 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"zapcore/internal/domain/eventsink"
+)
+
+// bufferFileExt é a extensão dos registros gravados no buffer
+const bufferFileExt = ".json"
+
+// DiskBuffer é uma fila FIFO de registros em disco, um arquivo por registro
+type DiskBuffer struct {
+	dir      string
+	maxBytes int64
+	mu       sync.Mutex
+	files    []string
+	sizes    map[string]int64
+	total    int64
+	nextSeq  uint64
+}
+
+// NewDiskBuffer abre (ou cria) o buffer no diretório e carrega os registros pendentes
+func NewDiskBuffer(dir string, maxBytes int64) (*DiskBuffer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório do buffer: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler diretório do buffer: %w", err)
+	}
+
+	b := &DiskBuffer{
+		dir:      dir,
+		maxBytes: maxBytes,
+		sizes:    make(map[string]int64),
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, bufferFileExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, bufferFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		b.files = append(b.files, name)
+		b.sizes[name] = info.Size()
+		b.total += info.Size()
+		if seq >= b.nextSeq {
+			b.nextSeq = seq + 1
+		}
+	}
+
+	// Nomes com zeros à esquerda mantêm a ordem de gravação
+	sort.Strings(b.files)
+	return b, nil
+}
+
+// Push grava um registro no fim da fila
+func (b *DiskBuffer) Push(record *eventsink.Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar registro: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	size := int64(len(data))
+	if b.maxBytes > 0 && b.total+size > b.maxBytes {
+		return eventsink.ErrBufferFull
+	}
+
+	name := fmt.Sprintf("%020d%s", b.nextSeq, bufferFileExt)
+	path := filepath.Join(b.dir, name)
+
+	// Gravar em arquivo temporário e renomear evita registros truncados
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("erro ao gravar registro no buffer: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("erro ao gravar registro no buffer: %w", err)
+	}
+
+	b.nextSeq++
+	b.files = append(b.files, name)
+	b.sizes[name] = size
+	b.total += size
+	return nil
+}
+
+// Peek lê o registro mais antigo sem removê-lo
+func (b *DiskBuffer) Peek() (*eventsink.Record, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.files) == 0 {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(b.dir, b.files[0]))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler registro do buffer: %w", err)
+	}
+
+	var record eventsink.Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("registro corrompido no buffer: %w", err)
+	}
+
+	return &record, nil
+}
+
+// Pop remove o registro mais antigo
+func (b *DiskBuffer) Pop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.files) == 0 {
+		return nil
+	}
+
+	name := b.files[0]
+	if err := os.Remove(filepath.Join(b.dir, name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erro ao remover registro do buffer: %w", err)
+	}
+
+	b.files = b.files[1:]
+	b.total -= b.sizes[name]
+	delete(b.sizes, name)
+	return nil
+}
+
+// Len retorna a quantidade de registros pendentes
+func (b *DiskBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.files)
+}
+
+// Size retorna o total de bytes pendentes
+func (b *DiskBuffer) Size() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
@@ -0,0 +1,346 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"zapcore/internal/domain/eventsink"
+	"zapcore/internal/domain/eventstream"
+	"zapcore/internal/infra/metrics"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultQueueSize é o tamanho da fila em memória de cada sink
+	DefaultQueueSize = 1000
+
+	// DefaultEnqueueTimeout é quanto o publisher espera por espaço na fila antes de usar o disco
+	DefaultEnqueueTimeout = 100 * time.Millisecond
+
+	// publishTimeout limita cada tentativa de envio ao broker
+	publishTimeout = 10 * time.Second
+
+	minRetryBackoff = time.Second
+	maxRetryBackoff = time.Minute
+)
+
+// Options configura filas e buffer em disco dos sinks
+type Options struct {
+	BufferDir      string
+	BufferMaxBytes int64
+	QueueSize      int
+	EnqueueTimeout time.Duration
+}
+
+// Open cria o EventSink correspondente ao driver configurado
+func Open(cfg *eventsink.Sink) (eventsink.EventSink, error) {
+	switch cfg.Driver {
+	case eventsink.DriverNATS:
+		return NewNATSSink(cfg), nil
+	case eventsink.DriverAMQP:
+		return NewAMQPSink(cfg), nil
+	case eventsink.DriverKafka:
+		return NewKafkaSink(cfg), nil
+	}
+	return nil, eventsink.ErrUnknownDriver
+}
+
+// Dispatcher recebe os eventos do CompositeEventHandler e os distribui aos sinks.
+// Sinks com SessionID nulo são globais e recebem eventos de todas as sessões.
+type Dispatcher struct {
+	options Options
+	mu      sync.RWMutex
+	workers map[uuid.UUID]*worker
+	logger  *logger.Logger
+}
+
+// NewDispatcher cria um novo dispatcher de sinks
+func NewDispatcher(options Options) *Dispatcher {
+	if options.QueueSize <= 0 {
+		options.QueueSize = DefaultQueueSize
+	}
+	if options.EnqueueTimeout <= 0 {
+		options.EnqueueTimeout = DefaultEnqueueTimeout
+	}
+	return &Dispatcher{
+		options: options,
+		workers: make(map[uuid.UUID]*worker),
+		logger:  logger.Get(),
+	}
+}
+
+// Load inicia todos os sinks ativos persistidos
+func (d *Dispatcher) Load(ctx context.Context, repo eventsink.Repository) error {
+	sinks, err := repo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar sinks: %w", err)
+	}
+
+	for _, sink := range sinks {
+		if err := d.Apply(ctx, sink); err != nil {
+			d.logger.Error().Err(err).Str("sink_id", sink.ID.String()).Msg("Erro ao iniciar sink")
+		}
+	}
+
+	return nil
+}
+
+// Apply inicia ou reinicia o sink com a configuração informada
+func (d *Dispatcher) Apply(ctx context.Context, sink *eventsink.Sink) error {
+	if !sink.IsActive {
+		d.Remove(sink.ID)
+		return nil
+	}
+
+	target, err := Open(sink)
+	if err != nil {
+		return err
+	}
+
+	// Parar a instância anterior antes de reabrir o buffer, que ela pode ter acabado de gravar
+	d.Remove(sink.ID)
+
+	// O buffer é mantido entre reinícios e reativações do sink
+	buffer, err := NewDiskBuffer(filepath.Join(d.options.BufferDir, sink.ID.String()), d.options.BufferMaxBytes)
+	if err != nil {
+		_ = target.Close()
+		return err
+	}
+
+	w := newWorker(sink, target, buffer, d.options)
+	d.mu.Lock()
+	d.workers[sink.ID] = w
+	d.mu.Unlock()
+
+	go w.run()
+
+	d.logger.Info().
+		Str("sink_id", sink.ID.String()).
+		Str("sink_name", sink.Name).
+		Str("driver", string(sink.Driver)).
+		Int("buffered", buffer.Len()).
+		Msg("Sink de eventos iniciado")
+	return nil
+}
+
+// Remove para o sink, preservando os registros pendentes em disco
+func (d *Dispatcher) Remove(id uuid.UUID) {
+	d.mu.Lock()
+	w, ok := d.workers[id]
+	delete(d.workers, id)
+	d.mu.Unlock()
+
+	if ok {
+		w.shutdown()
+	}
+}
+
+// Close para todos os sinks
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	workers := d.workers
+	d.workers = make(map[uuid.UUID]*worker)
+	d.mu.Unlock()
+
+	for _, w := range workers {
+		w.shutdown()
+	}
+}
+
+// Publish implementa eventstream.Publisher
+func (d *Dispatcher) Publish(ctx context.Context, event *eventstream.Event) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, w := range d.workers {
+		if w.sink.SessionID != uuid.Nil && w.sink.SessionID != event.SessionID {
+			continue
+		}
+		if !w.sink.Accepts(event.Type) {
+			continue
+		}
+
+		subject := eventsink.RenderSubject(w.sink.Subject, event.SessionID, event.Type)
+		envelope := eventsink.NewEnvelope(event, subject)
+		payload, err := json.Marshal(envelope)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar envelope: %w", err)
+		}
+
+		w.enqueue(ctx, &eventsink.Record{
+			ID:        envelope.ID.String(),
+			Subject:   subject,
+			SessionID: event.SessionID.String(),
+			Type:      event.Type,
+			Payload:   payload,
+		})
+	}
+
+	return nil
+}
+
+// worker entrega os registros de um sink em ordem, usando o disco quando o broker falha
+type worker struct {
+	sink           *eventsink.Sink
+	target         eventsink.EventSink
+	buffer         *DiskBuffer
+	queue          chan *eventsink.Record
+	enqueueTimeout time.Duration
+	stop           chan struct{}
+	done           chan struct{}
+	logger         *logger.Logger
+}
+
+func newWorker(sink *eventsink.Sink, target eventsink.EventSink, buffer *DiskBuffer, options Options) *worker {
+	return &worker{
+		sink:           sink,
+		target:         target,
+		buffer:         buffer,
+		queue:          make(chan *eventsink.Record, options.QueueSize),
+		enqueueTimeout: options.EnqueueTimeout,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+		logger:         logger.Get(),
+	}
+}
+
+// enqueue aplica backpressure: espera por espaço na fila e, esgotado o prazo, grava em disco
+func (w *worker) enqueue(ctx context.Context, record *eventsink.Record) {
+	select {
+	case w.queue <- record:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(w.enqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case w.queue <- record:
+		return
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	w.spill(record)
+}
+
+// run envia primeiro os registros em disco e depois os da fila
+func (w *worker) run() {
+	defer close(w.done)
+
+	backoff := minRetryBackoff
+	for {
+		if w.buffer.Len() > 0 {
+			if w.drainOne() {
+				backoff = minRetryBackoff
+				continue
+			}
+			if !w.wait(backoff) {
+				return
+			}
+			backoff = min(backoff*2, maxRetryBackoff)
+			continue
+		}
+
+		select {
+		case <-w.stop:
+			return
+		case record := <-w.queue:
+			if err := w.send(record); err != nil {
+				w.logger.Warn().
+					Err(err).
+					Str("sink_id", w.sink.ID.String()).
+					Msg("Broker indisponível, registrando evento no buffer em disco")
+				w.spill(record)
+			}
+		}
+	}
+}
+
+// drainOne reenvia o registro mais antigo do disco e indica se houve sucesso
+func (w *worker) drainOne() bool {
+	record, err := w.buffer.Peek()
+	if err != nil {
+		// Registro ilegível não pode ser reenviado: descartar para não travar a fila
+		w.logger.Error().Err(err).Str("sink_id", w.sink.ID.String()).Msg("Descartando registro inválido do buffer")
+		_ = w.buffer.Pop()
+		return true
+	}
+	if record == nil {
+		return true
+	}
+
+	if err := w.send(record); err != nil {
+		w.logger.Debug().Err(err).Str("sink_id", w.sink.ID.String()).Int("buffered", w.buffer.Len()).Msg("Reenvio do buffer falhou")
+		return false
+	}
+
+	if err := w.buffer.Pop(); err != nil {
+		w.logger.Error().Err(err).Str("sink_id", w.sink.ID.String()).Msg("Erro ao remover registro do buffer")
+	}
+	return true
+}
+
+// wait aguarda o backoff movendo para o disco o que chegar na fila (preservando a ordem)
+func (w *worker) wait(backoff time.Duration) bool {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return false
+		case record := <-w.queue:
+			w.spill(record)
+		case <-timer.C:
+			return true
+		}
+	}
+}
+
+// send publica um registro com timeout
+func (w *worker) send(record *eventsink.Record) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := w.target.Publish(ctx, record)
+	metrics.ObserveDelivery(string(w.sink.Driver), err, time.Since(start))
+	return err
+}
+
+// spill grava o registro no buffer em disco
+func (w *worker) spill(record *eventsink.Record) {
+	if err := w.buffer.Push(record); err != nil {
+		w.logger.Error().
+			Err(err).
+			Str("sink_id", w.sink.ID.String()).
+			Str("subject", record.Subject).
+			Msg("Evento descartado: não foi possível gravar no buffer em disco")
+	}
+}
+
+// shutdown para o worker, persiste a fila pendente e fecha a conexão
+func (w *worker) shutdown() {
+	close(w.stop)
+	<-w.done
+
+	for {
+		select {
+		case record := <-w.queue:
+			w.spill(record)
+		default:
+			if err := w.target.Close(); err != nil {
+				w.logger.Warn().Err(err).Str("sink_id", w.sink.ID.String()).Msg("Erro ao fechar sink")
+			}
+			return
+		}
+	}
+}
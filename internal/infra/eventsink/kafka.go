@@ -0,0 +1,67 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/eventsink"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink publica eventos no Kafka usando a sessão como chave de partição
+type KafkaSink struct {
+	topic  string
+	writer *kafka.Writer
+}
+
+// NewKafkaSink cria um sink para Kafka a partir da lista de brokers
+func NewKafkaSink(cfg *eventsink.Sink) *KafkaSink {
+	brokers := strings.Split(cfg.URL, ",")
+	for i := range brokers {
+		brokers[i] = strings.TrimSpace(brokers[i])
+	}
+
+	return &KafkaSink{
+		topic: cfg.Option(eventsink.OptionKafkaTopic, ""),
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+			BatchTimeout:           10 * time.Millisecond,
+		},
+	}
+}
+
+// Publish publica o registro; sem tópico fixo o subject é usado como tópico
+func (s *KafkaSink) Publish(ctx context.Context, record *eventsink.Record) error {
+	topic := s.topic
+	if topic == "" {
+		topic = record.Subject
+	}
+
+	err := s.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(record.SessionID),
+		Value: record.Payload,
+		Headers: []kafka.Header{
+			{Key: "content-type", Value: []byte("application/json")},
+			{Key: "zapcore-subject", Value: []byte(record.Subject)},
+			{Key: "zapcore-event-type", Value: []byte(record.Type)},
+			{Key: "zapcore-event-id", Value: []byte(record.ID)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao publicar no Kafka: %w", err)
+	}
+
+	return nil
+}
+
+// Close descarrega e encerra o writer
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
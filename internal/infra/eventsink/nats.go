@@ -0,0 +1,120 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"zapcore/internal/domain/eventsink"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSSink publica eventos no NATS JetStream
+type NATSSink struct {
+	url     string
+	stream  string
+	subject string
+	mu      sync.Mutex
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+}
+
+// NewNATSSink cria um sink para NATS JetStream; a conexão é aberta no primeiro envio
+func NewNATSSink(cfg *eventsink.Sink) *NATSSink {
+	return &NATSSink{
+		url:     cfg.URL,
+		stream:  cfg.Option(eventsink.OptionNATSStream, ""),
+		subject: cfg.Subject,
+	}
+}
+
+// Publish publica o registro e aguarda a confirmação do JetStream
+func (s *NATSSink) Publish(ctx context.Context, record *eventsink.Record) error {
+	js, err := s.jetStream()
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(record.Subject)
+	msg.Data = record.Payload
+	msg.Header.Set("Content-Type", "application/json")
+	msg.Header.Set("Zapcore-Event-Type", record.Type)
+
+	// O Msg-Id permite que o JetStream descarte reenvios do buffer em disco
+	if _, err := js.PublishMsg(msg, nats.Context(ctx), nats.MsgId(record.ID)); err != nil {
+		return fmt.Errorf("erro ao publicar no NATS: %w", err)
+	}
+
+	return nil
+}
+
+// Close encerra a conexão com o NATS
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+		s.js = nil
+	}
+	return nil
+}
+
+// jetStream retorna o contexto JetStream, reconectando quando necessário
+func (s *NATSSink) jetStream() (nats.JetStreamContext, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil && !s.conn.IsClosed() {
+		return s.js, nil
+	}
+
+	conn, err := nats.Connect(s.url, nats.Name("zapcore"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar no NATS: %w", err)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("erro ao obter contexto JetStream: %w", err)
+	}
+
+	if s.stream != "" {
+		if err := s.ensureStream(js); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	s.conn = conn
+	s.js = js
+	return js, nil
+}
+
+// ensureStream cria o stream cobrindo os subjects do padrão configurado
+func (s *NATSSink) ensureStream(js nats.JetStreamContext) error {
+	_, err := js.StreamInfo(s.stream)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, nats.ErrStreamNotFound) {
+		return fmt.Errorf("erro ao consultar stream %s: %w", s.stream, err)
+	}
+
+	wildcard := strings.NewReplacer("{session}", "*", "{type}", "*").Replace(s.subject)
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     s.stream,
+		Subjects: []string{wildcard},
+		Storage:  nats.FileStorage,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao criar stream %s: %w", s.stream, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"zapcore/internal/domain/eventstream"
+	"zapcore/pkg/logger"
+)
+
+const (
+	// DefaultLogSize é a quantidade de eventos mantida para retomada
+	DefaultLogSize = 1000
+
+	// DefaultSubscriberBuffer é o tamanho do buffer de cada consumidor
+	DefaultSubscriberBuffer = 256
+)
+
+// Broker grava os eventos no log e os distribui para os consumidores conectados
+type Broker struct {
+	log         eventstream.Log
+	bufferSize  int
+	mu          sync.Mutex
+	subscribers map[*eventstream.Subscription]struct{}
+	logger      *logger.Logger
+}
+
+// NewBroker cria um novo broker de eventos
+func NewBroker(log eventstream.Log, bufferSize int) *Broker {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriberBuffer
+	}
+	return &Broker{
+		log:         log,
+		bufferSize:  bufferSize,
+		subscribers: make(map[*eventstream.Subscription]struct{}),
+		logger:      logger.Get(),
+	}
+}
+
+// Publish grava o evento e o entrega aos consumidores interessados
+func (b *Broker) Publish(ctx context.Context, event *eventstream.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.log.Append(ctx, event); err != nil {
+		return fmt.Errorf("erro ao gravar evento no log: %w", err)
+	}
+
+	for sub := range b.subscribers {
+		if !sub.Filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case sub.Events <- event:
+		default:
+			// Consumidor lento: encerrar para que ele retome pelo último ID
+			delete(b.subscribers, sub)
+			close(sub.Events)
+			b.logger.Warn().
+				Int64("event_id", event.Seq).
+				Msg("Consumidor de eventos desconectado por estar atrasado")
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registra um consumidor e carrega os eventos perdidos
+func (b *Broker) Subscribe(ctx context.Context, filter eventstream.Filter, lastEventID int64) (*eventstream.Subscription, error) {
+	sub := &eventstream.Subscription{
+		Filter: filter,
+		Events: make(chan *eventstream.Event, b.bufferSize),
+	}
+
+	// Registrar e ler o backlog sob o mesmo lock garante que nenhum
+	// evento fique entre o backlog e o canal
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if lastEventID > 0 {
+		backlog, err := b.log.Since(ctx, lastEventID, filter, 0)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar eventos anteriores: %w", err)
+		}
+		sub.Backlog = backlog
+	}
+
+	b.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// Unsubscribe remove o consumidor
+func (b *Broker) Unsubscribe(sub *eventstream.Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.Events)
+	}
+}
@@ -0,0 +1,65 @@
+package eventstream
+
+import (
+	"context"
+	"sync"
+
+	"zapcore/internal/domain/eventstream"
+)
+
+// MemoryLog mantém os últimos eventos em um buffer circular
+type MemoryLog struct {
+	mu       sync.RWMutex
+	events   []*eventstream.Event
+	capacity int
+	nextSeq  int64
+}
+
+// NewMemoryLog cria um log em memória com a capacidade informada
+func NewMemoryLog(capacity int) *MemoryLog {
+	if capacity <= 0 {
+		capacity = DefaultLogSize
+	}
+	return &MemoryLog{
+		events:   make([]*eventstream.Event, 0, capacity),
+		capacity: capacity,
+		nextSeq:  1,
+	}
+}
+
+// Append grava o evento descartando o mais antigo quando cheio
+func (l *MemoryLog) Append(_ context.Context, event *eventstream.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	event.Seq = l.nextSeq
+	l.nextSeq++
+
+	if len(l.events) == l.capacity {
+		copy(l.events, l.events[1:])
+		l.events[len(l.events)-1] = event
+		return nil
+	}
+
+	l.events = append(l.events, event)
+	return nil
+}
+
+// Since retorna os eventos posteriores ao Seq informado
+func (l *MemoryLog) Since(_ context.Context, afterSeq int64, filter eventstream.Filter, limit int) ([]*eventstream.Event, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make([]*eventstream.Event, 0)
+	for _, event := range l.events {
+		if event.Seq <= afterSeq || !filter.Matches(event) {
+			continue
+		}
+		result = append(result, event)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+
+	return result, nil
+}
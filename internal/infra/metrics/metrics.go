@@ -0,0 +1,259 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace é o prefixo de todas as métricas da aplicação
+const Namespace = "zapcore"
+
+// Direções das mensagens contabilizadas
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
+
+// Resultados de entregas de eventos
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Eventos de pareamento contabilizados
+const (
+	PairingQRCode    = "qr_code"
+	PairingQRTimeout = "qr_timeout"
+	PairingSuccess   = "pair_success"
+	PairingLoggedOut = "logged_out"
+)
+
+// Ações do governador de envio sobre uma mensagem
+const (
+	ThrottleDelayed  = "delayed"
+	ThrottleRejected = "rejected"
+)
+
+// registry é isolado do registry global para expor apenas as métricas da aplicação
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total de requisições HTTP por método, rota e status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latência das requisições HTTP por método, rota e classe de status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status_class"})
+
+	messages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "messages",
+		Name:      "total",
+		Help:      "Mensagens enviadas e recebidas por sessão e tipo.",
+	}, []string{"session", "direction", "type"})
+
+	sendDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "messages",
+		Name:      "send_duration_seconds",
+		Help:      "Latência do envio de mensagens ao WhatsApp, incluindo upload de mídia.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"type"})
+
+	sendFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "messages",
+		Name:      "send_failures_total",
+		Help:      "Falhas no envio de mensagens por tipo e classe de erro.",
+	}, []string{"type", "class"})
+
+	deliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "events",
+		Name:      "deliveries_total",
+		Help:      "Tentativas de entrega de eventos por transporte (webhook, nats, amqp, kafka) e resultado.",
+	}, []string{"transport", "result"})
+
+	deliveryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "events",
+		Name:      "delivery_duration_seconds",
+		Help:      "Latência das entregas de eventos por transporte.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"transport"})
+
+	storageUploadBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "storage",
+		Name:      "upload_bytes_total",
+		Help:      "Bytes enviados ao armazenamento de mídia.",
+	})
+
+	storageUploadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "storage",
+		Name:      "upload_duration_seconds",
+		Help:      "Duração dos uploads ao armazenamento de mídia por resultado.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"result"})
+
+	mediaDedup = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "storage",
+		Name:      "dedup_total",
+		Help:      "Mídias reaproveitadas (hit) ou gravadas pela primeira vez (miss), por destino: armazenamento ou WhatsApp.",
+	}, []string{"target", "result"})
+
+	mediaScans = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "media",
+		Name:      "scans_total",
+		Help:      "Verificações de malware das mídias recebidas, por driver e resultado.",
+	}, []string{"driver", "verdict"})
+
+	pairingEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "whatsapp",
+		Name:      "pairing_events_total",
+		Help:      "Eventos de QR Code e pareamento por tipo.",
+	}, []string{"event"})
+
+	sendThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "messages",
+		Name:      "throttled_total",
+		Help:      "Envios atrasados ou recusados pelos limites de envio das sessões, por motivo.",
+	}, []string{"reason", "action"})
+
+	rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_total",
+		Help:      "Requisições recusadas pelo rate limiting por política.",
+	}, []string{"policy"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		messages,
+		sendDuration,
+		sendFailures,
+		deliveries,
+		deliveryDuration,
+		storageUploadBytes,
+		storageUploadDuration,
+		mediaDedup,
+		mediaScans,
+		pairingEvents,
+		sendThrottled,
+		rateLimited,
+	)
+}
+
+// Handler retorna o handler HTTP no formato de exposição do Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// Register adiciona coletores ao registry da aplicação
+func Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterDBStats expõe as estatísticas do pool de conexões do banco
+func RegisterDBStats(db *sql.DB) error {
+	return Register(collectors.NewDBStatsCollector(db, Namespace))
+}
+
+// ObserveHTTPRequest registra uma requisição HTTP
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(method, route, strconv.Itoa(status/100)+"xx").Observe(duration.Seconds())
+}
+
+// IncMessage contabiliza uma mensagem enviada ou recebida
+func IncMessage(sessionID, direction, messageType string) {
+	messages.WithLabelValues(sessionID, direction, messageType).Inc()
+}
+
+// ObserveSend registra o resultado de um envio; class vazio indica sucesso
+func ObserveSend(messageType, class string, duration time.Duration) {
+	sendDuration.WithLabelValues(messageType).Observe(duration.Seconds())
+	if class != "" {
+		sendFailures.WithLabelValues(messageType, class).Inc()
+	}
+}
+
+// ObserveDelivery registra uma tentativa de entrega de evento
+func ObserveDelivery(transport string, err error, duration time.Duration) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailure
+	}
+	deliveries.WithLabelValues(transport, result).Inc()
+	deliveryDuration.WithLabelValues(transport).Observe(duration.Seconds())
+}
+
+// ObserveStorageUpload registra um upload ao armazenamento de mídia
+func ObserveStorageUpload(bytes int64, err error, duration time.Duration) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailure
+	} else {
+		storageUploadBytes.Add(float64(bytes))
+	}
+	storageUploadDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// IncMediaDedup contabiliza uma mídia reaproveitada ou gravada pela primeira vez; target é
+// "storage" para o armazenamento e "whatsapp" para os uploads ao WhatsApp
+func IncMediaDedup(target string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	mediaDedup.WithLabelValues(target, result).Inc()
+}
+
+// IncMediaScan contabiliza uma verificação de malware de mídia recebida
+func IncMediaScan(driver, verdict string) {
+	mediaScans.WithLabelValues(driver, verdict).Inc()
+}
+
+// IncPairingEvent contabiliza um evento de QR Code ou pareamento
+func IncPairingEvent(event string) {
+	pairingEvents.WithLabelValues(event).Inc()
+}
+
+// IncSendThrottled contabiliza um envio atrasado ou recusado pelos limites de envio da sessão
+func IncSendThrottled(reason, action string) {
+	sendThrottled.WithLabelValues(reason, action).Inc()
+}
+
+// IncRateLimited contabiliza uma requisição recusada pelo rate limiting
+func IncRateLimited(policy string) {
+	rateLimited.WithLabelValues(policy).Inc()
+}
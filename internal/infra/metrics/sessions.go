@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"zapcore/internal/domain/session"
+	"zapcore/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sessionScrapeTimeout limita a consulta feita a cada coleta
+const sessionScrapeTimeout = 5 * time.Second
+
+// SessionStatusCounter fornece a contagem de sessões por status
+type SessionStatusCounter interface {
+	CountByStatus(ctx context.Context) (map[session.WhatsAppSessionStatus]int, error)
+}
+
+// SessionCollector expõe a quantidade de sessões por status, consultada no momento da coleta
+type SessionCollector struct {
+	counter SessionStatusCounter
+	desc    *prometheus.Desc
+	logger  *logger.Logger
+}
+
+// NewSessionCollector cria um novo coletor de sessões
+func NewSessionCollector(counter SessionStatusCounter) *SessionCollector {
+	return &SessionCollector{
+		counter: counter,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "sessions", "total"),
+			"Sessões cadastradas por status.",
+			[]string{"status"},
+			nil,
+		),
+		logger: logger.Get(),
+	}
+}
+
+// Describe implementa prometheus.Collector
+func (c *SessionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implementa prometheus.Collector
+func (c *SessionCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionScrapeTimeout)
+	defer cancel()
+
+	counts, err := c.counter.CountByStatus(ctx)
+	if err != nil {
+		c.logger.Warn().Err(err).Msg("Erro ao contar sessões para métricas")
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	// Status conhecidos são sempre expostos, mesmo zerados, para não sumirem dos gráficos
+	for _, status := range []session.WhatsAppSessionStatus{
+		session.WhatsAppStatusDisconnected,
+		session.WhatsAppStatusConnecting,
+		session.WhatsAppStatusConnected,
+	} {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), string(status))
+	}
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"zapcore/internal/domain/ratelimit"
+)
+
+// pruneInterval define a cada quantas consultas os buckets cheios são descartados
+const pruneInterval = 1000
+
+// memoryBucket guarda o bucket e o momento a partir do qual ele estará cheio
+type memoryBucket struct {
+	bucket *ratelimit.Bucket
+	fullAt time.Time
+}
+
+// MemoryLimiter mantém os buckets na memória do processo: não é compartilhado entre
+// réplicas e recomeça a cada reinício
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	calls   int
+}
+
+// NewMemoryLimiter cria um limitador em memória
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+// Allow consome uma ficha do bucket da chave
+func (l *MemoryLimiter) Allow(_ context.Context, key string, policy ratelimit.Policy) (*ratelimit.Decision, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+	if l.calls%pruneInterval == 0 {
+		l.prune(now)
+	}
+
+	entry, ok := l.buckets[key]
+	if !ok {
+		entry = &memoryBucket{bucket: ratelimit.NewBucket(policy, now)}
+		l.buckets[key] = entry
+	}
+
+	decision := entry.bucket.Take(policy, now)
+	entry.fullAt = now.Add(decision.Reset)
+
+	return decision, nil
+}
+
+// prune descarta buckets que já encheram: recriá-los cheios não altera o limite
+func (l *MemoryLimiter) prune(now time.Time) {
+	for key, entry := range l.buckets {
+		if !now.Before(entry.fullAt) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"zapcore/internal/domain/ratelimit"
+	"zapcore/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix separa as chaves do limitador das demais chaves do Redis
+const keyPrefix = "zapcore:ratelimit:"
+
+// tokenBucketScript aplica o token bucket de forma atômica no Redis, usando o relógio do
+// próprio Redis para que réplicas com relógios diferentes compartilhem o mesmo bucket.
+// Retorna {permitido (0/1), fichas restantes}.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter compartilha os buckets entre réplicas pelo Redis. Enquanto o Redis estiver
+// indisponível, as requisições são limitadas por um limitador local em memória.
+type RedisLimiter struct {
+	client   *redis.Client
+	fallback *MemoryLimiter
+	degraded atomic.Bool
+	logger   *logger.Logger
+}
+
+// NewRedisLimiter cria um limitador sobre o cliente Redis informado
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{
+		client:   client,
+		fallback: NewMemoryLimiter(),
+		logger:   logger.Get(),
+	}
+}
+
+// Allow consome uma ficha do bucket da chave no Redis
+func (l *RedisLimiter) Allow(ctx context.Context, key string, policy ratelimit.Policy) (*ratelimit.Decision, error) {
+	result, err := tokenBucketScript.Run(ctx, l.client, []string{keyPrefix + key},
+		policy.Capacity(), policy.Rate(),
+	).Slice()
+	if err == nil {
+		var decision *ratelimit.Decision
+		decision, err = parseScriptResult(policy, result)
+		if err == nil {
+			if l.degraded.CompareAndSwap(true, false) {
+				l.logger.Info().Msg("Redis do rate limiting disponível novamente")
+			}
+			return decision, nil
+		}
+	}
+
+	// Registrar apenas a transição para não inundar o log a cada requisição
+	if l.degraded.CompareAndSwap(false, true) {
+		l.logger.Warn().Err(err).Msg("Redis do rate limiting indisponível, usando limites locais")
+	}
+	return l.fallback.Allow(ctx, key, policy)
+}
+
+// parseScriptResult converte o retorno do script em decisão
+func parseScriptResult(policy ratelimit.Policy, result []interface{}) (*ratelimit.Decision, error) {
+	if len(result) != 2 {
+		return nil, fmt.Errorf("resposta inesperada do script de rate limiting: %v", result)
+	}
+
+	allowed, ok := result[0].(int64)
+	if !ok {
+		return nil, fmt.Errorf("resposta inesperada do script de rate limiting: %v", result)
+	}
+
+	raw, ok := result[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("resposta inesperada do script de rate limiting: %v", result)
+	}
+
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler fichas do rate limiting: %w", err)
+	}
+
+	return ratelimit.NewDecision(policy, tokens, allowed == 1), nil
+}
+
+// Ping verifica a conexão com o Redis
+func (l *RedisLimiter) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	return l.client.Ping(ctx).Err()
+}
+
+// Close encerra a conexão com o Redis
+func (l *RedisLimiter) Close() error {
+	return l.client.Close()
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// APIKeyRepository implementa o repositório de chaves de API usando Bun ORM
+type APIKeyRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewAPIKeyRepository cria uma nova instância do repositório
+func NewAPIKeyRepository(db *bun.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Create cria uma nova chave de API
+func (r *APIKeyRepository) Create(ctx context.Context, key *apikey.APIKey) error {
+	// Garantir que timestamps estão definidos
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+	if key.UpdatedAt.IsZero() {
+		key.UpdatedAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(key).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("key_name", key.Name).Msg("Erro ao criar chave de API")
+		return fmt.Errorf("erro ao criar chave de API: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID busca uma chave pelo ID
+func (r *APIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*apikey.APIKey, error) {
+	key := new(apikey.APIKey)
+	err := r.db.NewSelect().
+		Model(key).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, apikey.ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar chave de API por ID: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetByHash busca uma chave pelo hash do segredo
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (*apikey.APIKey, error) {
+	key := new(apikey.APIKey)
+	err := r.db.NewSelect().
+		Model(key).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Where("? = ?", bun.Ident("keyHash"), hash).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, apikey.ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar chave de API por hash: %w", err)
+	}
+
+	return key, nil
+}
+
+// List retorna todas as chaves, incluindo as revogadas
+func (r *APIKeyRepository) List(ctx context.Context) ([]*apikey.APIKey, error) {
+	var keys []*apikey.APIKey
+
+	err := r.db.NewSelect().
+		Model(&keys).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		OrderExpr("? ASC", bun.Ident("createdAt")).
+		Scan(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Erro ao listar chaves de API")
+		return nil, fmt.Errorf("erro ao listar chaves de API: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Update atualiza uma chave existente
+func (r *APIKeyRepository) Update(ctx context.Context, key *apikey.APIKey) error {
+	key.UpdatedAt = time.Now()
+
+	result, err := r.db.NewUpdate().
+		Model(key).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Where("? = ?", bun.Ident("id"), key.ID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar chave de API: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apikey.ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastUsed registra o último uso da chave sem alterar os demais campos
+func (r *APIKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*apikey.APIKey)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Set("? = ?", bun.Ident("lastUsedAt"), usedAt).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar último uso da chave de API: %w", err)
+	}
+
+	return nil
+}
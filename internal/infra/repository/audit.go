@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/audit"
+	"zapcore/pkg/logger"
+
+	"github.com/uptrace/bun"
+)
+
+// AuditRepository implementa o repositório da trilha de auditoria usando Bun ORM
+type AuditRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewAuditRepository cria uma nova instância do repositório
+func NewAuditRepository(db *bun.DB) *AuditRepository {
+	return &AuditRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Create grava um novo registro de auditoria
+func (r *AuditRepository) Create(ctx context.Context, entry *audit.Entry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(entry).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao gravar registro de auditoria: %w", err)
+	}
+
+	return nil
+}
+
+// List retorna registros de auditoria com filtros, dos mais recentes para os mais antigos
+func (r *AuditRepository) List(ctx context.Context, filters audit.ListFilters) ([]*audit.Entry, error) {
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var entries []*audit.Entry
+	err := r.db.NewSelect().
+		Model(&entries).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		ApplyQueryBuilder(applyAuditFilters(filters)).
+		OrderExpr("? DESC, ? DESC", bun.Ident("createdAt"), bun.Ident("id")).
+		Limit(limit).
+		Offset(filters.Offset).
+		Scan(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Erro ao listar registros de auditoria")
+		return nil, fmt.Errorf("erro ao listar registros de auditoria: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Stream percorre os registros de auditoria em ordem cronológica, um por vez
+func (r *AuditRepository) Stream(ctx context.Context, filters audit.ListFilters, fn func(*audit.Entry) error) error {
+	rows, err := r.db.NewSelect().
+		Model((*audit.Entry)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		ApplyQueryBuilder(applyAuditFilters(filters)).
+		OrderExpr("? ASC, ? ASC", bun.Ident("createdAt"), bun.Ident("id")).
+		Rows(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao exportar registros de auditoria: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := new(audit.Entry)
+		if err := r.db.ScanRow(ctx, rows, entry); err != nil {
+			return fmt.Errorf("erro ao ler registro de auditoria: %w", err)
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao exportar registros de auditoria: %w", err)
+	}
+
+	return nil
+}
+
+// applyAuditFilters aplica os filtros de consulta comuns à listagem e à exportação
+func applyAuditFilters(filters audit.ListFilters) func(bun.QueryBuilder) bun.QueryBuilder {
+	return func(q bun.QueryBuilder) bun.QueryBuilder {
+		if filters.TenantID != "" {
+			q = q.Where("? = ?", bun.Ident("tenantId"), filters.TenantID)
+		}
+		if filters.Action != "" {
+			// Sem ponto, o filtro seleciona todas as ações do recurso (ex.: "session")
+			if strings.Contains(string(filters.Action), ".") {
+				q = q.Where("? = ?", bun.Ident("action"), filters.Action)
+			} else {
+				q = q.Where("? LIKE ?", bun.Ident("action"), string(filters.Action)+".%")
+			}
+		}
+		if filters.ActorName != "" {
+			q = q.Where("? = ?", bun.Ident("actorName"), filters.ActorName)
+		}
+		if filters.KeyID != nil {
+			q = q.Where("? = ?", bun.Ident("keyId"), *filters.KeyID)
+		}
+		if filters.SessionID != nil {
+			q = q.Where("? = ?", bun.Ident("sessionId"), *filters.SessionID)
+		}
+		if filters.ResourceID != "" {
+			q = q.Where("? = ?", bun.Ident("resourceId"), filters.ResourceID)
+		}
+		if filters.RequestID != "" {
+			q = q.Where("? = ?", bun.Ident("requestId"), filters.RequestID)
+		}
+		if filters.Outcome != "" {
+			q = q.Where("? = ?", bun.Ident("outcome"), filters.Outcome)
+		}
+		if filters.From != nil {
+			q = q.Where("? >= ?", bun.Ident("createdAt"), *filters.From)
+		}
+		if filters.To != nil {
+			q = q.Where("? < ?", bun.Ident("createdAt"), *filters.To)
+		}
+		return q
+	}
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/autoreply"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// AutoReplyRuleRepository implementa o repositório de regras usando Bun ORM
+type AutoReplyRuleRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewAutoReplyRuleRepository cria uma nova instância do repositório
+func NewAutoReplyRuleRepository(db *bun.DB) *AutoReplyRuleRepository {
+	return &AutoReplyRuleRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Create cria uma nova regra
+func (r *AutoReplyRuleRepository) Create(ctx context.Context, rule *autoreply.Rule) error {
+	// Garantir que timestamps estão definidos
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = time.Now()
+	}
+	if rule.UpdatedAt.IsZero() {
+		rule.UpdatedAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(rule).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("session_id", rule.SessionID.String()).Msg("Erro ao criar regra")
+		return fmt.Errorf("erro ao criar regra: %w", err)
+	}
+
+	r.logger.Info().
+		Str("rule_id", rule.ID.String()).
+		Str("session_id", rule.SessionID.String()).
+		Msg("Regra de resposta automática criada com sucesso")
+	return nil
+}
+
+// GetByID busca uma regra pelo ID
+func (r *AutoReplyRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*autoreply.Rule, error) {
+	rule := new(autoreply.Rule)
+	err := r.db.NewSelect().
+		Model(rule).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, autoreply.ErrRuleNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar regra por ID: %w", err)
+	}
+
+	return rule, nil
+}
+
+// ListBySession retorna as regras de uma sessão ordenadas por prioridade
+func (r *AutoReplyRuleRepository) ListBySession(ctx context.Context, sessionID uuid.UUID, onlyActive bool) ([]*autoreply.Rule, error) {
+	var rules []*autoreply.Rule
+
+	query := r.db.NewSelect().
+		Model(&rules).
+		Where("? = ?", bun.Ident("sessionId"), sessionID)
+
+	if onlyActive {
+		query = query.Where("? = ?", bun.Ident("isActive"), true)
+	}
+
+	err := query.
+		OrderExpr("? ASC, ? ASC", bun.Ident("priority"), bun.Ident("createdAt")).
+		Scan(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao listar regras")
+		return nil, fmt.Errorf("erro ao listar regras: %w", err)
+	}
+
+	return rules, nil
+}
+
+// Update atualiza uma regra existente
+func (r *AutoReplyRuleRepository) Update(ctx context.Context, rule *autoreply.Rule) error {
+	rule.UpdatedAt = time.Now()
+
+	result, err := r.db.NewUpdate().
+		Model(rule).
+		Where("? = ?", bun.Ident("id"), rule.ID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar regra: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return autoreply.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// Delete remove uma regra
+func (r *AutoReplyRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.NewDelete().
+		Model((*autoreply.Rule)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao deletar regra: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return autoreply.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// ConversationStateRepository implementa o repositório de estados de fluxo usando Bun ORM
+type ConversationStateRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewConversationStateRepository cria uma nova instância do repositório
+func NewConversationStateRepository(db *bun.DB) *ConversationStateRepository {
+	return &ConversationStateRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Get busca o estado do fluxo de um chat
+func (r *ConversationStateRepository) Get(ctx context.Context, sessionID uuid.UUID, chatJID string) (*autoreply.ConversationState, error) {
+	state := new(autoreply.ConversationState)
+	err := r.db.NewSelect().
+		Model(state).
+		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("chatJid"), chatJID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, autoreply.ErrStateNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar estado da conversa: %w", err)
+	}
+
+	return state, nil
+}
+
+// Save cria ou substitui o estado do fluxo de um chat
+func (r *ConversationStateRepository) Save(ctx context.Context, state *autoreply.ConversationState) error {
+	now := time.Now()
+	if state.ID == uuid.Nil {
+		state.ID = uuid.New()
+	}
+	if state.CreatedAt.IsZero() {
+		state.CreatedAt = now
+	}
+	state.UpdatedAt = now
+
+	_, err := r.db.NewInsert().
+		Model(state).
+		On(`CONFLICT ("sessionId", "chatJid") DO UPDATE`).
+		Set(`"step" = EXCLUDED."step"`).
+		Set(`"data" = EXCLUDED."data"`).
+		Set(`"expiresAt" = EXCLUDED."expiresAt"`).
+		Set(`"updatedAt" = EXCLUDED."updatedAt"`).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao salvar estado da conversa: %w", err)
+	}
+
+	return nil
+}
+
+// Delete remove o estado do fluxo de um chat
+func (r *ConversationStateRepository) Delete(ctx context.Context, sessionID uuid.UUID, chatJID string) error {
+	_, err := r.db.NewDelete().
+		Model((*autoreply.ConversationState)(nil)).
+		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("chatJid"), chatJID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao remover estado da conversa: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired remove estados expirados
+func (r *ConversationStateRepository) DeleteExpired(ctx context.Context) (int, error) {
+	result, err := r.db.NewDelete().
+		Model((*autoreply.ConversationState)(nil)).
+		Where("? < ?", bun.Ident("expiresAt"), time.Now()).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover estados expirados: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
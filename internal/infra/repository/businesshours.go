@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/businesshours"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BusinessHoursRepository implementa o repositório de calendários usando Bun ORM
+type BusinessHoursRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewBusinessHoursRepository cria uma nova instância do repositório
+func NewBusinessHoursRepository(db *bun.DB) *BusinessHoursRepository {
+	return &BusinessHoursRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// GetBySession busca o calendário de uma sessão
+func (r *BusinessHoursRepository) GetBySession(ctx context.Context, sessionID uuid.UUID) (*businesshours.Calendar, error) {
+	calendar := new(businesshours.Calendar)
+	err := r.db.NewSelect().
+		Model(calendar).
+		Where("? = ?", bun.Ident("sessionId"), sessionID).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, businesshours.ErrCalendarNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar horário comercial: %w", err)
+	}
+
+	return calendar, nil
+}
+
+// Save cria ou substitui o calendário de uma sessão
+func (r *BusinessHoursRepository) Save(ctx context.Context, calendar *businesshours.Calendar) error {
+	now := time.Now()
+	if calendar.ID == uuid.Nil {
+		calendar.ID = uuid.New()
+	}
+	if calendar.CreatedAt.IsZero() {
+		calendar.CreatedAt = now
+	}
+	calendar.UpdatedAt = now
+
+	_, err := r.db.NewInsert().
+		Model(calendar).
+		On(`CONFLICT ("sessionId") DO UPDATE`).
+		Set(`"timezone" = EXCLUDED."timezone"`).
+		Set(`"weekly" = EXCLUDED."weekly"`).
+		Set(`"holidays" = EXCLUDED."holidays"`).
+		Set(`"awayEnabled" = EXCLUDED."awayEnabled"`).
+		Set(`"awayMessage" = EXCLUDED."awayMessage"`).
+		Set(`"greetingEnabled" = EXCLUDED."greetingEnabled"`).
+		Set(`"greetingMessage" = EXCLUDED."greetingMessage"`).
+		Set(`"updatedAt" = EXCLUDED."updatedAt"`).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao salvar horário comercial: %w", err)
+	}
+
+	r.logger.Info().
+		Str("session_id", calendar.SessionID.String()).
+		Msg("Horário comercial salvo com sucesso")
+	return nil
+}
+
+// Delete remove o calendário de uma sessão
+func (r *BusinessHoursRepository) Delete(ctx context.Context, sessionID uuid.UUID) error {
+	result, err := r.db.NewDelete().
+		Model((*businesshours.Calendar)(nil)).
+		Where("? = ?", bun.Ident("sessionId"), sessionID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao remover horário comercial: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return businesshours.ErrCalendarNotFound
+	}
+
+	return nil
+}
+
+// AwayNoticeRepository implementa o controle de mensagens de ausência usando Bun ORM
+type AwayNoticeRepository struct {
+	db *bun.DB
+}
+
+// NewAwayNoticeRepository cria uma nova instância do repositório
+func NewAwayNoticeRepository(db *bun.DB) *AwayNoticeRepository {
+	return &AwayNoticeRepository{db: db}
+}
+
+// MarkSent registra o envio da mensagem de ausência de forma atômica:
+// o registro só é alterado quando a janela é diferente da última registrada
+func (r *AwayNoticeRepository) MarkSent(ctx context.Context, notice *businesshours.AwayNotice) (bool, error) {
+	if notice.ID == uuid.Nil {
+		notice.ID = uuid.New()
+	}
+	if notice.SentAt.IsZero() {
+		notice.SentAt = time.Now()
+	}
+
+	result, err := r.db.NewInsert().
+		Model(notice).
+		On(`CONFLICT ("sessionId", "chatJid") DO UPDATE`).
+		Set(`"windowKey" = EXCLUDED."windowKey"`).
+		Set(`"sentAt" = EXCLUDED."sentAt"`).
+		Where(`"an"."windowKey" <> EXCLUDED."windowKey"`).
+		Exec(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("erro ao registrar mensagem de ausência: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -54,6 +54,7 @@ func (r *ChatRepository) GetByID(ctx context.Context, id uuid.UUID) (*chat.Chat,
 	c := new(chat.Chat)
 	err := r.db.NewSelect().
 		Model(c).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"id" = ?`, id).
 		Scan(ctx)
 
@@ -72,6 +73,7 @@ func (r *ChatRepository) GetByJID(ctx context.Context, sessionID uuid.UUID, jid
 	c := new(chat.Chat)
 	err := r.db.NewSelect().
 		Model(c).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ? AND "jid" = ?`, sessionID, jid).
 		Scan(ctx)
 
@@ -91,6 +93,7 @@ func (r *ChatRepository) Update(ctx context.Context, c *chat.Chat) error {
 
 	result, err := r.db.NewUpdate().
 		Model(c).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"id" = ?`, c.ID).
 		Exec(ctx)
 
@@ -116,6 +119,7 @@ func (r *ChatRepository) Update(ctx context.Context, c *chat.Chat) error {
 func (r *ChatRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	result, err := r.db.NewDelete().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"id" = ?`, id).
 		Exec(ctx)
 
@@ -139,7 +143,7 @@ func (r *ChatRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // List retorna uma lista de chats com filtros
 func (r *ChatRepository) List(ctx context.Context, filters chat.ListFilters) ([]*chat.Chat, error) {
-	query := r.db.NewSelect().Model(&[]*chat.Chat{})
+	query := r.db.NewSelect().Model(&[]*chat.Chat{}).ApplyQueryBuilder(scopeBySessionTenant(ctx))
 
 	// Aplicar filtros se fornecidos
 	if filters.SessionID != nil {
@@ -173,6 +177,7 @@ func (r *ChatRepository) ListBySessionID(ctx context.Context, sessionID uuid.UUI
 
 	err := r.db.NewSelect().
 		Model(&chats).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ?`, sessionID).
 		OrderExpr(`CASE WHEN "isPinned" THEN 0 ELSE 1 END, "lastMessageTime" DESC NULLS LAST`).
 		Limit(limit).
@@ -193,6 +198,7 @@ func (r *ChatRepository) ListByType(ctx context.Context, sessionID uuid.UUID, ch
 
 	err := r.db.NewSelect().
 		Model(&chats).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ? AND "chatType" = ?`, sessionID, chatType).
 		OrderExpr(`"lastMessageTime" DESC NULLS LAST`).
 		Limit(limit).
@@ -216,6 +222,7 @@ func (r *ChatRepository) ListArchived(ctx context.Context, sessionID uuid.UUID,
 
 	err := r.db.NewSelect().
 		Model(&chats).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ? AND "isArchived" = ?`, sessionID, true).
 		OrderExpr(`"lastMessageTime" DESC NULLS LAST`).
 		Limit(limit).
@@ -236,6 +243,7 @@ func (r *ChatRepository) ListPinned(ctx context.Context, sessionID uuid.UUID) ([
 
 	err := r.db.NewSelect().
 		Model(&chats).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ? AND "isPinned" = ?`, sessionID, true).
 		OrderExpr(`"lastMessageTime" DESC NULLS LAST`).
 		Scan(ctx)
@@ -252,6 +260,7 @@ func (r *ChatRepository) ListPinned(ctx context.Context, sessionID uuid.UUID) ([
 func (r *ChatRepository) UpdateLastMessage(ctx context.Context, sessionID uuid.UUID, jid string, timestamp time.Time) error {
 	result, err := r.db.NewUpdate().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Set(`"lastMessageTime" = ?`, timestamp).
 		Set(`"updatedAt" = ?`, time.Now()).
 		Where(`"sessionId" = ? AND "jid" = ?`, sessionID, jid).
@@ -277,6 +286,7 @@ func (r *ChatRepository) UpdateLastMessage(ctx context.Context, sessionID uuid.U
 func (r *ChatRepository) IncrementMessageCount(ctx context.Context, sessionID uuid.UUID, jid string) error {
 	result, err := r.db.NewUpdate().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Set("messageCount = messageCount + 1").
 		Set(`"updatedAt" = ?`, time.Now()).
 		Where(`"sessionId" = ? AND "jid" = ?`, sessionID, jid).
@@ -302,6 +312,7 @@ func (r *ChatRepository) IncrementMessageCount(ctx context.Context, sessionID uu
 func (r *ChatRepository) MarkAsRead(ctx context.Context, sessionID uuid.UUID, jid string) error {
 	result, err := r.db.NewUpdate().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Set("\"unreadCount\" = ?", 0).
 		Set("\"updatedAt\" = ?", time.Now()).
 		Where("\"sessionId\" = ? AND \"jid\" = ?", sessionID, jid).
@@ -327,6 +338,7 @@ func (r *ChatRepository) MarkAsRead(ctx context.Context, sessionID uuid.UUID, ji
 func (r *ChatRepository) Count(ctx context.Context, sessionID uuid.UUID) (int64, error) {
 	count, err := r.db.NewSelect().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("\"sessionId\" = ?", sessionID).
 		Count(ctx)
 
@@ -341,6 +353,7 @@ func (r *ChatRepository) Count(ctx context.Context, sessionID uuid.UUID) (int64,
 func (r *ChatRepository) CountUnread(ctx context.Context, sessionID uuid.UUID) (int64, error) {
 	count, err := r.db.NewSelect().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ? AND "unreadCount" > ?`, sessionID, 0).
 		Count(ctx)
 
@@ -355,6 +368,7 @@ func (r *ChatRepository) CountUnread(ctx context.Context, sessionID uuid.UUID) (
 func (r *ChatRepository) ExistsByJID(ctx context.Context, sessionID uuid.UUID, jid string) (bool, error) {
 	exists, err := r.db.NewSelect().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("\"sessionId\" = ? AND \"jid\" = ?", sessionID, jid).
 		Exists(ctx)
 
@@ -369,6 +383,7 @@ func (r *ChatRepository) ExistsByJID(ctx context.Context, sessionID uuid.UUID, j
 func (r *ChatRepository) IncrementUnreadCount(ctx context.Context, sessionID uuid.UUID, jid string) error {
 	result, err := r.db.NewUpdate().
 		Model((*chat.Chat)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Set("unreadCount = unreadCount + 1").
 		Set(`"updatedAt" = ?`, time.Now()).
 		Where(`"sessionId" = ? AND "jid" = ?`, sessionID, jid).
@@ -55,6 +55,7 @@ func (r *ContactRepository) GetByID(ctx context.Context, id uuid.UUID) (*contact
 	c := new(contact.Contact)
 	err := r.db.NewSelect().
 		Model(c).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"id" = ?`, id).
 		Scan(ctx)
 
@@ -73,6 +74,7 @@ func (r *ContactRepository) GetByJID(ctx context.Context, sessionID uuid.UUID, j
 	c := new(contact.Contact)
 	err := r.db.NewSelect().
 		Model(c).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("jid"), jid).
 		Scan(ctx)
 
@@ -92,6 +94,7 @@ func (r *ContactRepository) Update(ctx context.Context, c *contact.Contact) erro
 
 	result, err := r.db.NewUpdate().
 		Model(c).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ?", bun.Ident("id"), c.ID).
 		Exec(ctx)
 
@@ -117,6 +120,7 @@ func (r *ContactRepository) Update(ctx context.Context, c *contact.Contact) erro
 func (r *ContactRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	result, err := r.db.NewDelete().
 		Model((*contact.Contact)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ?", bun.Ident("id"), id).
 		Exec(ctx)
 
@@ -140,7 +144,7 @@ func (r *ContactRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // List retorna uma lista de contatos com filtros
 func (r *ContactRepository) List(ctx context.Context, filters contact.ListFilters) ([]*contact.Contact, error) {
-	query := r.db.NewSelect().Model(&[]*contact.Contact{})
+	query := r.db.NewSelect().Model(&[]*contact.Contact{}).ApplyQueryBuilder(scopeBySessionTenant(ctx))
 
 	// Aplicar filtros se fornecidos
 	if filters.SessionID != nil {
@@ -174,6 +178,7 @@ func (r *ContactRepository) ListBySessionID(ctx context.Context, sessionID uuid.
 
 	err := r.db.NewSelect().
 		Model(&contacts).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ?", bun.Ident("sessionId"), sessionID).
 		OrderExpr("? ASC NULLS LAST", bun.Ident("pushName")).
 		Limit(limit).
@@ -194,6 +199,7 @@ func (r *ContactRepository) ListGroups(ctx context.Context, sessionID uuid.UUID,
 
 	err := r.db.NewSelect().
 		Model(&contacts).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("isGroup"), true).
 		OrderExpr("? ASC NULLS LAST", bun.Ident("pushName")).
 		Limit(limit).
@@ -212,12 +218,12 @@ func (r *ContactRepository) ListGroups(ctx context.Context, sessionID uuid.UUID,
 func (r *ContactRepository) ListBusiness(ctx context.Context, sessionID uuid.UUID, limit, offset int) ([]*contact.Contact, error) {
 	var contacts []*contact.Contact
 
-	// Buscar todos os contatos da sessão e filtrar no Go
 	err := r.db.NewSelect().
 		Model(&contacts).
-		Where("? = ?", bun.Ident("sessionId"), sessionID).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("isBusiness"), true).
 		OrderExpr("? ASC", bun.Ident("businessName")).
-		Limit(limit * 2). // Buscar mais para compensar filtro
+		Limit(limit).
 		Offset(offset).
 		Scan(ctx)
 
@@ -226,18 +232,7 @@ func (r *ContactRepository) ListBusiness(ctx context.Context, sessionID uuid.UUI
 		return nil, fmt.Errorf("erro ao listar contatos business: %w", err)
 	}
 
-	// Filtrar contatos business no Go
-	var businessContacts []*contact.Contact
-	for _, contact := range contacts {
-		if contact.BusinessName != "" {
-			businessContacts = append(businessContacts, contact)
-			if len(businessContacts) >= limit {
-				break
-			}
-		}
-	}
-
-	return businessContacts, nil
+	return contacts, nil
 }
 
 // SearchByQuery busca contatos por nome ou JID (método interno)
@@ -247,6 +242,7 @@ func (r *ContactRepository) SearchByQuery(ctx context.Context, sessionID uuid.UU
 	// Buscar todos os contatos da sessão
 	err := r.db.NewSelect().
 		Model(&allContacts).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ?`, sessionID).
 		OrderExpr(`"pushName" ASC NULLS LAST`).
 		Scan(ctx)
@@ -288,6 +284,7 @@ func (r *ContactRepository) SearchByQuery(ctx context.Context, sessionID uuid.UU
 func (r *ContactRepository) UpdateLastSeen(ctx context.Context, sessionID uuid.UUID, jid string, lastSeen time.Time) error {
 	result, err := r.db.NewUpdate().
 		Model((*contact.Contact)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Set("? = ?", bun.Ident("lastSeen"), lastSeen).
 		Set("? = ?", bun.Ident("updatedAt"), time.Now()).
 		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("jid"), jid).
@@ -313,6 +310,7 @@ func (r *ContactRepository) UpdateLastSeen(ctx context.Context, sessionID uuid.U
 func (r *ContactRepository) Count(ctx context.Context, sessionID uuid.UUID) (int64, error) {
 	count, err := r.db.NewSelect().
 		Model((*contact.Contact)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ?", bun.Ident("sessionId"), sessionID).
 		Count(ctx)
 
@@ -327,6 +325,7 @@ func (r *ContactRepository) Count(ctx context.Context, sessionID uuid.UUID) (int
 func (r *ContactRepository) CountGroups(ctx context.Context, sessionID uuid.UUID) (int64, error) {
 	count, err := r.db.NewSelect().
 		Model((*contact.Contact)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("isGroup"), true).
 		Count(ctx)
 
@@ -341,6 +340,7 @@ func (r *ContactRepository) CountGroups(ctx context.Context, sessionID uuid.UUID
 func (r *ContactRepository) ExistsByJID(ctx context.Context, sessionID uuid.UUID, jid string) (bool, error) {
 	exists, err := r.db.NewSelect().
 		Model((*contact.Contact)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ? AND "jid" = ?`, sessionID, jid).
 		Exists(ctx)
 
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"zapcore/internal/domain/eventstream"
+	"zapcore/pkg/logger"
+
+	"github.com/uptrace/bun"
+)
+
+// eventTrimInterval define a cada quantos eventos gravados o log é podado
+const eventTrimInterval = 100
+
+// EventLogRepository implementa o log limitado de eventos usando Bun ORM
+type EventLogRepository struct {
+	db       *bun.DB
+	capacity int
+	appended atomic.Int64
+	logger   *logger.Logger
+}
+
+// NewEventLogRepository cria uma nova instância do repositório mantendo
+// no máximo capacity eventos
+func NewEventLogRepository(db *bun.DB, capacity int) *EventLogRepository {
+	return &EventLogRepository{
+		db:       db,
+		capacity: capacity,
+		logger:   logger.Get(),
+	}
+}
+
+// Append grava o evento e define seu Seq
+func (r *EventLogRepository) Append(ctx context.Context, event *eventstream.Event) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(event).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao gravar evento: %w", err)
+	}
+
+	if r.appended.Add(1)%eventTrimInterval == 0 {
+		if err := r.trim(ctx); err != nil {
+			r.logger.Warn().Err(err).Msg("Erro ao podar log de eventos")
+		}
+	}
+
+	return nil
+}
+
+// Since retorna os eventos posteriores ao Seq informado
+func (r *EventLogRepository) Since(ctx context.Context, afterSeq int64, filter eventstream.Filter, limit int) ([]*eventstream.Event, error) {
+	var events []*eventstream.Event
+
+	query := r.db.NewSelect().
+		Model(&events).
+		Where("? > ?", bun.Ident("seq"), afterSeq).
+		Order("seq ASC")
+
+	if len(filter.SessionIDs) > 0 {
+		query = query.Where("? IN (?)", bun.Ident("sessionId"), bun.In(filter.SessionIDs))
+	}
+	if len(filter.Types) > 0 {
+		query = query.Where("? IN (?)", bun.Ident("type"), bun.In(filter.Types))
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao buscar eventos: %w", err)
+	}
+
+	return events, nil
+}
+
+// trim remove os eventos além da capacidade configurada
+func (r *EventLogRepository) trim(ctx context.Context) error {
+	if r.capacity <= 0 {
+		return nil
+	}
+
+	_, err := r.db.NewDelete().
+		Model((*eventstream.Event)(nil)).
+		Where("? <= (SELECT MAX(?) FROM ?) - ?",
+			bun.Ident("seq"), bun.Ident("seq"), bun.Ident("zapcore_events"), r.capacity).
+		Exec(ctx)
+
+	return err
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/eventsink"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// EventSinkRepository implementa o repositório de sinks de eventos usando Bun ORM
+type EventSinkRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewEventSinkRepository cria uma nova instância do repositório
+func NewEventSinkRepository(db *bun.DB) *EventSinkRepository {
+	return &EventSinkRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Create cria um novo sink
+func (r *EventSinkRepository) Create(ctx context.Context, sink *eventsink.Sink) error {
+	// Garantir que timestamps estão definidos
+	if sink.CreatedAt.IsZero() {
+		sink.CreatedAt = time.Now()
+	}
+	if sink.UpdatedAt.IsZero() {
+		sink.UpdatedAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(sink).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("session_id", sink.SessionID.String()).Msg("Erro ao criar sink")
+		return fmt.Errorf("erro ao criar sink: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID busca um sink pelo ID
+func (r *EventSinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*eventsink.Sink, error) {
+	sink := new(eventsink.Sink)
+	err := r.db.NewSelect().
+		Model(sink).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, eventsink.ErrSinkNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar sink por ID: %w", err)
+	}
+
+	return sink, nil
+}
+
+// ListBySession retorna os sinks de uma sessão
+func (r *EventSinkRepository) ListBySession(ctx context.Context, sessionID uuid.UUID) ([]*eventsink.Sink, error) {
+	var sinks []*eventsink.Sink
+
+	err := r.db.NewSelect().
+		Model(&sinks).
+		Where("? = ?", bun.Ident("sessionId"), sessionID).
+		OrderExpr("? ASC", bun.Ident("createdAt")).
+		Scan(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao listar sinks")
+		return nil, fmt.Errorf("erro ao listar sinks: %w", err)
+	}
+
+	return sinks, nil
+}
+
+// ListActive retorna todos os sinks ativos
+func (r *EventSinkRepository) ListActive(ctx context.Context) ([]*eventsink.Sink, error) {
+	var sinks []*eventsink.Sink
+
+	err := r.db.NewSelect().
+		Model(&sinks).
+		Where("? = ?", bun.Ident("isActive"), true).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar sinks ativos: %w", err)
+	}
+
+	return sinks, nil
+}
+
+// Update atualiza um sink existente
+func (r *EventSinkRepository) Update(ctx context.Context, sink *eventsink.Sink) error {
+	sink.UpdatedAt = time.Now()
+
+	result, err := r.db.NewUpdate().
+		Model(sink).
+		Where("? = ?", bun.Ident("id"), sink.ID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar sink: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return eventsink.ErrSinkNotFound
+	}
+
+	return nil
+}
+
+// Delete remove um sink
+func (r *EventSinkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.NewDelete().
+		Model((*eventsink.Sink)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao deletar sink: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return eventsink.ErrSinkNotFound
+	}
+
+	return nil
+}
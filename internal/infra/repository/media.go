@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/message"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// MediaBlobRepository implementa o repositório dos conteúdos de mídia endereçados pelo SHA-256 usando Bun ORM
+type MediaBlobRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewMediaBlobRepository cria uma nova instância do repositório
+func NewMediaBlobRepository(db *bun.DB) *MediaBlobRepository {
+	return &MediaBlobRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// AddReference soma uma referência ao conteúdo do tenant e retorna o registro atualizado. O
+// registro reservado pela coleta não é alterado e resulta em ErrBlobDeleting.
+func (r *MediaBlobRepository) AddReference(ctx context.Context, tenantID, sha256 string) (*media.Blob, error) {
+	blob := new(media.Blob)
+	err := r.db.NewUpdate().
+		Model(blob).
+		Set("? = ? + 1", bun.Ident("refCount"), bun.Ident("refCount")).
+		Set("? = ?", bun.Ident("updatedAt"), time.Now()).
+		Where("? = ?", bun.Ident("tenantId"), tenantID).
+		Where("? = ?", bun.Ident("sha256"), sha256).
+		Where("? >= 0", bun.Ident("refCount")).
+		Returning("*").
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, r.missingBlobError(ctx, tenantID, sha256)
+		}
+		return nil, fmt.Errorf("erro ao referenciar conteúdo de mídia: %w", err)
+	}
+
+	return blob, nil
+}
+
+// missingBlobError distingue o conteúdo ainda não gravado do conteúdo reservado pela coleta,
+// cujo objeto não pode voltar a ser gravado no mesmo path enquanto é removido
+func (r *MediaBlobRepository) missingBlobError(ctx context.Context, tenantID, sha256 string) error {
+	deleting, err := r.db.NewSelect().
+		Model((*media.Blob)(nil)).
+		Where("? = ?", bun.Ident("tenantId"), tenantID).
+		Where("? = ?", bun.Ident("sha256"), sha256).
+		Where("? = ?", bun.Ident("refCount"), media.BlobDeleting).
+		Exists(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao verificar conteúdo de mídia: %w", err)
+	}
+	if deleting {
+		return media.ErrBlobDeleting
+	}
+	return media.ErrBlobNotFound
+}
+
+// Create grava o conteúdo; em conflito com um upload simultâneo do mesmo conteúdo, soma as
+// referências ao registro existente. O registro reservado pela coleta não é alterado e resulta
+// em ErrBlobDeleting.
+func (r *MediaBlobRepository) Create(ctx context.Context, blob *media.Blob) (*media.Blob, error) {
+	stored := new(media.Blob)
+	err := r.db.NewInsert().
+		Model(blob).
+		On("CONFLICT (?, ?) DO UPDATE", bun.Ident("tenantId"), bun.Ident("sha256")).
+		Set("? = ? + EXCLUDED.?", bun.Ident("refCount"), bun.Ident("mb.refCount"), bun.Ident("refCount")).
+		Set("? = EXCLUDED.?", bun.Ident("updatedAt"), bun.Ident("updatedAt")).
+		Where("? >= 0", bun.Ident("mb.refCount")).
+		Returning("*").
+		Scan(ctx, stored)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, media.ErrBlobDeleting
+		}
+		r.logger.Error().Err(err).Str("tenant_id", blob.TenantID).Str("sha256", blob.SHA256).Msg("Erro ao registrar conteúdo de mídia")
+		return nil, fmt.Errorf("erro ao registrar conteúdo de mídia: %w", err)
+	}
+
+	return stored, nil
+}
+
+// ReleaseReference retira uma referência ao conteúdo; a remoção fica para a coleta dos
+// conteúdos sem referências
+func (r *MediaBlobRepository) ReleaseReference(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.NewUpdate().
+		Model((*media.Blob)(nil)).
+		Set("? = GREATEST(? - 1, 0)", bun.Ident("refCount"), bun.Ident("refCount")).
+		Set("? = ?", bun.Ident("updatedAt"), time.Now()).
+		Where("? = ?", bun.Ident("id"), id).
+		Where("? > 0", bun.Ident("refCount")).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao liberar conteúdo de mídia: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByPaths remove os registros dos conteúdos cujos objetos não existem mais, para que o
+// próximo armazenamento do mesmo conteúdo volte a gravá-lo
+func (r *MediaBlobRepository) DeleteByPaths(ctx context.Context, objectPaths []string) (int, error) {
+	if len(objectPaths) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.db.NewDelete().
+		Model((*media.Blob)(nil)).
+		Where("? IN (?)", bun.Ident("objectPath"), bun.In(objectPaths)).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover conteúdos de mídia ausentes: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// RecountReferences recalcula as referências pelas mensagens que apontam para cada conteúdo,
+// corrigindo as que ficaram para trás quando mensagens foram removidas junto com a sessão. Os
+// conteúdos reservados pela coleta continuam reservados.
+func (r *MediaBlobRepository) RecountReferences(ctx context.Context) error {
+	references := r.db.NewSelect().
+		Model((*message.Message)(nil)).
+		ColumnExpr("COUNT(*)").
+		Where("? = ?", bun.Ident("m.mediaId"), bun.Ident("mb.id"))
+
+	_, err := r.db.NewUpdate().
+		Model((*media.Blob)(nil)).
+		Set("? = (?)", bun.Ident("refCount"), references).
+		Set("? = ?", bun.Ident("updatedAt"), time.Now()).
+		Where("? <> (?)", bun.Ident("mb.refCount"), references).
+		Where("? >= 0", bun.Ident("mb.refCount")).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao recalcular referências dos conteúdos de mídia: %w", err)
+	}
+
+	return nil
+}
+
+// ListUnreferenced retorna os conteúdos sem referências, dos mais antigos para os mais recentes
+func (r *MediaBlobRepository) ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]*media.Blob, error) {
+	var blobs []*media.Blob
+	query := r.db.NewSelect().
+		Model(&blobs).
+		Where("? <= 0", bun.Ident("refCount")).
+		Where("? < ?", bun.Ident("updatedAt"), before).
+		OrderExpr("? ASC", bun.Ident("updatedAt"))
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao listar conteúdos de mídia sem referências: %w", err)
+	}
+
+	return blobs, nil
+}
+
+// ClaimUnreferenced marca o conteúdo como em remoção apenas se nenhuma mensagem voltou a
+// referenciá-lo. Os registros marcados não recebem referências de AddReference nem de Create, o
+// que impede que um novo armazenamento do mesmo conteúdo aponte para o objeto sendo removido.
+func (r *MediaBlobRepository) ClaimUnreferenced(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.db.NewUpdate().
+		Model((*media.Blob)(nil)).
+		Set("? = ?", bun.Ident("refCount"), media.BlobDeleting).
+		Where("? = ?", bun.Ident("id"), id).
+		Where("? <= 0", bun.Ident("refCount")).
+		Exec(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("erro ao reservar conteúdo de mídia para remoção: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteClaimed remove o registro reservado pela coleta, depois da remoção do objeto
+func (r *MediaBlobRepository) DeleteClaimed(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.NewDelete().
+		Model((*media.Blob)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Where("? = ?", bun.Ident("refCount"), media.BlobDeleting).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao remover conteúdo de mídia: %w", err)
+	}
+
+	return nil
+}
+
+// ListMediaPaths retorna os caminhos dos conteúdos registrados; os sem referências são removidos
+// pela coleta dos conteúdos, não pela varredura de objetos órfãos
+func (r *MediaBlobRepository) ListMediaPaths(ctx context.Context) ([]string, error) {
+	var paths []string
+	err := r.db.NewSelect().
+		Model((*media.Blob)(nil)).
+		Column("objectPath").
+		Scan(ctx, &paths)
+
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar conteúdos de mídia: %w", err)
+	}
+
+	return paths, nil
+}
+
+// MediaUploadRepository implementa o repositório dos uploads feitos ao WhatsApp usando Bun ORM
+type MediaUploadRepository struct {
+	db *bun.DB
+}
+
+// NewMediaUploadRepository cria uma nova instância do repositório
+func NewMediaUploadRepository(db *bun.DB) *MediaUploadRepository {
+	return &MediaUploadRepository{db: db}
+}
+
+// Get busca o upload do conteúdo do tenant para o tipo de mídia
+func (r *MediaUploadRepository) Get(ctx context.Context, tenantID, sha256, mediaType string) (*media.Upload, error) {
+	upload := new(media.Upload)
+	err := r.db.NewSelect().
+		Model(upload).
+		Where("? = ?", bun.Ident("tenantId"), tenantID).
+		Where("? = ?", bun.Ident("sha256"), sha256).
+		Where("? = ?", bun.Ident("mediaType"), mediaType).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, media.ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar upload de mídia: %w", err)
+	}
+
+	return upload, nil
+}
+
+// Save grava o upload, substituindo o anterior do mesmo tenant, conteúdo e tipo de mídia
+func (r *MediaUploadRepository) Save(ctx context.Context, upload *media.Upload) error {
+	_, err := r.db.NewInsert().
+		Model(upload).
+		On("CONFLICT (?, ?, ?) DO UPDATE", bun.Ident("tenantId"), bun.Ident("sha256"), bun.Ident("mediaType")).
+		Set("? = EXCLUDED.?", bun.Ident("url"), bun.Ident("url")).
+		Set("? = EXCLUDED.?", bun.Ident("directPath"), bun.Ident("directPath")).
+		Set("? = EXCLUDED.?", bun.Ident("mediaKey"), bun.Ident("mediaKey")).
+		Set("? = EXCLUDED.?", bun.Ident("fileEncSha256"), bun.Ident("fileEncSha256")).
+		Set("? = EXCLUDED.?", bun.Ident("fileLength"), bun.Ident("fileLength")).
+		Set("? = EXCLUDED.?", bun.Ident("uploadedAt"), bun.Ident("uploadedAt")).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao gravar upload de mídia: %w", err)
+	}
+
+	return nil
+}
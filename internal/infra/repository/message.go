@@ -30,6 +30,7 @@ func NewMessageRepository(db *bun.DB) *MessageRepository {
 func (r *MessageRepository) ExistsByMsgID(ctx context.Context, msgID string) (bool, error) {
 	exists, err := r.db.NewSelect().
 		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"msgId" = ?`, msgID).
 		Exists(ctx)
 
@@ -44,6 +45,7 @@ func (r *MessageRepository) ExistsByMsgID(ctx context.Context, msgID string) (bo
 func (r *MessageRepository) ExistsByMsgIDAndSessionID(ctx context.Context, msgID string, sessionID uuid.UUID) (bool, error) {
 	exists, err := r.db.NewSelect().
 		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"msgId" = ? AND "sessionId" = ?`, msgID, sessionID).
 		Exists(ctx)
 
@@ -87,6 +89,7 @@ func (r *MessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*message
 	msg := new(message.Message)
 	err := r.db.NewSelect().
 		Model(msg).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"id" = ?`, id).
 		Scan(ctx)
 
@@ -105,6 +108,7 @@ func (r *MessageRepository) GetByMessageID(ctx context.Context, messageID string
 	msg := new(message.Message)
 	err := r.db.NewSelect().
 		Model(msg).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"msgId" = ?`, messageID).
 		Scan(ctx)
 
@@ -118,12 +122,33 @@ func (r *MessageRepository) GetByMessageID(ctx context.Context, messageID string
 	return msg, nil
 }
 
+// GetBySessionMsgID busca uma mensagem da sessão pelo MessageID do WhatsApp
+func (r *MessageRepository) GetBySessionMsgID(ctx context.Context, sessionID uuid.UUID, msgID string) (*message.Message, error) {
+	msg := new(message.Message)
+	err := r.db.NewSelect().
+		Model(msg).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Where(`"sessionId" = ? AND "msgId" = ?`, sessionID, msgID).
+		Limit(1).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, message.ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar mensagem da sessão por messageID: %w", err)
+	}
+
+	return msg, nil
+}
+
 // Update atualiza uma mensagem
 func (r *MessageRepository) Update(ctx context.Context, msg *message.Message) error {
 	msg.UpdatedAt = time.Now()
 
 	result, err := r.db.NewUpdate().
 		Model(msg).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ?", bun.Ident("id"), msg.ID).
 		Exec(ctx)
 
@@ -149,6 +174,7 @@ func (r *MessageRepository) Update(ctx context.Context, msg *message.Message) er
 func (r *MessageRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	result, err := r.db.NewDelete().
 		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ?", bun.Ident("id"), id).
 		Exec(ctx)
 
@@ -176,6 +202,7 @@ func (r *MessageRepository) ListBySessionID(ctx context.Context, sessionID uuid.
 
 	err := r.db.NewSelect().
 		Model(&messages).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ?`, sessionID).
 		OrderExpr(`"timestamp" DESC`).
 		Limit(limit).
@@ -196,6 +223,7 @@ func (r *MessageRepository) ListByChatJID(ctx context.Context, sessionID uuid.UU
 
 	err := r.db.NewSelect().
 		Model(&messages).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("chatJid"), chatJID).
 		OrderExpr("? DESC", bun.Ident("timestamp")).
 		Limit(limit).
@@ -217,6 +245,7 @@ func (r *MessageRepository) ListByChatJID(ctx context.Context, sessionID uuid.UU
 func (r *MessageRepository) CountBySessionID(ctx context.Context, sessionID uuid.UUID) (int64, error) {
 	count, err := r.db.NewSelect().
 		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where(`"sessionId" = ?`, sessionID).
 		Count(ctx)
 
@@ -232,6 +261,7 @@ func (r *MessageRepository) CountBySessionID(ctx context.Context, sessionID uuid
 func (r *MessageRepository) UpdateStatus(ctx context.Context, messageID string, status message.MessageStatus) error {
 	result, err := r.db.NewUpdate().
 		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Set("? = ?", bun.Ident("status"), status).
 		Set("? = ?", bun.Ident("updatedAt"), time.Now()).
 		Where("? = ?", bun.Ident("msgId"), messageID).
@@ -255,10 +285,218 @@ func (r *MessageRepository) UpdateStatus(ctx context.Context, messageID string,
 	return nil
 }
 
+// UpdateMediaInfo grava apenas as informações de mídia da mensagem, sem sobrescrever o status
+// atualizado pelas confirmações recebidas no meio-tempo
+func (r *MessageRepository) UpdateMediaInfo(ctx context.Context, msg *message.Message) error {
+	msg.UpdatedAt = time.Now()
+
+	result, err := r.db.NewUpdate().
+		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Set("? = ?", bun.Ident("mediaId"), msg.MediaID).
+		Set("? = ?", bun.Ident("mediaPath"), msg.MediaPath).
+		Set("? = ?", bun.Ident("mediaSize"), msg.MediaSize).
+		Set("? = ?", bun.Ident("mediaMimeType"), msg.MediaMimeType).
+		Set("? = ?", bun.Ident("mediaFileName"), msg.MediaFileName).
+		Set("? = ?", bun.Ident("mediaError"), msg.MediaError).
+		Set("? = ?", bun.Ident("mediaExpiredAt"), msg.MediaExpiredAt).
+		Set("? = ?", bun.Ident("mediaScanVerdict"), msg.MediaScanVerdict).
+		Set("? = ?", bun.Ident("mediaScanSignature"), msg.MediaScanSignature).
+		Set("? = ?", bun.Ident("mediaScannedAt"), msg.MediaScannedAt).
+		Set("? = ?", bun.Ident("mediaQuarantined"), msg.MediaQuarantined).
+		Set("? = ?", bun.Ident("updatedAt"), msg.UpdatedAt).
+		Where("? = ?", bun.Ident("id"), msg.ID).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("message_id", msg.MsgID).Msg("Erro ao atualizar mídia da mensagem")
+		return fmt.Errorf("erro ao atualizar mídia da mensagem: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return message.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// RecordMediaAttempt grava o número de tentativas de download da mídia, o horário da última e o
+// motivo da falha
+func (r *MessageRepository) RecordMediaAttempt(ctx context.Context, msg *message.Message) error {
+	result, err := r.db.NewUpdate().
+		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Set("? = ?", bun.Ident("mediaAttempts"), msg.MediaAttempts).
+		Set("? = ?", bun.Ident("mediaAttemptAt"), msg.MediaAttemptAt).
+		Set("? = ?", bun.Ident("mediaError"), msg.MediaError).
+		Where("? = ?", bun.Ident("id"), msg.ID).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("message_id", msg.MsgID).Msg("Erro ao registrar tentativa de download da mídia")
+		return fmt.Errorf("erro ao registrar tentativa de download da mídia: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return message.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// ListMissingMedia retorna as mensagens de mídia sem caminho no armazenamento, das mais recentes
+// para as mais antigas
+func (r *MessageRepository) ListMissingMedia(ctx context.Context, filter message.MissingMediaFilter) ([]*message.Message, error) {
+	var messages []*message.Message
+
+	query := r.db.NewSelect().
+		Model(&messages).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Where("COALESCE(?, '') = ''", bun.Ident("mediaPath")).
+		Where("? IS NULL", bun.Ident("mediaExpiredAt")).
+		Where("? IN (?)", bun.Ident("messageType"), bun.In(message.MediaMessageTypes))
+
+	if len(filter.SessionIDs) > 0 {
+		query = query.Where("? IN (?)", bun.Ident("sessionId"), bun.In(filter.SessionIDs))
+	}
+	if filter.MaxAttempts > 0 {
+		query = query.Where("? < ?", bun.Ident("mediaAttempts"), filter.MaxAttempts)
+	}
+	if !filter.AttemptBefore.IsZero() {
+		query = query.Where("(? IS NULL OR ? < ?)", bun.Ident("mediaAttemptAt"), bun.Ident("mediaAttemptAt"), filter.AttemptBefore)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("? >= ?", bun.Ident("timestamp"), filter.Since)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.OrderExpr("? DESC", bun.Ident("timestamp")).Scan(ctx); err != nil {
+		r.logger.Error().Err(err).Msg("Erro ao buscar mensagens com mídia pendente")
+		return nil, fmt.Errorf("erro ao buscar mensagens com mídia pendente: %w", err)
+	}
+
+	return messages, nil
+}
+
+// ListStoredMedia retorna as mensagens da sessão com mídia armazenada, das mais antigas para as
+// mais recentes
+func (r *MessageRepository) ListStoredMedia(ctx context.Context, filter message.StoredMediaFilter) ([]*message.Message, error) {
+	var messages []*message.Message
+
+	query := r.db.NewSelect().
+		Model(&messages).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Where("? = ?", bun.Ident("sessionId"), filter.SessionID).
+		Where("COALESCE(?, '') <> ''", bun.Ident("mediaPath")).
+		Where("? IN (?)", bun.Ident("messageType"), bun.In(filter.MessageTypes))
+
+	if filter.Direction != "" {
+		query = query.Where("? = ?", bun.Ident("direction"), filter.Direction)
+	}
+	if !filter.Before.IsZero() {
+		query = query.Where("? < ?", bun.Ident("timestamp"), filter.Before)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.OrderExpr("? ASC", bun.Ident("timestamp")).Scan(ctx); err != nil {
+		r.logger.Error().Err(err).Str("session_id", filter.SessionID.String()).Msg("Erro ao buscar mensagens com mídia armazenada")
+		return nil, fmt.Errorf("erro ao buscar mensagens com mídia armazenada: %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkMediaMissing desvincula das mensagens os objetos que não existem mais no armazenamento,
+// devolvendo-as ao download das mídias pendentes
+func (r *MessageRepository) MarkMediaMissing(ctx context.Context, objectPaths []string) (int, error) {
+	if len(objectPaths) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.db.NewUpdate().
+		Model((*message.Message)(nil)).
+		Set("? = ''", bun.Ident("mediaPath")).
+		Set("? = NULL", bun.Ident("mediaId")).
+		Set("? = ?", bun.Ident("mediaError"), "objeto ausente no armazenamento").
+		Set("? = 0", bun.Ident("mediaAttempts")).
+		Set("? = NULL", bun.Ident("mediaAttemptAt")).
+		Set("? = ?", bun.Ident("updatedAt"), time.Now()).
+		Where("? IN (?)", bun.Ident("mediaPath"), bun.In(objectPaths)).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("erro ao marcar mídias ausentes: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// UpdateSessionStatus atualiza o status de uma mensagem da sessão pelo MessageID do WhatsApp. O
+// status só avança: a mensagem já em um status igual ou posterior é mantida sem erro.
+func (r *MessageRepository) UpdateSessionStatus(ctx context.Context, sessionID uuid.UUID, msgID string, status message.MessageStatus) error {
+	query := r.db.NewUpdate().
+		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Set("? = ?", bun.Ident("status"), status).
+		Set("? = ?", bun.Ident("updatedAt"), time.Now()).
+		Where("? = ?", bun.Ident("sessionId"), sessionID).
+		Where("? = ?", bun.Ident("msgId"), msgID)
+	if before := message.StatusesBefore(status); before != nil {
+		query = query.Where("? IN (?)", bun.Ident("status"), bun.In(before))
+	}
+
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar status da mensagem: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		exists, err := r.db.NewSelect().
+			Model((*message.Message)(nil)).
+			ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+			Where("? = ?", bun.Ident("sessionId"), sessionID).
+			Where("? = ?", bun.Ident("msgId"), msgID).
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao verificar mensagem: %w", err)
+		}
+		if !exists {
+			return message.ErrMessageNotFound
+		}
+	}
+
+	return nil
+}
+
 // CountByStatus conta mensagens por status
 func (r *MessageRepository) CountByStatus(ctx context.Context, sessionID uuid.UUID, status message.MessageStatus) (int, error) {
 	count, err := r.db.NewSelect().
 		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("status"), status).
 		Count(ctx)
 
@@ -275,7 +513,7 @@ func (r *MessageRepository) CountByStatus(ctx context.Context, sessionID uuid.UU
 
 // List retorna mensagens com filtros opcionais
 func (r *MessageRepository) List(ctx context.Context, filters message.ListFilters) ([]*message.Message, error) {
-	query := r.db.NewSelect().Model(&[]*message.Message{})
+	query := r.db.NewSelect().Model(&[]*message.Message{}).ApplyQueryBuilder(scopeBySessionTenant(ctx))
 
 	// Aplicar filtros
 	if filters.SessionID != nil {
@@ -319,6 +557,7 @@ func (r *MessageRepository) GetPendingMessages(ctx context.Context, sessionID uu
 
 	err := r.db.NewSelect().
 		Model(&messages).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
 		Where("? = ? AND ? = ?", bun.Ident("sessionId"), sessionID, bun.Ident("status"), message.MessageStatusPending).
 		OrderExpr("? ASC", bun.Ident("timestamp")).
 		Scan(ctx)
@@ -330,3 +569,22 @@ func (r *MessageRepository) GetPendingMessages(ctx context.Context, sessionID uu
 
 	return messages, nil
 }
+
+// ListMediaPaths retorna os caminhos de mídia referenciados por mensagens
+func (r *MessageRepository) ListMediaPaths(ctx context.Context) ([]string, error) {
+	var paths []string
+
+	err := r.db.NewSelect().
+		Model((*message.Message)(nil)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Distinct().
+		Column("mediaPath").
+		Where("? IS NOT NULL AND ? <> ''", bun.Ident("mediaPath"), bun.Ident("mediaPath")).
+		Scan(ctx, &paths)
+
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar mídias das mensagens: %w", err)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"zapcore/internal/domain/ratelimit"
+	"zapcore/pkg/logger"
+
+	"github.com/uptrace/bun"
+)
+
+// rateLimitPruneInterval define a cada quantas consultas os buckets cheios são removidos
+const rateLimitPruneInterval = 1000
+
+// rateLimitAvailable calcula as fichas disponíveis: as restantes mais a reposição desde a
+// última consulta, limitadas à capacidade (?1 = capacidade, ?2 = fichas por segundo)
+const rateLimitAvailable = `LEAST(?1, "rl"."tokens" + GREATEST(0, EXTRACT(EPOCH FROM (now() - "rl"."updatedAt"))) * ?2)`
+
+// rateLimitRemaining são as fichas que restam após consumir uma, quando disponível
+const rateLimitRemaining = rateLimitAvailable + ` - (` + rateLimitAvailable + ` >= 1)::int`
+
+// rateLimitQuery aplica o token bucket em uma única instrução atômica. Buckets novos começam
+// cheios e já consomem a primeira ficha; expiresAt marca quando o bucket estará cheio de novo.
+const rateLimitQuery = `
+INSERT INTO "zapcore_rate_limits" AS "rl" ("key", "tokens", "allowed", "updatedAt", "expiresAt")
+VALUES (?0, ?1 - 1, true, now(), now() + make_interval(secs => 1 / ?2))
+ON CONFLICT ("key") DO UPDATE SET
+    "allowed" = ` + rateLimitAvailable + ` >= 1,
+    "tokens" = ` + rateLimitRemaining + `,
+    "updatedAt" = now(),
+    "expiresAt" = now() + make_interval(secs => (?1 - (` + rateLimitRemaining + `)) / ?2)
+RETURNING "tokens", "allowed"`
+
+// RateLimitRepository implementa o limitador de requisições sobre o Postgres, compartilhado
+// entre as réplicas que usam o mesmo banco
+type RateLimitRepository struct {
+	db     *bun.DB
+	calls  atomic.Int64
+	logger *logger.Logger
+}
+
+// NewRateLimitRepository cria uma nova instância do repositório
+func NewRateLimitRepository(db *bun.DB) *RateLimitRepository {
+	return &RateLimitRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Allow consome uma ficha do bucket da chave
+func (r *RateLimitRepository) Allow(ctx context.Context, key string, policy ratelimit.Policy) (*ratelimit.Decision, error) {
+	var tokens float64
+	var allowed bool
+
+	err := r.db.NewRaw(rateLimitQuery, key, policy.Capacity(), policy.Rate()).
+		Scan(ctx, &tokens, &allowed)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar rate limiting: %w", err)
+	}
+
+	if r.calls.Add(1)%rateLimitPruneInterval == 0 {
+		if err := r.prune(ctx); err != nil {
+			r.logger.Warn().Err(err).Msg("Erro ao remover buckets de rate limiting expirados")
+		}
+	}
+
+	return ratelimit.NewDecision(policy, tokens, allowed), nil
+}
+
+// prune remove buckets que já encheram: recriá-los cheios não altera o limite
+func (r *RateLimitRepository) prune(ctx context.Context) error {
+	_, err := r.db.NewDelete().
+		TableExpr("?", bun.Ident("zapcore_rate_limits")).
+		Where("? < now()", bun.Ident("expiresAt")).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao remover buckets de rate limiting: %w", err)
+	}
+	return nil
+}
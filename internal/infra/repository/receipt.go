@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/message"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ReceiptRepository implementa o repositório de confirmações por destinatário usando Bun ORM
+type ReceiptRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewReceiptRepository cria uma nova instância do repositório
+func NewReceiptRepository(db *bun.DB) *ReceiptRepository {
+	return &ReceiptRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Record grava as confirmações; a primeira confirmação de cada destinatário e tipo é mantida
+func (r *ReceiptRepository) Record(ctx context.Context, receipts []*message.Receipt) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&receipts).
+		On("CONFLICT (?, ?, ?, ?) DO NOTHING",
+			bun.Ident("sessionId"), bun.Ident("msgId"), bun.Ident("recipientJid"), bun.Ident("receiptType")).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao gravar confirmações da mensagem: %w", err)
+	}
+
+	return nil
+}
+
+// ListByMessage retorna as confirmações de uma mensagem da sessão, da mais antiga para a mais recente
+func (r *ReceiptRepository) ListByMessage(ctx context.Context, sessionID uuid.UUID, msgID string) ([]*message.Receipt, error) {
+	var receipts []*message.Receipt
+	err := r.db.NewSelect().
+		Model(&receipts).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Where("? = ?", bun.Ident("sessionId"), sessionID).
+		Where("? = ?", bun.Ident("msgId"), msgID).
+		OrderExpr("? ASC, ? ASC", bun.Ident("timestamp"), bun.Ident("recipientJid")).
+		Scan(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("message_id", msgID).Msg("Erro ao listar confirmações da mensagem")
+		return nil, fmt.Errorf("erro ao listar confirmações da mensagem: %w", err)
+	}
+
+	return receipts, nil
+}
+
+// Summarize conta os destinatários distintos de uma mensagem por confirmação. Leitura implica
+// recebimento e ouvir implica leitura, mesmo quando a confirmação anterior não chegou. Expected
+// vem da contagem de destinatários registrada na mensagem.
+func (r *ReceiptRepository) Summarize(ctx context.Context, sessionID uuid.UUID, msgID string) (*message.ReceiptSummary, error) {
+	summary := new(message.ReceiptSummary)
+	err := r.db.NewSelect().
+		Model((*message.Receipt)(nil)).
+		ColumnExpr("COUNT(DISTINCT ?) AS recipients", bun.Ident("recipientJid")).
+		ColumnExpr("COUNT(DISTINCT ?) AS delivered", bun.Ident("recipientJid")).
+		ColumnExpr("COUNT(DISTINCT ?) FILTER (WHERE ? IN (?, ?)) AS read_by",
+			bun.Ident("recipientJid"), bun.Ident("receiptType"), message.ReceiptTypeRead, message.ReceiptTypePlayed).
+		ColumnExpr("COUNT(DISTINCT ?) FILTER (WHERE ? = ?) AS played_by",
+			bun.Ident("recipientJid"), bun.Ident("receiptType"), message.ReceiptTypePlayed).
+		ColumnExpr("COALESCE((?), 0) AS expected", r.db.NewSelect().
+			Model((*message.Message)(nil)).
+			Column("recipientCount").
+			Where("? = ?", bun.Ident("sessionId"), sessionID).
+			Where("? = ?", bun.Ident("msgId"), msgID).
+			Limit(1)).
+		ApplyQueryBuilder(scopeBySessionTenant(ctx)).
+		Where("? = ?", bun.Ident("sessionId"), sessionID).
+		Where("? = ?", bun.Ident("msgId"), msgID).
+		Scan(ctx, &summary.Recipients, &summary.Delivered, &summary.Read, &summary.Played, &summary.Expected)
+
+	if err != nil {
+		return nil, fmt.Errorf("erro ao resumir confirmações da mensagem: %w", err)
+	}
+
+	return summary, nil
+}
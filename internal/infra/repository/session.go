@@ -53,6 +53,7 @@ func (r *SessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*session
 	sess := new(session.Session)
 	err := r.db.NewSelect().
 		Model(sess).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where(`"id" = ?`, id).
 		Scan(ctx)
 
@@ -71,6 +72,7 @@ func (r *SessionRepository) GetByName(ctx context.Context, name string) (*sessio
 	sess := new(session.Session)
 	err := r.db.NewSelect().
 		Model(sess).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where("? = ?", bun.Ident("name"), name).
 		Scan(ctx)
 
@@ -89,6 +91,7 @@ func (r *SessionRepository) GetByJID(ctx context.Context, jid string) (*session.
 	sess := new(session.Session)
 	err := r.db.NewSelect().
 		Model(sess).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where(`"jid" = ?`, jid).
 		Scan(ctx)
 
@@ -106,9 +109,12 @@ func (r *SessionRepository) GetByJID(ctx context.Context, jid string) (*session.
 func (r *SessionRepository) List(ctx context.Context, filters session.ListFilters) ([]*session.Session, error) {
 	var sessions []*session.Session
 
-	query := r.db.NewSelect().Model(&sessions)
+	query := r.db.NewSelect().Model(&sessions).ApplyQueryBuilder(scopeByTenant(ctx))
 
 	// Aplicar filtros
+	if len(filters.IDs) > 0 {
+		query = query.Where("? IN (?)", bun.Ident("id"), bun.In(filters.IDs))
+	}
 	if filters.Status != nil {
 		query = query.Where(`"status" = ?`, *filters.Status)
 	}
@@ -173,6 +179,7 @@ func (r *SessionRepository) ListActive(ctx context.Context) ([]*session.Session,
 
 	err := r.db.NewSelect().
 		Model(&sessions).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where(`"isActive" = ?`, true).
 		OrderExpr(`"createdAt" DESC`).
 		Scan(ctx)
@@ -191,6 +198,7 @@ func (r *SessionRepository) ListByStatus(ctx context.Context, status session.Wha
 
 	err := r.db.NewSelect().
 		Model(&sessions).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where(`"status" = ?`, status).
 		OrderExpr(`"createdAt" DESC`).
 		Scan(ctx)
@@ -209,6 +217,7 @@ func (r *SessionRepository) Update(ctx context.Context, sess *session.Session) e
 
 	result, err := r.db.NewUpdate().
 		Model(sess).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where("? = ?", bun.Ident("id"), sess.ID).
 		Exec(ctx)
 
@@ -233,6 +242,7 @@ func (r *SessionRepository) Update(ctx context.Context, sess *session.Session) e
 func (r *SessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	result, err := r.db.NewDelete().
 		Model((*session.Session)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where("? = ?", bun.Ident("id"), id).
 		Exec(ctx)
 
@@ -258,6 +268,7 @@ func (r *SessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 func (r *SessionRepository) UpdateStatus(ctx context.Context, sessionID uuid.UUID, status session.WhatsAppSessionStatus) error {
 	result, err := r.db.NewUpdate().
 		Model((*session.Session)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Set(`"status" = ?`, status).
 		Set(`"updatedAt" = ?`, time.Now()).
 		Where(`"id" = ?`, sessionID).
@@ -288,6 +299,7 @@ func (r *SessionRepository) UpdateStatus(ctx context.Context, sessionID uuid.UUI
 func (r *SessionRepository) UpdateJID(ctx context.Context, sessionID uuid.UUID, jid string) error {
 	result, err := r.db.NewUpdate().
 		Model((*session.Session)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Set(`"jid" = ?`, jid).
 		Set(`"updatedAt" = ?`, time.Now()).
 		Where(`"id" = ?`, sessionID).
@@ -319,6 +331,7 @@ func (r *SessionRepository) UpdateLastSeen(ctx context.Context, sessionID uuid.U
 	now := time.Now()
 	result, err := r.db.NewUpdate().
 		Model((*session.Session)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Set("? = ?", bun.Ident("lastSeen"), now).
 		Set("? = ?", bun.Ident("updatedAt"), now).
 		Where("? = ?", bun.Ident("id"), sessionID).
@@ -344,6 +357,7 @@ func (r *SessionRepository) UpdateLastSeen(ctx context.Context, sessionID uuid.U
 func (r *SessionRepository) Count(ctx context.Context) (int64, error) {
 	count, err := r.db.NewSelect().
 		Model((*session.Session)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Count(ctx)
 
 	if err != nil {
@@ -353,10 +367,37 @@ func (r *SessionRepository) Count(ctx context.Context) (int64, error) {
 	return int64(count), nil
 }
 
+// CountByStatus conta as sessões agrupadas por status
+func (r *SessionRepository) CountByStatus(ctx context.Context) (map[session.WhatsAppSessionStatus]int, error) {
+	var rows []struct {
+		Status session.WhatsAppSessionStatus `bun:"status"`
+		Count  int                           `bun:"count"`
+	}
+
+	err := r.db.NewSelect().
+		Model((*session.Session)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Column("status").
+		ColumnExpr("count(*) AS count").
+		Group("status").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao contar sessões por status: %w", err)
+	}
+
+	counts := make(map[session.WhatsAppSessionStatus]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
 // GetActiveCount retorna o número de sessões ativas
 func (r *SessionRepository) GetActiveCount(ctx context.Context) (int, error) {
 	count, err := r.db.NewSelect().
 		Model((*session.Session)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
 		Where("? = ?", bun.Ident("isActive"), true).
 		Count(ctx)
 
@@ -367,7 +408,7 @@ func (r *SessionRepository) GetActiveCount(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-// ExistsByName verifica se uma sessão existe pelo nome
+// ExistsByName verifica se uma sessão existe pelo nome em qualquer tenant
 func (r *SessionRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
 	exists, err := r.db.NewSelect().
 		Model((*session.Session)(nil)).
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/template"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// TemplateRepository implementa o repositório de templates usando Bun ORM
+type TemplateRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewTemplateRepository cria uma nova instância do repositório
+func NewTemplateRepository(db *bun.DB) *TemplateRepository {
+	return &TemplateRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// Create cria um novo template
+func (r *TemplateRepository) Create(ctx context.Context, tmpl *template.Template) error {
+	// Garantir que timestamps estão definidos
+	if tmpl.CreatedAt.IsZero() {
+		tmpl.CreatedAt = time.Now()
+	}
+	if tmpl.UpdatedAt.IsZero() {
+		tmpl.UpdatedAt = time.Now()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(tmpl).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("template_name", tmpl.Name).Msg("Erro ao criar template")
+		return fmt.Errorf("erro ao criar template: %w", err)
+	}
+
+	r.logger.Info().
+		Str("template_id", tmpl.ID.String()).
+		Str("tenant_id", tmpl.TenantID).
+		Msg("Template criado com sucesso")
+	return nil
+}
+
+// GetByID busca um template pelo ID
+func (r *TemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*template.Template, error) {
+	tmpl := new(template.Template)
+	err := r.db.NewSelect().
+		Model(tmpl).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, template.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar template por ID: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// GetByName busca um template pelo nome dentro de um tenant
+func (r *TemplateRepository) GetByName(ctx context.Context, tenantID, name string) (*template.Template, error) {
+	tmpl := new(template.Template)
+	err := r.db.NewSelect().
+		Model(tmpl).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Where("? = ? AND ? = ?", bun.Ident("tenantId"), tenantID, bun.Ident("name"), name).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, template.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar template por nome: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// List retorna templates com filtros opcionais
+func (r *TemplateRepository) List(ctx context.Context, filters template.ListFilters) ([]*template.Template, error) {
+	var templates []*template.Template
+
+	query := r.db.NewSelect().Model(&templates).ApplyQueryBuilder(scopeByTenant(ctx))
+
+	// Aplicar filtros
+	if filters.TenantID != "" {
+		query = query.Where("? = ?", bun.Ident("tenantId"), filters.TenantID)
+	}
+	if filters.Language != "" {
+		query = query.Where("? = ?", bun.Ident("language"), filters.Language)
+	}
+
+	// Definir limite padrão
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	err := query.
+		OrderExpr("? ASC", bun.Ident("name")).
+		Limit(limit).
+		Offset(filters.Offset).
+		Scan(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Erro ao listar templates")
+		return nil, fmt.Errorf("erro ao listar templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Update atualiza um template existente
+func (r *TemplateRepository) Update(ctx context.Context, tmpl *template.Template) error {
+	tmpl.UpdatedAt = time.Now()
+
+	result, err := r.db.NewUpdate().
+		Model(tmpl).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Where("? = ?", bun.Ident("id"), tmpl.ID).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("template_id", tmpl.ID.String()).Msg("Erro ao atualizar template")
+		return fmt.Errorf("erro ao atualizar template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return template.ErrTemplateNotFound
+	}
+
+	r.logger.Info().Str("template_id", tmpl.ID.String()).Msg("Template atualizado com sucesso")
+	return nil
+}
+
+// Delete remove um template
+func (r *TemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.NewDelete().
+		Model((*template.Template)(nil)).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("template_id", id.String()).Msg("Erro ao deletar template")
+		return fmt.Errorf("erro ao deletar template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return template.ErrTemplateNotFound
+	}
+
+	r.logger.Info().Str("template_id", id.String()).Msg("Template deletado com sucesso")
+	return nil
+}
+
+// ListMediaPaths retorna os caminhos de mídia referenciados por templates e suas variantes
+func (r *TemplateRepository) ListMediaPaths(ctx context.Context) ([]string, error) {
+	var templates []*template.Template
+
+	err := r.db.NewSelect().
+		Model(&templates).
+		ApplyQueryBuilder(scopeByTenant(ctx)).
+		Column("mediaPath", "variants").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar mídias dos templates: %w", err)
+	}
+
+	var paths []string
+	for _, tmpl := range templates {
+		if tmpl.MediaPath != "" {
+			paths = append(paths, tmpl.MediaPath)
+		}
+		for _, variant := range tmpl.Variants {
+			if variant.MediaPath != "" {
+				paths = append(paths, variant.MediaPath)
+			}
+		}
+	}
+
+	return paths, nil
+}
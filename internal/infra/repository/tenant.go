@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+
+	"github.com/uptrace/bun"
+)
+
+// TenantRepository implementa o repositório de tenants e de consumo usando Bun ORM
+type TenantRepository struct {
+	db     *bun.DB
+	logger *logger.Logger
+}
+
+// NewTenantRepository cria uma nova instância do repositório
+func NewTenantRepository(db *bun.DB) *TenantRepository {
+	return &TenantRepository{
+		db:     db,
+		logger: logger.Get(),
+	}
+}
+
+// tenantUsage representa o contador diário de mensagens de um tenant
+type tenantUsage struct {
+	bun.BaseModel `bun:"table:zapcore_tenant_usage,alias:tu"`
+
+	TenantID     string `bun:"tenantId,pk,type:varchar(100)"`
+	Day          string `bun:"day,pk,type:date"` // AAAA-MM-DD, sem conversão de fuso pelo banco
+	MessagesSent int    `bun:"messagesSent,type:integer,notnull"`
+}
+
+// Create cria um novo tenant
+func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) error {
+	// Garantir que timestamps estão definidos
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = time.Now()
+	}
+
+	result, err := r.db.NewInsert().
+		Model(t).
+		On("CONFLICT (?) DO NOTHING", bun.Ident("id")).
+		Exec(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Str("tenant_id", t.ID).Msg("Erro ao criar tenant")
+		return fmt.Errorf("erro ao criar tenant: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return tenant.ErrTenantAlreadyExists
+	}
+
+	return nil
+}
+
+// GetByID busca um tenant pelo ID
+func (r *TenantRepository) GetByID(ctx context.Context, id string) (*tenant.Tenant, error) {
+	t := new(tenant.Tenant)
+	err := r.db.NewSelect().
+		Model(t).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return nil, tenant.ErrTenantNotFound
+		}
+		return nil, fmt.Errorf("erro ao buscar tenant por ID: %w", err)
+	}
+
+	return t, nil
+}
+
+// List retorna todos os tenants
+func (r *TenantRepository) List(ctx context.Context) ([]*tenant.Tenant, error) {
+	var tenants []*tenant.Tenant
+
+	err := r.db.NewSelect().
+		Model(&tenants).
+		OrderExpr("? ASC", bun.Ident("createdAt")).
+		Scan(ctx)
+
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Erro ao listar tenants")
+		return nil, fmt.Errorf("erro ao listar tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// Update atualiza um tenant existente
+func (r *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) error {
+	t.UpdatedAt = time.Now()
+
+	result, err := r.db.NewUpdate().
+		Model(t).
+		Where("? = ?", bun.Ident("id"), t.ID).
+		Exec(ctx)
+
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar tenant: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return tenant.ErrTenantNotFound
+	}
+
+	return nil
+}
+
+// Delete remove um tenant sem sessões nem chaves de API, junto com seus contadores
+func (r *TenantRepository) Delete(ctx context.Context, id string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		sessions, err := tx.NewSelect().
+			Model((*session.Session)(nil)).
+			Where("? = ?", bun.Ident("tenantId"), id).
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao verificar sessões do tenant: %w", err)
+		}
+
+		keys, err := tx.NewSelect().
+			Model((*apikey.APIKey)(nil)).
+			Where("? = ?", bun.Ident("tenantId"), id).
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao verificar chaves de API do tenant: %w", err)
+		}
+
+		if sessions || keys {
+			return tenant.ErrTenantNotEmpty
+		}
+
+		if _, err := tx.NewDelete().
+			Model((*tenantUsage)(nil)).
+			Where("? = ?", bun.Ident("tenantId"), id).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("erro ao remover consumo do tenant: %w", err)
+		}
+
+		result, err := tx.NewDelete().
+			Model((*tenant.Tenant)(nil)).
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao deletar tenant: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return tenant.ErrTenantNotFound
+		}
+
+		return nil
+	})
+}
+
+// CountSessions retorna o número de sessões do tenant
+func (r *TenantRepository) CountSessions(ctx context.Context, tenantID string) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*session.Session)(nil)).
+		Where("? = ?", bun.Ident("tenantId"), tenantID).
+		Count(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("erro ao contar sessões do tenant: %w", err)
+	}
+
+	return count, nil
+}
+
+// StorageBytes soma o tamanho das mídias armazenadas das sessões do tenant
+func (r *TenantRepository) StorageBytes(ctx context.Context, tenantID string) (int64, error) {
+	var total int64
+
+	err := r.db.NewSelect().
+		Model((*message.Message)(nil)).
+		ColumnExpr("COALESCE(SUM(?), 0)", bun.Ident("mediaSize")).
+		Where("? IS NOT NULL AND ? <> ''", bun.Ident("mediaPath"), bun.Ident("mediaPath")).
+		ApplyQueryBuilder(scopeBySessionTenant(tenant.WithID(ctx, tenantID))).
+		Scan(ctx, &total)
+
+	if err != nil {
+		return 0, fmt.Errorf("erro ao calcular armazenamento do tenant: %w", err)
+	}
+
+	return total, nil
+}
+
+// MessagesSent retorna quantas mensagens o tenant enviou no dia
+func (r *TenantRepository) MessagesSent(ctx context.Context, tenantID string, day time.Time) (int, error) {
+	usage := new(tenantUsage)
+	err := r.db.NewSelect().
+		Model(usage).
+		Column("messagesSent").
+		Where("? = ? AND ? = ?", bun.Ident("tenantId"), tenantID, bun.Ident("day"), day.Format(time.DateOnly)).
+		Scan(ctx)
+
+	if err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("erro ao buscar consumo do tenant: %w", err)
+	}
+
+	return usage.MessagesSent, nil
+}
+
+// IncrementMessages soma delta ao contador diário de forma atômica e retorna o novo total
+func (r *TenantRepository) IncrementMessages(ctx context.Context, tenantID string, day time.Time, delta int) (int, error) {
+	usage := &tenantUsage{TenantID: tenantID, Day: day.Format(time.DateOnly), MessagesSent: delta}
+
+	err := r.db.NewInsert().
+		Model(usage).
+		On("CONFLICT (?, ?) DO UPDATE", bun.Ident("tenantId"), bun.Ident("day")).
+		Set("? = ?.? + EXCLUDED.?", bun.Ident("messagesSent"), bun.Ident("tu"), bun.Ident("messagesSent"), bun.Ident("messagesSent")).
+		Returning("?", bun.Ident("messagesSent")).
+		Scan(ctx, &usage.MessagesSent)
+
+	if err != nil {
+		return 0, fmt.Errorf("erro ao contabilizar mensagens do tenant: %w", err)
+	}
+
+	return usage.MessagesSent, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"zapcore/internal/domain/tenant"
+
+	"github.com/uptrace/bun"
+)
+
+// scopeByTenant restringe a consulta às linhas do tenant do contexto pela coluna "tenantId".
+// Sem tenant no contexto (chave mestre ou processos internos) a consulta não é alterada.
+func scopeByTenant(ctx context.Context) func(bun.QueryBuilder) bun.QueryBuilder {
+	return func(q bun.QueryBuilder) bun.QueryBuilder {
+		tenantID, ok := tenant.IDFromContext(ctx)
+		if !ok {
+			return q
+		}
+		return q.Where("? = ?", bun.Ident("tenantId"), tenantID)
+	}
+}
+
+// scopeBySessionTenant restringe a consulta às linhas cujas sessões ("sessionId") pertencem ao tenant do contexto
+func scopeBySessionTenant(ctx context.Context) func(bun.QueryBuilder) bun.QueryBuilder {
+	return func(q bun.QueryBuilder) bun.QueryBuilder {
+		tenantID, ok := tenant.IDFromContext(ctx)
+		if !ok {
+			return q
+		}
+		return q.Where("? IN (SELECT ? FROM ? WHERE ? = ?)",
+			bun.Ident("sessionId"), bun.Ident("id"), bun.Ident("zapcore_sessions"), bun.Ident("tenantId"), tenantID)
+	}
+}
@@ -0,0 +1,133 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/media"
+)
+
+// clamdChunkSize é o tamanho dos blocos enviados ao clamd; o StreamMaxLength do clamd limita
+// o total, não os blocos
+const clamdChunkSize = 64 * 1024
+
+// ClamdScanner verifica as mídias no daemon do ClamAV pelo comando INSTREAM
+type ClamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamdScanner cria um scanner para o clamd em tcp://host:porta ou unix:///caminho; um
+// endereço sem esquema é tratado como TCP
+func NewClamdScanner(address string, timeout time.Duration) (*ClamdScanner, error) {
+	network, addr := "tcp", address
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("endereço do clamd inválido: %w", err)
+		}
+		switch u.Scheme {
+		case "tcp":
+			addr = u.Host
+		case "unix":
+			network, addr = "unix", u.Path
+		default:
+			return nil, fmt.Errorf("esquema do endereço do clamd inválido: %s (use tcp ou unix)", u.Scheme)
+		}
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("endereço do clamd não informado")
+	}
+
+	return &ClamdScanner{network: network, address: addr, timeout: timeout}, nil
+}
+
+// Name retorna o nome do driver
+func (s *ClamdScanner) Name() string {
+	return DriverClamd
+}
+
+// Scan envia o conteúdo em blocos prefixados pelo tamanho (big-endian) e interpreta a resposta:
+// "stream: OK", "stream: <assinatura> FOUND" ou "<mensagem> ERROR"
+func (s *ClamdScanner) Scan(ctx context.Context, content io.Reader, size int64) (*media.ScanResult, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar no clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("erro ao configurar o prazo do clamd: %w", err)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, fmt.Errorf("erro ao enviar comando ao clamd: %w", err)
+	}
+
+	buf := make([]byte, clamdChunkSize)
+	header := make([]byte, 4)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(header, uint32(n))
+			if _, err := conn.Write(header); err != nil {
+				return nil, fmt.Errorf("erro ao enviar conteúdo ao clamd: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return nil, fmt.Errorf("erro ao enviar conteúdo ao clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("erro ao ler conteúdo da mídia: %w", readErr)
+		}
+	}
+
+	// Bloco de tamanho zero encerra o stream
+	binary.BigEndian.PutUint32(header, 0)
+	if _, err := conn.Write(header); err != nil {
+		return nil, fmt.Errorf("erro ao finalizar envio ao clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("erro ao ler resposta do clamd: %w", err)
+	}
+
+	return parseClamdReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamdReply interpreta a resposta do INSTREAM
+func parseClamdReply(reply string) (*media.ScanResult, error) {
+	result := &media.ScanResult{Scanner: DriverClamd}
+	status := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case status == "OK":
+		result.Verdict = media.ScanVerdictClean
+	case strings.HasSuffix(status, " FOUND"):
+		result.Verdict = media.ScanVerdictInfected
+		result.Signature = strings.TrimSpace(strings.TrimSuffix(status, " FOUND"))
+	case strings.HasSuffix(status, " ERROR"):
+		return nil, fmt.Errorf("clamd recusou a verificação: %s", strings.TrimSuffix(status, " ERROR"))
+	default:
+		return nil, fmt.Errorf("resposta inesperada do clamd: %q", reply)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"zapcore/internal/domain/media"
+)
+
+// HTTPScanner envia as mídias a um serviço de verificação genérico. O conteúdo vai no corpo de
+// um POST (application/octet-stream) e a resposta deve ser {"verdict":"clean|infected",
+// "signature":"..."}; qualquer status diferente de 2xx é tratado como falha da verificação.
+type HTTPScanner struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// httpScanResponse representa a resposta do serviço de verificação
+type httpScanResponse struct {
+	Verdict   string `json:"verdict"`
+	Signature string `json:"signature"`
+}
+
+// NewHTTPScanner cria um scanner para o endpoint informado; token vazio não envia Authorization
+func NewHTTPScanner(url, token string, timeout time.Duration) *HTTPScanner {
+	return &HTTPScanner{
+		url:        url,
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name retorna o nome do driver
+func (s *HTTPScanner) Name() string {
+	return DriverHTTP
+}
+
+// Scan envia o conteúdo ao serviço e interpreta o veredito
+func (s *HTTPScanner) Scan(ctx context.Context, content io.Reader, size int64) (*media.ScanResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, content)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição de verificação: %w", err)
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Accept", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao chamar o serviço de verificação: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("serviço de verificação respondeu com status %d", resp.StatusCode)
+	}
+
+	var body httpScanResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("resposta inválida do serviço de verificação: %w", err)
+	}
+
+	result := &media.ScanResult{Scanner: DriverHTTP, Signature: body.Signature}
+	switch media.ScanVerdict(body.Verdict) {
+	case media.ScanVerdictClean:
+		result.Verdict = media.ScanVerdictClean
+		result.Signature = ""
+	case media.ScanVerdictInfected:
+		result.Verdict = media.ScanVerdictInfected
+	default:
+		return nil, fmt.Errorf("veredito inválido do serviço de verificação: %q", body.Verdict)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"fmt"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/domain/media"
+)
+
+// Drivers de verificação de malware suportados (MEDIA_SCAN_DRIVER)
+const (
+	DriverClamd = "clamd"
+	DriverHTTP  = "http"
+	DriverNone  = "none"
+)
+
+// New cria o scanner do driver configurado; retorna nil sem erro quando a verificação está
+// desativada
+func New(cfg *config.ScanConfig) (media.Scanner, error) {
+	switch cfg.Driver {
+	case DriverClamd:
+		return NewClamdScanner(cfg.ClamdAddress, cfg.Timeout)
+	case DriverHTTP:
+		return NewHTTPScanner(cfg.HTTPURL, cfg.HTTPToken, cfg.Timeout), nil
+	case DriverNone, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("driver de verificação de mídias inválido: %s", cfg.Driver)
+	}
+}
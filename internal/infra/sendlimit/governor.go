@@ -0,0 +1,302 @@
+package sendlimit
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"zapcore/internal/domain/sendlimit"
+	"zapcore/internal/infra/metrics"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// window é a janela deslizante do limite de mensagens por minuto
+const window = time.Minute
+
+// recipientPruneSize define a partir de quantos destinatários os intervalos vencidos são descartados
+const recipientPruneSize = 1000
+
+// LimitsResolver retorna os limites próprios da sessão, ou nil para usar os padrões
+type LimitsResolver func(ctx context.Context, sessionID uuid.UUID) (*sendlimit.Limits, error)
+
+// RecipientChecker indica se a sessão já tem conversa com o destinatário
+type RecipientChecker func(ctx context.Context, sessionID uuid.UUID, recipient string) (bool, error)
+
+// sessionState guarda em memória o ritmo de envio de uma sessão. As sessões ficam
+// conectadas em uma única instância, então o estado local é suficiente.
+type sessionState struct {
+	mu       sync.Mutex
+	limits   *sendlimit.Limits // nil até a primeira consulta
+	custom   bool
+	slots    []time.Time          // horários reservados na janela, em ordem crescente
+	lastTo   map[string]time.Time // último horário reservado por destinatário
+	known    map[string]struct{}  // destinatários com conversa existente
+	day      string
+	newChats map[string]struct{} // destinatários novos contados no dia
+	queued   int
+	delayed  int64
+	rejected int64
+	lastSent *time.Time
+}
+
+// Governor aplica os limites de envio por sessão antes de cada mensagem
+type Governor struct {
+	defaults sendlimit.Limits
+	resolve  LimitsResolver
+	isKnown  RecipientChecker
+	now      func() time.Time
+
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*sessionState
+	logger   *logger.Logger
+}
+
+// NewGovernor cria o governador com os limites padrão; resolve e isKnown são opcionais
+func NewGovernor(defaults sendlimit.Limits, resolve LimitsResolver, isKnown RecipientChecker) *Governor {
+	return &Governor{
+		defaults: defaults,
+		resolve:  resolve,
+		isKnown:  isKnown,
+		now:      time.Now,
+		sessions: make(map[uuid.UUID]*sessionState),
+		logger:   logger.Get(),
+	}
+}
+
+// Acquire reserva o envio ao destinatário. Quando o envio cabe na espera máxima, aguarda na
+// fila até o horário reservado; caso contrário retorna *sendlimit.ThrottledError.
+func (g *Governor) Acquire(ctx context.Context, sessionID uuid.UUID, recipient string) error {
+	state := g.state(sessionID)
+	limits := g.limits(ctx, sessionID, state)
+
+	// Consultar o histórico fora do lock: a consulta ao banco não deve travar a fila
+	isNew := false
+	if limits.NewChatsPerDay > 0 {
+		isNew = !g.knownRecipient(ctx, sessionID, recipient, state)
+	}
+
+	state.mu.Lock()
+	now := g.now()
+	g.rollDay(state, now)
+	state.prune(now)
+
+	// Novas conversas: destinatários já contados no dia não consomem o limite de novo
+	if _, counted := state.newChats[recipient]; isNew && !counted && len(state.newChats) >= limits.NewChatsPerDay {
+		state.rejected++
+		state.mu.Unlock()
+		metrics.IncSendThrottled(sendlimit.ReasonNewChatsPerDay, metrics.ThrottleRejected)
+		return sendlimit.NewThrottledError(sendlimit.ReasonNewChatsPerDay, nextDay(now).Sub(now))
+	}
+
+	// Horário mais cedo que respeita o limite por minuto e o intervalo do destinatário
+	slot, reason := now, ""
+	if limits.MessagesPerMinute > 0 && len(state.slots) >= limits.MessagesPerMinute {
+		if free := state.slots[len(state.slots)-limits.MessagesPerMinute].Add(window); free.After(slot) {
+			slot, reason = free, sendlimit.ReasonMessagesPerMinute
+		}
+	}
+	if last, exists := state.lastTo[recipient]; exists && limits.RecipientGapSeconds > 0 {
+		if free := last.Add(limits.RecipientGap()); free.After(slot) {
+			slot, reason = free, sendlimit.ReasonRecipientGap
+		}
+	}
+
+	wait := slot.Sub(now)
+	if wait > limits.MaxQueue() {
+		state.rejected++
+		state.mu.Unlock()
+		metrics.IncSendThrottled(reason, metrics.ThrottleRejected)
+		return sendlimit.NewThrottledError(reason, wait)
+	}
+
+	// Reservar o horário: envios concorrentes da mesma sessão entram na fila em ordem
+	state.reserve(slot)
+	state.lastTo[recipient] = slot
+	state.lastSent = &slot
+	if isNew {
+		state.newChats[recipient] = struct{}{}
+		state.known[recipient] = struct{}{}
+	}
+	if wait > 0 {
+		state.queued++
+		state.delayed++
+	}
+	state.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	metrics.IncSendThrottled(reason, metrics.ThrottleDelayed)
+	g.logger.Debug().
+		Str("session_id", sessionID.String()).
+		Str("reason", reason).
+		Dur("wait", wait).
+		Msg("Envio aguardando na fila da sessão")
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	defer func() {
+		state.mu.Lock()
+		state.queued--
+		state.mu.Unlock()
+	}()
+
+	// O horário reservado não é devolvido em caso de cancelamento: a fila só fica mais conservadora
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Usage retorna o consumo atual dos limites da sessão
+func (g *Governor) Usage(ctx context.Context, sessionID uuid.UUID) (*sendlimit.Usage, error) {
+	state := g.state(sessionID)
+	limits := g.limits(ctx, sessionID, state)
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	now := g.now()
+	g.rollDay(state, now)
+	state.prune(now)
+
+	return &sendlimit.Usage{
+		SessionID:        sessionID,
+		Limits:           limits,
+		Custom:           state.custom,
+		MessagesInWindow: len(state.slots),
+		NewChatsToday:    len(state.newChats),
+		Queued:           state.queued,
+		Delayed:          state.delayed,
+		Rejected:         state.rejected,
+		Day:              state.day,
+		LastSentAt:       state.lastSent,
+	}, nil
+}
+
+// Reload descarta os limites em cache da sessão
+func (g *Governor) Reload(sessionID uuid.UUID) {
+	state := g.state(sessionID)
+	state.mu.Lock()
+	state.limits = nil
+	state.mu.Unlock()
+}
+
+// state retorna o estado da sessão, criando-o se necessário
+func (g *Governor) state(sessionID uuid.UUID) *sessionState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, exists := g.sessions[sessionID]
+	if !exists {
+		state = &sessionState{
+			lastTo:   make(map[string]time.Time),
+			known:    make(map[string]struct{}),
+			newChats: make(map[string]struct{}),
+		}
+		g.sessions[sessionID] = state
+	}
+	return state
+}
+
+// limits retorna os limites efetivos da sessão, consultando o resolver uma vez até o próximo Reload
+func (g *Governor) limits(ctx context.Context, sessionID uuid.UUID, state *sessionState) sendlimit.Limits {
+	state.mu.Lock()
+	if state.limits != nil {
+		limits := *state.limits
+		state.mu.Unlock()
+		return limits
+	}
+	state.mu.Unlock()
+
+	limits, custom := g.defaults, false
+	if g.resolve != nil {
+		override, err := g.resolve(ctx, sessionID)
+		if err != nil {
+			// Sem cache: a próxima mensagem tenta de novo
+			g.logger.Warn().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao carregar limites de envio da sessão, usando os padrões")
+			return limits
+		}
+		if override != nil {
+			limits, custom = *override, true
+		}
+	}
+
+	state.mu.Lock()
+	state.limits = &limits
+	state.custom = custom
+	state.mu.Unlock()
+	return limits
+}
+
+// knownRecipient indica se a sessão já conversou com o destinatário; na dúvida, considera conhecido
+func (g *Governor) knownRecipient(ctx context.Context, sessionID uuid.UUID, recipient string, state *sessionState) bool {
+	state.mu.Lock()
+	_, known := state.known[recipient]
+	state.mu.Unlock()
+	if known || g.isKnown == nil {
+		return true
+	}
+
+	known, err := g.isKnown(ctx, sessionID, recipient)
+	if err != nil {
+		g.logger.Warn().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao consultar conversa com o destinatário")
+		return true
+	}
+
+	if known {
+		state.mu.Lock()
+		state.known[recipient] = struct{}{}
+		state.mu.Unlock()
+	}
+	return known
+}
+
+// rollDay zera os contadores diários na virada do dia (UTC)
+func (g *Governor) rollDay(state *sessionState, now time.Time) {
+	day := now.UTC().Format("2006-01-02")
+	if state.day != day {
+		state.day = day
+		state.newChats = make(map[string]struct{})
+	}
+}
+
+// prune descarta os horários fora da janela e, com muitos destinatários, os intervalos vencidos
+func (s *sessionState) prune(now time.Time) {
+	start := now.Add(-window)
+	expired := sort.Search(len(s.slots), func(i int) bool { return s.slots[i].After(start) })
+	s.slots = s.slots[expired:]
+
+	if len(s.lastTo) < recipientPruneSize {
+		return
+	}
+	gap := time.Duration(0)
+	if s.limits != nil {
+		gap = s.limits.RecipientGap()
+	}
+	for recipient, last := range s.lastTo {
+		if now.Sub(last) > gap {
+			delete(s.lastTo, recipient)
+		}
+	}
+}
+
+// reserve insere o horário mantendo a ordem crescente
+func (s *sessionState) reserve(slot time.Time) {
+	i := sort.Search(len(s.slots), func(i int) bool { return s.slots[i].After(slot) })
+	s.slots = append(s.slots, time.Time{})
+	copy(s.slots[i+1:], s.slots[i:])
+	s.slots[i] = slot
+}
+
+// nextDay retorna o início do próximo dia em UTC
+func nextDay(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
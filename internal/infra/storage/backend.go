@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"time"
+
+	"zapcore/internal/app/config"
+	"zapcore/internal/domain/media"
+)
+
+// Drivers de armazenamento suportados (STORAGE_DRIVER)
+const (
+	DriverMinIO = "minio"
+	DriverS3    = "s3"
+	DriverLocal = "local"
+	DriverNone  = "none"
+)
+
+// ErrObjectNotFound indica que o objeto não existe no armazenamento
+var ErrObjectNotFound = media.ErrObjectNotFound
+
+// ObjectInfo representa os metadados de um objeto armazenado
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// PutOptions opções da gravação de um objeto
+type PutOptions struct {
+	ContentType string
+	Metadata    map[string]string // Metadados do objeto; ignorados pelo driver local
+}
+
+// Backend define as operações de um armazenamento de objetos. As chaves usam "/" como
+// separador em todos os drivers.
+type Backend interface {
+	// Name retorna o nome do driver
+	Name() string
+	// Put grava o objeto; size negativo indica tamanho desconhecido
+	Put(ctx context.Context, key string, reader io.Reader, size int64, opts PutOptions) (*ObjectInfo, error)
+	// Get abre o objeto para leitura, com suporte a Seek para leituras parciais
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, *ObjectInfo, error)
+	// Delete remove o objeto; remover um objeto inexistente não é erro
+	Delete(ctx context.Context, key string) error
+	// Stat retorna os metadados do objeto ou ErrObjectNotFound
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// PresignGet gera uma URL de download válida pelo tempo informado
+	PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// List percorre os objetos com o prefixo informado, interrompendo no primeiro erro de fn
+	List(ctx context.Context, prefix string, fn func(*ObjectInfo) error) error
+	// HealthCheck verifica se o armazenamento está acessível
+	HealthCheck(ctx context.Context) error
+}
+
+// NewBackend cria o backend do driver configurado (STORAGE_DRIVER); retorna nil sem erro
+// quando o armazenamento está desativado
+func NewBackend(cfg *config.Config) (Backend, error) {
+	var (
+		backend Backend
+		err     error
+	)
+
+	switch cfg.GetStorageDriver() {
+	case DriverMinIO:
+		backend, err = NewMinIOBackend(&cfg.MinIO)
+	case DriverS3:
+		backend, err = NewS3Backend(S3Options{
+			Endpoint:        cfg.Storage.S3.Endpoint,
+			Region:          cfg.Storage.S3.Region,
+			Bucket:          cfg.Storage.S3.Bucket,
+			AccessKeyID:     cfg.Storage.S3.AccessKeyID,
+			SecretAccessKey: cfg.Storage.S3.SecretAccessKey,
+			UseSSL:          cfg.Storage.S3.UseSSL,
+			PathStyle:       cfg.Storage.S3.PathStyle,
+		})
+	case DriverLocal:
+		backend, err = NewLocalBackend(LocalOptions{
+			Root:       cfg.WhatsApp.MediaPath,
+			BaseURL:    cfg.GetStoragePublicURL(),
+			SigningKey: signingKey(cfg),
+		})
+	case DriverNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("driver de armazenamento inválido: %s", cfg.Storage.Driver)
+	}
+
+	// Evita retornar um ponteiro nil dentro da interface
+	if err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
+
+// signingKey retorna a chave dos links assinados do driver local; sem STORAGE_SIGNING_KEY,
+// deriva uma chave estável da API_KEY para que os links sobrevivam a reinicializações
+func signingKey(cfg *config.Config) []byte {
+	if cfg.Storage.SigningKey != "" {
+		return []byte(cfg.Storage.SigningKey)
+	}
+	sum := sha256.Sum256([]byte("zapcore-storage:" + cfg.Auth.APIKey))
+	return sum[:]
+}
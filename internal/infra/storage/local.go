@@ -0,0 +1,343 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"zapcore/pkg/logger"
+)
+
+// LocalFilesPath é o caminho em que o zapcore serve os downloads assinados do driver local
+const LocalFilesPath = "/files"
+
+// Prefixo dos arquivos temporários de gravação, ignorados na listagem
+const localTempPrefix = ".upload-"
+
+// LocalOptions configura o backend em disco local
+type LocalOptions struct {
+	Root       string // Diretório raiz dos objetos
+	BaseURL    string // URL pública do zapcore, usada nos links assinados
+	SigningKey []byte // Chave HMAC dos links assinados
+}
+
+// LocalBackend armazena os objetos em disco, com downloads por links assinados servidos pelo
+// próprio zapcore. O tipo de conteúdo é deduzido da extensão e os metadados não são guardados.
+type LocalBackend struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+	logger     *logger.Logger
+}
+
+// NewLocalBackend cria o backend local, criando o diretório raiz se necessário
+func NewLocalBackend(opts LocalOptions) (*LocalBackend, error) {
+	if opts.Root == "" {
+		return nil, fmt.Errorf("diretório do armazenamento local não configurado")
+	}
+	if len(opts.SigningKey) == 0 {
+		return nil, fmt.Errorf("chave de assinatura do armazenamento local não configurada")
+	}
+
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, fmt.Errorf("diretório do armazenamento local inválido: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório do armazenamento local: %w", err)
+	}
+
+	backend := &LocalBackend{
+		root:       root,
+		baseURL:    strings.TrimRight(opts.BaseURL, "/"),
+		signingKey: opts.SigningKey,
+		logger:     logger.Get().WithField("component", DriverLocal),
+	}
+
+	backend.logger.WithFields(map[string]interface{}{
+		"component": "storage",
+		"provider":  DriverLocal,
+		"root":      root,
+		"base_url":  backend.baseURL,
+		"status":    "initialized",
+	}).Info().Msg("📦 Armazenamento OK")
+
+	return backend, nil
+}
+
+// Name retorna o nome do driver
+func (b *LocalBackend) Name() string {
+	return DriverLocal
+}
+
+// Root retorna o diretório raiz dos objetos
+func (b *LocalBackend) Root() string {
+	return b.root
+}
+
+// path resolve a chave no disco, recusando chaves que escapem do diretório raiz
+func (b *LocalBackend) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" {
+		return "", fmt.Errorf("chave de objeto inválida: %q", key)
+	}
+	return filepath.Join(b.root, filepath.FromSlash(clean)), nil
+}
+
+// Put grava o objeto em um arquivo temporário e o move para o destino, para que leituras
+// concorrentes nunca vejam o arquivo incompleto
+func (b *LocalBackend) Put(ctx context.Context, key string, reader io.Reader, _ int64, opts PutOptions) (*ObjectInfo, error) {
+	target, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), localTempPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return nil, fmt.Errorf("erro ao mover arquivo: %w", err)
+	}
+
+	info, err := b.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ContentType != "" {
+		info.ContentType = opts.ContentType
+	}
+	return info, nil
+}
+
+// Get abre o arquivo do objeto
+func (b *LocalBackend) Get(_ context.Context, key string) (io.ReadSeekCloser, *ObjectInfo, error) {
+	target, err := b.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(target)
+	if err != nil {
+		return nil, nil, localError(err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, localError(err)
+	}
+	if stat.IsDir() {
+		file.Close()
+		return nil, nil, ErrObjectNotFound
+	}
+
+	return file, localObjectInfo(key, stat), nil
+}
+
+// Delete remove o arquivo e os diretórios que ficarem vazios
+func (b *LocalBackend) Delete(_ context.Context, key string) error {
+	target, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// Remove os diretórios vazios até a raiz; o Remove falha nos que ainda têm arquivos
+	for dir := filepath.Dir(target); dir != b.root && strings.HasPrefix(dir, b.root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// Stat retorna os metadados do arquivo
+func (b *LocalBackend) Stat(_ context.Context, key string) (*ObjectInfo, error) {
+	target, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		return nil, localError(err)
+	}
+	if stat.IsDir() {
+		return nil, ErrObjectNotFound
+	}
+	return localObjectInfo(key, stat), nil
+}
+
+// PresignGet gera o link assinado servido em LocalFilesPath
+func (b *LocalBackend) PresignGet(_ context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := b.path(key); err != nil {
+		return "", err
+	}
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", b.sign(key, expires))
+	return fmt.Sprintf("%s%s/%s?%s", b.baseURL, LocalFilesPath, strings.Join(segments, "/"), query.Encode()), nil
+}
+
+// List percorre os arquivos cujas chaves começam com o prefixo, em ordem lexicográfica
+func (b *LocalBackend) List(ctx context.Context, prefix string, fn func(*ObjectInfo) error) error {
+	// Percorre apenas o diretório que contém o prefixo
+	start := b.root
+	if dir := path.Dir(prefix); strings.Contains(prefix, "/") && dir != "." {
+		start = filepath.Join(b.root, filepath.FromSlash(path.Clean("/"+dir)))
+	}
+
+	return filepath.WalkDir(start, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), localTempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.root, file)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		stat, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) { // Removido durante a listagem
+				return nil
+			}
+			return err
+		}
+		return fn(localObjectInfo(key, stat))
+	})
+}
+
+// HealthCheck verifica se o diretório raiz está acessível
+func (b *LocalBackend) HealthCheck(_ context.Context) error {
+	stat, err := os.Stat(b.root)
+	if err != nil {
+		return fmt.Errorf("armazenamento local não está acessível: %w", err)
+	}
+	if !stat.IsDir() {
+		return fmt.Errorf("armazenamento local não está acessível: %s não é um diretório", b.root)
+	}
+	return nil
+}
+
+// Handler serve os downloads dos links assinados, com suporte a Range e requisições
+// condicionais; deve ser montado em LocalFilesPath
+func (b *LocalBackend) Handler() http.Handler {
+	return http.StripPrefix(LocalFilesPath+"/", http.HandlerFunc(b.serveSigned))
+}
+
+// serveSigned valida a assinatura e a validade do link e envia o arquivo
+func (b *LocalBackend) serveSigned(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	expires := r.URL.Query().Get("expires")
+	signature := r.URL.Query().Get("signature")
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(b.sign(key, expires))) {
+		http.Error(w, "assinatura inválida", http.StatusForbidden)
+		return
+	}
+	if time.Now().Unix() > expiresAt {
+		http.Error(w, "link expirado", http.StatusForbidden)
+		return
+	}
+
+	file, info, err := b.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		b.logger.Error().Err(err).Str("object_path", key).Msg("Erro ao abrir arquivo do armazenamento local")
+		http.Error(w, "erro interno do servidor", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("ETag", info.ETag)
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.FormatInt(max(expiresAt-time.Now().Unix(), 0), 10))
+	http.ServeContent(w, r, path.Base(key), info.LastModified, file)
+}
+
+// sign calcula a assinatura HMAC-SHA256 da chave com a validade
+func (b *LocalBackend) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, b.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// localObjectInfo monta os metadados a partir do arquivo; o ETag combina data e tamanho
+func localObjectInfo(key string, stat fs.FileInfo) *ObjectInfo {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  contentType,
+		ETag:         fmt.Sprintf(`"%x-%x"`, stat.ModTime().UnixNano(), stat.Size()),
+		LastModified: stat.ModTime(),
+	}
+}
+
+// localError converte a ausência do arquivo em ErrObjectNotFound
+func localError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotFound
+	}
+	return err
+}
@@ -0,0 +1,281 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/tenant"
+	"zapcore/internal/infra/metrics"
+	"zapcore/pkg/logger"
+	"zapcore/pkg/tracing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultURLExpiry é a validade padrão das URLs de download das mídias
+const DefaultURLExpiry = 24 * time.Hour
+
+// MediaStorage organiza as mídias das sessões sobre o backend de armazenamento configurado
+type MediaStorage struct {
+	backend   Backend
+	urlExpiry time.Duration
+	logger    *logger.Logger
+}
+
+// NewMediaStorage cria o armazenamento de mídias; urlExpiry zero usa DefaultURLExpiry
+func NewMediaStorage(backend Backend, urlExpiry time.Duration) *MediaStorage {
+	if urlExpiry <= 0 {
+		urlExpiry = DefaultURLExpiry
+	}
+
+	return &MediaStorage{
+		backend:   backend,
+		urlExpiry: urlExpiry,
+		logger:    logger.Get().WithField("component", "storage"),
+	}
+}
+
+// Backend retorna o backend de armazenamento
+func (m *MediaStorage) Backend() Backend {
+	return m.backend
+}
+
+// MediaUploadOptions opções para upload de mídia
+type MediaUploadOptions struct {
+	TenantID    string
+	SessionID   uuid.UUID
+	ChatJID     string
+	Direction   string // "inbound" ou "outbound"
+	MessageID   string
+	ContentType string
+	Extension   string
+	Size        int64
+}
+
+// UploadMedia grava a mídia no armazenamento seguindo a estrutura de paths
+func (m *MediaStorage) UploadMedia(ctx context.Context, reader io.Reader, opts MediaUploadOptions) (string, error) {
+	// Construir path seguindo o padrão: {tenantID}/{sessionID}/{chatJID}/{direction}/{messageID}.{extension}
+	objectPath := m.buildMediaPath(opts)
+
+	err := m.put(ctx, objectPath, reader, opts.Size, PutOptions{
+		ContentType: opts.ContentType,
+		Metadata: map[string]string{
+			"tenant-id":  opts.TenantID,
+			"session-id": opts.SessionID.String(),
+			"chat-jid":   opts.ChatJID,
+			"direction":  opts.Direction,
+			"message-id": opts.MessageID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return objectPath, nil
+}
+
+// QuarantinePrefix separa as mídias em quarentena das demais; identificadores de tenant não
+// começam com sublinhado, então o prefixo não colide com nenhum deles
+const QuarantinePrefix = "_quarantine"
+
+// UploadQuarantine grava a mídia reprovada pela verificação de malware sob QuarantinePrefix,
+// fora da deduplicação, com o resultado da verificação nos metadados do objeto
+func (m *MediaStorage) UploadQuarantine(ctx context.Context, reader io.Reader, opts MediaUploadOptions, scan *media.ScanResult) (string, error) {
+	objectPath := path.Join(QuarantinePrefix, m.buildMediaPath(opts))
+
+	metadata := map[string]string{
+		"tenant-id":  opts.TenantID,
+		"session-id": opts.SessionID.String(),
+		"chat-jid":   opts.ChatJID,
+		"direction":  opts.Direction,
+		"message-id": opts.MessageID,
+	}
+	if scan != nil {
+		metadata["scan-verdict"] = string(scan.Verdict)
+		metadata["scan-scanner"] = scan.Scanner
+		if scan.Signature != "" {
+			metadata["scan-signature"] = scan.Signature
+		}
+	}
+
+	// Conteúdo genérico evita que o navegador interprete o arquivo em links assinados
+	if err := m.put(ctx, objectPath, reader, opts.Size, PutOptions{
+		ContentType: "application/octet-stream",
+		Metadata:    metadata,
+	}); err != nil {
+		return "", err
+	}
+
+	return objectPath, nil
+}
+
+// BlobUploadOptions opções para gravação de um conteúdo endereçado pelo SHA-256
+type BlobUploadOptions struct {
+	TenantID    string
+	SHA256      string // SHA-256 do conteúdo em hexadecimal
+	ContentType string
+	Extension   string
+	Size        int64
+}
+
+// UploadBlob grava o conteúdo no caminho derivado do seu SHA-256, compartilhado por todas as
+// mensagens do tenant com o mesmo conteúdo
+func (m *MediaStorage) UploadBlob(ctx context.Context, reader io.Reader, opts BlobUploadOptions) (string, error) {
+	objectPath := BlobPath(opts.TenantID, opts.SHA256, opts.Extension)
+
+	err := m.put(ctx, objectPath, reader, opts.Size, PutOptions{
+		ContentType: opts.ContentType,
+		Metadata: map[string]string{
+			"tenant-id": opts.TenantID,
+			"sha256":    opts.SHA256,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return objectPath, nil
+}
+
+// BlobPath constrói o caminho de um conteúdo: {tenantID}/blobs/{sha256[:2]}/{sha256}.{extension}.
+// O primeiro par de dígitos distribui os objetos do driver local entre diretórios.
+func BlobPath(tenantID, sha256, extension string) string {
+	name := sha256
+	if extension != "" {
+		name = fmt.Sprintf("%s.%s", sha256, extension)
+	}
+	return path.Join(tenant.StorageDir(tenantID), "blobs", sha256[:2], name)
+}
+
+// put grava o objeto no backend com métricas, tracing e logs
+func (m *MediaStorage) put(ctx context.Context, objectPath string, reader io.Reader, size int64, opts PutOptions) error {
+	uploadStart := time.Now()
+
+	m.logger.Debug().
+		Str("object_path", objectPath).
+		Int64("size", size).
+		Str("content_type", opts.ContentType).
+		Str("driver", m.backend.Name()).
+		Msg("🚀 Upload de mídia")
+
+	// Fazer upload
+	ctx, span := tracing.Start(ctx, "storage.Put",
+		attribute.String("storage.driver", m.backend.Name()),
+		attribute.String("storage.object", objectPath),
+		attribute.Int64("storage.size", size),
+	)
+	info, err := m.backend.Put(ctx, objectPath, reader, size, opts)
+	var stored int64
+	if info != nil {
+		stored = info.Size
+	}
+	metrics.ObserveStorageUpload(stored, err, time.Since(uploadStart))
+	tracing.End(span, err)
+	if err != nil {
+		m.logger.Error().
+			Err(err).
+			Str("object_path", objectPath).
+			Str("driver", m.backend.Name()).
+			Msg("❌ Erro upload de mídia")
+		return fmt.Errorf("erro ao fazer upload da mídia: %w", err)
+	}
+
+	m.logger.Info().
+		Str("object_path", objectPath).
+		Int64("size", info.Size).
+		Str("etag", info.ETag).
+		Dur("upload_duration", time.Since(uploadStart)).
+		Msg("✅ Upload de mídia OK")
+
+	return nil
+}
+
+// buildMediaPath constrói o path da mídia seguindo o padrão definido
+func (m *MediaStorage) buildMediaPath(opts MediaUploadOptions) string {
+	// Construir path: {tenantID}/{sessionID}/{chatJID}/{direction}/{messageID}.{extension}
+	// Usando chatJID real sem sanitização, pois todos os drivers suportam @ e .
+	return path.Join(
+		tenant.StorageDir(opts.TenantID),
+		opts.SessionID.String(),
+		opts.ChatJID,
+		opts.Direction,
+		fmt.Sprintf("%s.%s", opts.MessageID, opts.Extension),
+	)
+}
+
+// GetMediaURL retorna a URL assinada para acessar a mídia
+func (m *MediaStorage) GetMediaURL(ctx context.Context, objectPath string) (string, error) {
+	url, err := m.backend.PresignGet(ctx, objectPath, m.urlExpiry)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar URL da mídia: %w", err)
+	}
+
+	return url, nil
+}
+
+// DeleteMedia remove a mídia do armazenamento
+func (m *MediaStorage) DeleteMedia(ctx context.Context, objectPath string) error {
+	if err := m.backend.Delete(ctx, objectPath); err != nil {
+		return fmt.Errorf("erro ao remover mídia: %w", err)
+	}
+
+	m.logger.Info().Str("object_path", objectPath).Msg("Mídia removida do armazenamento com sucesso")
+	return nil
+}
+
+// GetMedia abre a mídia armazenada para leitura
+func (m *MediaStorage) GetMedia(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	reader, _, err := m.backend.Get(ctx, objectPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter mídia: %w", err)
+	}
+
+	return reader, nil
+}
+
+// OpenMedia abre a mídia armazenada com suporte a leituras parciais
+func (m *MediaStorage) OpenMedia(ctx context.Context, objectPath string) (io.ReadSeekCloser, *media.Object, error) {
+	reader, info, err := m.backend.Get(ctx, objectPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao obter mídia: %w", err)
+	}
+
+	return reader, mediaObject(info), nil
+}
+
+// GetMediaInfo retorna informações sobre a mídia
+func (m *MediaStorage) GetMediaInfo(ctx context.Context, objectPath string) (*ObjectInfo, error) {
+	info, err := m.backend.Stat(ctx, objectPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter informações da mídia: %w", err)
+	}
+
+	return info, nil
+}
+
+// WalkMedia percorre as mídias com o prefixo informado
+func (m *MediaStorage) WalkMedia(ctx context.Context, prefix string, fn func(*media.Object) error) error {
+	return m.backend.List(ctx, prefix, func(object *ObjectInfo) error {
+		return fn(mediaObject(object))
+	})
+}
+
+// mediaObject converte os metadados do backend para o domínio de mídia
+func mediaObject(object *ObjectInfo) *media.Object {
+	return &media.Object{
+		Path:         object.Key,
+		Size:         object.Size,
+		ContentType:  object.ContentType,
+		ETag:         object.ETag,
+		LastModified: object.LastModified,
+	}
+}
+
+// HealthCheck verifica se o armazenamento está acessível
+func (m *MediaStorage) HealthCheck(ctx context.Context) error {
+	return m.backend.HealthCheck(ctx)
+}
@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"zapcore/internal/app/config"
+	"zapcore/pkg/logger"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Options configura o backend compatível com S3
+type S3Options struct {
+	Endpoint        string // Host do serviço, sem esquema (ex.: s3.amazonaws.com, <conta>.r2.cloudflarestorage.com)
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	PathStyle       bool // Endereça o bucket no caminho em vez do subdomínio
+	CreateBucket    bool // Cria o bucket quando não existir
+}
+
+// S3Backend armazena os objetos em um bucket compatível com S3 (AWS, R2, GCS interop, MinIO)
+type S3Backend struct {
+	client *minio.Client
+	bucket string
+	name   string
+	logger *logger.Logger
+}
+
+// NewS3Backend cria o backend S3 e verifica o acesso ao bucket
+func NewS3Backend(opts S3Options) (*S3Backend, error) {
+	return newS3Backend(DriverS3, opts)
+}
+
+// NewMinIOBackend cria o backend S3 com as configurações do MinIO, criando o bucket se necessário
+func NewMinIOBackend(cfg *config.MinIOConfig) (*S3Backend, error) {
+	return newS3Backend(DriverMinIO, S3Options{
+		Endpoint:        cfg.Endpoint,
+		Bucket:          cfg.DefaultBucket,
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+		UseSSL:          cfg.UseSSL,
+		PathStyle:       true,
+		CreateBucket:    true,
+	})
+}
+
+// newS3Backend cria o backend com o nome de driver informado
+func newS3Backend(name string, opts S3Options) (*S3Backend, error) {
+	lookup := minio.BucketLookupAuto
+	if opts.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(opts.AccessKeyID, opts.SecretAccessKey, ""),
+		Secure:       opts.UseSSL,
+		Region:       opts.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente %s: %w", name, err)
+	}
+
+	backend := &S3Backend{
+		client: client,
+		bucket: opts.Bucket,
+		name:   name,
+		logger: logger.Get().WithField("component", name),
+	}
+
+	// Verificar conexão e criar bucket se necessário
+	if err := backend.ensureBucket(context.Background(), opts.CreateBucket, opts.Region); err != nil {
+		return nil, fmt.Errorf("erro ao verificar/criar bucket: %w", err)
+	}
+
+	backend.logger.WithFields(map[string]interface{}{
+		"component": "storage",
+		"provider":  name,
+		"endpoint":  opts.Endpoint,
+		"bucket":    opts.Bucket,
+		"ssl":       opts.UseSSL,
+		"status":    "initialized",
+	}).Info().Msg("📦 Armazenamento OK")
+
+	return backend, nil
+}
+
+// ensureBucket verifica se o bucket existe e, quando permitido, cria se necessário
+func (b *S3Backend) ensureBucket(ctx context.Context, create bool, region string) error {
+	exists, err := b.client.BucketExists(ctx, b.bucket)
+	if err != nil {
+		return fmt.Errorf("erro ao verificar existência do bucket: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if !create {
+		return fmt.Errorf("bucket %s não existe", b.bucket)
+	}
+
+	if err := b.client.MakeBucket(ctx, b.bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+		return fmt.Errorf("erro ao criar bucket: %w", err)
+	}
+	b.logger.Info().Str("bucket", b.bucket).Msg("Bucket criado com sucesso")
+	return nil
+}
+
+// Name retorna o nome do driver
+func (b *S3Backend) Name() string {
+	return b.name
+}
+
+// Bucket retorna o bucket usado pelo backend
+func (b *S3Backend) Bucket() string {
+	return b.bucket
+}
+
+// Put grava o objeto no bucket
+func (b *S3Backend) Put(ctx context.Context, key string, reader io.Reader, size int64, opts PutOptions) (*ObjectInfo, error) {
+	info, err := b.client.PutObject(ctx, b.bucket, key, reader, size, minio.PutObjectOptions{
+		ContentType:  opts.ContentType,
+		UserMetadata: opts.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  opts.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// Get abre o objeto; leituras após Seek usam requisições com Range
+func (b *S3Backend) Get(ctx context.Context, key string) (io.ReadSeekCloser, *ObjectInfo, error) {
+	object, err := b.client.GetObject(ctx, b.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, b.mapError(err)
+	}
+
+	// GetObject é preguiçoso; o Stat garante que o objeto existe antes de retornar
+	stat, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, nil, b.mapError(err)
+	}
+
+	return object, objectInfo(stat), nil
+}
+
+// Delete remove o objeto do bucket
+func (b *S3Backend) Delete(ctx context.Context, key string) error {
+	return b.client.RemoveObject(ctx, b.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// Stat retorna os metadados do objeto
+func (b *S3Backend) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	stat, err := b.client.StatObject(ctx, b.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, b.mapError(err)
+	}
+	return objectInfo(stat), nil
+}
+
+// PresignGet gera a URL pré-assinada do objeto
+func (b *S3Backend) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	url, err := b.client.PresignedGetObject(ctx, b.bucket, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return url.String(), nil
+}
+
+// List percorre os objetos do bucket com o prefixo informado
+func (b *S3Backend) List(ctx context.Context, prefix string, fn func(*ObjectInfo) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for object := range b.client.ListObjects(ctx, b.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return object.Err
+		}
+		if err := fn(objectInfo(object)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HealthCheck verifica se o bucket está acessível
+func (b *S3Backend) HealthCheck(ctx context.Context) error {
+	if _, err := b.client.BucketExists(ctx, b.bucket); err != nil {
+		return fmt.Errorf("%s não está acessível: %w", b.name, err)
+	}
+	return nil
+}
+
+// mapError converte a ausência do objeto em ErrObjectNotFound
+func (b *S3Backend) mapError(err error) error {
+	response := minio.ToErrorResponse(err)
+	if response.Code == "NoSuchKey" || response.StatusCode == http.StatusNotFound {
+		return ErrObjectNotFound
+	}
+	return err
+}
+
+// objectInfo converte os metadados do minio-go
+func objectInfo(info minio.ObjectInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}
+}
@@ -0,0 +1,83 @@
+package whatsapp
+
+import (
+	"sync"
+	"time"
+
+	"zapcore/internal/domain/whatsapp"
+
+	"github.com/google/uuid"
+)
+
+// activityTracker guarda em memória o último evento e o estado do QR de cada sessão
+type activityTracker struct {
+	mu       sync.RWMutex
+	sessions map[uuid.UUID]*whatsapp.SessionActivity
+}
+
+// newActivityTracker cria um novo rastreador de atividade
+func newActivityTracker() *activityTracker {
+	return &activityTracker{
+		sessions: make(map[uuid.UUID]*whatsapp.SessionActivity),
+	}
+}
+
+// get retorna a atividade da sessão, criando-a se necessário (chamar com lock de escrita)
+func (t *activityTracker) get(sessionID uuid.UUID) *whatsapp.SessionActivity {
+	activity, exists := t.sessions[sessionID]
+	if !exists {
+		activity = &whatsapp.SessionActivity{}
+		t.sessions[sessionID] = activity
+	}
+	return activity
+}
+
+// recordEvent registra o recebimento de um evento do WhatsApp
+func (t *activityTracker) recordEvent(sessionID uuid.UUID, eventType string) {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	activity := t.get(sessionID)
+	activity.LastEventAt = &now
+	activity.LastEventType = eventType
+}
+
+// recordQRCode registra a emissão de um QR Code; o início da espera é mantido entre códigos
+func (t *activityTracker) recordQRCode(sessionID uuid.UUID) {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	activity := t.get(sessionID)
+	if activity.QRPendingSince == nil {
+		activity.QRPendingSince = &now
+	}
+	activity.QRCodesIssued++
+}
+
+// clearQR encerra a espera pelo QR Code (pareado, expirado ou desconectado)
+func (t *activityTracker) clearQR(sessionID uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if activity, exists := t.sessions[sessionID]; exists {
+		activity.QRPendingSince = nil
+		activity.QRCodesIssued = 0
+	}
+}
+
+// snapshot retorna uma cópia da atividade da sessão
+func (t *activityTracker) snapshot(sessionID uuid.UUID) *whatsapp.SessionActivity {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	activity, exists := t.sessions[sessionID]
+	if !exists {
+		return &whatsapp.SessionActivity{}
+	}
+	copied := *activity
+	return &copied
+}
@@ -3,17 +3,29 @@ package whatsapp
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
+	domainMedia "zapcore/internal/domain/media"
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/sendlimit"
 	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
 	"zapcore/internal/domain/whatsapp"
+	"zapcore/internal/infra/metrics"
 	"zapcore/internal/infra/storage"
+	"zapcore/internal/shared/media"
 	"zapcore/pkg/logger"
+	"zapcore/pkg/tracing"
 
 	"github.com/google/uuid"
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Constantes para validação de mídia (baseado na documentação oficial do WhatsApp Business API 2024)
@@ -78,14 +90,30 @@ type WhatsAppClient struct {
 	killMutex    sync.RWMutex
 	sessionRepo  interface {
 		GetActiveSessions(ctx context.Context) ([]*session.Session, error)
+		GetByID(ctx context.Context, id uuid.UUID) (*session.Session, error)
 		UpdateJID(ctx context.Context, sessionID uuid.UUID, jid string) error
 		UpdateStatus(ctx context.Context, sessionID uuid.UUID, status session.WhatsAppSessionStatus) error
 	}
 	logger            *logger.Logger
 	eventHandler      EventHandler
-	minioClient       *storage.MinIOClient
+	mediaStorage      *storage.MediaStorage
+	mediaQuota        MediaQuota
+	mediaBlobs        domainMedia.BlobRepository
+	mediaScanner      domainMedia.Scanner
+	mediaScanOptions  MediaScanOptions
+	mediaUploads      domainMedia.UploadRepository
+	uploadValidity    time.Duration
+	sendGovernor      sendlimit.Governor
+	thumbnailer       *media.Thumbnailer
+	transcoder        media.AudioTranscoder
+	normalizer        *media.Normalizer
 	connectionManager *ConnectionManager
 	messageSender     *MessageSender
+	activity          *activityTracker
+	mediaRetries      *mediaRetryWaiters
+	mediaRetryTimeout time.Duration
+	mediaFetches      map[uuid.UUID]*mediaFetch
+	mediaFetchesMu    sync.Mutex
 }
 
 // PairSuccessEvent representa o evento de pareamento bem-sucedido
@@ -98,15 +126,16 @@ type PairSuccessEvent struct {
 
 // EventHandler define a interface para manipular eventos do WhatsApp
 type EventHandler interface {
-	HandleEvent(sessionID uuid.UUID, event interface{})
+	HandleEvent(ctx context.Context, sessionID uuid.UUID, event interface{})
 }
 
 // NewWhatsAppClient cria uma nova instância do cliente WhatsApp
 func NewWhatsAppClient(dbContainer *sqlstore.Container, sessionRepo interface {
 	GetActiveSessions(ctx context.Context) ([]*session.Session, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*session.Session, error)
 	UpdateJID(ctx context.Context, sessionID uuid.UUID, jid string) error
 	UpdateStatus(ctx context.Context, sessionID uuid.UUID, status session.WhatsAppSessionStatus) error
-}, eventHandler EventHandler, minioClient *storage.MinIOClient) *WhatsAppClient {
+}, eventHandler EventHandler, mediaStorage *storage.MediaStorage) *WhatsAppClient {
 	client := &WhatsAppClient{
 		container:    dbContainer,
 		clients:      make(map[uuid.UUID]*whatsmeow.Client),
@@ -114,16 +143,106 @@ func NewWhatsAppClient(dbContainer *sqlstore.Container, sessionRepo interface {
 		sessionRepo:  sessionRepo,
 		logger:       logger.Get(),
 		eventHandler: eventHandler,
-		minioClient:  minioClient,
+		mediaStorage: mediaStorage,
+		activity:     newActivityTracker(),
+		transcoder:   media.NoopTranscoder{},
+
+		mediaRetries:      newMediaRetryWaiters(),
+		mediaRetryTimeout: DefaultMediaRetryTimeout,
+		mediaFetches:      make(map[uuid.UUID]*mediaFetch),
 	}
 
 	// Inicializar componentes
 	client.connectionManager = NewConnectionManager(client)
 	client.messageSender = NewMessageSender(client)
 
+	if compositeHandler, ok := eventHandler.(*CompositeEventHandler); ok && mediaStorage != nil {
+		compositeHandler.SetHistoryMediaFetcher(historyMediaFetcher{client: client})
+	}
+	if compositeHandler, ok := eventHandler.(*CompositeEventHandler); ok {
+		compositeHandler.SetRecipientCounter(client)
+	}
+
 	return client
 }
 
+// SetMediaQuota define quem resolve o tenant e a cota de armazenamento das mídias recebidas.
+// Deve ser chamado antes de conectar as sessões.
+func (c *WhatsAppClient) SetMediaQuota(quota MediaQuota) {
+	c.mediaQuota = quota
+}
+
+// SetMediaBlobs ativa a deduplicação das mídias armazenadas: conteúdos repetidos são gravados
+// uma única vez por tenant e referenciados pelas mensagens. Deve ser chamado antes de conectar
+// as sessões.
+func (c *WhatsAppClient) SetMediaBlobs(blobs domainMedia.BlobRepository) {
+	c.mediaBlobs = blobs
+}
+
+// SetMediaScanner ativa a verificação de malware das mídias recebidas: as reprovadas vão para o
+// prefixo de quarentena e cada verificação publica um evento MediaScan. Deve ser chamado antes de
+// conectar as sessões.
+func (c *WhatsAppClient) SetMediaScanner(scanner domainMedia.Scanner, opts MediaScanOptions) {
+	c.mediaScanner = scanner
+	c.mediaScanOptions = opts
+}
+
+// publishMediaScan entrega o resultado da verificação de uma mídia ao handler de eventos
+func (c *WhatsAppClient) publishMediaScan(ctx context.Context, event *MediaScanEvent) {
+	if c.eventHandler != nil {
+		c.eventHandler.HandleEvent(ctx, event.SessionID, event)
+	}
+}
+
+// sessionTenant retorna o tenant dono da sessão, que separa as mídias no armazenamento e os
+// uploads reaproveitados
+func (c *WhatsAppClient) sessionTenant(ctx context.Context, sessionID uuid.UUID) (string, error) {
+	sess, err := c.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar tenant da sessão: %w", err)
+	}
+	return tenant.StorageDir(sess.TenantID), nil
+}
+
+// SetUploadCache ativa o reaproveitamento dos uploads ao WhatsApp: envios do mesmo conteúdo dentro
+// da validade usam o direct path e a chave da mídia do primeiro envio
+func (c *WhatsAppClient) SetUploadCache(uploads domainMedia.UploadRepository, validity time.Duration) {
+	c.mediaUploads = uploads
+	c.uploadValidity = validity
+}
+
+// SetSendGovernor define o governador que controla o ritmo de envio de todas as mensagens.
+// Deve ser chamado antes de conectar as sessões.
+func (c *WhatsAppClient) SetSendGovernor(governor sendlimit.Governor) {
+	c.sendGovernor = governor
+}
+
+// SetThumbnailer define o gerador dos thumbnails embutidos e dos previews das mídias enviadas.
+// Sem ele, as mensagens de mídia seguem sem thumbnail.
+func (c *WhatsAppClient) SetThumbnailer(thumbnailer *media.Thumbnailer) {
+	c.thumbnailer = thumbnailer
+}
+
+// SetAudioTranscoder define o conversor dos áudios enviados como mensagem de voz. Sem ele,
+// apenas áudios que já estão em OGG/Opus podem ser enviados como mensagem de voz.
+func (c *WhatsAppClient) SetAudioTranscoder(transcoder media.AudioTranscoder) {
+	c.transcoder = transcoder
+}
+
+// SetNormalizer define o normalizador aplicado às imagens, vídeos e stickers antes da validação.
+// Sem ele, as mídias são validadas como recebidas.
+func (c *WhatsAppClient) SetNormalizer(normalizer *media.Normalizer) {
+	c.normalizer = normalizer
+}
+
+// SetMediaRetryTimeout define a espera pelo reenvio de uma mídia expirada pedido ao aparelho do
+// remetente; zero mantém DefaultMediaRetryTimeout
+func (c *WhatsAppClient) SetMediaRetryTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		c.mediaRetryTimeout = timeout
+	}
+}
+
 // ConnectOnStartup reconecta automaticamente sessões ativas com JID
 func (c *WhatsAppClient) ConnectOnStartup(ctx context.Context) error {
 	return c.connectionManager.ConnectOnStartup(ctx)
@@ -139,6 +258,11 @@ func (c *WhatsAppClient) Disconnect(ctx context.Context, sessionID uuid.UUID) er
 	return c.connectionManager.Disconnect(ctx, sessionID)
 }
 
+// Logout desvincula o dispositivo da conta do WhatsApp
+func (c *WhatsAppClient) Logout(ctx context.Context, sessionID uuid.UUID) error {
+	return c.connectionManager.Logout(ctx, sessionID)
+}
+
 // GetQRCode obtém QR Code (método simplificado)
 func (c *WhatsAppClient) GetQRCode(ctx context.Context, sessionID uuid.UUID) (string, error) {
 	c.clientsMutex.RLock()
@@ -192,34 +316,69 @@ func (c *WhatsAppClient) IsLoggedIn(ctx context.Context, sessionID uuid.UUID) bo
 	return c.connectionManager.IsLoggedIn(sessionID)
 }
 
+// GetActivity retorna o último evento recebido e o estado do QR Code da sessão
+func (c *WhatsAppClient) GetActivity(ctx context.Context, sessionID uuid.UUID) *whatsapp.SessionActivity {
+	return c.activity.snapshot(sessionID)
+}
+
 // SendTextMessage envia mensagem de texto
 func (c *WhatsAppClient) SendTextMessage(ctx context.Context, req *whatsapp.SendTextRequest) (*whatsapp.MessageResponse, error) {
-	return c.messageSender.SendTextMessage(ctx, req)
+	ctx, span := tracing.Start(ctx, "WhatsAppClient.SendTextMessage", attribute.String("session.id", req.SessionID.String()))
+	start := time.Now()
+	resp, err := c.messageSender.SendTextMessage(ctx, req)
+	observeSend(req.SessionID, message.MessageTypeText, start, err)
+	tracing.End(span, err)
+	return resp, err
 }
 
 // SendImageMessage envia imagem
 func (c *WhatsAppClient) SendImageMessage(ctx context.Context, req *whatsapp.SendImageRequest) (*whatsapp.MessageResponse, error) {
-	return c.messageSender.SendImageMessage(ctx, req)
+	ctx, span := tracing.Start(ctx, "WhatsAppClient.SendImageMessage", attribute.String("session.id", req.SessionID.String()))
+	start := time.Now()
+	resp, err := c.messageSender.SendImageMessage(ctx, req)
+	observeSend(req.SessionID, message.MessageTypeImage, start, err)
+	tracing.End(span, err)
+	return resp, err
 }
 
 // SendAudioMessage envia áudio
 func (c *WhatsAppClient) SendAudioMessage(ctx context.Context, req *whatsapp.SendAudioRequest) (*whatsapp.MessageResponse, error) {
-	return c.messageSender.SendAudioMessage(ctx, req)
+	ctx, span := tracing.Start(ctx, "WhatsAppClient.SendAudioMessage", attribute.String("session.id", req.SessionID.String()))
+	start := time.Now()
+	resp, err := c.messageSender.SendAudioMessage(ctx, req)
+	observeSend(req.SessionID, message.MessageTypeAudio, start, err)
+	tracing.End(span, err)
+	return resp, err
 }
 
 // SendVideoMessage envia vídeo
 func (c *WhatsAppClient) SendVideoMessage(ctx context.Context, req *whatsapp.SendVideoRequest) (*whatsapp.MessageResponse, error) {
-	return c.messageSender.SendVideoMessage(ctx, req)
+	ctx, span := tracing.Start(ctx, "WhatsAppClient.SendVideoMessage", attribute.String("session.id", req.SessionID.String()))
+	start := time.Now()
+	resp, err := c.messageSender.SendVideoMessage(ctx, req)
+	observeSend(req.SessionID, message.MessageTypeVideo, start, err)
+	tracing.End(span, err)
+	return resp, err
 }
 
 // SendDocumentMessage envia documento
 func (c *WhatsAppClient) SendDocumentMessage(ctx context.Context, req *whatsapp.SendDocumentRequest) (*whatsapp.MessageResponse, error) {
-	return c.messageSender.SendDocumentMessage(ctx, req)
+	ctx, span := tracing.Start(ctx, "WhatsAppClient.SendDocumentMessage", attribute.String("session.id", req.SessionID.String()))
+	start := time.Now()
+	resp, err := c.messageSender.SendDocumentMessage(ctx, req)
+	observeSend(req.SessionID, message.MessageTypeDocument, start, err)
+	tracing.End(span, err)
+	return resp, err
 }
 
 // SendStickerMessage envia sticker
 func (c *WhatsAppClient) SendStickerMessage(ctx context.Context, req *whatsapp.SendStickerRequest) (*whatsapp.MessageResponse, error) {
-	return c.messageSender.SendStickerMessage(ctx, req)
+	ctx, span := tracing.Start(ctx, "WhatsAppClient.SendStickerMessage", attribute.String("session.id", req.SessionID.String()))
+	start := time.Now()
+	resp, err := c.messageSender.SendStickerMessage(ctx, req)
+	observeSend(req.SessionID, message.MessageTypeSticker, start, err)
+	tracing.End(span, err)
+	return resp, err
 }
 
 // SendLocationMessage envia localização
@@ -252,11 +411,6 @@ func (c *WhatsAppClient) RevokeMessage(ctx context.Context, req *whatsapp.Revoke
 	return nil, fmt.Errorf("RevokeMessage não implementado ainda")
 }
 
-// DownloadMedia faz download de mídia
-func (c *WhatsAppClient) DownloadMedia(ctx context.Context, req *whatsapp.DownloadMediaRequest) ([]byte, error) {
-	return nil, fmt.Errorf("DownloadMedia não implementado ainda")
-}
-
 // UploadMedia faz upload de mídia
 func (c *WhatsAppClient) UploadMedia(ctx context.Context, req *whatsapp.UploadMediaRequest) (*whatsapp.UploadResponse, error) {
 	return nil, fmt.Errorf("UploadMedia não implementado ainda")
@@ -282,6 +436,56 @@ func (c *WhatsAppClient) GetGroupInfo(ctx context.Context, sessionID uuid.UUID,
 	return nil, fmt.Errorf("GetGroupInfo não implementado ainda")
 }
 
+// CountRecipients conta os destinatários de uma mensagem enviada à conversa: os demais
+// participantes do grupo, os contatos no status ou o próprio contato. Retorna zero quando a
+// contagem não é conhecida, como nas listas de transmissão.
+func (c *WhatsAppClient) CountRecipients(ctx context.Context, sessionID uuid.UUID, chat types.JID) (int, error) {
+	switch chat.Server {
+	case types.DefaultUserServer, types.HiddenUserServer:
+		return 1, nil
+	case types.GroupServer, types.BroadcastServer:
+	default:
+		return 0, nil
+	}
+
+	c.clientsMutex.RLock()
+	client, exists := c.clients[sessionID]
+	c.clientsMutex.RUnlock()
+	if !exists || client.Store.ID == nil {
+		return 0, fmt.Errorf("cliente não encontrado para sessão %s", sessionID.String())
+	}
+
+	if chat == types.StatusBroadcastJID {
+		contacts, err := client.Store.Contacts.GetAllContacts(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("erro ao listar contatos: %w", err)
+		}
+		return len(contacts), nil
+	}
+	if chat.Server == types.BroadcastServer {
+		return 0, nil
+	}
+
+	info, err := client.GetGroupInfo(chat)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao obter participantes do grupo: %w", err)
+	}
+
+	// A própria conta aparece entre os participantes pelo telefone ou pelo LID
+	own := map[types.JID]bool{client.Store.ID.ToNonAD(): true}
+	if lid := client.Store.GetLID(); !lid.IsEmpty() {
+		own[lid.ToNonAD()] = true
+	}
+	count := 0
+	for _, participant := range info.Participants {
+		if own[participant.JID.ToNonAD()] || (!participant.LID.IsEmpty() && own[participant.LID.ToNonAD()]) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
 // MarkAsRead marca mensagem como lida
 func (c *WhatsAppClient) MarkAsRead(ctx context.Context, req *whatsapp.MarkAsReadRequest) error {
 	return fmt.Errorf("MarkAsRead não implementado ainda")
@@ -354,6 +558,19 @@ func (c *WhatsAppClient) JoinGroupWithLink(ctx context.Context, sessionID uuid.U
 
 // handleWhatsAppEvent manipula eventos do WhatsApp
 func (c *WhatsAppClient) handleWhatsAppEvent(sessionID uuid.UUID, evt interface{}) {
+	// Cada evento inicia um trace próprio que segue até o storage e os publishers
+	eventType := strings.TrimPrefix(fmt.Sprintf("%T", evt), "*events.")
+	ctx, span := tracing.Tracer().Start(context.Background(), "whatsapp.event "+eventType,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("session.id", sessionID.String()),
+			attribute.String("whatsapp.event.type", eventType),
+		),
+	)
+	defer span.End()
+
+	c.activity.recordEvent(sessionID, eventType)
+
 	switch e := evt.(type) {
 	case *events.Message:
 		c.logger.Info().
@@ -381,6 +598,14 @@ func (c *WhatsAppClient) handleWhatsAppEvent(sessionID uuid.UUID, evt interface{
 			Str("source", e.SourceString()).
 			Msg("Recibo recebido")
 
+	case *events.MediaRetry:
+		delivered := c.mediaRetries.deliver(sessionID, e)
+		c.logger.Info().
+			Str("session_id", sessionID.String()).
+			Str("message_id", e.MessageID).
+			Bool("awaited", delivered).
+			Msg("Resposta do pedido de reenvio de mídia recebida")
+
 	case *events.PairSuccess:
 		c.logger.Info().
 			Str("session_id", sessionID.String()).
@@ -388,9 +613,10 @@ func (c *WhatsAppClient) handleWhatsAppEvent(sessionID uuid.UUID, evt interface{
 			Str("business_name", e.BusinessName).
 			Str("platform", e.Platform).
 			Msg("Pareamento bem-sucedido")
+		metrics.IncPairingEvent(metrics.PairingSuccess)
+		c.activity.clearQR(sessionID)
 
 		// Atualizar JID no banco de dados
-		ctx := context.Background()
 		if err := c.sessionRepo.UpdateJID(ctx, sessionID, e.ID.String()); err != nil {
 			c.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao atualizar JID após pareamento")
 		} else {
@@ -401,9 +627,9 @@ func (c *WhatsAppClient) handleWhatsAppEvent(sessionID uuid.UUID, evt interface{
 		c.logger.Info().
 			Str("session_id", sessionID.String()).
 			Msg("WhatsApp conectado")
+		c.activity.clearQR(sessionID)
 
 		// Atualizar status para connected no banco de dados
-		ctx := context.Background()
 		if err := c.sessionRepo.UpdateStatus(ctx, sessionID, session.WhatsAppStatusConnected); err != nil {
 			c.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao atualizar status para connected")
 		} else {
@@ -415,6 +641,7 @@ func (c *WhatsAppClient) handleWhatsAppEvent(sessionID uuid.UUID, evt interface{
 			Str("session_id", sessionID.String()).
 			Int("reason", int(e.Reason)).
 			Msg("WhatsApp desconectado")
+		metrics.IncPairingEvent(metrics.PairingLoggedOut)
 
 	case *events.Presence:
 		if e.Unavailable {
@@ -446,18 +673,19 @@ func (c *WhatsAppClient) handleWhatsAppEvent(sessionID uuid.UUID, evt interface{
 
 	// Chamar handler externo se configurado
 	if c.eventHandler != nil {
-		c.eventHandler.HandleEvent(sessionID, evt)
+		c.eventHandler.HandleEvent(ctx, sessionID, evt)
 	}
 }
 
 // configureMediaDownloader configura o MediaDownloader para uma sessão específica
 func (c *WhatsAppClient) configureMediaDownloader(sessionID uuid.UUID, client *whatsmeow.Client) {
-	if c.minioClient == nil {
-		return // MinIO não está habilitado
+	if c.mediaStorage == nil {
+		return // Armazenamento de mídias desativado
 	}
 
 	// Criar MediaDownloader para esta sessão
-	mediaDownloader := NewMediaDownloader(client, c.minioClient)
+	mediaDownloader := NewMediaDownloader(client, c.mediaStorage, c.mediaQuota, c.mediaBlobs)
+	mediaDownloader.SetScanner(c.mediaScanner, c.mediaScanOptions, c.publishMediaScan)
 
 	// Configurar o MediaDownloader no StorageHandler se possível
 	if compositeHandler, ok := c.eventHandler.(*CompositeEventHandler); ok {
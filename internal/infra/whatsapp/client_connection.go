@@ -10,6 +10,7 @@ import (
 
 	"zapcore/internal/domain/session"
 	"zapcore/internal/domain/whatsapp"
+	"zapcore/internal/infra/metrics"
 
 	"github.com/google/uuid"
 	"github.com/mdp/qrterminal/v3"
@@ -176,8 +177,8 @@ func (cm *ConnectionManager) createWhatsmeowClient(deviceStore *store.Device, se
 		cm.client.handleWhatsAppEvent(sessionID, evt)
 	})
 
-	// Configurar MediaDownloader se MinIO estiver habilitado
-	if cm.client.minioClient != nil {
+	// Configurar MediaDownloader se o armazenamento estiver habilitado
+	if cm.client.mediaStorage != nil {
 		cm.client.configureMediaDownloader(sessionID, client)
 	}
 
@@ -408,6 +409,8 @@ func (cm *ConnectionManager) processQREvents(sessionID uuid.UUID, qrChan <-chan
 // handleQRCode processa evento de código QR
 func (cm *ConnectionManager) handleQRCode(sessionID uuid.UUID, code string) {
 	cm.client.logger.Info().Str("session_id", sessionID.String()).Msg("QR Code gerado")
+	metrics.IncPairingEvent(metrics.PairingQRCode)
+	cm.client.activity.recordQRCode(sessionID)
 
 	// Exibir QR code no terminal
 	cm.client.logger.Info().
@@ -433,6 +436,8 @@ func (cm *ConnectionManager) handleQRCode(sessionID uuid.UUID, code string) {
 
 // handleQRTimeout processa timeout do QR code
 func (cm *ConnectionManager) handleQRTimeout(sessionID uuid.UUID) {
+	metrics.IncPairingEvent(metrics.PairingQRTimeout)
+	cm.client.activity.clearQR(sessionID)
 	cm.client.logger.Warn().
 		Str("session_id", sessionID.String()).
 		Msg("⏰ QR Code expirou - Tente conectar novamente")
@@ -462,6 +467,7 @@ func (cm *ConnectionManager) handleQRSuccess(sessionID uuid.UUID) {
 	cm.client.logger.Info().
 		Str("session_id", sessionID.String()).
 		Msg("✅ QR Code escaneado com sucesso")
+	cm.client.activity.clearQR(sessionID)
 }
 
 // sendKillSignal envia sinal de kill para sessão
@@ -523,6 +529,7 @@ func (cm *ConnectionManager) Disconnect(ctx context.Context, sessionID uuid.UUID
 
 	// Enviar sinal de kill
 	cm.sendKillSignal(sessionID)
+	cm.client.activity.clearQR(sessionID)
 
 	// Remover e desconectar cliente
 	cm.client.clientsMutex.Lock()
@@ -548,3 +555,53 @@ func (cm *ConnectionManager) Disconnect(ctx context.Context, sessionID uuid.UUID
 
 	return nil
 }
+
+// Logout desvincula o dispositivo da conta; sessões autenticadas sem cliente ativo são reconectadas antes
+func (cm *ConnectionManager) Logout(ctx context.Context, sessionID uuid.UUID) error {
+	cm.client.clientsMutex.RLock()
+	client, exists := cm.client.clients[sessionID]
+	cm.client.clientsMutex.RUnlock()
+
+	if !exists || !client.IsConnected() {
+		sessions, err := cm.client.sessionRepo.GetActiveSessions(ctx)
+		if err != nil {
+			return fmt.Errorf("erro ao buscar sessões para sessão %s: %w", sessionID.String(), err)
+		}
+
+		var sessionData *session.Session
+		for _, s := range sessions {
+			if s.ID == sessionID {
+				sessionData = s
+				break
+			}
+		}
+		if sessionData == nil || sessionData.JID == "" {
+			return session.ErrSessionNotConnected
+		}
+
+		if err := cm.reconnectSession(ctx, sessionData); err != nil {
+			return err
+		}
+
+		cm.client.clientsMutex.RLock()
+		client = cm.client.clients[sessionID]
+		cm.client.clientsMutex.RUnlock()
+	}
+
+	if err := client.Logout(ctx); err != nil {
+		return fmt.Errorf("erro ao desvincular dispositivo da sessão %s: %w", sessionID.String(), err)
+	}
+
+	cm.client.logger.Info().Str("session_id", sessionID.String()).Msg("Dispositivo desvinculado do WhatsApp")
+
+	// Limpar cliente, keep-alive e status
+	if err := cm.Disconnect(ctx, sessionID); err != nil {
+		return err
+	}
+
+	if err := cm.client.sessionRepo.UpdateJID(ctx, sessionID, ""); err != nil {
+		return fmt.Errorf("erro ao limpar JID da sessão %s: %w", sessionID.String(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/whatsapp"
+	"zapcore/internal/infra/storage"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+// downloadMediaTypes converte os tipos de mídia das requisições para os do whatsmeow; stickers
+// usam as chaves de imagem
+var downloadMediaTypes = map[string]whatsmeow.MediaType{
+	MediaTypeImage:    whatsmeow.MediaImage,
+	MediaTypeVideo:    whatsmeow.MediaVideo,
+	MediaTypeAudio:    whatsmeow.MediaAudio,
+	MediaTypeDocument: whatsmeow.MediaDocument,
+	MediaTypeSticker:  whatsmeow.MediaImage,
+}
+
+// DownloadMedia baixa e descriptografa a mídia pelo direct path e pelas chaves da mensagem. Se a
+// CDN já descartou o arquivo e a mensagem de origem foi informada, pede o reenvio ao aparelho do
+// remetente e baixa pelo novo direct path.
+func (c *WhatsAppClient) DownloadMedia(ctx context.Context, req *whatsapp.DownloadMediaRequest) ([]byte, error) {
+	client, err := c.messageSender.getClient(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, ok := downloadMediaTypes[req.MediaType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", message.ErrInvalidMediaType, req.MediaType)
+	}
+	if req.DirectPath == "" || len(req.MediaKey) == 0 {
+		return nil, fmt.Errorf("mensagem sem direct path ou chave da mídia")
+	}
+
+	fileLength := -1
+	if req.FileLength > 0 {
+		fileLength = int(req.FileLength)
+	}
+	download := func(directPath string) ([]byte, error) {
+		return client.DownloadMediaWithPath(ctx, directPath, req.FileEncSHA256, req.FileSHA256, req.MediaKey, fileLength, mediaType, "")
+	}
+
+	data, err := download(req.DirectPath)
+	if err == nil {
+		return data, nil
+	}
+	if !isMediaExpired(err) || req.MessageID == "" || req.ChatJID == "" {
+		return nil, fmt.Errorf("erro ao baixar mídia: %w", err)
+	}
+
+	directPath, err := c.requestMediaRetry(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = download(directPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao baixar mídia reenviada: %w", err)
+	}
+	return data, nil
+}
+
+// mediaFetch é um download de mídia em andamento, compartilhado pelas requisições da mesma mensagem
+type mediaFetch struct {
+	done chan struct{}
+	info *MediaInfo
+	err  error
+}
+
+// FetchMedia baixa do WhatsApp a mídia de uma mensagem registrada, com as chaves gravadas no
+// payload bruto, e a grava no armazenamento. Mídias expiradas são pedidas novamente ao aparelho
+// do remetente. Exige a sessão conectada.
+func (c *WhatsAppClient) FetchMedia(ctx context.Context, msg *message.Message) error {
+	return c.fetchMedia(ctx, msg, true)
+}
+
+// fetchMedia baixa a mídia da mensagem; downloads simultâneos da mesma mensagem aguardam o que
+// já está em andamento
+func (c *WhatsAppClient) fetchMedia(ctx context.Context, msg *message.Message, retry bool) error {
+	if c.mediaStorage == nil {
+		return media.ErrStorageDisabled
+	}
+
+	c.mediaFetchesMu.Lock()
+	call, inflight := c.mediaFetches[msg.ID]
+	if !inflight {
+		call = &mediaFetch{done: make(chan struct{})}
+		c.mediaFetches[msg.ID] = call
+	}
+	c.mediaFetchesMu.Unlock()
+
+	if inflight {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else {
+		call.info, call.err = c.downloadMessageMedia(ctx, msg, retry)
+
+		c.mediaFetchesMu.Lock()
+		delete(c.mediaFetches, msg.ID)
+		c.mediaFetchesMu.Unlock()
+		close(call.done)
+	}
+
+	if call.err != nil {
+		return call.err
+	}
+	msg.SetMediaInfo(call.info.ObjectPath, call.info.Size, call.info.MimeType, call.info.FileName)
+	if call.info.BlobID != nil {
+		msg.SetMediaID(*call.info.BlobID)
+	}
+	if call.info.Scan != nil {
+		msg.SetMediaScan(string(call.info.Scan.Verdict), call.info.Scan.Signature, call.info.Quarantined)
+	}
+	return nil
+}
+
+// downloadMessageMedia baixa a mídia referenciada no payload bruto da mensagem e a envia ao
+// armazenamento com o mesmo caminho e nome usados nas mensagens recebidas ao vivo
+func (c *WhatsAppClient) downloadMessageMedia(ctx context.Context, msg *message.Message, retry bool) (*MediaInfo, error) {
+	waMsg, err := rawPayloadMessage(msg.RawPayload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", media.ErrMediaUnavailable, err)
+	}
+
+	content := messageMediaOf(waMsg)
+	if content == nil {
+		return nil, fmt.Errorf("%w: mensagem original sem mídia", media.ErrMediaUnavailable)
+	}
+
+	req := &whatsapp.DownloadMediaRequest{
+		SessionID:     msg.SessionID,
+		DirectPath:    content.GetDirectPath(),
+		MediaKey:      content.GetMediaKey(),
+		FileEncSHA256: content.GetFileEncSHA256(),
+		FileSHA256:    content.GetFileSHA256(),
+		FileLength:    content.fileLength,
+		MediaType:     content.mediaType,
+	}
+	if retry {
+		req.ChatJID = msg.ChatJID
+		req.SenderJID = msg.SenderJID
+		req.MessageID = msg.MsgID
+		req.IsFromMe = msg.IsOutbound()
+	}
+
+	data, err := c.DownloadMedia(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", media.ErrMediaUnavailable, err)
+	}
+
+	uploader := NewMediaDownloader(nil, c.mediaStorage, c.mediaQuota, c.mediaBlobs)
+	uploader.SetScanner(c.mediaScanner, c.mediaScanOptions, c.publishMediaScan)
+	extension := uploader.getExtensionFromMimeType(content.mimeType, content.defaultExt)
+	if ext := filepath.Ext(content.fileName); ext != "" {
+		extension = strings.TrimPrefix(ext, ".")
+	}
+
+	direction := DirectionInbound
+	if msg.IsOutbound() {
+		direction = DirectionOutbound
+	}
+
+	stored, err := uploader.uploadMedia(ctx, content.mediaType, data, storage.MediaUploadOptions{
+		SessionID:   msg.SessionID,
+		ChatJID:     msg.ChatJID,
+		Direction:   direction,
+		MessageID:   msg.MsgID,
+		ContentType: content.mimeType,
+		Extension:   extension,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := content.fileName
+	if fileName == "" {
+		fileName = fmt.Sprintf("%s.%s", msg.MsgID, extension)
+	}
+
+	return &MediaInfo{
+		MimeType:    content.mimeType,
+		Extension:   extension,
+		Size:        int64(len(data)),
+		FileName:    fileName,
+		ObjectPath:  stored.ObjectPath,
+		BlobID:      stored.BlobID,
+		Scan:        stored.Scan,
+		Quarantined: stored.Quarantined,
+	}, nil
+}
+
+// messageMedia é a submensagem baixável de uma mensagem do WhatsApp
+type messageMedia struct {
+	whatsmeow.DownloadableMessage
+	mediaType  string
+	mimeType   string
+	fileName   string // Nome original, apenas em documentos
+	defaultExt string
+	fileLength uint64
+}
+
+// messageMediaOf localiza a mídia da mensagem, inclusive dentro de mensagens temporárias e de
+// visualização única; retorna nil quando não há mídia
+func messageMediaOf(waMsg *waE2E.Message) *messageMedia {
+	switch {
+	case waMsg == nil:
+		return nil
+	case waMsg.GetImageMessage() != nil:
+		img := waMsg.GetImageMessage()
+		return &messageMedia{img, MediaTypeImage, img.GetMimetype(), "", ".jpg", img.GetFileLength()}
+	case waMsg.GetVideoMessage() != nil:
+		video := waMsg.GetVideoMessage()
+		return &messageMedia{video, MediaTypeVideo, video.GetMimetype(), "", ".mp4", video.GetFileLength()}
+	case waMsg.GetAudioMessage() != nil:
+		audio := waMsg.GetAudioMessage()
+		return &messageMedia{audio, MediaTypeAudio, audio.GetMimetype(), "", ".ogg", audio.GetFileLength()}
+	case waMsg.GetDocumentMessage() != nil:
+		doc := waMsg.GetDocumentMessage()
+		return &messageMedia{doc, MediaTypeDocument, doc.GetMimetype(), doc.GetFileName(), ".bin", doc.GetFileLength()}
+	case waMsg.GetStickerMessage() != nil:
+		sticker := waMsg.GetStickerMessage()
+		return &messageMedia{sticker, MediaTypeSticker, sticker.GetMimetype(), "", ".webp", sticker.GetFileLength()}
+	case waMsg.GetEphemeralMessage().GetMessage() != nil:
+		return messageMediaOf(waMsg.GetEphemeralMessage().GetMessage())
+	case waMsg.GetViewOnceMessage().GetMessage() != nil:
+		return messageMediaOf(waMsg.GetViewOnceMessage().GetMessage())
+	case waMsg.GetViewOnceMessageV2().GetMessage() != nil:
+		return messageMediaOf(waMsg.GetViewOnceMessageV2().GetMessage())
+	case waMsg.GetDocumentWithCaptionMessage().GetMessage() != nil:
+		return messageMediaOf(waMsg.GetDocumentWithCaptionMessage().GetMessage())
+	}
+	return nil
+}
+
+// historyMediaFetcher baixa as mídias do histórico sem pedir reenvio ao aparelho: a resposta
+// chegaria pelo mesmo loop de eventos que processa o histórico. As expiradas ficam para o
+// download periódico das mídias pendentes.
+type historyMediaFetcher struct {
+	client *WhatsAppClient
+}
+
+// FetchMedia baixa a mídia da mensagem do histórico
+func (f historyMediaFetcher) FetchMedia(ctx context.Context, msg *message.Message) error {
+	return f.client.fetchMedia(ctx, msg, false)
+}
+
+// rawPayloadMessage reconstrói a mensagem do WhatsApp gravada no payload bruto: em "message" nas
+// mensagens recebidas ao vivo e em "raw_data.message.message" nas do histórico
+func rawPayloadMessage(payload map[string]any) (*waE2E.Message, error) {
+	raw, ok := payload["message"]
+	if history, isHistory := payload["raw_data"].(map[string]any); isHistory {
+		info, _ := history["message"].(map[string]any)
+		raw, ok = info["message"]
+	}
+	if !ok || raw == nil {
+		return nil, fmt.Errorf("payload bruto sem a mensagem original")
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar mensagem original: %w", err)
+	}
+
+	var waMsg waE2E.Message
+	if err := json.Unmarshal(data, &waMsg); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar mensagem original: %w", err)
+	}
+	return &waMsg, nil
+}
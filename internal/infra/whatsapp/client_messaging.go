@@ -1,21 +1,31 @@
 package whatsapp
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
+	domainMedia "zapcore/internal/domain/media"
 	"zapcore/internal/domain/whatsapp"
+	"zapcore/internal/infra/metrics"
+	"zapcore/internal/infra/storage"
 	"zapcore/internal/shared/media"
+	"zapcore/pkg/tracing"
 
 	"github.com/google/uuid"
 	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -49,9 +59,13 @@ func (ms *MessageSender) SendTextMessage(ctx context.Context, req *whatsapp.Send
 		return nil, fmt.Errorf("JID inválido: %w", err)
 	}
 
+	if err := ms.throttle(ctx, req.SessionID, jid); err != nil {
+		return nil, err
+	}
+
 	message := ms.buildTextMessage(req.Content, req.ReplyToID)
 
-	resp, err := client.SendMessage(ctx, jid, message)
+	resp, err := ms.sendMessage(ctx, client, jid, message)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao enviar mensagem: %w", err)
 	}
@@ -75,30 +89,39 @@ func (ms *MessageSender) SendImageMessage(ctx context.Context, req *whatsapp.Sen
 		return nil, fmt.Errorf("JID inválido: %w", err)
 	}
 
-	// Obter e validar dados da imagem
-	imageData, err := ms.getAndValidateMediaData(ctx, req.ImageData, req.ImageURL, req.Base64Data, req.MimeType, "image")
+	// Aplicar os limites de envio da sessão antes do upload
+	if err := ms.throttle(ctx, req.SessionID, jid); err != nil {
+		return nil, err
+	}
+
+	// Obter, normalizar e validar dados da imagem
+	imageMedia, err := ms.getAndValidateMediaData(ctx, req.ImageData, req.ImageURL, req.Base64Data, req.MimeType, "image", req.Normalize)
 	if err != nil {
 		return nil, err
 	}
+	imageData, mimeType := imageMedia.Data, imageMedia.MimeType
 
 	// Fazer upload da imagem
-	uploaded, err := client.Upload(ctx, imageData, whatsmeow.MediaImage)
+	uploaded, err := ms.upload(ctx, client, req.SessionID, imageData, whatsmeow.MediaImage)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao fazer upload da imagem: %w", err)
 	}
 
 	// Criar mensagem de imagem
-	message := ms.buildImageMessage(uploaded, req.MimeType, req.Caption, imageData, req.ReplyToID)
+	thumbs := ms.thumbnails(ctx, imageData, mimeType)
+	message := ms.buildImageMessage(uploaded, mimeType, req.Caption, imageData, thumbs, req.ReplyToID)
 
-	resp, err := client.SendMessage(ctx, jid, message)
+	resp, err := ms.sendMessage(ctx, client, jid, message)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao enviar imagem: %w", err)
 	}
 
 	return &whatsapp.MessageResponse{
-		MessageID: resp.ID,
-		Status:    "sent",
-		Timestamp: resp.Timestamp.Unix(),
+		MessageID:  resp.ID,
+		Status:     "sent",
+		Timestamp:  resp.Timestamp.Unix(),
+		PreviewURL: ms.storePreview(ctx, req.SessionID, jid, resp.ID, thumbs),
+		Normalized: imageMedia.Changes,
 	}, nil
 }
 
@@ -114,22 +137,40 @@ func (ms *MessageSender) SendAudioMessage(ctx context.Context, req *whatsapp.Sen
 		return nil, fmt.Errorf("JID inválido: %w", err)
 	}
 
+	// Aplicar os limites de envio da sessão antes do upload
+	if err := ms.throttle(ctx, req.SessionID, jid); err != nil {
+		return nil, err
+	}
+
 	// Obter e validar dados do áudio
-	audioData, err := ms.getAndValidateMediaData(ctx, req.AudioData, req.AudioURL, req.Base64Data, req.MimeType, "audio")
+	audioMedia, err := ms.getAndValidateMediaData(ctx, req.AudioData, req.AudioURL, req.Base64Data, req.MimeType, "audio", nil)
 	if err != nil {
 		return nil, err
 	}
+	audioData, mimeType := audioMedia.Data, audioMedia.MimeType
+
+	// Converter para mensagem de voz quando solicitado
+	var voice *media.VoiceNote
+	if req.PTT {
+		voice, err = ms.voiceNote(ctx, audioData, mimeType)
+		if err != nil {
+			return nil, err
+		}
+		if voice != nil {
+			audioData, mimeType = voice.Data, voice.MimeType
+		}
+	}
 
 	// Fazer upload do áudio
-	uploaded, err := client.Upload(ctx, audioData, whatsmeow.MediaAudio)
+	uploaded, err := ms.upload(ctx, client, req.SessionID, audioData, whatsmeow.MediaAudio)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao fazer upload do áudio: %w", err)
 	}
 
 	// Criar mensagem de áudio
-	message := ms.buildAudioMessage(uploaded, req.MimeType, audioData, req.ReplyToID)
+	message := ms.buildAudioMessage(uploaded, mimeType, audioData, voice, req.ReplyToID)
 
-	resp, err := client.SendMessage(ctx, jid, message)
+	resp, err := ms.sendMessage(ctx, client, jid, message)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao enviar áudio: %w", err)
 	}
@@ -138,6 +179,7 @@ func (ms *MessageSender) SendAudioMessage(ctx context.Context, req *whatsapp.Sen
 		MessageID: resp.ID,
 		Status:    "sent",
 		Timestamp: resp.Timestamp.Unix(),
+		PTT:       voice != nil,
 	}, nil
 }
 
@@ -153,30 +195,39 @@ func (ms *MessageSender) SendVideoMessage(ctx context.Context, req *whatsapp.Sen
 		return nil, fmt.Errorf("JID inválido: %w", err)
 	}
 
-	// Obter e validar dados do vídeo
-	videoData, err := ms.getAndValidateMediaData(ctx, req.VideoData, req.VideoURL, req.Base64Data, req.MimeType, "video")
+	// Aplicar os limites de envio da sessão antes do upload
+	if err := ms.throttle(ctx, req.SessionID, jid); err != nil {
+		return nil, err
+	}
+
+	// Obter, normalizar e validar dados do vídeo
+	videoMedia, err := ms.getAndValidateMediaData(ctx, req.VideoData, req.VideoURL, req.Base64Data, req.MimeType, "video", req.Normalize)
 	if err != nil {
 		return nil, err
 	}
+	videoData, mimeType := videoMedia.Data, videoMedia.MimeType
 
 	// Fazer upload do vídeo
-	uploaded, err := client.Upload(ctx, videoData, whatsmeow.MediaVideo)
+	uploaded, err := ms.upload(ctx, client, req.SessionID, videoData, whatsmeow.MediaVideo)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao fazer upload do vídeo: %w", err)
 	}
 
 	// Criar mensagem de vídeo
-	message := ms.buildVideoMessage(uploaded, req.MimeType, req.Caption, videoData, req.ReplyToID)
+	thumbs := ms.thumbnails(ctx, videoData, mimeType)
+	message := ms.buildVideoMessage(uploaded, mimeType, req.Caption, videoData, thumbs, req.ReplyToID)
 
-	resp, err := client.SendMessage(ctx, jid, message)
+	resp, err := ms.sendMessage(ctx, client, jid, message)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao enviar vídeo: %w", err)
 	}
 
 	return &whatsapp.MessageResponse{
-		MessageID: resp.ID,
-		Status:    "sent",
-		Timestamp: resp.Timestamp.Unix(),
+		MessageID:  resp.ID,
+		Status:     "sent",
+		Timestamp:  resp.Timestamp.Unix(),
+		PreviewURL: ms.storePreview(ctx, req.SessionID, jid, resp.ID, thumbs),
+		Normalized: videoMedia.Changes,
 	}, nil
 }
 
@@ -192,30 +243,38 @@ func (ms *MessageSender) SendDocumentMessage(ctx context.Context, req *whatsapp.
 		return nil, fmt.Errorf("JID inválido: %w", err)
 	}
 
+	// Aplicar os limites de envio da sessão antes do upload
+	if err := ms.throttle(ctx, req.SessionID, jid); err != nil {
+		return nil, err
+	}
+
 	// Obter e validar dados do documento
-	documentData, err := ms.getAndValidateMediaData(ctx, req.DocumentData, req.DocumentURL, req.Base64Data, req.MimeType, "document")
+	documentMedia, err := ms.getAndValidateMediaData(ctx, req.DocumentData, req.DocumentURL, req.Base64Data, req.MimeType, "document", nil)
 	if err != nil {
 		return nil, err
 	}
+	documentData := documentMedia.Data
 
 	// Fazer upload do documento
-	uploaded, err := client.Upload(ctx, documentData, whatsmeow.MediaDocument)
+	uploaded, err := ms.upload(ctx, client, req.SessionID, documentData, whatsmeow.MediaDocument)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao fazer upload do documento: %w", err)
 	}
 
 	// Criar mensagem de documento
-	message := ms.buildDocumentMessage(uploaded, req.MimeType, req.FileName, documentData, req.ReplyToID)
+	thumbs := ms.thumbnails(ctx, documentData, req.MimeType)
+	message := ms.buildDocumentMessage(uploaded, req.MimeType, req.FileName, documentData, thumbs, req.ReplyToID)
 
-	resp, err := client.SendMessage(ctx, jid, message)
+	resp, err := ms.sendMessage(ctx, client, jid, message)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao enviar documento: %w", err)
 	}
 
 	return &whatsapp.MessageResponse{
-		MessageID: resp.ID,
-		Status:    "sent",
-		Timestamp: resp.Timestamp.Unix(),
+		MessageID:  resp.ID,
+		Status:     "sent",
+		Timestamp:  resp.Timestamp.Unix(),
+		PreviewURL: ms.storePreview(ctx, req.SessionID, jid, resp.ID, thumbs),
 	}, nil
 }
 
@@ -231,33 +290,238 @@ func (ms *MessageSender) SendStickerMessage(ctx context.Context, req *whatsapp.S
 		return nil, fmt.Errorf("JID inválido: %w", err)
 	}
 
-	// Obter e validar dados do sticker
-	stickerData, err := ms.getAndValidateMediaData(ctx, req.StickerData, req.StickerURL, req.Base64Data, req.MimeType, "sticker")
+	// Aplicar os limites de envio da sessão antes do upload
+	if err := ms.throttle(ctx, req.SessionID, jid); err != nil {
+		return nil, err
+	}
+
+	// Obter, normalizar e validar dados do sticker
+	stickerMedia, err := ms.getAndValidateMediaData(ctx, req.StickerData, req.StickerURL, req.Base64Data, req.MimeType, "sticker", req.Normalize)
 	if err != nil {
 		return nil, err
 	}
+	stickerData, mimeType := stickerMedia.Data, stickerMedia.MimeType
 
 	// Fazer upload do sticker
-	uploaded, err := client.Upload(ctx, stickerData, whatsmeow.MediaImage)
+	uploaded, err := ms.upload(ctx, client, req.SessionID, stickerData, whatsmeow.MediaImage)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao fazer upload do sticker: %w", err)
 	}
 
 	// Criar mensagem de sticker
-	message := ms.buildStickerMessage(uploaded, req.MimeType, stickerData, req.ReplyToID)
+	thumbs := ms.thumbnails(ctx, stickerData, mimeType)
+	message := ms.buildStickerMessage(uploaded, mimeType, stickerData, thumbs, req.ReplyToID)
 
-	resp, err := client.SendMessage(ctx, jid, message)
+	resp, err := ms.sendMessage(ctx, client, jid, message)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao enviar sticker: %w", err)
 	}
 
 	return &whatsapp.MessageResponse{
-		MessageID: resp.ID,
-		Status:    "sent",
-		Timestamp: resp.Timestamp.Unix(),
+		MessageID:  resp.ID,
+		Status:     "sent",
+		Timestamp:  resp.Timestamp.Unix(),
+		PreviewURL: ms.storePreview(ctx, req.SessionID, jid, resp.ID, thumbs),
+		Normalized: stickerMedia.Changes,
 	}, nil
 }
 
+// throttle aplica os limites de envio da sessão ao destinatário, aguardando na fila quando necessário
+func (ms *MessageSender) throttle(ctx context.Context, sessionID uuid.UUID, to types.JID) error {
+	if ms.client.sendGovernor == nil {
+		return nil
+	}
+	ctx, span := tracing.Start(ctx, "SendGovernor.Acquire")
+	err := ms.client.sendGovernor.Acquire(ctx, sessionID, to.ToNonAD().String())
+	tracing.End(span, err)
+	return err
+}
+
+// sendMessage envia a mensagem pelo whatsmeow dentro de um span
+func (ms *MessageSender) sendMessage(ctx context.Context, client *whatsmeow.Client, to types.JID, message *waProto.Message) (whatsmeow.SendResponse, error) {
+	ctx, span := tracing.Start(ctx, "whatsmeow.SendMessage", attribute.String("messaging.destination.name", to.Server))
+	resp, err := client.SendMessage(ctx, to, message)
+	if err == nil {
+		span.SetAttributes(attribute.String("messaging.message.id", resp.ID))
+	}
+	tracing.End(span, err)
+	return resp, err
+}
+
+// upload envia a mídia criptografada para os servidores do WhatsApp dentro de um span. Com o
+// cache de uploads ativo, um conteúdo já enviado pelo mesmo tenant dentro da validade reaproveita o
+// upload anterior.
+func (ms *MessageSender) upload(ctx context.Context, client *whatsmeow.Client, sessionID uuid.UUID, data []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	var tenantID, digest string
+	if ms.client.mediaUploads != nil {
+		var err error
+		tenantID, err = ms.client.sessionTenant(ctx, sessionID)
+		if err != nil {
+			ms.client.logger.Warn().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao resolver tenant da sessão, enviando mídia sem reaproveitar uploads")
+		} else {
+			sum := sha256.Sum256(data)
+			digest = hex.EncodeToString(sum[:])
+			if uploaded, ok := ms.cachedUpload(ctx, tenantID, digest, mediaType); ok {
+				return uploaded, nil
+			}
+		}
+	}
+
+	ctx, span := tracing.Start(ctx, "whatsmeow.Upload",
+		attribute.String("media.type", string(mediaType)),
+		attribute.Int("media.size", len(data)),
+	)
+	uploaded, err := client.Upload(ctx, data, mediaType)
+	tracing.End(span, err)
+
+	if err == nil && digest != "" {
+		ms.saveUpload(ctx, tenantID, digest, mediaType, uploaded)
+	}
+	return uploaded, err
+}
+
+// cachedUpload busca o upload anterior do conteúdo pelo tenant; falhas na consulta apenas levam a
+// um novo upload
+func (ms *MessageSender) cachedUpload(ctx context.Context, tenantID, digest string, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, bool) {
+	cached, err := ms.client.mediaUploads.Get(ctx, tenantID, digest, string(mediaType))
+	if err != nil {
+		if !errors.Is(err, domainMedia.ErrUploadNotFound) {
+			ms.client.logger.Warn().Err(err).Str("sha256", digest).Msg("Erro ao consultar uploads anteriores da mídia")
+		}
+		metrics.IncMediaDedup("whatsapp", false)
+		return whatsmeow.UploadResponse{}, false
+	}
+	if !cached.IsReusable(ms.client.uploadValidity) {
+		metrics.IncMediaDedup("whatsapp", false)
+		return whatsmeow.UploadResponse{}, false
+	}
+
+	fileSHA256, _ := hex.DecodeString(digest)
+	metrics.IncMediaDedup("whatsapp", true)
+	ms.client.logger.Debug().
+		Str("sha256", digest).
+		Str("media_type", string(mediaType)).
+		Time("uploaded_at", cached.UploadedAt).
+		Msg("♻️ Reaproveitando upload anterior da mídia")
+
+	return whatsmeow.UploadResponse{
+		URL:           cached.URL,
+		DirectPath:    cached.DirectPath,
+		MediaKey:      cached.MediaKey,
+		FileEncSHA256: cached.FileEncSHA256,
+		FileSHA256:    fileSHA256,
+		FileLength:    uint64(cached.FileLength),
+	}, true
+}
+
+// saveUpload guarda o upload para os próximos envios do mesmo conteúdo; falhas não impedem o envio
+func (ms *MessageSender) saveUpload(ctx context.Context, tenantID, digest string, mediaType whatsmeow.MediaType, uploaded whatsmeow.UploadResponse) {
+	err := ms.client.mediaUploads.Save(ctx, &domainMedia.Upload{
+		TenantID:      tenantID,
+		SHA256:        digest,
+		MediaType:     string(mediaType),
+		URL:           uploaded.URL,
+		DirectPath:    uploaded.DirectPath,
+		MediaKey:      uploaded.MediaKey,
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileLength:    int64(uploaded.FileLength),
+		UploadedAt:    time.Now(),
+	})
+	if err != nil {
+		ms.client.logger.Warn().Err(err).Str("sha256", digest).Msg("Erro ao guardar upload da mídia")
+	}
+}
+
+// thumbnails gera o thumbnail embutido e o preview da mídia; falhas não impedem o envio
+func (ms *MessageSender) thumbnails(ctx context.Context, data []byte, mimeType string) *media.Thumbnails {
+	if ms.client.thumbnailer == nil {
+		return nil
+	}
+	if mimeType == "" {
+		mimeType = media.DetectMimeType(data)
+	}
+
+	ctx, span := tracing.Start(ctx, "Thumbnailer.Generate", attribute.String("media.mime_type", mimeType))
+	thumbs, err := ms.client.thumbnailer.Generate(ctx, data, mimeType)
+	if errors.Is(err, media.ErrThumbnailUnsupported) {
+		tracing.End(span, nil)
+		return nil
+	}
+	tracing.End(span, err)
+	if err != nil {
+		ms.client.logger.Warn().Err(err).Str("mime_type", mimeType).Msg("Erro ao gerar thumbnail, enviando mídia sem thumbnail")
+		return nil
+	}
+
+	return thumbs
+}
+
+// storePreview grava o preview da mídia enviada no armazenamento e retorna sua URL pré-assinada;
+// sem armazenamento, sem preview ou em caso de erro retorna vazio
+func (ms *MessageSender) storePreview(ctx context.Context, sessionID uuid.UUID, to types.JID, messageID string, thumbs *media.Thumbnails) string {
+	if ms.client.mediaStorage == nil || thumbs == nil || len(thumbs.Preview) == 0 {
+		return ""
+	}
+
+	// O preview fica sob o prefixo do tenant da sessão, como as demais mídias, para entrar na
+	// retenção e na coleta do tenant
+	tenantID, err := ms.client.sessionTenant(ctx, sessionID)
+	if err != nil {
+		ms.client.logger.Warn().Err(err).Str("message_id", messageID).Msg("Preview da mídia não armazenado")
+		return ""
+	}
+
+	opts := storage.MediaUploadOptions{
+		TenantID:    tenantID,
+		SessionID:   sessionID,
+		ChatJID:     to.String(),
+		Direction:   "outbound",
+		MessageID:   messageID,
+		ContentType: "image/jpeg",
+		Extension:   "preview.jpg",
+		Size:        int64(len(thumbs.Preview)),
+	}
+
+	if ms.client.mediaQuota != nil {
+		if _, err := ms.client.mediaQuota.CheckStorage(ctx, sessionID, opts.Size); err != nil {
+			ms.client.logger.Warn().Err(err).Str("message_id", messageID).Msg("Preview da mídia não armazenado")
+			return ""
+		}
+	}
+
+	objectPath, err := ms.client.mediaStorage.UploadMedia(ctx, bytes.NewReader(thumbs.Preview), opts)
+	if err != nil {
+		ms.client.logger.Warn().Err(err).Str("message_id", messageID).Msg("Erro ao armazenar preview da mídia")
+		return ""
+	}
+
+	previewURL, err := ms.client.mediaStorage.GetMediaURL(ctx, objectPath)
+	if err != nil {
+		ms.client.logger.Warn().Err(err).Str("object_path", objectPath).Msg("Erro ao gerar URL do preview")
+		return ""
+	}
+
+	return previewURL
+}
+
+// voiceNote converte o áudio em mensagem de voz; sem conversor disponível para o formato,
+// retorna nil e o áudio segue como arquivo
+func (ms *MessageSender) voiceNote(ctx context.Context, data []byte, mimeType string) (*media.VoiceNote, error) {
+	ctx, span := tracing.Start(ctx, "AudioTranscoder.ToVoiceNote", attribute.String("media.mime_type", mimeType))
+	voice, err := ms.client.transcoder.ToVoiceNote(ctx, data, mimeType)
+	if errors.Is(err, media.ErrTranscodeUnsupported) {
+		tracing.End(span, nil)
+		ms.client.logger.Warn().Str("mime_type", mimeType).Msg("ffmpeg indisponível para converter o áudio, enviando como arquivo de áudio")
+		return nil, nil
+	}
+	tracing.End(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter áudio para mensagem de voz: %w", err)
+	}
+
+	return voice, nil
+}
+
 // getClient obtém cliente whatsmeow para sessão
 func (ms *MessageSender) getClient(sessionID uuid.UUID) (*whatsmeow.Client, error) {
 	ms.client.clientsMutex.RLock()
@@ -326,7 +590,7 @@ func (ms *MessageSender) buildTextMessage(content, replyToID string) *waProto.Me
 }
 
 // buildImageMessage constrói mensagem de imagem
-func (ms *MessageSender) buildImageMessage(uploaded whatsmeow.UploadResponse, mimeType, caption string, data []byte, replyToID string) *waProto.Message {
+func (ms *MessageSender) buildImageMessage(uploaded whatsmeow.UploadResponse, mimeType, caption string, data []byte, thumbs *media.Thumbnails, replyToID string) *waProto.Message {
 	imageMsg := &waProto.ImageMessage{
 		URL:           proto.String(uploaded.URL),
 		DirectPath:    proto.String(uploaded.DirectPath),
@@ -338,6 +602,12 @@ func (ms *MessageSender) buildImageMessage(uploaded whatsmeow.UploadResponse, mi
 		Caption:       proto.String(caption),
 	}
 
+	if thumbs != nil {
+		imageMsg.JPEGThumbnail = thumbs.Inline
+		imageMsg.Width = proto.Uint32(uint32(thumbs.Width))
+		imageMsg.Height = proto.Uint32(uint32(thumbs.Height))
+	}
+
 	message := &waProto.Message{
 		ImageMessage: imageMsg,
 	}
@@ -352,7 +622,7 @@ func (ms *MessageSender) buildImageMessage(uploaded whatsmeow.UploadResponse, mi
 }
 
 // buildAudioMessage constrói mensagem de áudio
-func (ms *MessageSender) buildAudioMessage(uploaded whatsmeow.UploadResponse, mimeType string, data []byte, replyToID string) *waProto.Message {
+func (ms *MessageSender) buildAudioMessage(uploaded whatsmeow.UploadResponse, mimeType string, data []byte, voice *media.VoiceNote, replyToID string) *waProto.Message {
 	audioMsg := &waProto.AudioMessage{
 		URL:           proto.String(uploaded.URL),
 		DirectPath:    proto.String(uploaded.DirectPath),
@@ -363,6 +633,14 @@ func (ms *MessageSender) buildAudioMessage(uploaded whatsmeow.UploadResponse, mi
 		FileLength:    proto.Uint64(uint64(len(data))),
 	}
 
+	if voice != nil {
+		audioMsg.PTT = proto.Bool(true)
+		audioMsg.Seconds = proto.Uint32(voice.Seconds)
+		if len(voice.Waveform) > 0 {
+			audioMsg.Waveform = voice.Waveform
+		}
+	}
+
 	message := &waProto.Message{
 		AudioMessage: audioMsg,
 	}
@@ -377,7 +655,7 @@ func (ms *MessageSender) buildAudioMessage(uploaded whatsmeow.UploadResponse, mi
 }
 
 // buildVideoMessage constrói mensagem de vídeo
-func (ms *MessageSender) buildVideoMessage(uploaded whatsmeow.UploadResponse, mimeType, caption string, data []byte, replyToID string) *waProto.Message {
+func (ms *MessageSender) buildVideoMessage(uploaded whatsmeow.UploadResponse, mimeType, caption string, data []byte, thumbs *media.Thumbnails, replyToID string) *waProto.Message {
 	videoMsg := &waProto.VideoMessage{
 		URL:           proto.String(uploaded.URL),
 		DirectPath:    proto.String(uploaded.DirectPath),
@@ -389,6 +667,12 @@ func (ms *MessageSender) buildVideoMessage(uploaded whatsmeow.UploadResponse, mi
 		Caption:       proto.String(caption),
 	}
 
+	if thumbs != nil {
+		videoMsg.JPEGThumbnail = thumbs.Inline
+		videoMsg.Width = proto.Uint32(uint32(thumbs.Width))
+		videoMsg.Height = proto.Uint32(uint32(thumbs.Height))
+	}
+
 	message := &waProto.Message{
 		VideoMessage: videoMsg,
 	}
@@ -403,7 +687,7 @@ func (ms *MessageSender) buildVideoMessage(uploaded whatsmeow.UploadResponse, mi
 }
 
 // buildDocumentMessage constrói mensagem de documento
-func (ms *MessageSender) buildDocumentMessage(uploaded whatsmeow.UploadResponse, mimeType, fileName string, data []byte, replyToID string) *waProto.Message {
+func (ms *MessageSender) buildDocumentMessage(uploaded whatsmeow.UploadResponse, mimeType, fileName string, data []byte, thumbs *media.Thumbnails, replyToID string) *waProto.Message {
 	docMsg := &waProto.DocumentMessage{
 		URL:           proto.String(uploaded.URL),
 		DirectPath:    proto.String(uploaded.DirectPath),
@@ -415,6 +699,12 @@ func (ms *MessageSender) buildDocumentMessage(uploaded whatsmeow.UploadResponse,
 		FileName:      proto.String(fileName),
 	}
 
+	if thumbs != nil {
+		docMsg.JPEGThumbnail = thumbs.Inline
+		docMsg.ThumbnailWidth = proto.Uint32(uint32(thumbs.InlineWidth))
+		docMsg.ThumbnailHeight = proto.Uint32(uint32(thumbs.InlineHeight))
+	}
+
 	message := &waProto.Message{
 		DocumentMessage: docMsg,
 	}
@@ -429,7 +719,7 @@ func (ms *MessageSender) buildDocumentMessage(uploaded whatsmeow.UploadResponse,
 }
 
 // buildStickerMessage constrói mensagem de sticker
-func (ms *MessageSender) buildStickerMessage(uploaded whatsmeow.UploadResponse, mimeType string, data []byte, replyToID string) *waProto.Message {
+func (ms *MessageSender) buildStickerMessage(uploaded whatsmeow.UploadResponse, mimeType string, data []byte, thumbs *media.Thumbnails, replyToID string) *waProto.Message {
 	stickerMsg := &waProto.StickerMessage{
 		URL:           proto.String(uploaded.URL),
 		DirectPath:    proto.String(uploaded.DirectPath),
@@ -440,6 +730,14 @@ func (ms *MessageSender) buildStickerMessage(uploaded whatsmeow.UploadResponse,
 		FileLength:    proto.Uint64(uint64(len(data))),
 	}
 
+	if thumbs != nil {
+		stickerMsg.Width = proto.Uint32(uint32(thumbs.Width))
+		stickerMsg.Height = proto.Uint32(uint32(thumbs.Height))
+		if thumbnail, err := thumbs.InlinePNG(); err == nil {
+			stickerMsg.PngThumbnail = thumbnail
+		}
+	}
+
 	message := &waProto.Message{
 		StickerMessage: stickerMsg,
 	}
@@ -454,7 +752,7 @@ func (ms *MessageSender) buildStickerMessage(uploaded whatsmeow.UploadResponse,
 }
 
 // getAndValidateMediaData obtém e valida dados de mídia
-func (ms *MessageSender) getAndValidateMediaData(ctx context.Context, data io.Reader, url, base64Data, mimeType, mediaType string) ([]byte, error) {
+func (ms *MessageSender) getAndValidateMediaData(ctx context.Context, data io.Reader, url, base64Data, mimeType, mediaType string, opts *whatsapp.NormalizeOptions) (*media.Normalized, error) {
 	// Obter dados da mídia
 	mediaReader, err := ms.getMediaData(ctx, data, url, base64Data)
 	if err != nil {
@@ -467,12 +765,77 @@ func (ms *MessageSender) getAndValidateMediaData(ctx context.Context, data io.Re
 		return nil, fmt.Errorf("erro ao ler dados da mídia: %w", err)
 	}
 
+	// Normalizar antes de validar, corrigindo formatos e tamanhos que seriam recusados
+	normalized, err := ms.normalize(ctx, mediaData, mimeType, mediaType, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validar mídia
-	if err := ms.validateMedia(mediaData, mimeType, mediaType); err != nil {
+	if err := ms.validateMedia(normalized.Data, normalized.MimeType, mediaType); err != nil {
 		return nil, fmt.Errorf("validação de mídia falhou: %w", err)
 	}
 
-	return mediaData, nil
+	return normalized, nil
+}
+
+// normalize aplica a normalização configurada, ajustada pelas opções da requisição
+func (ms *MessageSender) normalize(ctx context.Context, data []byte, mimeType, mediaType string, opts *whatsapp.NormalizeOptions) (*media.Normalized, error) {
+	normalizer := ms.client.normalizer
+	if normalizer == nil {
+		return &media.Normalized{Data: data, MimeType: mimeType}, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "Normalizer.Normalize",
+		attribute.String("media.type", mediaType),
+		attribute.String("media.mime_type", mimeType),
+	)
+	normalized, err := normalizer.Normalize(ctx, data, mimeType, mediaType, normalizeSteps(normalizer.Defaults(), opts))
+	tracing.End(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao normalizar mídia: %w", err)
+	}
+
+	if len(normalized.Changes) > 0 {
+		ms.client.logger.Info().
+			Str("media_type", mediaType).
+			Strs("changes", normalized.Changes).
+			Int("original_size", len(data)).
+			Int("size", len(normalized.Data)).
+			Msg("Mídia normalizada antes do envio")
+	}
+
+	return normalized, nil
+}
+
+// normalizeSteps aplica as opções da requisição sobre as etapas padrão do servidor
+func normalizeSteps(defaults media.NormalizeSteps, opts *whatsapp.NormalizeOptions) media.NormalizeSteps {
+	if opts == nil {
+		return defaults
+	}
+
+	steps := defaults
+	if opts.Enabled != nil {
+		steps = media.NormalizeSteps{}
+		if *opts.Enabled {
+			steps = media.AllNormalizeSteps()
+		}
+	}
+
+	if opts.TranscodeVideo != nil {
+		steps.TranscodeVideo = *opts.TranscodeVideo
+	}
+	if opts.ResizeImage != nil {
+		steps.ResizeImage = *opts.ResizeImage
+	}
+	if opts.ConvertSticker != nil {
+		steps.ConvertSticker = *opts.ConvertSticker
+	}
+	if opts.StripGPS != nil {
+		steps.StripGPS = *opts.StripGPS
+	}
+
+	return steps
 }
 
 // getMediaData obtém dados de mídia de Reader, URL ou base64
@@ -540,10 +903,9 @@ func (ms *MessageSender) validateMedia(data []byte, mimeType, mediaType string)
 		return fmt.Errorf("dados de mídia estão vazios")
 	}
 
-	// Validar tamanho máximo (64MB)
-	maxSize := 64 * 1024 * 1024
-	if len(data) > maxSize {
-		return fmt.Errorf("arquivo muito grande: %d bytes (máximo: %d bytes)", len(data), maxSize)
+	// Validar tamanho máximo do tipo de mídia
+	if err := media.ValidateFileSize(int64(len(data)), mediaType); err != nil {
+		return err
 	}
 
 	// Validar MIME type baseado no tipo de mídia
@@ -1,25 +1,23 @@
 package whatsapp
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
 	"zapcore/internal/domain/chat"
 	"zapcore/internal/domain/contact"
+	"zapcore/internal/domain/media"
 	"zapcore/internal/domain/message"
-	"zapcore/internal/infra/storage"
+	"zapcore/internal/domain/whatsapp"
 	"zapcore/pkg/logger"
 
 	"github.com/google/uuid"
-	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
 
@@ -31,17 +29,31 @@ const (
 	LogFieldChatJID    = "chat_jid"
 	LogFieldEventType  = "event_type"
 	LogFieldError      = "error"
+
+	// inboundProcessTimeout limita o tempo de processamento pós-persistência
+	inboundProcessTimeout = 30 * time.Second
 )
 
 // StorageHandler gerencia a persistência automática de eventos do WhatsApp
 type StorageHandler struct {
 	messageRepo     message.Repository
+	receiptRepo     message.ReceiptRepository
+	readRule        message.ReadRule
 	chatRepo        chat.Repository
 	contactRepo     contact.Repository
 	mediaDownloader *MediaDownloader
+	historyMedia    media.Fetcher
+	recipients      RecipientCounter
 	logger          *logger.Logger
 	handlers        *EventHandlers
 	storage         *StorageOperations
+	processors      []whatsapp.InboundProcessor
+}
+
+// RecipientCounter conta os destinatários de uma mensagem enviada à conversa; zero quando a
+// contagem não é conhecida
+type RecipientCounter interface {
+	CountRecipients(ctx context.Context, sessionID uuid.UUID, chat types.JID) (int, error)
 }
 
 // NewStorageHandler cria uma nova instância do handler de storage
@@ -56,6 +68,7 @@ func NewStorageHandler(
 		chatRepo:        chatRepo,
 		contactRepo:     contactRepo,
 		mediaDownloader: mediaDownloader,
+		readRule:        message.ReadRule{Mode: message.ReadRuleAny},
 		logger:          logger.Get(),
 	}
 
@@ -66,6 +79,41 @@ func NewStorageHandler(
 	return handler
 }
 
+// SetReceiptTracking grava as confirmações de cada destinatário e agrega o status das
+// mensagens pela regra de leitura informada
+func (h *StorageHandler) SetReceiptTracking(repo message.ReceiptRepository, rule message.ReadRule) {
+	h.receiptRepo = repo
+	h.readRule = rule
+}
+
+// AddInboundProcessor registra um processador executado para cada mensagem recebida
+func (h *StorageHandler) AddInboundProcessor(processor whatsapp.InboundProcessor) {
+	h.processors = append(h.processors, processor)
+}
+
+// dispatchInbound executa os processadores registrados fora do loop de eventos do whatsmeow
+func (h *StorageHandler) dispatchInbound(event *whatsapp.MessageEvent) {
+	if len(h.processors) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), inboundProcessTimeout)
+		defer cancel()
+
+		for _, processor := range h.processors {
+			if err := processor.ProcessInbound(ctx, event); err != nil {
+				h.logger.Error().
+					Err(err).
+					Str(LogFieldSessionID, event.SessionID.String()).
+					Str(LogFieldMessageID, event.MessageID).
+					Str("processor", fmt.Sprintf("%T", processor)).
+					Msg("Erro ao processar mensagem recebida")
+			}
+		}
+	}()
+}
+
 // HandleEvent processa eventos do WhatsApp e os persiste no banco de dados
 func (h *StorageHandler) HandleEvent(ctx context.Context, sessionID uuid.UUID, evt any) error {
 	switch v := evt.(type) {
@@ -462,7 +510,7 @@ func (h *StorageHandler) processCompleteHistoricalMessage(ctx context.Context, s
 			Bool("media_downloader_available", h.mediaDownloader != nil).
 			Msg("🔍 DEBUG: Resultado da verificação de URL")
 
-		if hasURL && h.mediaDownloader != nil {
+		if hasURL && h.historyMedia != nil {
 			// Verificar se a mensagem é recente o suficiente para tentar download
 			// URLs do WhatsApp expiram após um tempo, então só tentamos baixar mídias recentes
 			cutoffTime := time.Now().AddDate(0, 0, -7) // 7 dias atrás (URLs do WhatsApp expiram rapidamente)
@@ -475,7 +523,7 @@ func (h *StorageHandler) processCompleteHistoricalMessage(ctx context.Context, s
 					Time("cutoff_time", cutoffTime).
 					Msg("🎬 INICIANDO: Processamento de mídia histórica (mensagem recente)")
 
-				if err := h.processHistoricalMedia(ctx, msg, mediaData); err != nil {
+				if err := h.processHistoricalMedia(ctx, msg); err != nil {
 					h.logger.Error().Err(err).
 						Str("message_id", msgID).
 						Time("message_timestamp", msg.Timestamp).
@@ -493,14 +541,14 @@ func (h *StorageHandler) processCompleteHistoricalMessage(ctx context.Context, s
 					Str("message_id", msgID).
 					Time("message_timestamp", msg.Timestamp).
 					Time("cutoff_time", cutoffTime).
-					Msg("⏭️ PULANDO: Mídia muito antiga, URLs provavelmente expiradas")
+					Msg("⏭️ PULANDO: Mídia muito antiga, fica para o download periódico das mídias pendentes")
 			}
 		} else {
 			h.logger.Debug().
 				Str("session_id", sessionID.String()).
 				Str("message_id", msgID).
 				Bool("has_url", hasURL).
-				Bool("has_downloader", h.mediaDownloader != nil).
+				Bool("has_downloader", h.historyMedia != nil).
 				Msg("🚫 DEBUG: Mídia não processada - condições não atendidas")
 		}
 	} else {
@@ -629,350 +677,23 @@ func getMapKeys(m map[string]any) []string {
 	return keys
 }
 
-// processHistoricalMedia processa mídia de mensagens históricas usando DownloadMediaWithPath do whatsmeow
-func (h *StorageHandler) processHistoricalMedia(ctx context.Context, msg *message.Message, mediaData map[string]any) error {
-	msgIDStr := msg.ID.String()
-
-	// Verificar se a mídia já foi processada para esta mensagem
-	if h.storage.IsMediaAlreadyProcessed(msgIDStr) {
-		h.logger.Debug().
-			Str("message_id", msgIDStr).
-			Msg("Mídia já processada para esta mensagem, pulando")
-		return nil
-	}
-
-	h.logger.Info().
-		Str("message_id", msgIDStr).
-		Interface("media_data", mediaData).
-		Msg("🎬 Iniciando processamento de mídia histórica")
-
-	// Tentar extrair informações necessárias para download via whatsmeow
-	directPath, mediaKey, fileEncSHA256, fileSHA256, fileLength, mimeType, fileName := h.extractMediaMetadata(mediaData)
-
-	if directPath == "" || len(mediaKey) == 0 {
-		h.logger.Warn().
-			Str("message_id", msgIDStr).
-			Str("direct_path", directPath).
-			Int("media_key_len", len(mediaKey)).
-			Msg("⚠️ Metadados insuficientes para download via whatsmeow - usando fallback")
-
-		// Usar fallback se não temos metadados suficientes
-		return h.processHistoricalMediaFallback(ctx, msg, mediaData)
-	}
-
-	// Obter cliente whatsmeow da sessão
-	client, err := h.getWhatsmeowClient(msg.SessionID)
-	if err != nil {
-		h.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Msg("❌ Erro ao obter cliente whatsmeow - usando fallback")
-		return h.processHistoricalMediaFallback(ctx, msg, mediaData)
-	}
-
-	// Se não há nome de arquivo, usar o ID da mensagem
-	if fileName == "" {
-		fileName = msgIDStr
-	}
-
-	h.logger.Info().
-		Str("message_id", msgIDStr).
-		Str("direct_path", directPath).
-		Str("mime_type", mimeType).
-		Str("file_name", fileName).
-		Uint64("file_length", fileLength).
-		Msg("🔑 Baixando mídia via DownloadMediaWithPath com chaves de descriptografia")
-
-	// Usar DownloadMediaWithPath para download da mídia
-	h.logger.Info().
-		Str("message_id", msgIDStr).
-		Str("direct_path", directPath).
-		Msg("🔑 Baixando mídia via DownloadMediaWithPath com chaves de descriptografia")
-
-	mediaBytes, err := client.DownloadMediaWithPath(ctx, directPath, fileEncSHA256, fileSHA256, mediaKey, int(fileLength), whatsmeow.MediaImage, "")
-	if err != nil {
-		h.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Str("direct_path", directPath).
-			Msg("❌ Erro ao baixar mídia via DownloadMediaWithPath - usando fallback")
-
-		// Usar fallback se falhou
-		return h.processHistoricalMediaFallback(ctx, msg, mediaData)
-	}
-
-	// Determinar extensão
-	extension := h.storage.GetExtensionFromMimeType(mimeType, ".bin")
-	if fileName != "" {
-		if ext := filepath.Ext(fileName); ext != "" {
-			extension = strings.TrimPrefix(ext, ".")
-		}
-	}
-
-	// Fazer upload para MinIO usando o MediaDownloader existente
-	if h.mediaDownloader == nil {
-		return fmt.Errorf("MediaDownloader não disponível")
-	}
-
-	// Usar o método existente do MediaDownloader para upload
-	reader := bytes.NewReader(mediaBytes)
-	objectPath, err := h.mediaDownloader.minioClient.UploadMedia(ctx, reader, storage.MediaUploadOptions{
-		SessionID:   msg.SessionID,
-		ChatJID:     msg.ChatJID,
-		Direction:   string(msg.Direction),
-		MessageID:   msgIDStr,
-		ContentType: mimeType,
-		Extension:   extension,
-		Size:        int64(len(mediaBytes)),
-	})
-
-	if err != nil {
-		return fmt.Errorf("erro ao fazer upload para MinIO: %w", err)
+// processHistoricalMedia baixa a mídia da mensagem histórica com as chaves gravadas no payload
+// bruto e grava o caminho na mensagem. Mídias expiradas ficam para o download periódico.
+func (h *StorageHandler) processHistoricalMedia(ctx context.Context, msg *message.Message) error {
+	if err := h.historyMedia.FetchMedia(ctx, msg); err != nil {
+		return err
 	}
 
-	// Atualizar mensagem com path da mídia
-	if err := h.storage.UpdateMessageWithMediaPath(ctx, msgIDStr, objectPath); err != nil {
-		h.logger.Error().Err(err).
-			Str("message_id", msgIDStr).
-			Str("object_path", objectPath).
-			Msg("Erro ao atualizar mensagem com path da mídia")
-		// Não retorna erro para não impedir o processamento
+	if err := h.messageRepo.UpdateMediaInfo(ctx, msg); err != nil {
+		return fmt.Errorf("erro ao gravar caminho da mídia: %w", err)
 	}
 
 	h.logger.Info().
-		Str("message_id", msgIDStr).
-		Str("object_path", objectPath).
-		Int("size_bytes", len(mediaBytes)).
-		Msg("✅ Mídia histórica baixada via DownloadMediaWithPath e armazenada com sucesso")
-
-	return nil
-}
-
-// extractMediaMetadata extrai metadados necessários para download via whatsmeow
-func (h *StorageHandler) extractMediaMetadata(mediaData map[string]any) (directPath string, mediaKey []byte, fileEncSHA256 []byte, fileSHA256 []byte, fileLength uint64, mimeType string, fileName string) {
-	// Procurar em diferentes tipos de mensagem de mídia
-	mediaTypes := []string{"audioMessage", "imageMessage", "videoMessage", "documentMessage", "stickerMessage"}
-
-	for _, mediaType := range mediaTypes {
-		if mediaObj, exists := mediaData[mediaType]; exists {
-			if mediaMap, ok := mediaObj.(map[string]any); ok {
-				// Extrair directPath
-				if dp, exists := mediaMap["directPath"]; exists {
-					if dpStr, ok := dp.(string); ok {
-						directPath = dpStr
-					}
-				}
-
-				// Extrair mediaKey (base64 encoded)
-				if mk, exists := mediaMap["mediaKey"]; exists {
-					if mkStr, ok := mk.(string); ok {
-						if decoded, err := base64.StdEncoding.DecodeString(mkStr); err == nil {
-							mediaKey = decoded
-						}
-					}
-				}
-
-				// Extrair fileEncSha256 (base64 encoded)
-				if fes, exists := mediaMap["fileEncSha256"]; exists {
-					if fesStr, ok := fes.(string); ok {
-						if decoded, err := base64.StdEncoding.DecodeString(fesStr); err == nil {
-							fileEncSHA256 = decoded
-						}
-					}
-				}
-
-				// Extrair fileSha256 (base64 encoded)
-				if fs, exists := mediaMap["fileSha256"]; exists {
-					if fsStr, ok := fs.(string); ok {
-						if decoded, err := base64.StdEncoding.DecodeString(fsStr); err == nil {
-							fileSHA256 = decoded
-						}
-					}
-				}
-
-				// Extrair fileLength
-				if fl, exists := mediaMap["fileLength"]; exists {
-					switch v := fl.(type) {
-					case uint64:
-						fileLength = v
-					case int64:
-						fileLength = uint64(v)
-					case int:
-						fileLength = uint64(v)
-					case float64:
-						fileLength = uint64(v)
-					case string:
-						if parsed, err := strconv.ParseUint(v, 10, 64); err == nil {
-							fileLength = parsed
-						}
-					}
-				}
-
-				// Extrair mimeType
-				if mt, exists := mediaMap["mimetype"]; exists {
-					if mtStr, ok := mt.(string); ok {
-						mimeType = mtStr
-					}
-				}
-
-				// Extrair fileName
-				if fn, exists := mediaMap["fileName"]; exists {
-					if fnStr, ok := fn.(string); ok {
-						fileName = fnStr
-					}
-				}
-
-				// Se encontrou directPath e mediaKey, temos o suficiente
-				if directPath != "" && len(mediaKey) > 0 {
-					break
-				}
-			}
-		}
-	}
-
-	return directPath, mediaKey, fileEncSHA256, fileSHA256, fileLength, mimeType, fileName
-}
-
-// getWhatsmeowClient obtém o cliente whatsmeow para uma sessão específica
-func (h *StorageHandler) getWhatsmeowClient(_ uuid.UUID) (*whatsmeow.Client, error) {
-	// Aqui precisamos acessar o cliente whatsmeow da sessão
-	// Por enquanto, vamos retornar um erro indicando que não está implementado
-	// TODO: Implementar acesso ao cliente whatsmeow da sessão
-	return nil, fmt.Errorf("acesso ao cliente whatsmeow não implementado ainda")
-}
-
-// processHistoricalMediaFallback processa mídia histórica usando método de fallback (URL direta)
-func (h *StorageHandler) processHistoricalMediaFallback(ctx context.Context, msg *message.Message, mediaData map[string]any) error {
-	msgIDStr := msg.ID.String()
-
-	// Extrair URL da mídia e metadados
-	var mediaURL, mimeType, fileName string
-
-	// Verificar URL nos dados de mídia (tanto maiúsculo quanto minúsculo)
-	urlKeys := []string{"url", "URL"}
-	for _, key := range urlKeys {
-		if url, exists := mediaData[key]; exists {
-			if urlStr, ok := url.(string); ok {
-				mediaURL = urlStr
-				break
-			}
-		}
-	}
-
-	// Se não encontrou, procurar em diferentes tipos de mensagem de mídia (fallback)
-	if mediaURL == "" {
-		mediaTypes := []string{"audioMessage", "imageMessage", "videoMessage", "documentMessage", "stickerMessage"}
-
-		for _, mediaType := range mediaTypes {
-			if mediaObj, exists := mediaData[mediaType]; exists {
-				if mediaMap, ok := mediaObj.(map[string]any); ok {
-					for _, key := range urlKeys {
-						if url, exists := mediaMap[key]; exists {
-							if urlStr, ok := url.(string); ok {
-								mediaURL = urlStr
-								break
-							}
-						}
-					}
-					// Extrair metadados do tipo específico
-					if mt, exists := mediaMap["mimetype"]; exists {
-						if mtStr, ok := mt.(string); ok {
-							mimeType = mtStr
-						}
-					}
-					if fn, exists := mediaMap["fileName"]; exists {
-						if fnStr, ok := fn.(string); ok {
-							fileName = fnStr
-						}
-					}
-					if mediaURL != "" {
-						break
-					}
-				}
-			}
-		}
-	}
-
-	if mediaURL == "" {
-		return fmt.Errorf("URL de mídia não encontrada")
-	}
-
-	// Extrair metadados gerais se não foram encontrados nos tipos específicos
-	if mimeType == "" {
-		if mt, exists := mediaData["mimetype"]; exists {
-			if mtStr, ok := mt.(string); ok {
-				mimeType = mtStr
-			}
-		}
-	}
-	if fileName == "" {
-		if fn, exists := mediaData["fileName"]; exists {
-			if fnStr, ok := fn.(string); ok {
-				fileName = fnStr
-			}
-		}
-	}
-
-	// Se não há nome de arquivo, usar o ID da mensagem
-	if fileName == "" {
-		fileName = msgIDStr
-	}
-
-	h.logger.Warn().
-		Str("message_id", msgIDStr).
-		Str("media_url", mediaURL).
-		Str("mime_type", mimeType).
-		Str("file_name", fileName).
-		Msg("⚠️ Usando fallback: download direto de URL (mídia pode estar corrompida)")
-
-	// Baixar mídia da URL
-	mediaBytes, err := h.storage.DownloadMediaFromURL(ctx, mediaURL)
-	if err != nil {
-		return fmt.Errorf("erro ao baixar mídia: %w", err)
-	}
-
-	// Determinar extensão
-	extension := h.storage.GetExtensionFromMimeType(mimeType, ".bin")
-	if fileName != "" {
-		if ext := filepath.Ext(fileName); ext != "" {
-			extension = strings.TrimPrefix(ext, ".")
-		}
-	}
-
-	// Fazer upload para MinIO usando o MediaDownloader existente
-	if h.mediaDownloader == nil {
-		return fmt.Errorf("MediaDownloader não disponível")
-	}
-
-	// Usar o método existente do MediaDownloader para upload
-	reader := bytes.NewReader(mediaBytes)
-	objectPath, err := h.mediaDownloader.minioClient.UploadMedia(ctx, reader, storage.MediaUploadOptions{
-		SessionID:   msg.SessionID,
-		ChatJID:     msg.ChatJID,
-		Direction:   string(msg.Direction),
-		MessageID:   msgIDStr,
-		ContentType: mimeType,
-		Extension:   extension,
-		Size:        int64(len(mediaBytes)),
-	})
-	if err != nil {
-		return fmt.Errorf("erro ao fazer upload da mídia histórica: %w", err)
-	}
-
-	// Atualizar mensagem com path da mídia
-	if err := h.storage.UpdateMessageWithMediaPath(ctx, msgIDStr, objectPath); err != nil {
-		h.logger.Error().Err(err).
-			Str("message_id", msgIDStr).
-			Str("object_path", objectPath).
-			Msg("Erro ao atualizar mensagem com path da mídia")
-		// Não retorna erro para não impedir o processamento
-	}
-
-	h.logger.Warn().
-		Str("message_id", msgIDStr).
-		Str("object_path", objectPath).
-		Int("size_bytes", len(mediaBytes)).
-		Msg("⚠️ Mídia histórica processada via fallback (pode estar corrompida)")
+		Str(LogFieldSessionID, msg.SessionID.String()).
+		Str(LogFieldMessageID, msg.MsgID).
+		Str("object_path", msg.MediaPath).
+		Int64("size_bytes", msg.MediaSize).
+		Msg("✅ Mídia histórica baixada e armazenada com sucesso")
 
 	return nil
 }
@@ -1074,6 +795,14 @@ func (h *StorageHandler) extractMessageContent(messageField map[string]any) (mes
 		if seconds, exists := audioMsg["seconds"].(float64); exists {
 			mediaData["duration"] = int(seconds)
 		}
+		if mediaData["is_ptt"] == true {
+			// A forma de onda chega em base64 na serialização JSON do protobuf
+			if encoded, exists := audioMsg["waveform"].(string); exists {
+				if waveform, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+					mediaData["waveform"] = waveformLevels(waveform)
+				}
+			}
+		}
 
 		return message.MessageTypeAudio, content, mediaData
 	}
@@ -2,14 +2,18 @@ package whatsapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"zapcore/internal/domain/chat"
 	"zapcore/internal/domain/contact"
 	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/whatsapp"
+	"zapcore/internal/infra/metrics"
 
 	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
 
@@ -57,6 +61,20 @@ func (eh *EventHandlers) HandleMessage(ctx context.Context, sessionID uuid.UUID,
 		return err
 	}
 
+	// Destinatários no envio, base das regras de leitura all e threshold
+	if evt.Info.IsFromMe && eh.storage.recipients != nil {
+		count, err := eh.storage.recipients.CountRecipients(ctx, sessionID, evt.Info.Chat)
+		if err != nil {
+			eh.storage.logger.Warn().
+				Err(err).
+				Str("session_id", sessionID.String()).
+				Str("message_id", evt.Info.ID).
+				Str("chat_jid", evt.Info.Chat.String()).
+				Msg("Não foi possível contar os destinatários da mensagem")
+		}
+		msg.RecipientCount = count
+	}
+
 	// Armazenar payload bruto
 	if err := eh.storage.storeRawPayload(msg, evt); err != nil {
 		eh.storage.logger.Error().Err(err).Msg("Erro ao armazenar payload bruto")
@@ -68,6 +86,10 @@ func (eh *EventHandlers) HandleMessage(ctx context.Context, sessionID uuid.UUID,
 		return err
 	}
 
+	if !evt.Info.IsFromMe {
+		metrics.IncMessage(sessionID.String(), metrics.DirectionReceived, string(msg.MessageType))
+	}
+
 	// Processar mídia se presente
 	if msg.MessageType != message.MessageTypeText {
 		if err := eh.storage.processMediaMessage(ctx, msg, evt); err != nil {
@@ -75,6 +97,13 @@ func (eh *EventHandlers) HandleMessage(ctx context.Context, sessionID uuid.UUID,
 		}
 	}
 
+	// Detectar a primeira mensagem do contato antes de o chat ser criado
+	isFirstMessage := false
+	if !evt.Info.IsFromMe {
+		_, err := eh.storage.chatRepo.GetByJID(ctx, sessionID, msg.ChatJID)
+		isFirstMessage = err == chat.ErrChatNotFound
+	}
+
 	// Atualizar informações do chat
 	if err := eh.storage.updateChatFromMessage(ctx, sessionID, evt); err != nil {
 		eh.storage.logger.Error().Err(err).Msg("Erro ao atualizar chat")
@@ -85,6 +114,25 @@ func (eh *EventHandlers) HandleMessage(ctx context.Context, sessionID uuid.UUID,
 		eh.storage.logger.Error().Err(err).Msg("Erro ao atualizar contato")
 	}
 
+	// Encaminhar mensagens recebidas para os processadores (respostas automáticas etc.)
+	if !evt.Info.IsFromMe {
+		eh.storage.dispatchInbound(&whatsapp.MessageEvent{
+			SessionID: sessionID,
+			MessageID: msg.MsgID,
+			Type:      string(msg.MessageType),
+			FromJID:   msg.SenderJID,
+			ToJID:     msg.ChatJID,
+			Content:   msg.Content,
+			Caption:   msg.Caption,
+			Timestamp: msg.Timestamp,
+			IsFromMe:  false,
+			IsGroup:   evt.Info.IsGroup,
+			PushName:  evt.Info.PushName,
+
+			IsFirstMessage: isFirstMessage,
+		})
+	}
+
 	return nil
 }
 
@@ -134,44 +182,112 @@ func (eh *EventHandlers) HandleUndecryptableMessage(ctx context.Context, session
 	return nil
 }
 
-// HandleReceipt processa eventos de confirmação de entrega/leitura
+// HandleReceipt processa eventos de confirmação de entrega/leitura. As confirmações de outros
+// destinatários são gravadas uma a uma e o status da mensagem é agregado pela regra de leitura;
+// as leituras feitas nos próprios aparelhos marcam a mensagem recebida como lida.
 func (eh *EventHandlers) HandleReceipt(ctx context.Context, sessionID uuid.UUID, evt *events.Receipt) error {
 	if len(evt.MessageIDs) == 0 {
 		return nil
 	}
 
-	var status message.MessageStatus
+	var receiptType message.ReceiptType
 	switch evt.Type {
-	case "delivered":
-		status = message.MessageStatusDelivered
-	case "read":
-		status = message.MessageStatusRead
-	case "read-self":
-		status = message.MessageStatusRead
+	case types.ReceiptTypeDelivered:
+		receiptType = message.ReceiptTypeDelivered
+	case types.ReceiptTypeRead:
+		receiptType = message.ReceiptTypeRead
+	case types.ReceiptTypePlayed:
+		receiptType = message.ReceiptTypePlayed
+	case types.ReceiptTypeReadSelf, types.ReceiptTypePlayedSelf:
+		eh.markRead(ctx, sessionID, evt.MessageIDs)
+		return nil
 	default:
 		return nil // Tipo de recibo não reconhecido
 	}
 
-	// Atualizar status de todas as mensagens mencionadas no recibo
+	// Confirmações dos próprios aparelhos não representam destinatários
+	if evt.IsFromMe {
+		return nil
+	}
+
+	// Sem repositório de confirmações, manter o status da primeira confirmação
+	if eh.storage.receiptRepo == nil {
+		status := message.MessageStatusDelivered
+		if receiptType != message.ReceiptTypeDelivered {
+			status = message.MessageStatusRead
+		}
+		for _, msgID := range evt.MessageIDs {
+			eh.updateMessageStatus(ctx, sessionID, msgID, status)
+		}
+		return nil
+	}
+
+	timestamp := evt.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	chatJID := evt.Chat.String()
+	recipientJID := evt.Sender.ToNonAD().String()
+	receipts := make([]*message.Receipt, 0, len(evt.MessageIDs))
 	for _, msgID := range evt.MessageIDs {
-		if err := eh.storage.messageRepo.UpdateStatus(ctx, msgID, status); err != nil {
+		receipts = append(receipts, message.NewReceipt(sessionID, msgID, chatJID, recipientJID, receiptType, timestamp))
+	}
+
+	if err := eh.storage.receiptRepo.Record(ctx, receipts); err != nil {
+		return err
+	}
+
+	for _, msgID := range evt.MessageIDs {
+		summary, err := eh.storage.receiptRepo.Summarize(ctx, sessionID, msgID)
+		if err != nil {
 			eh.storage.logger.Error().
 				Err(err).
 				Str("session_id", sessionID.String()).
 				Str("message_id", msgID).
-				Str("status", string(status)).
-				Msg("Erro ao atualizar status da mensagem")
+				Msg("Erro ao resumir confirmações da mensagem")
 			continue
 		}
 
-		eh.storage.logger.Debug().
+		summary.Expected = message.ExpectedRecipients(chatJID, summary.Expected)
+
+		if status := eh.storage.readRule.Status(*summary); status != "" {
+			eh.updateMessageStatus(ctx, sessionID, msgID, status)
+		}
+	}
+
+	return nil
+}
+
+// markRead marca como lidas as mensagens lidas em outro aparelho da própria conta
+func (eh *EventHandlers) markRead(ctx context.Context, sessionID uuid.UUID, msgIDs []string) {
+	for _, msgID := range msgIDs {
+		eh.updateMessageStatus(ctx, sessionID, msgID, message.MessageStatusRead)
+	}
+}
+
+// updateMessageStatus atualiza o status agregado de uma mensagem da sessão
+func (eh *EventHandlers) updateMessageStatus(ctx context.Context, sessionID uuid.UUID, msgID string, status message.MessageStatus) {
+	err := eh.storage.messageRepo.UpdateSessionStatus(ctx, sessionID, msgID, status)
+	if err != nil {
+		// Confirmações de mensagens que não foram gravadas (ex.: anteriores à sessão) são esperadas
+		if errors.Is(err, message.ErrMessageNotFound) {
+			return
+		}
+		eh.storage.logger.Error().
+			Err(err).
 			Str("session_id", sessionID.String()).
 			Str("message_id", msgID).
 			Str("status", string(status)).
-			Msg("Status da mensagem atualizado")
+			Msg("Erro ao atualizar status da mensagem")
+		return
 	}
 
-	return nil
+	eh.storage.logger.Debug().
+		Str("session_id", sessionID.String()).
+		Str("message_id", msgID).
+		Str("status", string(status)).
+		Msg("Status da mensagem atualizado")
 }
 
 // HandleContact processa eventos de informações de contato
@@ -2,22 +2,14 @@ package whatsapp
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
-	"io"
-	"mime"
-	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
 	"zapcore/internal/domain/chat"
 	"zapcore/internal/domain/contact"
 	"zapcore/internal/domain/message"
-	"zapcore/internal/infra/storage"
 
 	"github.com/google/uuid"
-	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -62,6 +54,9 @@ func (so *StorageOperations) ProcessMessageContent(msg *message.Message, msgCont
 	case msgContent.AudioMessage != nil:
 		msg.MessageType = message.MessageTypeAudio
 		msg.Content = "[Áudio]"
+		if msgContent.AudioMessage.GetPTT() {
+			msg.Content = "[Áudio de voz]"
+		}
 
 	case msgContent.DocumentMessage != nil:
 		msg.MessageType = message.MessageTypeDocument
@@ -179,8 +174,12 @@ func (so *StorageOperations) ProcessMessageContent(msg *message.Message, msgCont
 	return nil
 }
 
-// ProcessMediaMessage processa mídia da mensagem fazendo download e upload para MinIO
+// ProcessMediaMessage processa mídia da mensagem fazendo download e upload para o armazenamento
 func (so *StorageOperations) ProcessMediaMessage(ctx context.Context, msg *message.Message, evt *events.Message) error {
+	if so.storage.mediaDownloader == nil {
+		return nil // Armazenamento de mídias desativado
+	}
+
 	// Fazer download e upload da mídia
 	mediaInfo, err := so.storage.mediaDownloader.DownloadAndUploadMedia(ctx, evt, msg.SessionID)
 	if err != nil {
@@ -188,10 +187,15 @@ func (so *StorageOperations) ProcessMediaMessage(ctx context.Context, msg *messa
 	}
 
 	// Atualizar mensagem com informações da mídia
-	if mediaInfo != nil {
-		// Note: MediaInfo fields may vary - adjust according to actual struct
-		msg.MediaMimeType = mediaInfo.MimeType
-		msg.MediaSize = mediaInfo.Size
+	msg.SetMediaInfo(mediaInfo.ObjectPath, mediaInfo.Size, mediaInfo.MimeType, mediaInfo.FileName)
+	if mediaInfo.BlobID != nil {
+		msg.SetMediaID(*mediaInfo.BlobID)
+	}
+	if mediaInfo.Scan != nil {
+		msg.SetMediaScan(string(mediaInfo.Scan.Verdict), mediaInfo.Scan.Signature, mediaInfo.Quarantined)
+	}
+	if err := so.storage.messageRepo.UpdateMediaInfo(ctx, msg); err != nil {
+		return fmt.Errorf("erro ao gravar caminho da mídia: %w", err)
 	}
 
 	return nil
@@ -289,12 +293,35 @@ func (so *StorageOperations) StoreRawPayload(msg *message.Message, evt *events.M
 		"message": evt.Message,
 	}
 
+	// Duração e forma de onda das mensagens de voz
+	if audio := evt.Message.GetAudioMessage(); audio.GetPTT() {
+		rawPayload["voice_note"] = voiceNoteMetadata(audio.GetSeconds(), audio.GetWaveform())
+	}
+
 	// Armazenar no campo RawPayload da mensagem
 	msg.RawPayload = rawPayload
 
 	return nil
 }
 
+// voiceNoteMetadata monta os metadados de uma mensagem de voz
+func voiceNoteMetadata(seconds uint32, waveform []byte) map[string]any {
+	return map[string]any{
+		"duration": seconds,
+		"waveform": waveformLevels(waveform),
+	}
+}
+
+// waveformLevels converte a forma de onda em valores numéricos (0 a 100), que no JSON
+// seriam serializados como base64
+func waveformLevels(waveform []byte) []int {
+	levels := make([]int, len(waveform))
+	for i, level := range waveform {
+		levels[i] = int(level)
+	}
+	return levels
+}
+
 // CreateHistoricalMessage cria um registro básico de mensagem histórica
 func (so *StorageOperations) CreateHistoricalMessage(ctx context.Context, msgID string, sessionID uuid.UUID, chatJID, senderJID string, status message.MessageStatus) error {
 	// Criar entidade de mensagem básica
@@ -308,217 +335,6 @@ func (so *StorageOperations) CreateHistoricalMessage(ctx context.Context, msgID
 	return so.storage.messageRepo.Create(ctx, msg)
 }
 
-// ProcessHistoricalMedia processa mídia de mensagens históricas usando DownloadMediaWithPath do whatsmeow
-func (so *StorageOperations) ProcessHistoricalMedia(ctx context.Context, msg *message.Message, mediaData map[string]any) error {
-	msgIDStr := msg.ID.String()
-
-	// Verificar se a mídia já foi processada para esta mensagem
-	if so.IsMediaAlreadyProcessed(msgIDStr) {
-		so.storage.logger.Debug().
-			Str("message_id", msgIDStr).
-			Msg("🔄 Mídia já processada, pulando")
-		return nil
-	}
-
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Interface("media_data_keys", so.getMapKeys(mediaData)).
-		Msg("🚀 INICIANDO: processHistoricalMedia")
-
-	// Verificar se há URL de mídia válida
-	if !so.HasMediaURL(mediaData) {
-		so.storage.logger.Warn().
-			Str("message_id", msgIDStr).
-			Msg("⚠️ Nenhuma URL de mídia válida encontrada")
-		return nil
-	}
-
-	// Extrair metadados necessários para download via whatsmeow
-	directPath, mediaKey, fileEncSHA256, fileSHA256, fileLength, mimeType, _ := so.ExtractMediaMetadata(mediaData)
-
-	if directPath == "" || len(mediaKey) == 0 {
-		so.storage.logger.Warn().
-			Str("message_id", msgIDStr).
-			Str("direct_path", directPath).
-			Int("media_key_len", len(mediaKey)).
-			Msg("⚠️ Metadados de mídia insuficientes para download via whatsmeow")
-
-		// Tentar método de fallback
-		return so.ProcessHistoricalMediaFallback(ctx, msg, mediaData)
-	}
-
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Str("direct_path", directPath).
-		Str("mime_type", mimeType).
-		Uint64("file_length", fileLength).
-		Msg("📋 Metadados extraídos com sucesso")
-
-	// Obter cliente whatsmeow para download
-	client, err := so.GetWhatsmeowClient(msg.SessionID)
-	if err != nil {
-		so.storage.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Msg("❌ Erro ao obter cliente whatsmeow")
-		return so.ProcessHistoricalMediaFallback(ctx, msg, mediaData)
-	}
-
-	// Fazer download usando whatsmeow
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Msg("⬇️ Iniciando download via whatsmeow")
-
-	mediaBytes, err := client.DownloadMediaWithPath(ctx, directPath, fileEncSHA256, fileSHA256, mediaKey, int(fileLength), whatsmeow.MediaImage, "")
-	if err != nil {
-		so.storage.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Msg("❌ Erro no download via whatsmeow, tentando fallback")
-		return so.ProcessHistoricalMediaFallback(ctx, msg, mediaData)
-	}
-
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Int("bytes_downloaded", len(mediaBytes)).
-		Msg("✅ Download via whatsmeow concluído")
-
-	// Fazer upload para MinIO
-	uploadOpts := storage.MediaUploadOptions{
-		SessionID:   msg.SessionID,
-		ChatJID:     msg.ChatJID,
-		Direction:   string(msg.Direction),
-		MessageID:   msg.MsgID,
-		ContentType: mimeType,
-	}
-
-	objectPath, err := so.storage.mediaDownloader.uploadToMinIO(ctx, mediaBytes, uploadOpts)
-	if err != nil {
-		so.storage.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Str("object_path", objectPath).
-			Msg("❌ Erro ao fazer upload para MinIO")
-		return fmt.Errorf("erro ao fazer upload para MinIO: %w", err)
-	}
-
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Str("object_path", objectPath).
-		Msg("✅ Upload para MinIO concluído")
-
-	// Atualizar mensagem com path da mídia
-	if err := so.UpdateMessageWithMediaPath(ctx, msgIDStr, objectPath); err != nil {
-		so.storage.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Msg("❌ Erro ao atualizar mensagem com path da mídia")
-	}
-
-	return nil
-}
-
-// ProcessHistoricalMediaFallback processa mídia histórica usando método de fallback (URL direta)
-func (so *StorageOperations) ProcessHistoricalMediaFallback(ctx context.Context, msg *message.Message, mediaData map[string]any) error {
-	msgIDStr := msg.ID.String()
-
-	// Extrair URL da mídia e metadados
-	var mediaURL, mimeType string
-
-	// Procurar em diferentes tipos de mensagem de mídia
-	mediaTypes := []string{"audioMessage", "imageMessage", "videoMessage", "documentMessage", "stickerMessage"}
-
-	for _, mediaType := range mediaTypes {
-		if mediaObj, exists := mediaData[mediaType]; exists {
-			if mediaMap, ok := mediaObj.(map[string]any); ok {
-				if url, exists := mediaMap["url"]; exists {
-					if urlStr, ok := url.(string); ok && urlStr != "" {
-						mediaURL = urlStr
-					}
-				}
-				if mime, exists := mediaMap["mimetype"]; exists {
-					if mimeStr, ok := mime.(string); ok {
-						mimeType = mimeStr
-					}
-				}
-
-				break
-			}
-		}
-	}
-
-	if mediaURL == "" {
-		so.storage.logger.Warn().
-			Str("message_id", msgIDStr).
-			Msg("⚠️ URL de mídia não encontrada no fallback")
-		return nil
-	}
-
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Str("media_url", mediaURL).
-		Str("mime_type", mimeType).
-		Msg("🔄 Processando mídia via fallback (URL direta)")
-
-	// Fazer download da mídia
-	mediaBytes, err := so.DownloadMediaFromURL(ctx, mediaURL)
-	if err != nil {
-		so.storage.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Str("media_url", mediaURL).
-			Msg("❌ Erro no download via URL direta")
-		return fmt.Errorf("erro ao fazer download da mídia: %w", err)
-	}
-
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Int("bytes_downloaded", len(mediaBytes)).
-		Msg("✅ Download via URL direta concluído")
-
-	// Fazer upload para MinIO
-	uploadOpts := storage.MediaUploadOptions{
-		SessionID:   msg.SessionID,
-		ChatJID:     msg.ChatJID,
-		Direction:   string(msg.Direction),
-		MessageID:   msg.MsgID,
-		ContentType: mimeType,
-	}
-
-	objectPath, err := so.storage.mediaDownloader.uploadToMinIO(ctx, mediaBytes, uploadOpts)
-	if err != nil {
-		so.storage.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Str("object_path", objectPath).
-			Msg("❌ Erro ao fazer upload para MinIO")
-		return fmt.Errorf("erro ao fazer upload para MinIO: %w", err)
-	}
-
-	so.storage.logger.Info().
-		Str("message_id", msgIDStr).
-		Str("object_path", objectPath).
-		Msg("✅ Upload para MinIO concluído via fallback")
-
-	// Atualizar mensagem com path da mídia
-	if err := so.UpdateMessageWithMediaPath(ctx, msgIDStr, objectPath); err != nil {
-		so.storage.logger.Error().
-			Err(err).
-			Str("message_id", msgIDStr).
-			Msg("❌ Erro ao atualizar mensagem com path da mídia")
-	}
-
-	return nil
-}
-
-// IsMediaAlreadyProcessed verifica se a mídia já foi processada para esta mensagem
-func (so *StorageOperations) IsMediaAlreadyProcessed(_ string) bool {
-	// Verificar se a mensagem já tem um path de mídia no banco
-	// Por simplicidade, vamos assumir que se chegou até aqui, ainda não foi processada
-	// Em uma implementação mais robusta, poderia consultar o banco para verificar se já existe um path de mídia
-	return false
-}
-
 // getMapKeys obtém as chaves de um map para logging (otimizado para performance)
 func (so *StorageOperations) getMapKeys(data map[string]any) []string {
 	if len(data) == 0 {
@@ -578,146 +394,6 @@ func (so *StorageOperations) HasMediaURL(mediaData map[string]any) bool {
 	return false
 }
 
-// ExtractMediaMetadata extrai metadados necessários para download via whatsmeow
-func (so *StorageOperations) ExtractMediaMetadata(mediaData map[string]any) (directPath string, mediaKey []byte, fileEncSHA256 []byte, fileSHA256 []byte, fileLength uint64, mimeType string, fileName string) {
-	// Procurar em diferentes tipos de mensagem de mídia
-	mediaTypes := []string{"audioMessage", "imageMessage", "videoMessage", "documentMessage", "stickerMessage"}
-
-	for _, mediaType := range mediaTypes {
-		if mediaObj, exists := mediaData[mediaType]; exists {
-			if mediaMap, ok := mediaObj.(map[string]any); ok {
-				// Extrair directPath
-				if dp, exists := mediaMap["directPath"]; exists {
-					if dpStr, ok := dp.(string); ok {
-						directPath = dpStr
-					}
-				}
-
-				// Extrair mediaKey (base64)
-				if mk, exists := mediaMap["mediaKey"]; exists {
-					if mkStr, ok := mk.(string); ok {
-						// Decodificar base64
-						if decoded, err := base64.StdEncoding.DecodeString(mkStr); err == nil {
-							mediaKey = decoded
-						}
-					}
-				}
-
-				// Extrair fileEncSha256 (base64)
-				if fes, exists := mediaMap["fileEncSha256"]; exists {
-					if fesStr, ok := fes.(string); ok {
-						if decoded, err := base64.StdEncoding.DecodeString(fesStr); err == nil {
-							fileEncSHA256 = decoded
-						}
-					}
-				}
-
-				// Extrair fileSha256 (base64)
-				if fs, exists := mediaMap["fileSha256"]; exists {
-					if fsStr, ok := fs.(string); ok {
-						if decoded, err := base64.StdEncoding.DecodeString(fsStr); err == nil {
-							fileSHA256 = decoded
-						}
-					}
-				}
-
-				// Extrair fileLength
-				if fl, exists := mediaMap["fileLength"]; exists {
-					switch v := fl.(type) {
-					case string:
-						if parsed, err := strconv.ParseUint(v, 10, 64); err == nil {
-							fileLength = parsed
-						}
-					case float64:
-						fileLength = uint64(v)
-					case int:
-						fileLength = uint64(v)
-					case int64:
-						fileLength = uint64(v)
-					}
-				}
-
-				// Extrair mimeType
-				if mt, exists := mediaMap["mimetype"]; exists {
-					if mtStr, ok := mt.(string); ok {
-						mimeType = mtStr
-					}
-				}
-
-				// Extrair fileName (apenas para documentos)
-				if fn, exists := mediaMap["fileName"]; exists {
-					if fnStr, ok := fn.(string); ok {
-						fileName = fnStr
-					}
-				}
-
-				break
-			}
-		}
-	}
-
-	return directPath, mediaKey, fileEncSHA256, fileSHA256, fileLength, mimeType, fileName
-}
-
-// GetWhatsmeowClient obtém o cliente whatsmeow para uma sessão específica
-func (so *StorageOperations) GetWhatsmeowClient(_ uuid.UUID) (*whatsmeow.Client, error) {
-	// Aqui precisamos acessar o cliente whatsmeow da sessão
-	// Por enquanto, vamos retornar um erro indicando que não está implementado
-	// TODO: Implementar acesso ao cliente whatsmeow da sessão
-	return nil, fmt.Errorf("acesso ao cliente whatsmeow não implementado ainda")
-}
-
-// GetExtensionFromMimeType obtém a extensão do arquivo baseada no MIME type
-func (so *StorageOperations) GetExtensionFromMimeType(mimeType, defaultExt string) string {
-	if mimeType == "" {
-		return strings.TrimPrefix(defaultExt, ".")
-	}
-
-	exts, err := mime.ExtensionsByType(mimeType)
-	if err != nil || len(exts) == 0 {
-		return strings.TrimPrefix(defaultExt, ".")
-	}
-
-	// Retornar a primeira extensão sem o ponto
-	return strings.TrimPrefix(exts[0], ".")
-}
-
-// UpdateMessageWithMediaPath atualiza a mensagem com o path da mídia no MinIO
-func (so *StorageOperations) UpdateMessageWithMediaPath(_ context.Context, messageID, objectPath string) error {
-	// Por enquanto, apenas log da operação
-	// Em uma implementação completa, atualizaria o registro no banco de dados
-	so.storage.logger.Debug().
-		Str("message_id", messageID).
-		Str("object_path", objectPath).
-		Msg("Atualizando mensagem com path da mídia")
-	return nil
-}
-
-// DownloadMediaFromURL baixa mídia de uma URL do WhatsApp
-func (so *StorageOperations) DownloadMediaFromURL(ctx context.Context, mediaURL string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", mediaURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao fazer requisição: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status HTTP %d", resp.StatusCode)
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao ler resposta: %w", err)
-	}
-
-	return data, nil
-}
-
 // StoreUndecryptableRawPayload armazena o payload bruto do evento UndecryptableMessage
 func (so *StorageOperations) StoreUndecryptableRawPayload(msg *message.Message, evt *events.UndecryptableMessage) error {
 	// Converter o evento UndecryptableMessage para map[string]any
@@ -3,10 +3,14 @@ package whatsapp
 import (
 	"context"
 
+	"zapcore/internal/domain/eventstream"
+	"zapcore/internal/domain/media"
 	"zapcore/internal/domain/session"
 	"zapcore/pkg/logger"
+	"zapcore/pkg/tracing"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Constantes para padronização de logging
@@ -46,9 +50,7 @@ type DisconnectedEvent struct {
 }
 
 // HandleEvent processa eventos do WhatsApp
-func (h *SessionEventHandler) HandleEvent(sessionID uuid.UUID, event any) {
-	ctx := context.Background()
-
+func (h *SessionEventHandler) HandleEvent(ctx context.Context, sessionID uuid.UUID, event any) {
 	switch e := event.(type) {
 	case *PairSuccessEvent:
 		h.handlePairSuccess(ctx, sessionID, e)
@@ -135,6 +137,8 @@ func getEventType(event any) string {
 		return "Connected"
 	case *DisconnectedEvent:
 		return "Disconnected"
+	case *MediaScanEvent:
+		return "MediaScan"
 	default:
 		return "Unknown"
 	}
@@ -144,6 +148,7 @@ func getEventType(event any) string {
 type CompositeEventHandler struct {
 	sessionHandler *SessionEventHandler
 	storageHandler *StorageHandler
+	publishers     []eventstream.Publisher
 	logger         *logger.Logger
 }
 
@@ -160,17 +165,18 @@ func NewCompositeEventHandler(
 }
 
 // HandleEvent processa eventos através de ambos os handlers
-func (c *CompositeEventHandler) HandleEvent(sessionID uuid.UUID, event any) {
-	ctx := context.Background()
-
+func (c *CompositeEventHandler) HandleEvent(ctx context.Context, sessionID uuid.UUID, event any) {
 	// Processar eventos de sessão primeiro
 	if c.sessionHandler != nil {
-		c.sessionHandler.HandleEvent(sessionID, event)
+		c.sessionHandler.HandleEvent(ctx, sessionID, event)
 	}
 
 	// Processar eventos de storage
 	if c.storageHandler != nil {
-		if err := c.storageHandler.HandleEvent(ctx, sessionID, event); err != nil {
+		storageCtx, span := tracing.Start(ctx, "StorageHandler.HandleEvent")
+		err := c.storageHandler.HandleEvent(storageCtx, sessionID, event)
+		tracing.End(span, err)
+		if err != nil {
 			c.logger.Error().
 				Err(err).
 				Str("session_id", sessionID.String()).
@@ -178,6 +184,58 @@ func (c *CompositeEventHandler) HandleEvent(sessionID uuid.UUID, event any) {
 				Msg("Erro ao processar evento no storage handler")
 		}
 	}
+
+	// Publicar o evento para os consumidores em tempo real
+	c.publish(ctx, sessionID, event)
+}
+
+// AddPublisher registra um destino para os eventos processados
+func (c *CompositeEventHandler) AddPublisher(publisher eventstream.Publisher) {
+	c.publishers = append(c.publishers, publisher)
+}
+
+// publish converte e entrega o evento para os publishers registrados
+func (c *CompositeEventHandler) publish(ctx context.Context, sessionID uuid.UUID, event any) {
+	if len(c.publishers) == 0 {
+		return
+	}
+
+	streamEvent, ok := c.toStreamEvent(sessionID, event)
+	if !ok {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "eventstream.Publish",
+		attribute.String("event.type", streamEvent.Type),
+		attribute.Int("publishers", len(c.publishers)),
+	)
+	defer span.End()
+
+	for _, publisher := range c.publishers {
+		if err := publisher.Publish(ctx, streamEvent); err != nil {
+			span.RecordError(err)
+			c.logger.Error().
+				Err(err).
+				Str("session_id", sessionID.String()).
+				Str("event_type", streamEvent.Type).
+				Msg("Erro ao publicar evento")
+		}
+	}
+}
+
+// SetHistoryMediaFetcher define quem baixa as mídias recentes recebidas no histórico
+func (c *CompositeEventHandler) SetHistoryMediaFetcher(fetcher media.Fetcher) {
+	if c.storageHandler != nil {
+		c.storageHandler.historyMedia = fetcher
+	}
+}
+
+// SetRecipientCounter define quem conta os destinatários das mensagens enviadas, usados pela
+// regra de leitura
+func (c *CompositeEventHandler) SetRecipientCounter(counter RecipientCounter) {
+	if c.storageHandler != nil {
+		c.storageHandler.recipients = counter
+	}
 }
 
 // SetMediaDownloader configura o MediaDownloader no StorageHandler
@@ -3,6 +3,9 @@ package whatsapp
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"mime"
 	"path/filepath"
@@ -14,6 +17,9 @@ import (
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types/events"
 
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/tenant"
+	"zapcore/internal/infra/metrics"
 	"zapcore/internal/infra/storage"
 	"zapcore/pkg/logger"
 )
@@ -41,33 +47,86 @@ const (
 	LogFieldMimeType  = "mime_type"
 )
 
-// MediaDownloader gerencia o download de mídias do WhatsApp e upload para MinIO
+// MediaQuota resolve o tenant dono da sessão e verifica se a mídia cabe na sua cota de armazenamento
+type MediaQuota interface {
+	CheckStorage(ctx context.Context, sessionID uuid.UUID, size int64) (string, error)
+}
+
+// MediaDownloader gerencia o download de mídias do WhatsApp e upload para o armazenamento
 type MediaDownloader struct {
-	client      *whatsmeow.Client
-	minioClient *storage.MinIOClient
-	logger      *logger.Logger
+	client       *whatsmeow.Client
+	mediaStorage *storage.MediaStorage
+	quota        MediaQuota
+	blobs        media.BlobRepository
+	scanner      media.Scanner
+	scanOptions  MediaScanOptions
+	onScan       func(ctx context.Context, event *MediaScanEvent)
+	logger       *logger.Logger
 }
 
-// NewMediaDownloader cria uma nova instância do MediaDownloader
-func NewMediaDownloader(client *whatsmeow.Client, minioClient *storage.MinIOClient) *MediaDownloader {
+// MediaScanOptions define quais mídias recebidas são verificadas e o que fazer quando a
+// verificação falha
+type MediaScanOptions struct {
+	Types      []string // Tipos de mídia verificados; vazio verifica todos
+	MaxSize    int64    // Mídias maiores não são verificadas; zero não limita
+	FailClosed bool     // Coloca em quarentena as mídias que não puderam ser verificadas
+}
+
+// MediaScanEvent representa o resultado da verificação de malware de uma mídia recebida
+type MediaScanEvent struct {
+	SessionID   uuid.UUID
+	MessageID   string
+	ChatJID     string
+	MediaType   string
+	Size        int64
+	ObjectPath  string
+	Result      *media.ScanResult
+	Quarantined bool
+}
+
+// storedMedia é o resultado da gravação de uma mídia no armazenamento
+type storedMedia struct {
+	ObjectPath  string
+	BlobID      *uuid.UUID
+	Scan        *media.ScanResult
+	Quarantined bool
+}
+
+// NewMediaDownloader cria uma nova instância do MediaDownloader; sem quota as mídias vão para o
+// tenant padrão e sem blobs cada mensagem grava sua própria cópia da mídia
+func NewMediaDownloader(client *whatsmeow.Client, mediaStorage *storage.MediaStorage, quota MediaQuota, blobs media.BlobRepository) *MediaDownloader {
 	return &MediaDownloader{
-		client:      client,
-		minioClient: minioClient,
-		logger:      logger.Get().WithField("component", "media_downloader"),
+		client:       client,
+		mediaStorage: mediaStorage,
+		quota:        quota,
+		blobs:        blobs,
+		logger:       logger.Get().WithField("component", "media_downloader"),
 	}
 }
 
+// SetScanner ativa a verificação de malware das mídias recebidas antes do armazenamento; onScan,
+// quando informado, recebe o resultado de cada mídia verificada
+func (md *MediaDownloader) SetScanner(scanner media.Scanner, opts MediaScanOptions, onScan func(ctx context.Context, event *MediaScanEvent)) {
+	md.scanner = scanner
+	md.scanOptions = opts
+	md.onScan = onScan
+}
+
 // MediaInfo contém informações sobre a mídia baixada e processada
 type MediaInfo struct {
-	Data       []byte `json:"-"`           // Dados binários da mídia (não serializado)
-	MimeType   string `json:"mime_type"`   // Tipo MIME da mídia
-	Extension  string `json:"extension"`   // Extensão do arquivo
-	Size       int64  `json:"size"`        // Tamanho em bytes
-	FileName   string `json:"file_name"`   // Nome do arquivo
-	ObjectPath string `json:"object_path"` // Caminho no MinIO
+	Data       []byte     `json:"-"`                 // Dados binários da mídia (não serializado)
+	MimeType   string     `json:"mime_type"`         // Tipo MIME da mídia
+	Extension  string     `json:"extension"`         // Extensão do arquivo
+	Size       int64      `json:"size"`              // Tamanho em bytes
+	FileName   string     `json:"file_name"`         // Nome do arquivo
+	ObjectPath string     `json:"object_path"`       // Caminho no armazenamento
+	BlobID     *uuid.UUID `json:"blob_id,omitempty"` // Conteúdo compartilhado, quando a deduplicação está ativa
+
+	Scan        *media.ScanResult `json:"scan,omitempty"`        // Resultado da verificação de malware, quando verificada
+	Quarantined bool              `json:"quarantined,omitempty"` // Gravada sob o prefixo de quarentena
 }
 
-// DownloadAndUploadMedia baixa mídia do WhatsApp e faz upload para MinIO
+// DownloadAndUploadMedia baixa mídia do WhatsApp e faz upload para o armazenamento
 func (md *MediaDownloader) DownloadAndUploadMedia(ctx context.Context, evt *events.Message, sessionID uuid.UUID) (*MediaInfo, error) {
 	// Validações de entrada
 	if evt == nil || evt.Message == nil {
@@ -79,8 +138,8 @@ func (md *MediaDownloader) DownloadAndUploadMedia(ctx context.Context, evt *even
 	if md.client == nil {
 		return nil, fmt.Errorf("cliente WhatsApp não configurado")
 	}
-	if md.minioClient == nil {
-		return nil, fmt.Errorf("cliente MinIO não configurado")
+	if md.mediaStorage == nil {
+		return nil, fmt.Errorf("armazenamento de mídias não configurado")
 	}
 
 	startTime := time.Now()
@@ -231,10 +290,10 @@ func (md *MediaDownloader) processImageMessage(ctx context.Context, img *waE2E.I
 		Str("session_id", sessionID.String()).
 		Str("message_id", messageID).
 		Str("extension", extension).
-		Msg("📤 Iniciando upload para MinIO")
+		Msg("📤 Iniciando upload para o armazenamento")
 
-	// Upload para MinIO
-	objectPath, err := md.uploadToMinIO(ctx, data, storage.MediaUploadOptions{
+	// Upload para o armazenamento
+	stored, err := md.uploadMedia(ctx, MediaTypeImage, data, storage.MediaUploadOptions{
 		SessionID:   sessionID,
 		ChatJID:     chatJID,
 		Direction:   direction,
@@ -248,7 +307,7 @@ func (md *MediaDownloader) processImageMessage(ctx context.Context, img *waE2E.I
 			Err(err).
 			Str("session_id", sessionID.String()).
 			Str("message_id", messageID).
-			Msg("❌ Erro ao fazer upload da imagem para MinIO")
+			Msg("❌ Erro ao fazer upload da imagem para o armazenamento")
 		return nil, fmt.Errorf("erro ao fazer upload da imagem: %w", err)
 	}
 
@@ -257,17 +316,20 @@ func (md *MediaDownloader) processImageMessage(ctx context.Context, img *waE2E.I
 	md.logger.Debug().
 		Str("session_id", sessionID.String()).
 		Str("message_id", messageID).
-		Str("object_path", objectPath).
+		Str("object_path", stored.ObjectPath).
 		Dur("upload_duration", uploadDuration).
-		Msg("✅ Upload da imagem para MinIO concluído")
+		Msg("✅ Upload da imagem para o armazenamento concluído")
 
 	return &MediaInfo{
-		Data:       data,
-		MimeType:   mimeType,
-		Extension:  extension,
-		Size:       int64(len(data)),
-		FileName:   fmt.Sprintf("%s.%s", messageID, extension),
-		ObjectPath: objectPath,
+		Data:        data,
+		MimeType:    mimeType,
+		Extension:   extension,
+		Size:        int64(len(data)),
+		FileName:    fmt.Sprintf("%s.%s", messageID, extension),
+		ObjectPath:  stored.ObjectPath,
+		BlobID:      stored.BlobID,
+		Scan:        stored.Scan,
+		Quarantined: stored.Quarantined,
 	}, nil
 }
 
@@ -283,8 +345,8 @@ func (md *MediaDownloader) processVideoMessage(ctx context.Context, video *waE2E
 	mimeType := video.GetMimetype()
 	extension := md.getExtensionFromMimeType(mimeType, ".mp4")
 
-	// Upload para MinIO
-	objectPath, err := md.uploadToMinIO(ctx, data, storage.MediaUploadOptions{
+	// Upload para o armazenamento
+	stored, err := md.uploadMedia(ctx, MediaTypeVideo, data, storage.MediaUploadOptions{
 		SessionID:   sessionID,
 		ChatJID:     chatJID,
 		Direction:   direction,
@@ -298,12 +360,15 @@ func (md *MediaDownloader) processVideoMessage(ctx context.Context, video *waE2E
 	}
 
 	return &MediaInfo{
-		Data:       data,
-		MimeType:   mimeType,
-		Extension:  extension,
-		Size:       int64(len(data)),
-		FileName:   fmt.Sprintf("%s.%s", messageID, extension),
-		ObjectPath: objectPath,
+		Data:        data,
+		MimeType:    mimeType,
+		Extension:   extension,
+		Size:        int64(len(data)),
+		FileName:    fmt.Sprintf("%s.%s", messageID, extension),
+		ObjectPath:  stored.ObjectPath,
+		BlobID:      stored.BlobID,
+		Scan:        stored.Scan,
+		Quarantined: stored.Quarantined,
 	}, nil
 }
 
@@ -319,8 +384,8 @@ func (md *MediaDownloader) processAudioMessage(ctx context.Context, audio *waE2E
 	mimeType := audio.GetMimetype()
 	extension := md.getExtensionFromMimeType(mimeType, ".ogg")
 
-	// Upload para MinIO
-	objectPath, err := md.uploadToMinIO(ctx, data, storage.MediaUploadOptions{
+	// Upload para o armazenamento
+	stored, err := md.uploadMedia(ctx, MediaTypeAudio, data, storage.MediaUploadOptions{
 		SessionID:   sessionID,
 		ChatJID:     chatJID,
 		Direction:   direction,
@@ -334,12 +399,15 @@ func (md *MediaDownloader) processAudioMessage(ctx context.Context, audio *waE2E
 	}
 
 	return &MediaInfo{
-		Data:       data,
-		MimeType:   mimeType,
-		Extension:  extension,
-		Size:       int64(len(data)),
-		FileName:   fmt.Sprintf("%s.%s", messageID, extension),
-		ObjectPath: objectPath,
+		Data:        data,
+		MimeType:    mimeType,
+		Extension:   extension,
+		Size:        int64(len(data)),
+		FileName:    fmt.Sprintf("%s.%s", messageID, extension),
+		ObjectPath:  stored.ObjectPath,
+		BlobID:      stored.BlobID,
+		Scan:        stored.Scan,
+		Quarantined: stored.Quarantined,
 	}, nil
 }
 
@@ -362,8 +430,8 @@ func (md *MediaDownloader) processDocumentMessage(ctx context.Context, doc *waE2
 		}
 	}
 
-	// Upload para MinIO
-	objectPath, err := md.uploadToMinIO(ctx, data, storage.MediaUploadOptions{
+	// Upload para o armazenamento
+	stored, err := md.uploadMedia(ctx, MediaTypeDocument, data, storage.MediaUploadOptions{
 		SessionID:   sessionID,
 		ChatJID:     chatJID,
 		Direction:   direction,
@@ -382,12 +450,15 @@ func (md *MediaDownloader) processDocumentMessage(ctx context.Context, doc *waE2
 	}
 
 	return &MediaInfo{
-		Data:       data,
-		MimeType:   mimeType,
-		Extension:  extension,
-		Size:       int64(len(data)),
-		FileName:   fileName,
-		ObjectPath: objectPath,
+		Data:        data,
+		MimeType:    mimeType,
+		Extension:   extension,
+		Size:        int64(len(data)),
+		FileName:    fileName,
+		ObjectPath:  stored.ObjectPath,
+		BlobID:      stored.BlobID,
+		Scan:        stored.Scan,
+		Quarantined: stored.Quarantined,
 	}, nil
 }
 
@@ -403,8 +474,8 @@ func (md *MediaDownloader) processStickerMessage(ctx context.Context, sticker *w
 	mimeType := sticker.GetMimetype()
 	extension := md.getExtensionFromMimeType(mimeType, ".webp")
 
-	// Upload para MinIO
-	objectPath, err := md.uploadToMinIO(ctx, data, storage.MediaUploadOptions{
+	// Upload para o armazenamento
+	stored, err := md.uploadMedia(ctx, MediaTypeSticker, data, storage.MediaUploadOptions{
 		SessionID:   sessionID,
 		ChatJID:     chatJID,
 		Direction:   direction,
@@ -418,19 +489,188 @@ func (md *MediaDownloader) processStickerMessage(ctx context.Context, sticker *w
 	}
 
 	return &MediaInfo{
-		Data:       data,
-		MimeType:   mimeType,
-		Extension:  extension,
-		Size:       int64(len(data)),
-		FileName:   fmt.Sprintf("%s.%s", messageID, extension),
-		ObjectPath: objectPath,
+		Data:        data,
+		MimeType:    mimeType,
+		Extension:   extension,
+		Size:        int64(len(data)),
+		FileName:    fmt.Sprintf("%s.%s", messageID, extension),
+		ObjectPath:  stored.ObjectPath,
+		BlobID:      stored.BlobID,
+		Scan:        stored.Scan,
+		Quarantined: stored.Quarantined,
 	}, nil
 }
 
-// uploadToMinIO faz upload dos dados para o MinIO
-func (md *MediaDownloader) uploadToMinIO(ctx context.Context, data []byte, opts storage.MediaUploadOptions) (string, error) {
-	reader := bytes.NewReader(data)
-	return md.minioClient.UploadMedia(ctx, reader, opts)
+// uploadMedia faz upload dos dados para o armazenamento sob o prefixo do tenant da sessão,
+// recusando a mídia quando a cota de armazenamento do tenant está esgotada. Com a deduplicação
+// ativa, retorna também o conteúdo compartilhado que a mensagem passa a referenciar. Mídias
+// recebidas reprovadas pela verificação de malware vão para o prefixo de quarentena.
+func (md *MediaDownloader) uploadMedia(ctx context.Context, mediaType string, data []byte, opts storage.MediaUploadOptions) (*storedMedia, error) {
+	opts.Size = int64(len(data))
+	opts.TenantID = tenant.DefaultTenantID
+
+	if md.quota != nil {
+		tenantID, err := md.quota.CheckStorage(ctx, opts.SessionID, opts.Size)
+		if err != nil {
+			return nil, fmt.Errorf("mídia não armazenada: %w", err)
+		}
+		opts.TenantID = tenantID
+	}
+
+	stored := &storedMedia{}
+	if md.shouldScan(mediaType, opts) {
+		stored.Scan = md.scan(ctx, data, opts)
+		stored.Quarantined = stored.Scan.Verdict == media.ScanVerdictInfected ||
+			(stored.Scan.Verdict == media.ScanVerdictError && md.scanOptions.FailClosed)
+	}
+
+	var err error
+	switch {
+	case stored.Quarantined:
+		// Conteúdos em quarentena nunca são compartilhados com outras mensagens
+		stored.ObjectPath, err = md.mediaStorage.UploadQuarantine(ctx, bytes.NewReader(data), opts, stored.Scan)
+	case md.blobs != nil:
+		stored.ObjectPath, stored.BlobID, err = md.uploadBlob(ctx, data, opts)
+	default:
+		stored.ObjectPath, err = md.mediaStorage.UploadMedia(ctx, bytes.NewReader(data), opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.Scan != nil {
+		md.notifyScan(ctx, mediaType, opts, stored)
+	}
+	return stored, nil
+}
+
+// shouldScan indica se a mídia passa pela verificação: apenas mídias recebidas, dos tipos
+// configurados e dentro do tamanho máximo
+func (md *MediaDownloader) shouldScan(mediaType string, opts storage.MediaUploadOptions) bool {
+	if md.scanner == nil || opts.Direction != DirectionInbound {
+		return false
+	}
+	if md.scanOptions.MaxSize > 0 && opts.Size > md.scanOptions.MaxSize {
+		return false
+	}
+	if len(md.scanOptions.Types) == 0 {
+		return true
+	}
+	for _, t := range md.scanOptions.Types {
+		if t == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// scan verifica o conteúdo; falhas do scanner resultam no veredito de erro
+func (md *MediaDownloader) scan(ctx context.Context, data []byte, opts storage.MediaUploadOptions) *media.ScanResult {
+	scanStart := time.Now()
+
+	result, err := md.scanner.Scan(ctx, bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		md.logger.Warn().
+			Err(err).
+			Str("session_id", opts.SessionID.String()).
+			Str("message_id", opts.MessageID).
+			Str("scanner", md.scanner.Name()).
+			Msg("⚠️ Erro na verificação de malware da mídia")
+		result = &media.ScanResult{Verdict: media.ScanVerdictError, Scanner: md.scanner.Name()}
+	}
+	metrics.IncMediaScan(md.scanner.Name(), string(result.Verdict))
+
+	event := md.logger.Debug()
+	if result.Verdict == media.ScanVerdictInfected {
+		event = md.logger.Warn()
+	}
+	event.
+		Str("session_id", opts.SessionID.String()).
+		Str("message_id", opts.MessageID).
+		Str("scanner", result.Scanner).
+		Str("verdict", string(result.Verdict)).
+		Str("signature", result.Signature).
+		Dur("scan_duration", time.Since(scanStart)).
+		Msg("🛡️ Verificação de malware da mídia concluída")
+
+	return result
+}
+
+// notifyScan entrega o resultado da verificação ao callback configurado
+func (md *MediaDownloader) notifyScan(ctx context.Context, mediaType string, opts storage.MediaUploadOptions, stored *storedMedia) {
+	if md.onScan == nil {
+		return
+	}
+	md.onScan(ctx, &MediaScanEvent{
+		SessionID:   opts.SessionID,
+		MessageID:   opts.MessageID,
+		ChatJID:     opts.ChatJID,
+		MediaType:   mediaType,
+		Size:        opts.Size,
+		ObjectPath:  stored.ObjectPath,
+		Result:      stored.Scan,
+		Quarantined: stored.Quarantined,
+	})
+}
+
+// uploadBlob grava o conteúdo uma única vez por tenant: se o mesmo SHA-256 já foi armazenado, a
+// mensagem apenas passa a referenciá-lo. Referências de mensagens que não chegam a ser gravadas
+// são corrigidas pela coleta de mídias, que recalcula as referências antes de remover conteúdos.
+// Conteúdos reservados pela coleta não são reaproveitados nem regravados.
+func (md *MediaDownloader) uploadBlob(ctx context.Context, data []byte, opts storage.MediaUploadOptions) (string, *uuid.UUID, error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	blob, err := md.blobs.AddReference(ctx, opts.TenantID, digest)
+	if err == nil {
+		metrics.IncMediaDedup("storage", true)
+		md.logger.Debug().
+			Str("session_id", opts.SessionID.String()).
+			Str("message_id", opts.MessageID).
+			Str("object_path", blob.ObjectPath).
+			Int("ref_count", blob.RefCount).
+			Msg("♻️ Mídia já armazenada, reaproveitando conteúdo")
+		return blob.ObjectPath, &blob.ID, nil
+	}
+	if errors.Is(err, media.ErrBlobDeleting) {
+		metrics.IncMediaDedup("storage", false)
+		return md.uploadPrivate(ctx, data, opts)
+	}
+	if !errors.Is(err, media.ErrBlobNotFound) {
+		return "", nil, err
+	}
+
+	metrics.IncMediaDedup("storage", false)
+	objectPath, err := md.mediaStorage.UploadBlob(ctx, bytes.NewReader(data), storage.BlobUploadOptions{
+		TenantID:    opts.TenantID,
+		SHA256:      digest,
+		ContentType: opts.ContentType,
+		Extension:   opts.Extension,
+		Size:        opts.Size,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	blob, err = md.blobs.Create(ctx, media.NewBlob(opts.TenantID, digest, objectPath, opts.Size, opts.ContentType))
+	if errors.Is(err, media.ErrBlobDeleting) {
+		return md.uploadPrivate(ctx, data, opts)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return blob.ObjectPath, &blob.ID, nil
+}
+
+// uploadPrivate grava uma cópia própria da mensagem quando a coleta está removendo o conteúdo
+// compartilhado: o path do conteúdo não é regravado, para que a coleta não apague o objeto recém
+// gravado, e a mensagem não passa a apontar para um objeto que pode desaparecer
+func (md *MediaDownloader) uploadPrivate(ctx context.Context, data []byte, opts storage.MediaUploadOptions) (string, *uuid.UUID, error) {
+	objectPath, err := md.mediaStorage.UploadMedia(ctx, bytes.NewReader(data), opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return objectPath, nil, nil
 }
 
 // getExtensionFromMimeType obtém a extensão do arquivo baseada no MIME type
@@ -0,0 +1,162 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"zapcore/internal/domain/whatsapp"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waMmsRetry"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// DefaultMediaRetryTimeout é a espera padrão pelo reenvio de uma mídia expirada
+const DefaultMediaRetryTimeout = 30 * time.Second
+
+// ErrMediaRetryTimeout indica que o aparelho do remetente não respondeu ao pedido de reenvio
+var ErrMediaRetryTimeout = errors.New("o aparelho do remetente não reenviou a mídia a tempo")
+
+// mediaRetryWaiters entrega as respostas dos pedidos de reenvio de mídia aos downloads que as aguardam
+type mediaRetryWaiters struct {
+	mu      sync.Mutex
+	waiters map[string][]chan *events.MediaRetry
+}
+
+func newMediaRetryWaiters() *mediaRetryWaiters {
+	return &mediaRetryWaiters{waiters: make(map[string][]chan *events.MediaRetry)}
+}
+
+func mediaRetryKey(sessionID uuid.UUID, msgID string) string {
+	return sessionID.String() + "/" + msgID
+}
+
+// register passa a aguardar a resposta do pedido de reenvio da mensagem; a função retornada
+// encerra a espera
+func (w *mediaRetryWaiters) register(sessionID uuid.UUID, msgID string) (<-chan *events.MediaRetry, func()) {
+	key := mediaRetryKey(sessionID, msgID)
+	ch := make(chan *events.MediaRetry, 1)
+
+	w.mu.Lock()
+	w.waiters[key] = append(w.waiters[key], ch)
+	w.mu.Unlock()
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		remaining := w.waiters[key][:0]
+		for _, waiter := range w.waiters[key] {
+			if waiter != ch {
+				remaining = append(remaining, waiter)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(w.waiters, key)
+			return
+		}
+		w.waiters[key] = remaining
+	}
+}
+
+// deliver entrega a resposta recebida a quem a aguarda; retorna false quando ninguém aguardava
+func (w *mediaRetryWaiters) deliver(sessionID uuid.UUID, evt *events.MediaRetry) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	waiters := w.waiters[mediaRetryKey(sessionID, evt.MessageID)]
+	for _, ch := range waiters {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+	return len(waiters) > 0
+}
+
+// requestMediaRetry pede ao aparelho do remetente que reenvie a mídia expirada e retorna o novo
+// direct path. Não deve ser chamado dentro do loop de eventos do whatsmeow, que entrega a resposta.
+func (c *WhatsAppClient) requestMediaRetry(ctx context.Context, client *whatsmeow.Client, req *whatsapp.DownloadMediaRequest) (string, error) {
+	info, err := mediaRetryInfo(client, req)
+	if err != nil {
+		return "", err
+	}
+
+	responses, stop := c.mediaRetries.register(req.SessionID, req.MessageID)
+	defer stop()
+
+	if err := client.SendMediaRetryReceipt(info, req.MediaKey); err != nil {
+		return "", fmt.Errorf("erro ao pedir reenvio da mídia: %w", err)
+	}
+
+	c.logger.Info().
+		Str("session_id", req.SessionID.String()).
+		Str("message_id", req.MessageID).
+		Str("chat_jid", req.ChatJID).
+		Msg("Mídia expirada no WhatsApp, reenvio pedido ao aparelho do remetente")
+
+	timer := time.NewTimer(c.mediaRetryTimeout)
+	defer timer.Stop()
+
+	var evt *events.MediaRetry
+	select {
+	case evt = <-responses:
+	case <-timer.C:
+		return "", ErrMediaRetryTimeout
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	notification, err := whatsmeow.DecryptMediaRetryNotification(evt, req.MediaKey)
+	if err != nil {
+		return "", fmt.Errorf("reenvio da mídia recusado: %w", err)
+	}
+	if notification.GetResult() != waMmsRetry.MediaRetryNotification_SUCCESS || notification.GetDirectPath() == "" {
+		return "", fmt.Errorf("reenvio da mídia recusado: %s", notification.GetResult())
+	}
+
+	return notification.GetDirectPath(), nil
+}
+
+// mediaRetryInfo monta a identificação da mensagem exigida pelo pedido de reenvio; em grupos,
+// o remetente é o participante que enviou a mídia
+func mediaRetryInfo(client *whatsmeow.Client, req *whatsapp.DownloadMediaRequest) (*types.MessageInfo, error) {
+	chat, err := types.ParseJID(req.ChatJID)
+	if err != nil {
+		return nil, fmt.Errorf("JID do chat inválido: %w", err)
+	}
+
+	info := &types.MessageInfo{
+		MessageSource: types.MessageSource{
+			Chat:     chat,
+			IsFromMe: req.IsFromMe,
+			IsGroup:  chat.Server == types.GroupServer,
+		},
+		ID: req.MessageID,
+	}
+
+	if info.IsGroup {
+		sender, err := types.ParseJID(req.SenderJID)
+		switch {
+		case err == nil && sender.User != "":
+			info.Sender = sender
+		case req.IsFromMe && client.Store.ID != nil:
+			info.Sender = client.Store.ID.ToNonAD()
+		default:
+			return nil, fmt.Errorf("remetente da mensagem de grupo inválido: %s", req.SenderJID)
+		}
+	}
+
+	return info, nil
+}
+
+// isMediaExpired verifica se o download falhou porque a CDN do WhatsApp já descartou o arquivo
+func isMediaExpired(err error) bool {
+	return errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith404) ||
+		errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith410)
+}
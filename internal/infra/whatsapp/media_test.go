@@ -0,0 +1,180 @@
+package whatsapp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/infra/storage"
+
+	"github.com/google/uuid"
+)
+
+// memoryBlobRepository reproduz em memória as regras de referência e reserva do repositório
+type memoryBlobRepository struct {
+	mu    sync.Mutex
+	blobs map[string]*media.Blob
+}
+
+func newMemoryBlobRepository() *memoryBlobRepository {
+	return &memoryBlobRepository{blobs: make(map[string]*media.Blob)}
+}
+
+func (r *memoryBlobRepository) key(tenantID, sha string) string {
+	return tenantID + "/" + sha
+}
+
+func (r *memoryBlobRepository) ListMediaPaths(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	paths := make([]string, 0, len(r.blobs))
+	for _, blob := range r.blobs {
+		paths = append(paths, blob.ObjectPath)
+	}
+	return paths, nil
+}
+
+func (r *memoryBlobRepository) AddReference(ctx context.Context, tenantID, sha string) (*media.Blob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	blob, ok := r.blobs[r.key(tenantID, sha)]
+	if !ok {
+		return nil, media.ErrBlobNotFound
+	}
+	if blob.RefCount == media.BlobDeleting {
+		return nil, media.ErrBlobDeleting
+	}
+	blob.RefCount++
+	return blob, nil
+}
+
+func (r *memoryBlobRepository) Create(ctx context.Context, blob *media.Blob) (*media.Blob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.blobs[r.key(blob.TenantID, blob.SHA256)]; ok {
+		if existing.RefCount < 0 {
+			return nil, media.ErrBlobDeleting
+		}
+		existing.RefCount += blob.RefCount
+		return existing, nil
+	}
+	r.blobs[r.key(blob.TenantID, blob.SHA256)] = blob
+	return blob, nil
+}
+
+func (r *memoryBlobRepository) ReleaseReference(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (r *memoryBlobRepository) DeleteByPaths(ctx context.Context, objectPaths []string) (int, error) {
+	return 0, nil
+}
+
+func (r *memoryBlobRepository) RecountReferences(ctx context.Context) error {
+	return nil
+}
+
+func (r *memoryBlobRepository) ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]*media.Blob, error) {
+	return nil, nil
+}
+
+func (r *memoryBlobRepository) ClaimUnreferenced(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, blob := range r.blobs {
+		if blob.ID == id && blob.RefCount <= 0 {
+			blob.RefCount = media.BlobDeleting
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryBlobRepository) DeleteClaimed(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, blob := range r.blobs {
+		if blob.ID == id && blob.RefCount == media.BlobDeleting {
+			delete(r.blobs, key)
+		}
+	}
+	return nil
+}
+
+func TestUploadBlobDuringCollection(t *testing.T) {
+	ctx := context.Background()
+
+	backend, err := storage.NewLocalBackend(storage.LocalOptions{
+		Root:       t.TempDir(),
+		BaseURL:    "http://localhost",
+		SigningKey: []byte("test"),
+	})
+	if err != nil {
+		t.Fatalf("backend local: %v", err)
+	}
+	store := storage.NewMediaStorage(backend, 0)
+	blobs := newMemoryBlobRepository()
+	downloader := NewMediaDownloader(nil, store, nil, blobs)
+
+	data := []byte("conteúdo compartilhado")
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	opts := storage.MediaUploadOptions{
+		TenantID:    "tenant-a",
+		SessionID:   uuid.New(),
+		ChatJID:     "5511999999999@s.whatsapp.net",
+		Direction:   "inbound",
+		MessageID:   "MSG1",
+		ContentType: "image/jpeg",
+		Extension:   "jpg",
+		Size:        int64(len(data)),
+	}
+
+	// Conteúdo gravado anteriormente e que perdeu todas as referências
+	blobPath := storage.BlobPath(opts.TenantID, digest, opts.Extension)
+	orphan := media.NewBlob(opts.TenantID, digest, blobPath, opts.Size, opts.ContentType)
+	orphan.RefCount = 0
+	if _, err := blobs.Create(ctx, orphan); err != nil {
+		t.Fatalf("registrar conteúdo: %v", err)
+	}
+
+	// A coleta reserva o conteúdo, o upload acontece e só então a coleta remove objeto e registro
+	claimed, err := blobs.ClaimUnreferenced(ctx, orphan.ID)
+	if err != nil || !claimed {
+		t.Fatalf("reservar conteúdo: claimed=%v err=%v", claimed, err)
+	}
+
+	objectPath, blobID, err := downloader.uploadBlob(ctx, data, opts)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	if err := store.DeleteMedia(ctx, blobPath); err != nil {
+		t.Fatalf("remover objeto: %v", err)
+	}
+	if err := blobs.DeleteClaimed(ctx, orphan.ID); err != nil {
+		t.Fatalf("remover registro: %v", err)
+	}
+
+	if blobID != nil {
+		t.Errorf("mensagem não deveria referenciar o conteúdo em remoção, blobID=%s", blobID)
+	}
+	if objectPath == blobPath {
+		t.Fatalf("upload não deveria gravar no path do conteúdo em remoção")
+	}
+	if _, err := store.GetMediaInfo(ctx, objectPath); err != nil {
+		t.Errorf("objeto armazenado deveria existir após a coleta: %v", err)
+	}
+	if _, err := blobs.AddReference(ctx, opts.TenantID, digest); err != media.ErrBlobNotFound {
+		t.Errorf("nenhum registro deveria apontar para o objeto removido, err=%v", err)
+	}
+}
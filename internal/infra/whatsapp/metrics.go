@@ -0,0 +1,80 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/sendlimit"
+	"zapcore/internal/infra/metrics"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow"
+)
+
+// Classes de erro de envio expostas nas métricas
+const (
+	SendErrorNotConnected     = "not_connected"
+	SendErrorInvalidRecipient = "invalid_recipient"
+	SendErrorMedia            = "media"
+	SendErrorUpload           = "upload"
+	SendErrorTimeout          = "timeout"
+	SendErrorRateLimited      = "rate_limited"
+	SendErrorThrottled        = "throttled"
+	SendErrorServer           = "server"
+	SendErrorOther            = "other"
+)
+
+// observeSend registra latência, contagem e classe de erro de um envio
+func observeSend(sessionID uuid.UUID, messageType message.MessageType, start time.Time, err error) {
+	metrics.ObserveSend(string(messageType), classifySendError(err), time.Since(start))
+	if err == nil {
+		metrics.IncMessage(sessionID.String(), metrics.DirectionSent, string(messageType))
+	}
+}
+
+// classifySendError agrupa os erros de envio em classes de baixa cardinalidade
+func classifySendError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var throttled *sendlimit.ThrottledError
+	if errors.As(err, &throttled) {
+		return SendErrorThrottled
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, whatsmeow.ErrIQTimedOut),
+		errors.Is(err, whatsmeow.ErrMessageTimedOut):
+		return SendErrorTimeout
+	case errors.Is(err, whatsmeow.ErrNotConnected), errors.Is(err, whatsmeow.ErrNotLoggedIn):
+		return SendErrorNotConnected
+	case errors.Is(err, whatsmeow.ErrIQRateOverLimit):
+		return SendErrorRateLimited
+	case errors.Is(err, whatsmeow.ErrServerReturnedError):
+		return SendErrorServer
+	}
+
+	// Os erros do MessageSender não têm tipo próprio: classificar pela mensagem
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "cliente não"):
+		return SendErrorNotConnected
+	case strings.HasPrefix(msg, "JID inválido"):
+		return SendErrorInvalidRecipient
+	case strings.HasPrefix(msg, "erro ao fazer upload"):
+		return SendErrorUpload
+	case strings.HasPrefix(msg, "erro ao obter dados da mídia"),
+		strings.HasPrefix(msg, "erro ao ler dados da mídia"),
+		strings.HasPrefix(msg, "validação de mídia falhou"):
+		return SendErrorMedia
+	case strings.HasPrefix(msg, "erro ao enviar"):
+		return SendErrorServer
+	default:
+		return SendErrorOther
+	}
+}
@@ -53,6 +53,7 @@ func (w *WhatsAppLoggerAdapter) Sub(module string) waLog.Logger {
 // StoreManager gerencia o store do whatsmeow
 type StoreManager struct {
 	container *sqlstore.Container
+	db        *sql.DB
 	logger    *logger.Logger
 }
 
@@ -79,6 +80,7 @@ func NewStoreManager(db *sql.DB, zeroLogger zerolog.Logger) (*StoreManager, erro
 
 	return &StoreManager{
 		container: container,
+		db:        db,
 		logger:    logger.NewFromZerolog(zeroLogger),
 	}, nil
 }
@@ -88,6 +90,15 @@ func (sm *StoreManager) GetContainer() *sqlstore.Container {
 	return sm.container
 }
 
+// HealthCheck verifica se as tabelas do store do whatsmeow estão acessíveis
+func (sm *StoreManager) HealthCheck(ctx context.Context) error {
+	var devices int
+	if err := sm.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM whatsmeow_device").Scan(&devices); err != nil {
+		return fmt.Errorf("store do WhatsApp não está acessível: %w", err)
+	}
+	return nil
+}
+
 // Close fecha o container
 func (sm *StoreManager) Close() error {
 	if sm.container != nil {
@@ -0,0 +1,190 @@
+package whatsapp
+
+import (
+	"zapcore/internal/domain/eventstream"
+	"zapcore/internal/domain/message"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// toStreamEvent converte um evento do whatsmeow para o formato publicado no
+// stream de eventos. Retorna false para eventos que não são publicados.
+func (c *CompositeEventHandler) toStreamEvent(sessionID uuid.UUID, evt any) (*eventstream.Event, bool) {
+	var (
+		eventType string
+		payload   map[string]any
+	)
+
+	switch e := evt.(type) {
+	case *events.Message:
+		if e.Message == nil {
+			return nil, false
+		}
+		msg := message.NewMessage(sessionID, message.MessageTypeText, message.MessageDirectionInbound)
+		if c.storageHandler != nil {
+			_ = c.storageHandler.processMessageContent(msg, e.Message)
+		}
+		eventType = eventstream.TypeMessage
+		payload = map[string]any{
+			"messageId":   e.Info.ID,
+			"chatJid":     e.Info.Chat.String(),
+			"senderJid":   e.Info.Sender.String(),
+			"pushName":    e.Info.PushName,
+			"isFromMe":    e.Info.IsFromMe,
+			"isGroup":     e.Info.IsGroup,
+			"messageType": string(msg.MessageType),
+			"content":     msg.Content,
+			"timestamp":   e.Info.Timestamp,
+		}
+
+	case *events.UndecryptableMessage:
+		eventType = eventstream.TypeUndecryptableMessage
+		payload = map[string]any{
+			"messageId":       e.Info.ID,
+			"chatJid":         e.Info.Chat.String(),
+			"senderJid":       e.Info.Sender.String(),
+			"isUnavailable":   e.IsUnavailable,
+			"unavailableType": string(e.UnavailableType),
+			"timestamp":       e.Info.Timestamp,
+		}
+
+	case *events.Receipt:
+		eventType = eventstream.TypeReceipt
+		payload = map[string]any{
+			"messageIds": e.MessageIDs,
+			"chatJid":    e.Chat.String(),
+			"senderJid":  e.Sender.String(),
+			"type":       string(e.Type),
+			"timestamp":  e.Timestamp,
+		}
+
+	case *events.Presence:
+		eventType = eventstream.TypePresence
+		payload = map[string]any{
+			"fromJid":     e.From.String(),
+			"unavailable": e.Unavailable,
+		}
+		if !e.LastSeen.IsZero() {
+			payload["lastSeen"] = e.LastSeen
+		}
+
+	case *events.ChatPresence:
+		eventType = eventstream.TypeChatPresence
+		payload = map[string]any{
+			"chatJid":   e.Chat.String(),
+			"senderJid": e.Sender.String(),
+			"state":     string(e.State),
+			"media":     string(e.Media),
+		}
+
+	case *events.Connected:
+		eventType = eventstream.TypeConnected
+		payload = map[string]any{}
+
+	case *events.Disconnected:
+		eventType = eventstream.TypeDisconnected
+		payload = map[string]any{}
+
+	case *events.LoggedOut:
+		eventType = eventstream.TypeLoggedOut
+		payload = map[string]any{
+			"onConnect": e.OnConnect,
+			"reason":    int(e.Reason),
+		}
+
+	case *events.PairSuccess:
+		eventType = eventstream.TypePairSuccess
+		payload = map[string]any{
+			"jid":          e.ID.String(),
+			"businessName": e.BusinessName,
+			"platform":     e.Platform,
+		}
+
+	case *events.HistorySync:
+		if e.Data == nil {
+			return nil, false
+		}
+		eventType = eventstream.TypeHistorySync
+		payload = map[string]any{
+			"syncType":      e.Data.GetSyncType().String(),
+			"conversations": len(e.Data.GetConversations()),
+		}
+
+	case *events.Contact:
+		eventType = eventstream.TypeContact
+		payload = map[string]any{
+			"jid":       e.JID.String(),
+			"fullName":  e.Action.GetFullName(),
+			"firstName": e.Action.GetFirstName(),
+			"timestamp": e.Timestamp,
+		}
+
+	case *events.PushName:
+		eventType = eventstream.TypePushName
+		payload = map[string]any{
+			"jid":         e.JID.String(),
+			"oldPushName": e.OldPushName,
+			"newPushName": e.NewPushName,
+		}
+
+	case *events.GroupInfo:
+		eventType = eventstream.TypeGroupInfo
+		payload = map[string]any{
+			"groupJid":  e.JID.String(),
+			"join":      jidStrings(e.Join),
+			"leave":     jidStrings(e.Leave),
+			"promote":   jidStrings(e.Promote),
+			"demote":    jidStrings(e.Demote),
+			"timestamp": e.Timestamp,
+		}
+		if e.Name != nil {
+			payload["name"] = e.Name.Name
+		}
+		if e.Topic != nil {
+			payload["topic"] = e.Topic.Topic
+		}
+
+	case *events.Picture:
+		eventType = eventstream.TypePicture
+		payload = map[string]any{
+			"jid":       e.JID.String(),
+			"authorJid": e.Author.String(),
+			"removed":   e.Remove,
+			"pictureId": e.PictureID,
+			"timestamp": e.Timestamp,
+		}
+
+	case *MediaScanEvent:
+		if e.Result == nil {
+			return nil, false
+		}
+		eventType = eventstream.TypeMediaScan
+		payload = map[string]any{
+			"messageId":   e.MessageID,
+			"chatJid":     e.ChatJID,
+			"mediaType":   e.MediaType,
+			"size":        e.Size,
+			"scanner":     e.Result.Scanner,
+			"verdict":     string(e.Result.Verdict),
+			"quarantined": e.Quarantined,
+		}
+		if e.Result.Signature != "" {
+			payload["signature"] = e.Result.Signature
+		}
+
+	default:
+		return nil, false
+	}
+
+	return eventstream.NewEvent(sessionID, eventType, payload), true
+}
+
+// jidStrings converte uma lista de JIDs para strings
+func jidStrings[T interface{ String() string }](jids []T) []string {
+	result := make([]string, 0, len(jids))
+	for _, jid := range jids {
+		result = append(result, jid.String())
+	}
+	return result
+}
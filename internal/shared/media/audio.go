@@ -0,0 +1,175 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Parâmetros das mensagens de voz (PTT) do WhatsApp
+const (
+	VoiceNoteMimeType = "audio/ogg; codecs=opus" // Formato exigido para o áudio ser exibido como mensagem de voz
+	WaveformSamples   = 64                       // Quantidade de amostras da forma de onda exibida no balão
+
+	waveformSampleRate = 8000 // Taxa do PCM usado para calcular duração e forma de onda
+	opusGranuleRate    = 48000
+	transcodeCmdLimit  = 2 * time.Minute
+)
+
+// ErrTranscodeUnsupported indica que o áudio não pode ser convertido em mensagem de voz,
+// normalmente por falta do ffmpeg quando o áudio não está em OGG/Opus
+var ErrTranscodeUnsupported = errors.New("conversão para mensagem de voz não suportada para o áudio")
+
+// VoiceNote representa um áudio pronto para envio como mensagem de voz
+type VoiceNote struct {
+	Data     []byte
+	MimeType string
+	Seconds  uint32 // Duração arredondada para cima
+	Waveform []byte // WaveformSamples valores de 0 a 100; vazio quando não foi possível calcular
+}
+
+// AudioTranscoder converte áudios em mensagens de voz OGG/Opus
+type AudioTranscoder interface {
+	// ToVoiceNote converte o áudio, calculando duração e forma de onda
+	ToVoiceNote(ctx context.Context, data []byte, mimeType string) (*VoiceNote, error)
+}
+
+// NewAudioTranscoder cria o conversor baseado no ffmpeg; sem o executável, retorna o NoopTranscoder
+func NewAudioTranscoder(ffmpegPath string) AudioTranscoder {
+	if path := lookPath(ffmpegPath); path != "" {
+		return &FFmpegTranscoder{path: path}
+	}
+	return NoopTranscoder{}
+}
+
+// NoopTranscoder não converte áudios: aceita apenas os que já estão em OGG/Opus, com a duração
+// lida do próprio arquivo e sem forma de onda
+type NoopTranscoder struct{}
+
+// ToVoiceNote retorna o áudio sem alterações, ou ErrTranscodeUnsupported se não for OGG/Opus
+func (NoopTranscoder) ToVoiceNote(_ context.Context, data []byte, _ string) (*VoiceNote, error) {
+	seconds, ok := oggOpusSeconds(data)
+	if !ok {
+		return nil, ErrTranscodeUnsupported
+	}
+
+	return &VoiceNote{
+		Data:     data,
+		MimeType: VoiceNoteMimeType,
+		Seconds:  seconds,
+	}, nil
+}
+
+// FFmpegTranscoder converte qualquer áudio suportado pelo ffmpeg em OGG/Opus mono
+type FFmpegTranscoder struct {
+	path string
+}
+
+// ToVoiceNote converte o áudio para OGG/Opus e calcula a forma de onda a partir do PCM decodificado
+func (t *FFmpegTranscoder) ToVoiceNote(ctx context.Context, data []byte, _ string) (*VoiceNote, error) {
+	dir, err := os.MkdirTemp("", "zapcore-audio-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, fmt.Errorf("erro ao gravar áudio temporário: %w", err)
+	}
+
+	ogg, err := runTool(ctx, transcodeCmdLimit, t.path,
+		"-hide_banner", "-loglevel", "error",
+		"-i", input,
+		"-vn", "-map_metadata", "-1",
+		"-ac", "1", "-ar", "48000",
+		"-c:a", "libopus", "-b:a", "32k", "-application", "voip",
+		"-f", "ogg", "pipe:1",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter áudio para OGG/Opus: %w", err)
+	}
+
+	pcm, err := runTool(ctx, transcodeCmdLimit, t.path,
+		"-hide_banner", "-loglevel", "error",
+		"-i", input,
+		"-vn", "-ac", "1", "-ar", fmt.Sprint(waveformSampleRate),
+		"-f", "s16le", "-acodec", "pcm_s16le", "pipe:1",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decodificar áudio: %w", err)
+	}
+
+	seconds, ok := oggOpusSeconds(ogg)
+	if !ok {
+		samples := len(pcm) / 2
+		seconds = uint32((samples + waveformSampleRate - 1) / waveformSampleRate)
+	}
+
+	return &VoiceNote{
+		Data:     ogg,
+		MimeType: VoiceNoteMimeType,
+		Seconds:  seconds,
+		Waveform: waveform(pcm),
+	}, nil
+}
+
+// waveform reduz o PCM 16 bits mono a WaveformSamples médias de amplitude, normalizadas de 0 a 100
+func waveform(pcm []byte) []byte {
+	samples := len(pcm) / 2
+	if samples < WaveformSamples {
+		return nil
+	}
+
+	levels := make([]float64, WaveformSamples)
+	peak := 0.0
+	for i := range levels {
+		start, end := i*samples/WaveformSamples, (i+1)*samples/WaveformSamples
+		sum := 0.0
+		for j := start; j < end; j++ {
+			sum += math.Abs(float64(int16(binary.LittleEndian.Uint16(pcm[j*2:]))))
+		}
+		levels[i] = sum / float64(end-start)
+		peak = max(peak, levels[i])
+	}
+
+	result := make([]byte, WaveformSamples)
+	if peak == 0 {
+		return result
+	}
+	for i, level := range levels {
+		result[i] = byte(math.Round(level / peak * 100))
+	}
+	return result
+}
+
+// oggOpusSeconds lê a duração de um OGG/Opus pela posição da última página, descontando o pre-skip
+func oggOpusSeconds(data []byte) (uint32, bool) {
+	if !bytes.HasPrefix(data, []byte("OggS")) {
+		return 0, false
+	}
+
+	head := bytes.Index(data, []byte("OpusHead"))
+	if head < 0 || len(data) < head+12 {
+		return 0, false
+	}
+	preSkip := int64(binary.LittleEndian.Uint16(data[head+10:]))
+
+	last := bytes.LastIndex(data, []byte("OggS"))
+	if len(data) < last+14 {
+		return 0, false
+	}
+	granule := int64(binary.LittleEndian.Uint64(data[last+6:]))
+	if granule <= preSkip {
+		return 0, false
+	}
+
+	samples := granule - preSkip
+	return uint32((samples + opusGranuleRate - 1) / opusGranuleRate), true
+}
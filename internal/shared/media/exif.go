@@ -0,0 +1,201 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// Tags EXIF usadas na normalização de imagens
+const (
+	exifTagOrientation = 0x0112
+	exifTagGPSInfo     = 0x8825
+)
+
+// jpegExif localiza o bloco TIFF do segmento APP1 Exif de um JPEG. O retorno aponta para os
+// bytes originais, permitindo alterações no lugar; nil quando não há EXIF.
+func jpegExif(data []byte) []byte {
+	if !bytes.HasPrefix(data, []byte{0xFF, 0xD8}) {
+		return nil
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 { // Início dos dados da imagem ou fim do arquivo
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+
+		segment := data[pos+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		pos = end
+	}
+	return nil
+}
+
+// tiffIFD representa um diretório de tags de um bloco TIFF
+type tiffIFD struct {
+	tiff   []byte
+	order  binary.ByteOrder
+	offset int
+	count  int
+}
+
+// parseIFD0 lê o primeiro diretório do bloco TIFF
+func parseIFD0(tiff []byte) (*tiffIFD, bool) {
+	if len(tiff) < 8 {
+		return nil, false
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, false
+	}
+
+	return parseIFD(tiff, order, int(order.Uint32(tiff[4:])))
+}
+
+// parseIFD lê o diretório no deslocamento informado
+func parseIFD(tiff []byte, order binary.ByteOrder, offset int) (*tiffIFD, bool) {
+	if offset <= 0 || offset+2 > len(tiff) {
+		return nil, false
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	if offset+2+count*12 > len(tiff) {
+		return nil, false
+	}
+	return &tiffIFD{tiff: tiff, order: order, offset: offset, count: count}, true
+}
+
+// entry retorna os 12 bytes da entrada com a tag informada
+func (ifd *tiffIFD) entry(tag uint16) []byte {
+	for i := 0; i < ifd.count; i++ {
+		start := ifd.offset + 2 + i*12
+		if ifd.order.Uint16(ifd.tiff[start:]) == tag {
+			return ifd.tiff[start : start+12]
+		}
+	}
+	return nil
+}
+
+// exifOrientation retorna a orientação EXIF do JPEG (1 quando ausente)
+func exifOrientation(data []byte) int {
+	ifd, ok := parseIFD0(jpegExif(data))
+	if !ok {
+		return 1
+	}
+	entry := ifd.entry(exifTagOrientation)
+	if entry == nil {
+		return 1
+	}
+	return int(ifd.order.Uint16(entry[8:]))
+}
+
+// hasGPS informa se o JPEG tem localização no EXIF
+func hasGPS(data []byte) bool {
+	gps := gpsIFD(jpegExif(data))
+	return gps != nil && gps.count > 0
+}
+
+// stripGPS apaga no lugar as tags de localização do EXIF, mantendo as demais (como a
+// orientação). O diretório GPS fica vazio e os valores apontados por ele são zerados.
+func stripGPS(data []byte) bool {
+	gps := gpsIFD(jpegExif(data))
+	if gps == nil || gps.count == 0 {
+		return false
+	}
+
+	for i := 0; i < gps.count; i++ {
+		entry := gps.tiff[gps.offset+2+i*12:]
+		size := exifTypeSize(gps.order.Uint16(entry[2:])) * int(gps.order.Uint32(entry[4:]))
+		if size > 4 {
+			valueOffset := int(gps.order.Uint32(entry[8:]))
+			if valueOffset > 0 && valueOffset+size <= len(gps.tiff) {
+				clear(gps.tiff[valueOffset : valueOffset+size])
+			}
+		}
+	}
+
+	clear(gps.tiff[gps.offset : gps.offset+2+gps.count*12])
+	return true
+}
+
+// gpsIFD localiza o diretório GPS apontado pelo primeiro diretório
+func gpsIFD(tiff []byte) *tiffIFD {
+	ifd0, ok := parseIFD0(tiff)
+	if !ok {
+		return nil
+	}
+	entry := ifd0.entry(exifTagGPSInfo)
+	if entry == nil {
+		return nil
+	}
+	gps, ok := parseIFD(tiff, ifd0.order, int(ifd0.order.Uint32(entry[8:])))
+	if !ok {
+		return nil
+	}
+	return gps
+}
+
+// exifTypeSize retorna o tamanho em bytes de um valor do tipo TIFF
+func exifTypeSize(typ uint16) int {
+	switch typ {
+	case 3, 8: // SHORT, SSHORT
+		return 2
+	case 4, 9, 11: // LONG, SLONG, FLOAT
+		return 4
+	case 5, 10, 12: // RATIONAL, SRATIONAL, DOUBLE
+		return 8
+	default: // BYTE, ASCII, UNDEFINED
+		return 1
+	}
+}
+
+// applyOrientation gira a imagem conforme a orientação EXIF, que se perde ao recodificar o JPEG.
+// Apenas rotações são tratadas; orientações espelhadas são raras em fotos de celular.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation != 3 && orientation != 6 && orientation != 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	var dst *image.RGBA
+	if orientation == 3 {
+		dst = image.NewRGBA(image.Rect(0, 0, width, height))
+	} else {
+		dst = image.NewRGBA(image.Rect(0, 0, height, width))
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pixel := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+			switch orientation {
+			case 3: // 180°
+				dst.Set(width-1-x, height-1-y, pixel)
+			case 6: // 90° no sentido horário
+				dst.Set(height-1-y, x, pixel)
+			case 8: // 90° no sentido anti-horário
+				dst.Set(y, width-1-x, pixel)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,302 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Limites usados na normalização das mídias enviadas
+const (
+	DefaultImageMaxSide = 2560            // Lado máximo padrão das imagens enviadas
+	MaxImageBytes       = 5 * 1024 * 1024 // Imagens maiores são recomprimidas
+	StickerSize         = 512             // Stickers do WhatsApp são WebP de 512x512
+
+	videoTranscodeLimit = 5 * time.Minute
+	videoMaxSide        = 1280
+)
+
+// Alterações aplicadas pela normalização, informadas na resposta do envio
+const (
+	ChangeVideoTranscoded   = "video_transcoded"   // Vídeo convertido para H.264/AAC MP4 com faststart
+	ChangeImageResized      = "image_resized"      // Imagem reduzida para o lado máximo
+	ChangeImageRecompressed = "image_recompressed" // Imagem recodificada em JPEG para caber no limite
+	ChangeStickerConverted  = "sticker_converted"  // Imagem convertida em sticker WebP 512x512
+	ChangeGPSRemoved        = "gps_removed"        // Localização removida do EXIF
+)
+
+// NormalizeSteps define quais etapas da normalização são aplicadas
+type NormalizeSteps struct {
+	TranscodeVideo bool
+	ResizeImage    bool
+	ConvertSticker bool
+	StripGPS       bool
+}
+
+// AllNormalizeSteps ativa todas as etapas
+func AllNormalizeSteps() NormalizeSteps {
+	return NormalizeSteps{TranscodeVideo: true, ResizeImage: true, ConvertSticker: true, StripGPS: true}
+}
+
+// Normalized representa a mídia após a normalização
+type Normalized struct {
+	Data     []byte
+	MimeType string
+	Changes  []string // Alterações aplicadas; vazio quando a mídia foi mantida
+}
+
+// NormalizerOptions configura o normalizador de mídias
+type NormalizerOptions struct {
+	Defaults     NormalizeSteps // Etapas aplicadas quando a requisição não informa as suas
+	ImageMaxSide int            // Lado máximo das imagens; zero usa DefaultImageMaxSide
+	FFmpegPath   string         // Executável do ffmpeg, para vídeos e stickers; vazio desativa essas etapas
+}
+
+// Normalizer corrige mídias que o WhatsApp recusaria ou exibiria mal antes do envio: vídeos fora
+// de H.264/AAC MP4, imagens grandes demais, stickers fora do formato WebP e localização no EXIF
+type Normalizer struct {
+	defaults     NormalizeSteps
+	imageMaxSide int
+	ffmpegPath   string
+}
+
+// NewNormalizer cria o normalizador; com o ffmpeg ausente do PATH, vídeos e stickers não são convertidos
+func NewNormalizer(opts NormalizerOptions) *Normalizer {
+	imageMaxSide := opts.ImageMaxSide
+	if imageMaxSide <= 0 {
+		imageMaxSide = DefaultImageMaxSide
+	}
+
+	return &Normalizer{
+		defaults:     opts.Defaults,
+		imageMaxSide: imageMaxSide,
+		ffmpegPath:   lookPath(opts.FFmpegPath),
+	}
+}
+
+// Defaults retorna as etapas aplicadas por padrão
+func (n *Normalizer) Defaults() NormalizeSteps {
+	return n.defaults
+}
+
+// HasFFmpeg informa se o ffmpeg foi encontrado
+func (n *Normalizer) HasFFmpeg() bool {
+	return n.ffmpegPath != ""
+}
+
+// Normalize aplica as etapas à mídia do tipo informado (image, video ou sticker); os demais tipos
+// e as mídias que já estão no formato esperado são mantidos. Os dados de entrada não são alterados.
+func (n *Normalizer) Normalize(ctx context.Context, data []byte, mimeType, mediaType string, steps NormalizeSteps) (*Normalized, error) {
+	result := &Normalized{Data: data, MimeType: mimeType}
+	baseMime := strings.ToLower(strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0]))
+
+	switch mediaType {
+	case "video":
+		if steps.TranscodeVideo && n.ffmpegPath != "" && !isWhatsAppMP4(data) {
+			video, err := n.transcodeVideo(ctx, data)
+			if err != nil {
+				return nil, err
+			}
+			result.Data, result.MimeType = video, "video/mp4"
+			result.Changes = append(result.Changes, ChangeVideoTranscoded)
+		}
+
+	case "image":
+		if steps.ResizeImage && isStillImage(baseMime) {
+			if err := n.normalizeImage(result, steps.StripGPS); err != nil {
+				return nil, err
+			}
+		}
+		if steps.StripGPS && isJPEG(result.Data) && hasGPS(result.Data) {
+			result.Data = bytes.Clone(result.Data)
+			stripGPS(result.Data)
+			result.Changes = append(result.Changes, ChangeGPSRemoved)
+		}
+
+	case "sticker":
+		if steps.ConvertSticker && n.ffmpegPath != "" && isStillImage(baseMime) {
+			sticker, err := n.convertSticker(ctx, data)
+			if err != nil {
+				return nil, err
+			}
+			result.Data, result.MimeType = sticker, "image/webp"
+			result.Changes = append(result.Changes, ChangeStickerConverted)
+		}
+	}
+
+	return result, nil
+}
+
+// normalizeImage reduz imagens acima do lado máximo e recomprime as acima de MaxImageBytes em JPEG.
+// A recodificação descarta todo o EXIF, aplicando antes a orientação.
+func (n *Normalizer) normalizeImage(result *Normalized, stripLocation bool) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(result.Data))
+	if err != nil {
+		return fmt.Errorf("erro ao ler dimensões da imagem: %w", err)
+	}
+
+	oversized := config.Width > n.imageMaxSide || config.Height > n.imageMaxSide
+	if !oversized && len(result.Data) <= MaxImageBytes {
+		return nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(result.Data))
+	if err != nil {
+		return fmt.Errorf("erro ao decodificar imagem: %w", err)
+	}
+
+	hadGPS := isJPEG(result.Data) && hasGPS(result.Data)
+	if isJPEG(result.Data) {
+		img = applyOrientation(img, exifOrientation(result.Data))
+	}
+	img = resize(img, n.imageMaxSide, color.White)
+
+	// Reduz a qualidade até caber no limite
+	var encoded []byte
+	for _, quality := range []int{85, 75, 65, 55} {
+		encoded, err = encodeJPEG(img, quality)
+		if err != nil {
+			return err
+		}
+		if len(encoded) <= MaxImageBytes {
+			break
+		}
+	}
+
+	result.Data, result.MimeType = encoded, "image/jpeg"
+	if oversized {
+		result.Changes = append(result.Changes, ChangeImageResized)
+	}
+	result.Changes = append(result.Changes, ChangeImageRecompressed)
+	if hadGPS && stripLocation {
+		result.Changes = append(result.Changes, ChangeGPSRemoved)
+	}
+	return nil
+}
+
+// transcodeVideo converte o vídeo para H.264/AAC em MP4 com o índice no início (faststart),
+// limitando o lado maior a videoMaxSide
+func (n *Normalizer) transcodeVideo(ctx context.Context, data []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "zapcore-video-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, fmt.Errorf("erro ao gravar vídeo temporário: %w", err)
+	}
+
+	// O MP4 com faststart precisa de saída em arquivo: o índice é movido após a codificação
+	output := filepath.Join(dir, "output.mp4")
+	if _, err := runTool(ctx, videoTranscodeLimit, n.ffmpegPath,
+		"-hide_banner", "-loglevel", "error",
+		"-i", input,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-map_metadata", "-1",
+		"-vf", fmt.Sprintf("scale=w='min(%d,iw)':h='min(%d,ih)':force_original_aspect_ratio=decrease:force_divisible_by=2", videoMaxSide, videoMaxSide),
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "26", "-profile:v", "main", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k", "-ac", "2",
+		"-movflags", "+faststart",
+		output,
+	); err != nil {
+		return nil, fmt.Errorf("erro ao converter vídeo para MP4: %w", err)
+	}
+
+	video, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler vídeo convertido: %w", err)
+	}
+	return video, nil
+}
+
+// convertSticker converte a imagem em WebP de StickerSize x StickerSize, centralizada com fundo transparente
+func (n *Normalizer) convertSticker(ctx context.Context, data []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "zapcore-sticker-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, fmt.Errorf("erro ao gravar imagem temporária: %w", err)
+	}
+
+	output := filepath.Join(dir, "sticker.webp")
+	if _, err := runTool(ctx, thumbnailCmdLimit, n.ffmpegPath,
+		"-hide_banner", "-loglevel", "error",
+		"-i", input,
+		"-vf", fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=decrease,format=rgba,pad=%d:%d:(ow-iw)/2:(oh-ih)/2:color=black@0", StickerSize, StickerSize, StickerSize, StickerSize),
+		"-frames:v", "1",
+		"-c:v", "libwebp", "-quality", "80",
+		output,
+	); err != nil {
+		return nil, fmt.Errorf("erro ao converter sticker para WebP: %w", err)
+	}
+
+	sticker, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler sticker convertido: %w", err)
+	}
+	return sticker, nil
+}
+
+// isStillImage informa se o tipo MIME é de uma imagem estática que pode ser recodificada
+func isStillImage(mimeType string) bool {
+	return mimeType == "image/jpeg" || mimeType == "image/jpg" || mimeType == "image/png"
+}
+
+// isJPEG verifica a assinatura de um JPEG
+func isJPEG(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF})
+}
+
+// isWhatsAppMP4 verifica se o vídeo já é um MP4 reproduzível pelo WhatsApp: contêiner MP4, vídeo
+// H.264, áudio AAC (quando houver) e o índice (moov) antes dos dados (mdat)
+func isWhatsAppMP4(data []byte) bool {
+	var moov []byte
+	for pos := 0; pos+8 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[pos:]))
+		boxType := string(data[pos+4 : pos+8])
+		if size == 1 && pos+16 <= len(data) { // Tamanho estendido de 64 bits
+			size = int(binary.BigEndian.Uint64(data[pos+8:]))
+		} else if size == 0 { // Caixa até o fim do arquivo
+			size = len(data) - pos
+		}
+		if size < 8 || pos+size > len(data) {
+			return false
+		}
+
+		switch boxType {
+		case "ftyp":
+			// Contêiner QuickTime (.mov) não é MP4, mesmo com H.264
+			if size >= 12 && string(data[pos+8:pos+12]) == "qt  " {
+				return false
+			}
+		case "moov":
+			moov = data[pos : pos+size]
+		case "mdat":
+			if moov == nil {
+				return false
+			}
+		}
+		pos += size
+	}
+
+	if moov == nil || !bytes.Contains(moov, []byte("avc1")) {
+		return false
+	}
+	if bytes.Contains(moov, []byte("soun")) && !bytes.Contains(moov, []byte("mp4a")) {
+		return false
+	}
+	return true
+}
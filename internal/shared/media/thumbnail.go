@@ -0,0 +1,345 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Decodificador de GIF (primeiro quadro)
+	"image/jpeg"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Decodificador de WebP (stickers)
+)
+
+// Tamanhos e qualidade dos thumbnails gerados
+const (
+	InlineThumbnailSize = 72  // Lado máximo do thumbnail embutido nos campos do protocolo do WhatsApp
+	DefaultPreviewSize  = 480 // Lado máximo padrão do preview armazenado junto à mídia
+
+	inlineQuality     = 60
+	previewQuality    = 80
+	thumbnailCmdLimit = 20 * time.Second
+
+	// MaxDecodePixels limita a área das imagens decodificadas: o cabeçalho é lido antes, para que
+	// uma imagem pequena em bytes e enorme em pixels não esgote a memória
+	MaxDecodePixels = 50_000_000
+)
+
+var (
+	// ErrThumbnailUnsupported indica que não é possível gerar thumbnail para a mídia,
+	// seja pelo tipo ou pela falta da ferramenta externa necessária
+	ErrThumbnailUnsupported = errors.New("thumbnail não suportado para a mídia")
+
+	// ErrImageTooLarge indica uma imagem com mais de MaxDecodePixels
+	ErrImageTooLarge = errors.New("imagem grande demais para decodificar")
+)
+
+// Thumbnails contém o thumbnail embutido, o preview maior e as dimensões da imagem de origem
+type Thumbnails struct {
+	Inline       []byte // JPEG de até InlineThumbnailSize px
+	InlineWidth  int
+	InlineHeight int
+	Preview      []byte // JPEG de até PreviewSize px
+	Width        int    // Largura da imagem, do primeiro quadro do vídeo ou da primeira página
+	Height       int
+
+	source image.Image
+}
+
+// InlinePNG gera o thumbnail embutido em PNG, preservando a transparência, formato usado pelos stickers
+func (t *Thumbnails) InlinePNG() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, resize(t.source, InlineThumbnailSize, nil)); err != nil {
+		return nil, fmt.Errorf("erro ao codificar thumbnail PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ThumbnailOptions configura o gerador de thumbnails
+type ThumbnailOptions struct {
+	PreviewSize  int    // Lado máximo do preview; zero usa DefaultPreviewSize
+	FFmpegPath   string // Executável do ffmpeg, para o primeiro quadro de vídeos; vazio desativa
+	PdftoppmPath string // Executável do pdftoppm, para a primeira página de PDFs; vazio usa a imagem embutida
+}
+
+// Thumbnailer gera thumbnails de imagens, stickers, vídeos e PDFs. Imagens e stickers usam apenas
+// bibliotecas Go; vídeos dependem do ffmpeg e PDFs usam o pdftoppm quando disponível.
+type Thumbnailer struct {
+	previewSize  int
+	ffmpegPath   string
+	pdftoppmPath string
+}
+
+// NewThumbnailer cria um gerador de thumbnails; ferramentas externas ausentes do PATH são ignoradas
+func NewThumbnailer(opts ThumbnailOptions) *Thumbnailer {
+	previewSize := opts.PreviewSize
+	if previewSize <= 0 {
+		previewSize = DefaultPreviewSize
+	}
+
+	return &Thumbnailer{
+		previewSize:  previewSize,
+		ffmpegPath:   lookPath(opts.FFmpegPath),
+		pdftoppmPath: lookPath(opts.PdftoppmPath),
+	}
+}
+
+// HasFFmpeg informa se o ffmpeg foi encontrado
+func (t *Thumbnailer) HasFFmpeg() bool {
+	return t.ffmpegPath != ""
+}
+
+// HasPdftoppm informa se o pdftoppm foi encontrado
+func (t *Thumbnailer) HasPdftoppm() bool {
+	return t.pdftoppmPath != ""
+}
+
+// Generate gera os thumbnails da mídia pelo tipo MIME; retorna ErrThumbnailUnsupported quando
+// o tipo não tem thumbnail ou a ferramenta necessária não está instalada
+func (t *Thumbnailer) Generate(ctx context.Context, data []byte, mimeType string) (*Thumbnails, error) {
+	mimeType = strings.ToLower(strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0]))
+
+	var (
+		img image.Image
+		err error
+	)
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		img, err = decodeImage(data)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao decodificar imagem: %w", err)
+		}
+	case strings.HasPrefix(mimeType, "video/"):
+		img, err = t.videoFrame(ctx, data)
+	case mimeType == "application/pdf":
+		img, err = t.pdfPage(ctx, data)
+	default:
+		return nil, ErrThumbnailUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t.fromImage(img)
+}
+
+// fromImage redimensiona a imagem de origem no thumbnail embutido e no preview
+func (t *Thumbnailer) fromImage(img image.Image) (*Thumbnails, error) {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, fmt.Errorf("imagem sem dimensões")
+	}
+
+	inline := resize(img, InlineThumbnailSize, color.White)
+	inlineJPEG, err := encodeJPEG(inline, inlineQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := encodeJPEG(resize(img, t.previewSize, color.White), previewQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Thumbnails{
+		Inline:       inlineJPEG,
+		InlineWidth:  inline.Bounds().Dx(),
+		InlineHeight: inline.Bounds().Dy(),
+		Preview:      preview,
+		Width:        bounds.Dx(),
+		Height:       bounds.Dy(),
+		source:       img,
+	}, nil
+}
+
+// videoFrame extrai o primeiro quadro do vídeo com o ffmpeg
+func (t *Thumbnailer) videoFrame(ctx context.Context, data []byte) (image.Image, error) {
+	if t.ffmpegPath == "" {
+		return nil, ErrThumbnailUnsupported
+	}
+
+	dir, err := os.MkdirTemp("", "zapcore-thumb-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// O vídeo vai para um arquivo: MP4 com o índice no final não pode ser lido pelo stdin
+	input := filepath.Join(dir, "input")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, fmt.Errorf("erro ao gravar vídeo temporário: %w", err)
+	}
+
+	frame, err := runTool(ctx, thumbnailCmdLimit, t.ffmpegPath,
+		"-hide_banner", "-loglevel", "error",
+		"-i", input,
+		"-frames:v", "1",
+		"-f", "image2pipe", "-vcodec", "mjpeg",
+		"pipe:1",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao extrair quadro do vídeo: %w", err)
+	}
+
+	img, err := decodeImage(frame)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decodificar quadro do vídeo: %w", err)
+	}
+	return img, nil
+}
+
+// pdfPage renderiza a primeira página com o pdftoppm; sem ele, usa a primeira imagem JPEG
+// embutida no PDF, que costuma ser a capa de documentos digitalizados
+func (t *Thumbnailer) pdfPage(ctx context.Context, data []byte) (image.Image, error) {
+	if t.pdftoppmPath == "" {
+		return embeddedPDFImage(data)
+	}
+
+	dir, err := os.MkdirTemp("", "zapcore-thumb-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, fmt.Errorf("erro ao gravar PDF temporário: %w", err)
+	}
+
+	output := filepath.Join(dir, "page")
+	if _, err := runTool(ctx, thumbnailCmdLimit, t.pdftoppmPath,
+		"-jpeg", "-f", "1", "-l", "1", "-singlefile",
+		"-scale-to", strconv.Itoa(t.previewSize),
+		input, output,
+	); err != nil {
+		return nil, fmt.Errorf("erro ao renderizar página do PDF: %w", err)
+	}
+
+	page, err := os.ReadFile(output + ".jpg")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler página renderizada: %w", err)
+	}
+
+	img, err := decodeImage(page)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decodificar página do PDF: %w", err)
+	}
+	return img, nil
+}
+
+// embeddedPDFImage procura o primeiro stream DCTDecode (JPEG) do PDF que possa ser decodificado
+func embeddedPDFImage(data []byte) (image.Image, error) {
+	rest := data
+	for {
+		idx := bytes.Index(rest, []byte("/DCTDecode"))
+		if idx < 0 {
+			return nil, ErrThumbnailUnsupported
+		}
+		rest = rest[idx+len("/DCTDecode"):]
+
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			return nil, ErrThumbnailUnsupported
+		}
+		stream := bytes.TrimLeft(rest[start+len("stream"):], "\r\n")
+
+		end := bytes.Index(stream, []byte("endstream"))
+		if end < 0 {
+			return nil, ErrThumbnailUnsupported
+		}
+
+		if img, err := decodeImage(stream[:end]); err == nil {
+			return img, nil
+		}
+		rest = stream[end:]
+	}
+}
+
+// decodeImage decodifica a imagem depois de conferir pelo cabeçalho que ela não passa de
+// MaxDecodePixels
+func decodeImage(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if int64(config.Width)*int64(config.Height) > MaxDecodePixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// runTool executa uma ferramenta externa com tempo limite e retorna a saída padrão
+func runTool(ctx context.Context, timeout time.Duration, path string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// lookPath resolve o executável no PATH; retorna vazio quando não configurado ou não encontrado
+func lookPath(name string) string {
+	if name == "" {
+		return ""
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// resize reduz a imagem para caber em um quadrado de lado maxSide, mantendo a proporção. Com
+// fundo, as transparências são preenchidas para que não fiquem pretas no JPEG.
+func resize(img image.Image, maxSide int, background color.Color) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			height = max(1, height*maxSide/width)
+			width = maxSide
+		} else {
+			width = max(1, width*maxSide/height)
+			height = maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	if background != nil {
+		draw.Draw(dst, dst.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	}
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+// encodeJPEG codifica a imagem em JPEG com a qualidade informada
+func encodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("erro ao codificar thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"image/color"
 	"io"
 	"net/http"
 	"strings"
@@ -215,12 +216,13 @@ func ValidateFileSize(size int64, mediaType string) error {
 	return nil
 }
 
-// CreateThumbnail cria um thumbnail para imagens (implementação básica)
-// TODO: Implementar geração real de thumbnails usando uma biblioteca de imagem
+// CreateThumbnail cria o thumbnail JPEG de até InlineThumbnailSize px de uma imagem
 func CreateThumbnail(imageData []byte) ([]byte, error) {
-	// Por enquanto, retorna a imagem original
-	// Em uma implementação real, usaríamos uma biblioteca como imaging ou resize
-	return imageData, nil
+	img, err := decodeImage(imageData)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decodificar imagem: %w", err)
+	}
+	return encodeJPEG(resize(img, InlineThumbnailSize, color.White), inlineQuality)
 }
 
 // IsValidURL verifica se uma string é uma URL válida
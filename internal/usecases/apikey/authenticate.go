@@ -0,0 +1,71 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+)
+
+// lastUsedInterval evita uma escrita no banco a cada requisição da mesma chave
+const lastUsedInterval = time.Minute
+
+// AuthenticateUseCase representa o caso de uso para autenticar uma chave de API
+type AuthenticateUseCase struct {
+	keyRepo    apikey.Repository
+	tenantRepo tenant.Repository
+	logger     *logger.Logger
+}
+
+// NewAuthenticateUseCase cria uma nova instância do caso de uso
+func NewAuthenticateUseCase(keyRepo apikey.Repository, tenantRepo tenant.Repository) *AuthenticateUseCase {
+	return &AuthenticateUseCase{
+		keyRepo:    keyRepo,
+		tenantRepo: tenantRepo,
+		logger:     logger.Get(),
+	}
+}
+
+// Authenticate valida o segredo e retorna o principal com escopos e sessões permitidas
+func (uc *AuthenticateUseCase) Authenticate(ctx context.Context, secret string) (*apikey.Principal, error) {
+	key, err := uc.keyRepo.GetByHash(ctx, apikey.HashKey(secret))
+	if err != nil {
+		if err == apikey.ErrKeyNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar chave de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	now := time.Now()
+	if key.IsRevoked() {
+		return nil, apikey.ErrKeyRevoked
+	}
+	if key.IsExpired(now) {
+		return nil, apikey.ErrKeyExpired
+	}
+
+	// Desativar o tenant suspende todas as suas chaves sem revogá-las
+	owner, err := uc.tenantRepo.GetByID(ctx, key.TenantID)
+	if err != nil {
+		if err == tenant.ErrTenantNotFound {
+			return nil, tenant.ErrTenantInactive
+		}
+		uc.logger.Error().Err(err).Str("tenant_id", key.TenantID).Msg("Erro ao buscar tenant da chave de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+	if !owner.IsActive {
+		return nil, tenant.ErrTenantInactive
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedInterval {
+		if err := uc.keyRepo.UpdateLastUsed(ctx, key.ID, now); err != nil {
+			uc.logger.Warn().Err(err).Str("key_id", key.ID.String()).Msg("Erro ao registrar último uso da chave de API")
+		}
+	}
+
+	return apikey.NewPrincipal(key), nil
+}
@@ -0,0 +1,115 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// CreateKeyUseCase representa o caso de uso para emitir uma chave de API
+type CreateKeyUseCase struct {
+	keyRepo     apikey.Repository
+	sessionRepo session.Repository
+	tenantRepo  tenant.Repository
+	logger      *logger.Logger
+}
+
+// NewCreateKeyUseCase cria uma nova instância do caso de uso
+func NewCreateKeyUseCase(keyRepo apikey.Repository, sessionRepo session.Repository, tenantRepo tenant.Repository) *CreateKeyUseCase {
+	return &CreateKeyUseCase{
+		keyRepo:     keyRepo,
+		sessionRepo: sessionRepo,
+		tenantRepo:  tenantRepo,
+		logger:      logger.Get(),
+	}
+}
+
+// CreateKeyRequest representa a requisição para emitir chave
+type CreateKeyRequest struct {
+	TenantID   string            `json:"tenantId,omitempty"` // Ignorado fora da chave mestre: a chave herda o tenant de quem a cria
+	Name       string            `json:"name" validate:"required,max=100"`
+	Scopes     []apikey.Scope    `json:"scopes" validate:"required"`
+	SessionIDs []uuid.UUID       `json:"sessionIds,omitempty"`
+	ExpiresAt  *time.Time        `json:"expiresAt,omitempty"`
+	RateLimit  *apikey.RateLimit `json:"rateLimit,omitempty"` // Substitui a política padrão por chave
+}
+
+// KeyWithSecretResponse representa uma chave recém emitida; o segredo só é exibido nesta resposta
+type KeyWithSecretResponse struct {
+	APIKey  *apikey.APIKey `json:"apiKey"`
+	Key     string         `json:"key"`
+	Message string         `json:"message"`
+}
+
+// Execute executa o caso de uso de emissão de chave
+func (uc *CreateKeyUseCase) Execute(ctx context.Context, req *CreateKeyRequest) (*KeyWithSecretResponse, error) {
+	tenantID, ok := tenant.IDFromContext(ctx)
+	if !ok {
+		tenantID = req.TenantID
+		if tenantID == "" {
+			tenantID = tenant.DefaultTenantID
+		}
+	}
+
+	owner, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if err == tenant.ErrTenantNotFound {
+			return nil, apikey.NewKeyValidationError("tenantId", "tenant não encontrado: "+tenantID)
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao validar tenant")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+	if !owner.IsActive {
+		return nil, tenant.ErrTenantInactive
+	}
+
+	// Sessões inexistentes ou de outro tenant deixariam a chave sem acesso a nada
+	tenantCtx := tenant.WithID(ctx, tenantID)
+	for _, sessionID := range req.SessionIDs {
+		if _, err := uc.sessionRepo.GetByID(tenantCtx, sessionID); err != nil {
+			if err == session.ErrSessionNotFound {
+				return nil, apikey.NewKeyValidationError("sessionIds", "sessão não encontrada: "+sessionID.String())
+			}
+			uc.logger.Error().Err(err).Msg("Erro ao validar sessão")
+			return nil, fmt.Errorf("erro interno do servidor")
+		}
+	}
+
+	key, secret, err := apikey.NewAPIKey(tenantID, req.Name, req.Scopes, req.SessionIDs, req.ExpiresAt)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao gerar chave de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	key.RateLimit = req.RateLimit
+
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.keyRepo.Create(ctx, key); err != nil {
+		uc.logger.Error().Err(err).Str("key_name", key.Name).Msg("Erro ao criar chave de API")
+		return nil, fmt.Errorf("erro ao criar chave de API: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("key_id", key.ID.String()).
+		Str("key_name", key.Name).
+		Str("tenant_id", key.TenantID).
+		Str("prefix", key.Prefix).
+		Int("sessions", len(key.SessionIDs)).
+		Msg("Chave de API criada com sucesso")
+
+	return &KeyWithSecretResponse{
+		APIKey:  key,
+		Key:     secret,
+		Message: "Chave criada com sucesso. Guarde-a agora: ela não será exibida novamente",
+	}, nil
+}
@@ -0,0 +1,59 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// ListKeysUseCase representa o caso de uso para listar e consultar chaves de API
+type ListKeysUseCase struct {
+	keyRepo apikey.Repository
+	logger  *logger.Logger
+}
+
+// NewListKeysUseCase cria uma nova instância do caso de uso
+func NewListKeysUseCase(keyRepo apikey.Repository) *ListKeysUseCase {
+	return &ListKeysUseCase{
+		keyRepo: keyRepo,
+		logger:  logger.Get(),
+	}
+}
+
+// ListKeysResponse representa a resposta da listagem de chaves
+type ListKeysResponse struct {
+	APIKeys []*apikey.APIKey `json:"apiKeys"`
+	Total   int              `json:"total"`
+}
+
+// Execute executa o caso de uso de listagem de chaves
+func (uc *ListKeysUseCase) Execute(ctx context.Context) (*ListKeysResponse, error) {
+	keys, err := uc.keyRepo.List(ctx)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao listar chaves de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return &ListKeysResponse{
+		APIKeys: keys,
+		Total:   len(keys),
+	}, nil
+}
+
+// Get busca uma chave pelo ID
+func (uc *ListKeysUseCase) Get(ctx context.Context, keyID uuid.UUID) (*apikey.APIKey, error) {
+	key, err := uc.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		if err == apikey.ErrKeyNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("key_id", keyID.String()).Msg("Erro ao buscar chave de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return key, nil
+}
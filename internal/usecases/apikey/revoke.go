@@ -0,0 +1,54 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// RevokeKeyUseCase representa o caso de uso para revogar uma chave de API
+type RevokeKeyUseCase struct {
+	keyRepo apikey.Repository
+	logger  *logger.Logger
+}
+
+// NewRevokeKeyUseCase cria uma nova instância do caso de uso
+func NewRevokeKeyUseCase(keyRepo apikey.Repository) *RevokeKeyUseCase {
+	return &RevokeKeyUseCase{
+		keyRepo: keyRepo,
+		logger:  logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de revogação; a chave é mantida para consulta
+func (uc *RevokeKeyUseCase) Execute(ctx context.Context, keyID uuid.UUID) (*apikey.APIKey, error) {
+	key, err := uc.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		if err == apikey.ErrKeyNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("key_id", keyID.String()).Msg("Erro ao buscar chave de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if key.IsRevoked() {
+		return key, nil
+	}
+
+	key.Revoke()
+	if err := uc.keyRepo.Update(ctx, key); err != nil {
+		uc.logger.Error().Err(err).Str("key_id", keyID.String()).Msg("Erro ao revogar chave de API")
+		return nil, fmt.Errorf("erro ao revogar chave de API: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("key_id", key.ID.String()).
+		Str("prefix", key.Prefix).
+		Msg("Chave de API revogada")
+
+	return key, nil
+}
@@ -0,0 +1,63 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/apikey"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// RotateKeyUseCase representa o caso de uso para gerar um novo segredo para uma chave
+type RotateKeyUseCase struct {
+	keyRepo apikey.Repository
+	logger  *logger.Logger
+}
+
+// NewRotateKeyUseCase cria uma nova instância do caso de uso
+func NewRotateKeyUseCase(keyRepo apikey.Repository) *RotateKeyUseCase {
+	return &RotateKeyUseCase{
+		keyRepo: keyRepo,
+		logger:  logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de rotação; escopos, sessões e expiração são mantidos
+func (uc *RotateKeyUseCase) Execute(ctx context.Context, keyID uuid.UUID) (*KeyWithSecretResponse, error) {
+	key, err := uc.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		if err == apikey.ErrKeyNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("key_id", keyID.String()).Msg("Erro ao buscar chave de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if key.IsRevoked() {
+		return nil, apikey.ErrKeyRevoked
+	}
+
+	secret, err := key.Rotate()
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao gerar chave de API")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if err := uc.keyRepo.Update(ctx, key); err != nil {
+		uc.logger.Error().Err(err).Str("key_id", keyID.String()).Msg("Erro ao rotacionar chave de API")
+		return nil, fmt.Errorf("erro ao rotacionar chave de API: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("key_id", key.ID.String()).
+		Str("prefix", key.Prefix).
+		Msg("Chave de API rotacionada com sucesso")
+
+	return &KeyWithSecretResponse{
+		APIKey:  key,
+		Key:     secret,
+		Message: "Chave rotacionada com sucesso. O segredo anterior deixou de valer",
+	}, nil
+}
@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/audit"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// maxListLimit é o maior número de registros retornado por página
+const maxListLimit = 500
+
+// ListUseCase representa o caso de uso para consultar e exportar a trilha de auditoria
+type ListUseCase struct {
+	auditRepo audit.Repository
+	logger    *logger.Logger
+}
+
+// NewListUseCase cria uma nova instância do caso de uso
+func NewListUseCase(auditRepo audit.Repository) *ListUseCase {
+	return &ListUseCase{
+		auditRepo: auditRepo,
+		logger:    logger.Get(),
+	}
+}
+
+// ListRequest representa os filtros da consulta, recebidos pela query string
+type ListRequest struct {
+	TenantID   string `form:"tenantId"`
+	Action     string `form:"action"`
+	Actor      string `form:"actor"`
+	KeyID      string `form:"keyId"`
+	SessionID  string `form:"sessionId"`
+	ResourceID string `form:"resourceId"`
+	RequestID  string `form:"requestId"`
+	Outcome    string `form:"outcome"`
+	From       string `form:"from"`
+	To         string `form:"to"`
+	Limit      int    `form:"limit"`
+	Offset     int    `form:"offset"`
+}
+
+// ListResponse representa a resposta da consulta
+type ListResponse struct {
+	Entries []*audit.Entry `json:"entries"`
+	Limit   int            `json:"limit"`
+	Offset  int            `json:"offset"`
+}
+
+// Execute executa o caso de uso de consulta paginada
+func (uc *ListUseCase) Execute(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	filters, err := req.filters()
+	if err != nil {
+		return nil, err
+	}
+
+	// Aplicar valores padrão se não fornecidos
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > maxListLimit {
+		filters.Limit = maxListLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	entries, err := uc.auditRepo.List(ctx, filters)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao consultar trilha de auditoria")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return &ListResponse{
+		Entries: entries,
+		Limit:   filters.Limit,
+		Offset:  filters.Offset,
+	}, nil
+}
+
+// Export percorre todos os registros que atendem aos filtros, em ordem cronológica
+func (uc *ListUseCase) Export(ctx context.Context, req *ListRequest, fn func(*audit.Entry) error) error {
+	filters, err := req.filters()
+	if err != nil {
+		return err
+	}
+
+	if err := uc.auditRepo.Stream(ctx, filters, fn); err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao exportar trilha de auditoria")
+		return fmt.Errorf("erro ao exportar trilha de auditoria: %w", err)
+	}
+
+	return nil
+}
+
+// filters valida a requisição e a converte nos filtros do repositório
+func (req *ListRequest) filters() (audit.ListFilters, error) {
+	filters := audit.ListFilters{
+		TenantID:   req.TenantID,
+		Action:     audit.Action(req.Action),
+		ActorName:  req.Actor,
+		ResourceID: req.ResourceID,
+		RequestID:  req.RequestID,
+		Outcome:    audit.Outcome(req.Outcome),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+
+	if req.KeyID != "" {
+		id, err := uuid.Parse(req.KeyID)
+		if err != nil {
+			return filters, audit.NewFilterValidationError("keyId", "deve ser um UUID válido")
+		}
+		filters.KeyID = &id
+	}
+
+	if req.SessionID != "" {
+		id, err := uuid.Parse(req.SessionID)
+		if err != nil {
+			return filters, audit.NewFilterValidationError("sessionId", "deve ser um UUID válido")
+		}
+		filters.SessionID = &id
+	}
+
+	if filters.Outcome != "" && filters.Outcome != audit.OutcomeSuccess && filters.Outcome != audit.OutcomeFailure {
+		return filters, audit.NewFilterValidationError("outcome", "use success ou failure")
+	}
+
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return filters, audit.NewFilterValidationError("from", "use o formato RFC 3339 (ex.: 2026-10-01T00:00:00Z)")
+		}
+		filters.From = &from
+	}
+
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return filters, audit.NewFilterValidationError("to", "use o formato RFC 3339 (ex.: 2026-11-01T00:00:00Z)")
+		}
+		filters.To = &to
+	}
+
+	return filters, nil
+}
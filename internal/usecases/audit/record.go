@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+
+	"zapcore/internal/domain/audit"
+	"zapcore/internal/domain/session"
+	"zapcore/pkg/logger"
+)
+
+// RecordUseCase representa o caso de uso para gravar registros na trilha de auditoria
+type RecordUseCase struct {
+	auditRepo   audit.Repository
+	sessionRepo session.Repository
+	logger      *logger.Logger
+}
+
+// NewRecordUseCase cria uma nova instância do caso de uso
+func NewRecordUseCase(auditRepo audit.Repository, sessionRepo session.Repository) *RecordUseCase {
+	return &RecordUseCase{
+		auditRepo:   auditRepo,
+		sessionRepo: sessionRepo,
+		logger:      logger.Get(),
+	}
+}
+
+// Record grava o registro. A ação já foi executada quando ele chega aqui, por isso falhas
+// são apenas registradas no log e não alteram a resposta de quem a executou.
+func (uc *RecordUseCase) Record(ctx context.Context, entry *audit.Entry) {
+	// A gravação não deve ser interrompida pelo cancelamento da requisição de origem
+	ctx = context.WithoutCancel(ctx)
+
+	// Ações da chave mestre herdam o tenant da sessão afetada, para que o tenant as enxergue
+	if entry.TenantID == "" && entry.SessionID != nil {
+		if sess, err := uc.sessionRepo.GetByID(ctx, *entry.SessionID); err == nil {
+			entry.TenantID = sess.TenantID
+		}
+	}
+
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		uc.logger.Error().Err(err).
+			Str("action", string(entry.Action)).
+			Str("actor", entry.ActorName).
+			Str("resource_id", entry.ResourceID).
+			Str("request_id", entry.RequestID).
+			Msg("Erro ao gravar registro de auditoria")
+	}
+}
@@ -0,0 +1,83 @@
+package autoreply
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/autoreply"
+	"zapcore/internal/domain/session"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// CreateRuleUseCase representa o caso de uso para criar regra de resposta automática
+type CreateRuleUseCase struct {
+	ruleRepo    autoreply.RuleRepository
+	sessionRepo session.Repository
+	logger      *logger.Logger
+}
+
+// NewCreateRuleUseCase cria uma nova instância do caso de uso
+func NewCreateRuleUseCase(ruleRepo autoreply.RuleRepository, sessionRepo session.Repository) *CreateRuleUseCase {
+	return &CreateRuleUseCase{
+		ruleRepo:    ruleRepo,
+		sessionRepo: sessionRepo,
+		logger:      logger.Get(),
+	}
+}
+
+// CreateRuleRequest representa a requisição para criar regra
+type CreateRuleRequest struct {
+	SessionID  uuid.UUID            `json:"-"`
+	Name       string               `json:"name" validate:"required,max=100"`
+	Priority   int                  `json:"priority"`
+	IsActive   *bool                `json:"isActive,omitempty"`
+	Conditions autoreply.Conditions `json:"conditions"`
+	Actions    []autoreply.Action   `json:"actions" validate:"required,min=1"`
+}
+
+// CreateRuleResponse representa a resposta da criação de regra
+type CreateRuleResponse struct {
+	Rule    *autoreply.Rule `json:"rule"`
+	Message string          `json:"message"`
+}
+
+// Execute executa o caso de uso de criação de regra
+func (uc *CreateRuleUseCase) Execute(ctx context.Context, req *CreateRuleRequest) (*CreateRuleResponse, error) {
+	if _, err := uc.sessionRepo.GetByID(ctx, req.SessionID); err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao validar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	rule := autoreply.NewRule(req.SessionID, req.Name)
+	rule.Priority = req.Priority
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	rule.Conditions = req.Conditions
+	rule.Actions = req.Actions
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+		uc.logger.Error().Err(err).Str("rule_name", req.Name).Msg("Erro ao criar regra de resposta automática")
+		return nil, fmt.Errorf("erro ao criar regra: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("rule_id", rule.ID.String()).
+		Str("session_id", rule.SessionID.String()).
+		Str("rule_name", rule.Name).
+		Msg("Regra de resposta automática criada com sucesso")
+
+	return &CreateRuleResponse{
+		Rule:    rule,
+		Message: "Regra criada com sucesso",
+	}, nil
+}
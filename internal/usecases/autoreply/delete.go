@@ -0,0 +1,53 @@
+package autoreply
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/autoreply"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DeleteRuleUseCase representa o caso de uso para remover regra
+type DeleteRuleUseCase struct {
+	ruleRepo autoreply.RuleRepository
+	logger   *logger.Logger
+}
+
+// NewDeleteRuleUseCase cria uma nova instância do caso de uso
+func NewDeleteRuleUseCase(ruleRepo autoreply.RuleRepository) *DeleteRuleUseCase {
+	return &DeleteRuleUseCase{
+		ruleRepo: ruleRepo,
+		logger:   logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de remoção de regra
+func (uc *DeleteRuleUseCase) Execute(ctx context.Context, sessionID, ruleID uuid.UUID) error {
+	rule, err := uc.ruleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		if err == autoreply.ErrRuleNotFound {
+			return err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar regra")
+		return fmt.Errorf("erro interno do servidor")
+	}
+
+	if rule.SessionID != sessionID {
+		return autoreply.ErrRuleNotFound
+	}
+
+	if err := uc.ruleRepo.Delete(ctx, ruleID); err != nil {
+		uc.logger.Error().Err(err).Str("rule_id", ruleID.String()).Msg("Erro ao remover regra")
+		return fmt.Errorf("erro ao remover regra: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("rule_id", ruleID.String()).
+		Str("session_id", sessionID.String()).
+		Msg("Regra de resposta automática removida com sucesso")
+
+	return nil
+}
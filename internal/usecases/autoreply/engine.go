@@ -0,0 +1,382 @@
+package autoreply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"zapcore/internal/domain/autoreply"
+	"zapcore/internal/domain/chat"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/whatsapp"
+	templateUseCase "zapcore/internal/usecases/template"
+	tenantUseCase "zapcore/internal/usecases/tenant"
+	"zapcore/pkg/logger"
+)
+
+// labelsMetadataKey é a chave dos metadados do chat onde ficam as etiquetas
+const labelsMetadataKey = "labels"
+
+// Engine avalia as regras de resposta automática para mensagens recebidas
+type Engine struct {
+	ruleRepo       autoreply.RuleRepository
+	stateRepo      autoreply.StateRepository
+	chatRepo       chat.Repository
+	whatsappClient whatsapp.Client
+	templates      *templateUseCase.RenderUseCase
+	hours          autoreply.BusinessHours
+	quotas         *tenantUseCase.QuotaUseCase
+	httpClient     *http.Client
+	logger         *logger.Logger
+}
+
+// NewEngine cria uma nova instância do motor de respostas automáticas
+func NewEngine(
+	ruleRepo autoreply.RuleRepository,
+	stateRepo autoreply.StateRepository,
+	chatRepo chat.Repository,
+	whatsappClient whatsapp.Client,
+	templates *templateUseCase.RenderUseCase,
+	hours autoreply.BusinessHours,
+) *Engine {
+	return &Engine{
+		ruleRepo:       ruleRepo,
+		stateRepo:      stateRepo,
+		chatRepo:       chatRepo,
+		whatsappClient: whatsappClient,
+		templates:      templates,
+		hours:          hours,
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+		logger:         logger.Get(),
+	}
+}
+
+// SetQuotas contabiliza as respostas automáticas na cota diária de mensagens do tenant da sessão
+func (e *Engine) SetQuotas(quotas *tenantUseCase.QuotaUseCase) {
+	e.quotas = quotas
+}
+
+// ProcessInbound implementa whatsapp.InboundProcessor
+func (e *Engine) ProcessInbound(ctx context.Context, evt *whatsapp.MessageEvent) error {
+	rules, err := e.ruleRepo.ListBySession(ctx, evt.SessionID, true)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar regras: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	chatJID := evt.ToJID
+	now := time.Now()
+
+	// Carregar o passo atual do fluxo, descartando estados expirados
+	step := ""
+	state, err := e.stateRepo.Get(ctx, evt.SessionID, chatJID)
+	switch {
+	case err == nil && state.IsExpired(now):
+		if err := e.stateRepo.Delete(ctx, evt.SessionID, chatJID); err != nil {
+			e.logger.Warn().Err(err).Str("chat_jid", chatJID).Msg("Erro ao remover estado de fluxo expirado")
+		}
+	case err == nil:
+		step = state.Step
+	case err != autoreply.ErrStateNotFound:
+		return fmt.Errorf("erro ao carregar estado do fluxo: %w", err)
+	}
+
+	text := evt.Content
+	if text == "" {
+		text = evt.Caption
+	}
+
+	input := autoreply.MatchInput{
+		Text:        text,
+		MessageType: evt.Type,
+		IsGroup:     evt.IsGroup,
+		Now:         now,
+		Step:        step,
+	}
+
+	// Horário comercial da sessão para regras sem agenda própria
+	if e.hours != nil {
+		open, known, err := e.hours.IsOpen(ctx, evt.SessionID, now)
+		if err != nil {
+			e.logger.Warn().Err(err).Str("session_id", evt.SessionID.String()).Msg("Erro ao consultar horário comercial")
+		} else if known {
+			input.IsOpen = &open
+		}
+	}
+
+	for _, rule := range rules {
+		if !rule.Matches(input) {
+			continue
+		}
+
+		e.logger.Debug().
+			Str("session_id", evt.SessionID.String()).
+			Str("rule_id", rule.ID.String()).
+			Str("rule_name", rule.Name).
+			Str("chat_jid", chatJID).
+			Msg("Regra de resposta automática aplicada")
+
+		stop, err := e.executeActions(ctx, rule, evt, chatJID)
+		if err != nil {
+			return fmt.Errorf("erro ao executar regra %s: %w", rule.Name, err)
+		}
+		if stop {
+			break
+		}
+	}
+
+	return nil
+}
+
+// executeActions executa as ações da regra e indica se o processamento deve parar
+func (e *Engine) executeActions(ctx context.Context, rule *autoreply.Rule, evt *whatsapp.MessageEvent, chatJID string) (bool, error) {
+	for _, action := range rule.Actions {
+		var err error
+
+		switch action.Type {
+		case autoreply.ActionReplyText, autoreply.ActionReplyMedia, autoreply.ActionReplyTemplate:
+			err = e.reply(ctx, evt, chatJID, action)
+		case autoreply.ActionAddLabel:
+			err = e.addLabel(ctx, evt, chatJID, action.Label)
+		case autoreply.ActionWebhook:
+			err = e.forwardWebhook(ctx, rule, evt, action.WebhookURL)
+		case autoreply.ActionSetStep:
+			err = e.stateRepo.Save(ctx, &autoreply.ConversationState{
+				SessionID: evt.SessionID,
+				ChatJID:   chatJID,
+				Step:      action.Step,
+				ExpiresAt: time.Now().Add(action.StepTimeout()),
+			})
+		case autoreply.ActionEndFlow:
+			err = e.stateRepo.Delete(ctx, evt.SessionID, chatJID)
+		case autoreply.ActionStop:
+			return true, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("ação %s falhou: %w", action.Type, err)
+		}
+	}
+
+	return false, nil
+}
+
+// reply envia a resposta da ação, contabilizada na cota diária do tenant da sessão
+func (e *Engine) reply(ctx context.Context, evt *whatsapp.MessageEvent, chatJID string, action autoreply.Action) error {
+	var sess *session.Session
+	if e.quotas != nil {
+		reserved, err := e.quotas.ReserveSessionMessage(ctx, evt.SessionID)
+		if err != nil {
+			return err
+		}
+		sess = reserved
+	}
+
+	var err error
+	switch action.Type {
+	case autoreply.ActionReplyText:
+		_, err = e.whatsappClient.SendTextMessage(ctx, &whatsapp.SendTextRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			Content:   action.Text,
+		})
+	case autoreply.ActionReplyMedia:
+		err = e.sendMedia(ctx, evt, chatJID, action)
+	default:
+		err = e.sendTemplate(ctx, evt, chatJID, action)
+	}
+
+	if err != nil && sess != nil {
+		e.quotas.ReleaseMessage(ctx, sess)
+	}
+	return err
+}
+
+// sendMedia envia uma mídia a partir de URL conforme o tipo configurado
+func (e *Engine) sendMedia(ctx context.Context, evt *whatsapp.MessageEvent, chatJID string, action autoreply.Action) error {
+	var err error
+
+	switch action.MediaType {
+	case "image":
+		_, err = e.whatsappClient.SendImageMessage(ctx, &whatsapp.SendImageRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			ImageURL:  action.MediaURL,
+			Caption:   action.Caption,
+		})
+	case "video":
+		_, err = e.whatsappClient.SendVideoMessage(ctx, &whatsapp.SendVideoRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			VideoURL:  action.MediaURL,
+			Caption:   action.Caption,
+		})
+	case "audio":
+		_, err = e.whatsappClient.SendAudioMessage(ctx, &whatsapp.SendAudioRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			AudioURL:  action.MediaURL,
+		})
+	default:
+		_, err = e.whatsappClient.SendDocumentMessage(ctx, &whatsapp.SendDocumentRequest{
+			SessionID:   evt.SessionID,
+			ToJID:       chatJID,
+			DocumentURL: action.MediaURL,
+			FileName:    action.FileName,
+			Caption:     action.Caption,
+		})
+	}
+
+	return err
+}
+
+// sendTemplate renderiza um template e envia como texto ou mídia
+func (e *Engine) sendTemplate(ctx context.Context, evt *whatsapp.MessageEvent, chatJID string, action autoreply.Action) error {
+	if e.templates == nil {
+		return fmt.Errorf("templates não estão habilitados")
+	}
+
+	rendered, err := e.templates.Execute(ctx, &templateUseCase.RenderRequest{
+		TemplateID: *action.TemplateID,
+		Language:   action.Language,
+		Variables:  templateVariables(action.Variables, evt),
+	})
+	if err != nil {
+		return err
+	}
+
+	if rendered.MediaPath == "" {
+		_, err = e.whatsappClient.SendTextMessage(ctx, &whatsapp.SendTextRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			Content:   rendered.Text,
+		})
+		return err
+	}
+
+	media, err := e.templates.OpenMedia(ctx, rendered)
+	if err != nil {
+		return err
+	}
+	defer media.Close()
+
+	switch rendered.MediaType {
+	case "image":
+		_, err = e.whatsappClient.SendImageMessage(ctx, &whatsapp.SendImageRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			ImageData: media,
+			Caption:   rendered.Text,
+			MimeType:  rendered.MediaMimeType,
+			FileName:  rendered.MediaFileName,
+		})
+	case "video":
+		_, err = e.whatsappClient.SendVideoMessage(ctx, &whatsapp.SendVideoRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			VideoData: media,
+			Caption:   rendered.Text,
+			MimeType:  rendered.MediaMimeType,
+			FileName:  rendered.MediaFileName,
+		})
+	case "audio":
+		_, err = e.whatsappClient.SendAudioMessage(ctx, &whatsapp.SendAudioRequest{
+			SessionID: evt.SessionID,
+			ToJID:     chatJID,
+			AudioData: media,
+			MimeType:  rendered.MediaMimeType,
+			FileName:  rendered.MediaFileName,
+		})
+	default:
+		_, err = e.whatsappClient.SendDocumentMessage(ctx, &whatsapp.SendDocumentRequest{
+			SessionID:    evt.SessionID,
+			ToJID:        chatJID,
+			DocumentData: media,
+			FileName:     rendered.MediaFileName,
+			Caption:      rendered.Text,
+			MimeType:     rendered.MediaMimeType,
+		})
+	}
+
+	return err
+}
+
+// addLabel adiciona uma etiqueta aos metadados do chat
+func (e *Engine) addLabel(ctx context.Context, evt *whatsapp.MessageEvent, chatJID, label string) error {
+	chatEntity, err := e.chatRepo.GetByJID(ctx, evt.SessionID, chatJID)
+	if err != nil {
+		return err
+	}
+
+	labels := []string{}
+	if value, ok := chatEntity.GetMetadata(labelsMetadataKey); ok {
+		if list, ok := value.([]any); ok {
+			for _, item := range list {
+				if s, ok := item.(string); ok {
+					labels = append(labels, s)
+				}
+			}
+		}
+	}
+
+	for _, existing := range labels {
+		if existing == label {
+			return nil
+		}
+	}
+
+	labels = append(labels, label)
+	chatEntity.SetMetadata(labelsMetadataKey, labels)
+	chatEntity.UpdatedAt = time.Now()
+
+	return e.chatRepo.Update(ctx, chatEntity)
+}
+
+// forwardWebhook encaminha a mensagem recebida para um webhook externo
+func (e *Engine) forwardWebhook(ctx context.Context, rule *autoreply.Rule, evt *whatsapp.MessageEvent, url string) error {
+	payload, err := json.Marshal(map[string]any{
+		"event":     "autoreply.matched",
+		"sessionId": evt.SessionID,
+		"ruleId":    rule.ID,
+		"ruleName":  rule.Name,
+		"message":   evt,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao serializar payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao chamar webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook retornou status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// templateVariables completa as variáveis da ação com dados da mensagem
+func templateVariables(vars map[string]string, evt *whatsapp.MessageEvent) map[string]string {
+	result := map[string]string{
+		"name":  evt.PushName,
+		"phone": evt.FromJID,
+	}
+	for key, value := range vars {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package autoreply
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/autoreply"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// ListRulesUseCase representa o caso de uso para listar regras de uma sessão
+type ListRulesUseCase struct {
+	ruleRepo autoreply.RuleRepository
+	logger   *logger.Logger
+}
+
+// NewListRulesUseCase cria uma nova instância do caso de uso
+func NewListRulesUseCase(ruleRepo autoreply.RuleRepository) *ListRulesUseCase {
+	return &ListRulesUseCase{
+		ruleRepo: ruleRepo,
+		logger:   logger.Get(),
+	}
+}
+
+// ListRulesResponse representa a resposta da listagem de regras
+type ListRulesResponse struct {
+	Rules []*autoreply.Rule `json:"rules"`
+	Total int               `json:"total"`
+}
+
+// Execute executa o caso de uso de listagem de regras
+func (uc *ListRulesUseCase) Execute(ctx context.Context, sessionID uuid.UUID) (*ListRulesResponse, error) {
+	rules, err := uc.ruleRepo.ListBySession(ctx, sessionID, false)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao listar regras")
+		return nil, fmt.Errorf("erro ao listar regras: %w", err)
+	}
+
+	return &ListRulesResponse{
+		Rules: rules,
+		Total: len(rules),
+	}, nil
+}
@@ -0,0 +1,97 @@
+package autoreply
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/autoreply"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// UpdateRuleUseCase representa o caso de uso para atualizar regra
+type UpdateRuleUseCase struct {
+	ruleRepo autoreply.RuleRepository
+	logger   *logger.Logger
+}
+
+// NewUpdateRuleUseCase cria uma nova instância do caso de uso
+func NewUpdateRuleUseCase(ruleRepo autoreply.RuleRepository) *UpdateRuleUseCase {
+	return &UpdateRuleUseCase{
+		ruleRepo: ruleRepo,
+		logger:   logger.Get(),
+	}
+}
+
+// UpdateRuleRequest representa a requisição para atualizar regra
+type UpdateRuleRequest struct {
+	SessionID  uuid.UUID             `json:"-"`
+	RuleID     uuid.UUID             `json:"-"`
+	Name       *string               `json:"name,omitempty"`
+	Priority   *int                  `json:"priority,omitempty"`
+	IsActive   *bool                 `json:"isActive,omitempty"`
+	Conditions *autoreply.Conditions `json:"conditions,omitempty"`
+	Actions    []autoreply.Action    `json:"actions,omitempty"`
+}
+
+// UpdateRuleResponse representa a resposta da atualização de regra
+type UpdateRuleResponse struct {
+	Rule    *autoreply.Rule `json:"rule"`
+	Message string          `json:"message"`
+}
+
+// Execute executa o caso de uso de atualização de regra
+func (uc *UpdateRuleUseCase) Execute(ctx context.Context, req *UpdateRuleRequest) (*UpdateRuleResponse, error) {
+	rule, err := uc.ruleRepo.GetByID(ctx, req.RuleID)
+	if err != nil {
+		if err == autoreply.ErrRuleNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar regra")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if rule.SessionID != req.SessionID {
+		return nil, autoreply.ErrRuleNotFound
+	}
+
+	// Aplicar apenas os campos informados
+	if req.Name != nil {
+		rule.Name = *req.Name
+	}
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	if req.Conditions != nil {
+		rule.Conditions = *req.Conditions
+	}
+	if req.Actions != nil {
+		rule.Actions = req.Actions
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	rule.UpdatedAt = time.Now()
+
+	if err := uc.ruleRepo.Update(ctx, rule); err != nil {
+		uc.logger.Error().Err(err).Str("rule_id", rule.ID.String()).Msg("Erro ao atualizar regra")
+		return nil, fmt.Errorf("erro ao atualizar regra: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("rule_id", rule.ID.String()).
+		Str("session_id", rule.SessionID.String()).
+		Msg("Regra de resposta automática atualizada com sucesso")
+
+	return &UpdateRuleResponse{
+		Rule:    rule,
+		Message: "Regra atualizada com sucesso",
+	}, nil
+}
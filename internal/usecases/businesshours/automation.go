@@ -0,0 +1,122 @@
+package businesshours
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/businesshours"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/whatsapp"
+	tenantUseCase "zapcore/internal/usecases/tenant"
+	"zapcore/pkg/logger"
+)
+
+// Automation envia as mensagens de saudação e de ausência para mensagens recebidas
+type Automation struct {
+	calendarRepo   businesshours.Repository
+	noticeRepo     businesshours.NoticeRepository
+	whatsappClient whatsapp.Client
+	quotas         *tenantUseCase.QuotaUseCase
+	logger         *logger.Logger
+}
+
+// NewAutomation cria uma nova instância da automação de horário comercial
+func NewAutomation(
+	calendarRepo businesshours.Repository,
+	noticeRepo businesshours.NoticeRepository,
+	whatsappClient whatsapp.Client,
+) *Automation {
+	return &Automation{
+		calendarRepo:   calendarRepo,
+		noticeRepo:     noticeRepo,
+		whatsappClient: whatsappClient,
+		logger:         logger.Get(),
+	}
+}
+
+// SetQuotas contabiliza as mensagens de saudação e de ausência na cota diária de mensagens do
+// tenant da sessão
+func (a *Automation) SetQuotas(quotas *tenantUseCase.QuotaUseCase) {
+	a.quotas = quotas
+}
+
+// ProcessInbound implementa whatsapp.InboundProcessor
+func (a *Automation) ProcessInbound(ctx context.Context, evt *whatsapp.MessageEvent) error {
+	// Saudação e ausência valem apenas para conversas individuais
+	if evt.IsGroup {
+		return nil
+	}
+
+	calendar, err := a.calendarRepo.GetBySession(ctx, evt.SessionID)
+	if err != nil {
+		if err == businesshours.ErrCalendarNotFound {
+			return nil
+		}
+		return fmt.Errorf("erro ao carregar horário comercial: %w", err)
+	}
+
+	chatJID := evt.ToJID
+
+	if calendar.GreetingEnabled && evt.IsFirstMessage {
+		if err := a.send(ctx, evt, chatJID, calendar.GreetingMessage); err != nil {
+			return fmt.Errorf("erro ao enviar saudação: %w", err)
+		}
+		a.logger.Info().
+			Str("session_id", evt.SessionID.String()).
+			Str("chat_jid", chatJID).
+			Msg("Mensagem de saudação enviada")
+	}
+
+	now := time.Now()
+	if !calendar.AwayEnabled || calendar.IsOpen(now) {
+		return nil
+	}
+
+	// Registrar antes de enviar garante no máximo um envio por janela,
+	// mesmo com mensagens simultâneas do mesmo chat
+	sent, err := a.noticeRepo.MarkSent(ctx, &businesshours.AwayNotice{
+		SessionID: evt.SessionID,
+		ChatJID:   chatJID,
+		WindowKey: calendar.WindowKey(now),
+		SentAt:    now,
+	})
+	if err != nil {
+		return err
+	}
+	if !sent {
+		return nil
+	}
+
+	if err := a.send(ctx, evt, chatJID, calendar.AwayMessage); err != nil {
+		return fmt.Errorf("erro ao enviar mensagem de ausência: %w", err)
+	}
+
+	a.logger.Info().
+		Str("session_id", evt.SessionID.String()).
+		Str("chat_jid", chatJID).
+		Msg("Mensagem de ausência enviada")
+	return nil
+}
+
+// send envia uma mensagem de texto para o chat, contabilizada na cota diária do tenant da sessão
+func (a *Automation) send(ctx context.Context, evt *whatsapp.MessageEvent, chatJID, text string) error {
+	var sess *session.Session
+	if a.quotas != nil {
+		reserved, err := a.quotas.ReserveSessionMessage(ctx, evt.SessionID)
+		if err != nil {
+			return err
+		}
+		sess = reserved
+	}
+
+	_, err := a.whatsappClient.SendTextMessage(ctx, &whatsapp.SendTextRequest{
+		SessionID: evt.SessionID,
+		ToJID:     chatJID,
+		Content:   text,
+	})
+	if err != nil && sess != nil {
+		a.quotas.ReleaseMessage(ctx, sess)
+	}
+	return err
+}
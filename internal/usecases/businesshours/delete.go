@@ -0,0 +1,39 @@
+package businesshours
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/businesshours"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DeleteUseCase representa o caso de uso para remover o horário comercial
+type DeleteUseCase struct {
+	calendarRepo businesshours.Repository
+	logger       *logger.Logger
+}
+
+// NewDeleteUseCase cria uma nova instância do caso de uso
+func NewDeleteUseCase(calendarRepo businesshours.Repository) *DeleteUseCase {
+	return &DeleteUseCase{
+		calendarRepo: calendarRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de remoção do horário comercial
+func (uc *DeleteUseCase) Execute(ctx context.Context, sessionID uuid.UUID) error {
+	if err := uc.calendarRepo.Delete(ctx, sessionID); err != nil {
+		if err == businesshours.ErrCalendarNotFound {
+			return err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao remover horário comercial")
+		return fmt.Errorf("erro ao remover horário comercial: %w", err)
+	}
+
+	uc.logger.Info().Str("session_id", sessionID.String()).Msg("Horário comercial removido com sucesso")
+	return nil
+}
@@ -0,0 +1,39 @@
+package businesshours
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/businesshours"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// GetUseCase representa o caso de uso para obter o horário comercial de uma sessão
+type GetUseCase struct {
+	calendarRepo businesshours.Repository
+	logger       *logger.Logger
+}
+
+// NewGetUseCase cria uma nova instância do caso de uso
+func NewGetUseCase(calendarRepo businesshours.Repository) *GetUseCase {
+	return &GetUseCase{
+		calendarRepo: calendarRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de consulta do horário comercial
+func (uc *GetUseCase) Execute(ctx context.Context, sessionID uuid.UUID) (*businesshours.Calendar, error) {
+	calendar, err := uc.calendarRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		if err == businesshours.ErrCalendarNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao buscar horário comercial")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return calendar, nil
+}
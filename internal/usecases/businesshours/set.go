@@ -0,0 +1,101 @@
+package businesshours
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/businesshours"
+	"zapcore/internal/domain/session"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTimezone é o fuso horário usado quando o calendário não informa um
+const DefaultTimezone = "America/Sao_Paulo"
+
+// SetUseCase representa o caso de uso para configurar o horário comercial
+type SetUseCase struct {
+	calendarRepo businesshours.Repository
+	sessionRepo  session.Repository
+	logger       *logger.Logger
+}
+
+// NewSetUseCase cria uma nova instância do caso de uso
+func NewSetUseCase(calendarRepo businesshours.Repository, sessionRepo session.Repository) *SetUseCase {
+	return &SetUseCase{
+		calendarRepo: calendarRepo,
+		sessionRepo:  sessionRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// SetRequest representa a requisição para configurar o horário comercial
+type SetRequest struct {
+	SessionID       uuid.UUID                   `json:"-"`
+	Timezone        string                      `json:"timezone,omitempty"`
+	Weekly          []businesshours.WeeklyRange `json:"weekly"`
+	Holidays        []businesshours.Holiday     `json:"holidays,omitempty"`
+	AwayEnabled     bool                        `json:"awayEnabled"`
+	AwayMessage     string                      `json:"awayMessage,omitempty"`
+	GreetingEnabled bool                        `json:"greetingEnabled"`
+	GreetingMessage string                      `json:"greetingMessage,omitempty"`
+}
+
+// SetResponse representa a resposta da configuração do horário comercial
+type SetResponse struct {
+	Calendar *businesshours.Calendar `json:"calendar"`
+	Status   businesshours.Status    `json:"status"`
+	Message  string                  `json:"message"`
+}
+
+// Execute executa o caso de uso de configuração do horário comercial
+func (uc *SetUseCase) Execute(ctx context.Context, req *SetRequest) (*SetResponse, error) {
+	if _, err := uc.sessionRepo.GetByID(ctx, req.SessionID); err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao validar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+
+	calendar := businesshours.NewCalendar(req.SessionID, timezone)
+	if req.Weekly != nil {
+		calendar.Weekly = req.Weekly
+	}
+	if req.Holidays != nil {
+		calendar.Holidays = req.Holidays
+	}
+	calendar.AwayEnabled = req.AwayEnabled
+	calendar.AwayMessage = req.AwayMessage
+	calendar.GreetingEnabled = req.GreetingEnabled
+	calendar.GreetingMessage = req.GreetingMessage
+
+	if err := calendar.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.calendarRepo.Save(ctx, calendar); err != nil {
+		uc.logger.Error().Err(err).Str("session_id", req.SessionID.String()).Msg("Erro ao salvar horário comercial")
+		return nil, fmt.Errorf("erro ao salvar horário comercial: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("session_id", req.SessionID.String()).
+		Str("timezone", calendar.Timezone).
+		Int("weekly_ranges", len(calendar.Weekly)).
+		Int("holidays", len(calendar.Holidays)).
+		Msg("Horário comercial configurado com sucesso")
+
+	return &SetResponse{
+		Calendar: calendar,
+		Status:   calendar.StatusAt(time.Now()),
+		Message:  "Horário comercial configurado com sucesso",
+	}, nil
+}
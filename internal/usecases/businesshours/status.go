@@ -0,0 +1,63 @@
+package businesshours
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/businesshours"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// StatusUseCase representa o caso de uso para consultar se a sessão está em atendimento
+type StatusUseCase struct {
+	calendarRepo businesshours.Repository
+	logger       *logger.Logger
+}
+
+// NewStatusUseCase cria uma nova instância do caso de uso
+func NewStatusUseCase(calendarRepo businesshours.Repository) *StatusUseCase {
+	return &StatusUseCase{
+		calendarRepo: calendarRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// StatusResponse representa a resposta da consulta de atendimento
+type StatusResponse struct {
+	SessionID uuid.UUID `json:"sessionId"`
+	businesshours.Status
+}
+
+// Execute executa o caso de uso de consulta de atendimento
+func (uc *StatusUseCase) Execute(ctx context.Context, sessionID uuid.UUID) (*StatusResponse, error) {
+	calendar, err := uc.calendarRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		if err == businesshours.ErrCalendarNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao buscar horário comercial")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return &StatusResponse{
+		SessionID: sessionID,
+		Status:    calendar.StatusAt(time.Now()),
+	}, nil
+}
+
+// IsOpen implementa autoreply.BusinessHours. Retorna known = false
+// quando a sessão não possui horário comercial configurado.
+func (uc *StatusUseCase) IsOpen(ctx context.Context, sessionID uuid.UUID, now time.Time) (bool, bool, error) {
+	calendar, err := uc.calendarRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		if err == businesshours.ErrCalendarNotFound {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+
+	return calendar.IsOpen(now), true, nil
+}
@@ -0,0 +1,94 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/eventsink"
+	"zapcore/internal/domain/session"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// CreateSinkUseCase representa o caso de uso para criar sink de eventos
+type CreateSinkUseCase struct {
+	sinkRepo    eventsink.Repository
+	sessionRepo session.Repository
+	manager     eventsink.Manager
+	logger      *logger.Logger
+}
+
+// NewCreateSinkUseCase cria uma nova instância do caso de uso
+func NewCreateSinkUseCase(sinkRepo eventsink.Repository, sessionRepo session.Repository, manager eventsink.Manager) *CreateSinkUseCase {
+	return &CreateSinkUseCase{
+		sinkRepo:    sinkRepo,
+		sessionRepo: sessionRepo,
+		manager:     manager,
+		logger:      logger.Get(),
+	}
+}
+
+// CreateSinkRequest representa a requisição para criar sink
+type CreateSinkRequest struct {
+	SessionID uuid.UUID         `json:"-"`
+	Name      string            `json:"name" validate:"required,max=100"`
+	Driver    eventsink.Driver  `json:"driver" validate:"required,oneof=nats amqp kafka"`
+	URL       string            `json:"url" validate:"required"`
+	Subject   string            `json:"subject,omitempty"`
+	Types     []string          `json:"types,omitempty"`
+	Options   map[string]string `json:"options,omitempty"`
+	IsActive  *bool             `json:"isActive,omitempty"`
+}
+
+// CreateSinkResponse representa a resposta da criação de sink
+type CreateSinkResponse struct {
+	Sink    *eventsink.Sink `json:"sink"`
+	Message string          `json:"message"`
+}
+
+// Execute executa o caso de uso de criação de sink
+func (uc *CreateSinkUseCase) Execute(ctx context.Context, req *CreateSinkRequest) (*CreateSinkResponse, error) {
+	if _, err := uc.sessionRepo.GetByID(ctx, req.SessionID); err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao validar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	sink := eventsink.NewSink(req.SessionID, req.Name, req.Driver, req.URL)
+	sink.Subject = req.Subject
+	sink.Types = req.Types
+	if req.Options != nil {
+		sink.Options = req.Options
+	}
+	if req.IsActive != nil {
+		sink.IsActive = *req.IsActive
+	}
+
+	if err := sink.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.sinkRepo.Create(ctx, sink); err != nil {
+		uc.logger.Error().Err(err).Str("sink_name", req.Name).Msg("Erro ao criar sink de eventos")
+		return nil, fmt.Errorf("erro ao criar sink: %w", err)
+	}
+
+	if err := uc.manager.Apply(ctx, sink); err != nil {
+		uc.logger.Error().Err(err).Str("sink_id", sink.ID.String()).Msg("Erro ao iniciar sink de eventos")
+		return nil, fmt.Errorf("erro ao iniciar sink: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("sink_id", sink.ID.String()).
+		Str("session_id", sink.SessionID.String()).
+		Str("driver", string(sink.Driver)).
+		Msg("Sink de eventos criado com sucesso")
+
+	return &CreateSinkResponse{
+		Sink:    sink,
+		Message: "Sink criado com sucesso",
+	}, nil
+}
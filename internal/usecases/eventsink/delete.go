@@ -0,0 +1,57 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/eventsink"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DeleteSinkUseCase representa o caso de uso para remover sink
+type DeleteSinkUseCase struct {
+	sinkRepo eventsink.Repository
+	manager  eventsink.Manager
+	logger   *logger.Logger
+}
+
+// NewDeleteSinkUseCase cria uma nova instância do caso de uso
+func NewDeleteSinkUseCase(sinkRepo eventsink.Repository, manager eventsink.Manager) *DeleteSinkUseCase {
+	return &DeleteSinkUseCase{
+		sinkRepo: sinkRepo,
+		manager:  manager,
+		logger:   logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de remoção de sink
+func (uc *DeleteSinkUseCase) Execute(ctx context.Context, sessionID, sinkID uuid.UUID) error {
+	sink, err := uc.sinkRepo.GetByID(ctx, sinkID)
+	if err != nil {
+		if err == eventsink.ErrSinkNotFound {
+			return err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar sink")
+		return fmt.Errorf("erro interno do servidor")
+	}
+
+	if sink.SessionID != sessionID {
+		return eventsink.ErrSinkNotFound
+	}
+
+	if err := uc.sinkRepo.Delete(ctx, sinkID); err != nil {
+		uc.logger.Error().Err(err).Str("sink_id", sinkID.String()).Msg("Erro ao remover sink")
+		return fmt.Errorf("erro ao remover sink: %w", err)
+	}
+
+	uc.manager.Remove(sinkID)
+
+	uc.logger.Info().
+		Str("sink_id", sinkID.String()).
+		Str("session_id", sessionID.String()).
+		Msg("Sink de eventos removido com sucesso")
+
+	return nil
+}
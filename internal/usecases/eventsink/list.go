@@ -0,0 +1,45 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/eventsink"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// ListSinksUseCase representa o caso de uso para listar sinks de uma sessão
+type ListSinksUseCase struct {
+	sinkRepo eventsink.Repository
+	logger   *logger.Logger
+}
+
+// NewListSinksUseCase cria uma nova instância do caso de uso
+func NewListSinksUseCase(sinkRepo eventsink.Repository) *ListSinksUseCase {
+	return &ListSinksUseCase{
+		sinkRepo: sinkRepo,
+		logger:   logger.Get(),
+	}
+}
+
+// ListSinksResponse representa a resposta da listagem de sinks
+type ListSinksResponse struct {
+	Sinks []*eventsink.Sink `json:"sinks"`
+	Total int               `json:"total"`
+}
+
+// Execute executa o caso de uso de listagem de sinks
+func (uc *ListSinksUseCase) Execute(ctx context.Context, sessionID uuid.UUID) (*ListSinksResponse, error) {
+	sinks, err := uc.sinkRepo.ListBySession(ctx, sessionID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao listar sinks")
+		return nil, fmt.Errorf("erro ao listar sinks: %w", err)
+	}
+
+	return &ListSinksResponse{
+		Sinks: sinks,
+		Total: len(sinks),
+	}, nil
+}
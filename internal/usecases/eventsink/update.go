@@ -0,0 +1,112 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/eventsink"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// UpdateSinkUseCase representa o caso de uso para atualizar sink
+type UpdateSinkUseCase struct {
+	sinkRepo eventsink.Repository
+	manager  eventsink.Manager
+	logger   *logger.Logger
+}
+
+// NewUpdateSinkUseCase cria uma nova instância do caso de uso
+func NewUpdateSinkUseCase(sinkRepo eventsink.Repository, manager eventsink.Manager) *UpdateSinkUseCase {
+	return &UpdateSinkUseCase{
+		sinkRepo: sinkRepo,
+		manager:  manager,
+		logger:   logger.Get(),
+	}
+}
+
+// UpdateSinkRequest representa a requisição para atualizar sink
+type UpdateSinkRequest struct {
+	SessionID uuid.UUID         `json:"-"`
+	SinkID    uuid.UUID         `json:"-"`
+	Name      *string           `json:"name,omitempty"`
+	Driver    *eventsink.Driver `json:"driver,omitempty"`
+	URL       *string           `json:"url,omitempty"`
+	Subject   *string           `json:"subject,omitempty"`
+	Types     []string          `json:"types,omitempty"`
+	Options   map[string]string `json:"options,omitempty"`
+	IsActive  *bool             `json:"isActive,omitempty"`
+}
+
+// UpdateSinkResponse representa a resposta da atualização de sink
+type UpdateSinkResponse struct {
+	Sink    *eventsink.Sink `json:"sink"`
+	Message string          `json:"message"`
+}
+
+// Execute executa o caso de uso de atualização de sink
+func (uc *UpdateSinkUseCase) Execute(ctx context.Context, req *UpdateSinkRequest) (*UpdateSinkResponse, error) {
+	sink, err := uc.sinkRepo.GetByID(ctx, req.SinkID)
+	if err != nil {
+		if err == eventsink.ErrSinkNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar sink")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if sink.SessionID != req.SessionID {
+		return nil, eventsink.ErrSinkNotFound
+	}
+
+	// Aplicar apenas os campos informados
+	if req.Name != nil {
+		sink.Name = *req.Name
+	}
+	if req.Driver != nil {
+		sink.Driver = *req.Driver
+	}
+	if req.URL != nil {
+		sink.URL = *req.URL
+	}
+	if req.Subject != nil {
+		sink.Subject = *req.Subject
+	}
+	if req.Types != nil {
+		sink.Types = req.Types
+	}
+	if req.Options != nil {
+		sink.Options = req.Options
+	}
+	if req.IsActive != nil {
+		sink.IsActive = *req.IsActive
+	}
+
+	if err := sink.Validate(); err != nil {
+		return nil, err
+	}
+
+	sink.UpdatedAt = time.Now()
+
+	if err := uc.sinkRepo.Update(ctx, sink); err != nil {
+		uc.logger.Error().Err(err).Str("sink_id", sink.ID.String()).Msg("Erro ao atualizar sink")
+		return nil, fmt.Errorf("erro ao atualizar sink: %w", err)
+	}
+
+	if err := uc.manager.Apply(ctx, sink); err != nil {
+		uc.logger.Error().Err(err).Str("sink_id", sink.ID.String()).Msg("Erro ao reiniciar sink de eventos")
+		return nil, fmt.Errorf("erro ao reiniciar sink: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("sink_id", sink.ID.String()).
+		Str("session_id", sink.SessionID.String()).
+		Msg("Sink de eventos atualizado com sucesso")
+
+	return &UpdateSinkResponse{
+		Sink:    sink,
+		Message: "Sink atualizado com sucesso",
+	}, nil
+}
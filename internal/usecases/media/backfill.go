@@ -0,0 +1,227 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/whatsapp"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Padrões da varredura de mídias pendentes
+const (
+	DefaultBackfillBatch       = 50
+	DefaultBackfillConcurrency = 2
+	DefaultBackfillMaxAttempts = 5
+	// DefaultBackfillRetryAfter é o intervalo mínimo entre duas tentativas da mesma mensagem
+	DefaultBackfillRetryAfter = time.Hour
+)
+
+// BackfillUseCase baixa as mídias de mensagens que não foram armazenadas no recebimento, com
+// um limite de downloads simultâneos. Apenas sessões conectadas são consideradas.
+type BackfillUseCase struct {
+	messageRepo    message.Repository
+	sessionRepo    session.Repository
+	whatsappClient whatsapp.Client
+	fetcher        media.Fetcher
+	logger         *logger.Logger
+}
+
+// NewBackfillUseCase cria uma nova instância do caso de uso
+func NewBackfillUseCase(messageRepo message.Repository, sessionRepo session.Repository, whatsappClient whatsapp.Client, fetcher media.Fetcher) *BackfillUseCase {
+	return &BackfillUseCase{
+		messageRepo:    messageRepo,
+		sessionRepo:    sessionRepo,
+		whatsappClient: whatsappClient,
+		fetcher:        fetcher,
+		logger:         logger.Get().WithField("component", "media_backfill"),
+	}
+}
+
+// BackfillRequest representa uma varredura de mídias pendentes; valores zerados usam os padrões
+type BackfillRequest struct {
+	SessionID   *uuid.UUID    `json:"sessionId,omitempty"`
+	Limit       int           `json:"limit,omitempty"`
+	Concurrency int           `json:"concurrency,omitempty"`
+	MaxAttempts int           `json:"maxAttempts,omitempty"`
+	MaxAge      time.Duration `json:"maxAge,omitempty"`     // Ignora mensagens mais antigas; zero não limita
+	RetryAfter  time.Duration `json:"retryAfter,omitempty"` // Intervalo mínimo entre tentativas da mesma mensagem
+}
+
+// BackfillResponse representa o resultado da varredura
+type BackfillResponse struct {
+	Sessions   int    `json:"sessions"`
+	Scanned    int    `json:"scanned"`
+	Downloaded int    `json:"downloaded"`
+	Failed     int    `json:"failed"`
+	Message    string `json:"message"`
+}
+
+// Execute baixa um lote de mídias pendentes das sessões conectadas, das mensagens mais recentes
+// para as mais antigas
+func (uc *BackfillUseCase) Execute(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	limit := valueOrDefault(req.Limit, DefaultBackfillBatch)
+	concurrency := valueOrDefault(req.Concurrency, DefaultBackfillConcurrency)
+	maxAttempts := valueOrDefault(req.MaxAttempts, DefaultBackfillMaxAttempts)
+	retryAfter := req.RetryAfter
+	if retryAfter <= 0 {
+		retryAfter = DefaultBackfillRetryAfter
+	}
+
+	sessionIDs, err := uc.connectedSessions(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BackfillResponse{Sessions: len(sessionIDs)}
+	if len(sessionIDs) == 0 {
+		response.Message = "Nenhuma sessão conectada"
+		return response, nil
+	}
+
+	now := time.Now()
+	filter := message.MissingMediaFilter{
+		SessionIDs:    sessionIDs,
+		MaxAttempts:   maxAttempts,
+		AttemptBefore: now.Add(-retryAfter),
+		Limit:         limit,
+	}
+	if req.MaxAge > 0 {
+		filter.Since = now.Add(-req.MaxAge)
+	}
+
+	messages, err := uc.messageRepo.ListMissingMedia(ctx, filter)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao buscar mensagens com mídia pendente")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+	response.Scanned = len(messages)
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, concurrency)
+	)
+
+dispatch:
+	for _, msg := range messages {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+
+		wg.Add(1)
+		go func(msg *message.Message) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := uc.backfill(ctx, msg)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				response.Failed++
+			} else {
+				response.Downloaded++
+			}
+		}(msg)
+	}
+	wg.Wait()
+
+	response.Message = fmt.Sprintf("%d mídias baixadas, %d falhas", response.Downloaded, response.Failed)
+	return response, nil
+}
+
+// Run executa uma varredura a cada intervalo até o contexto ser cancelado
+func (uc *BackfillUseCase) Run(ctx context.Context, interval time.Duration, req BackfillRequest) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		response, err := uc.Execute(ctx, &req)
+		if err != nil {
+			continue
+		}
+		if response.Scanned > 0 {
+			uc.logger.Info().
+				Int("sessions", response.Sessions).
+				Int("scanned", response.Scanned).
+				Int("downloaded", response.Downloaded).
+				Int("failed", response.Failed).
+				Msg("Varredura de mídias pendentes concluída")
+		}
+	}
+}
+
+// backfill baixa a mídia da mensagem e grava o resultado; falhas contam uma tentativa, exceto
+// quando a varredura foi interrompida
+func (uc *BackfillUseCase) backfill(ctx context.Context, msg *message.Message) error {
+	if err := uc.fetcher.FetchMedia(ctx, msg); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		msg.RecordMediaFailure(err.Error())
+		if recordErr := uc.messageRepo.RecordMediaAttempt(ctx, msg); recordErr != nil {
+			uc.logger.Error().Err(recordErr).Str("message_id", msg.MsgID).Msg("Erro ao registrar tentativa de download da mídia")
+		}
+
+		uc.logger.Warn().
+			Err(err).
+			Str("session_id", msg.SessionID.String()).
+			Str("message_id", msg.MsgID).
+			Int("attempts", msg.MediaAttempts).
+			Msg("Não foi possível baixar a mídia pendente")
+		return err
+	}
+
+	if err := uc.messageRepo.UpdateMediaInfo(ctx, msg); err != nil {
+		uc.logger.Error().Err(err).Str("message_id", msg.MsgID).Msg("Erro ao gravar mídia da mensagem")
+		return err
+	}
+	return nil
+}
+
+// connectedSessions retorna as sessões conectadas e autenticadas, restritas à sessão informada
+func (uc *BackfillUseCase) connectedSessions(ctx context.Context, sessionID *uuid.UUID) ([]uuid.UUID, error) {
+	filters := session.ListFilters{}
+	if sessionID != nil {
+		filters.IDs = []uuid.UUID{*sessionID}
+	}
+
+	sessions, err := uc.sessionRepo.List(ctx, filters)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao listar sessões")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	connected := make([]uuid.UUID, 0, len(sessions))
+	for _, sess := range sessions {
+		if sess.IsActive && uc.whatsappClient.IsConnected(ctx, sess.ID) && uc.whatsappClient.IsLoggedIn(ctx, sess.ID) {
+			connected = append(connected, sess.ID)
+		}
+	}
+	return connected, nil
+}
+
+// valueOrDefault retorna value quando positivo ou o padrão informado
+func valueOrDefault(value, fallback int) int {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
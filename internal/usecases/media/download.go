@@ -0,0 +1,141 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/message"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DownloadUseCase entrega a mídia de uma mensagem a partir do armazenamento, baixando do
+// WhatsApp na primeira requisição quando a mídia ainda não foi armazenada
+type DownloadUseCase struct {
+	messageRepo message.Repository
+	reader      media.Reader
+	fetcher     media.Fetcher
+	logger      *logger.Logger
+}
+
+// NewDownloadUseCase cria uma nova instância do caso de uso; sem reader o armazenamento está desativado
+func NewDownloadUseCase(messageRepo message.Repository, reader media.Reader, fetcher media.Fetcher) *DownloadUseCase {
+	return &DownloadUseCase{
+		messageRepo: messageRepo,
+		reader:      reader,
+		fetcher:     fetcher,
+		logger:      logger.Get(),
+	}
+}
+
+// DownloadResponse representa a mídia aberta para envio; Content deve ser fechado pelo chamador
+type DownloadResponse struct {
+	Content      io.ReadSeekCloser
+	FileName     string
+	ContentType  string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// Execute abre a mídia da mensagem informada
+func (uc *DownloadUseCase) Execute(ctx context.Context, sessionID uuid.UUID, msgID string) (*DownloadResponse, error) {
+	msg, err := uc.messageRepo.GetBySessionMsgID(ctx, sessionID, msgID)
+	if err != nil {
+		if errors.Is(err, message.ErrMessageNotFound) {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Str("message_id", msgID).Msg("Erro ao buscar mensagem")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if !msg.IsMediaMessage() {
+		return nil, message.ErrMediaNotFound
+	}
+	if uc.reader == nil {
+		return nil, media.ErrStorageDisabled
+	}
+	// A mídia removida pela política de retenção só volta pelo reenvio explícito
+	if msg.IsMediaExpired() {
+		return nil, media.ErrMediaExpired
+	}
+	// Mídias em quarentena ficam disponíveis apenas direto no armazenamento, para análise
+	if msg.MediaQuarantined {
+		return nil, media.ErrMediaQuarantined
+	}
+
+	if msg.MediaPath != "" {
+		response, err := uc.open(ctx, msg)
+		if !errors.Is(err, media.ErrObjectNotFound) {
+			return response, err
+		}
+		uc.logger.Warn().
+			Str("session_id", sessionID.String()).
+			Str("message_id", msgID).
+			Str("object_path", msg.MediaPath).
+			Msg("Mídia ausente do armazenamento, baixando novamente do WhatsApp")
+	}
+
+	if err := uc.fetch(ctx, msg); err != nil {
+		if errors.Is(err, media.ErrMediaUnavailable) || errors.Is(err, media.ErrStorageDisabled) {
+			uc.logger.Warn().Err(err).Str("session_id", sessionID.String()).Str("message_id", msgID).Msg("Não foi possível baixar a mídia do WhatsApp")
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Str("message_id", msgID).Msg("Erro ao baixar mídia do WhatsApp")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+	if msg.MediaQuarantined {
+		return nil, media.ErrMediaQuarantined
+	}
+
+	response, err := uc.open(ctx, msg)
+	if errors.Is(err, media.ErrObjectNotFound) {
+		return nil, media.ErrMediaUnavailable
+	}
+	return response, err
+}
+
+// open abre o objeto da mídia no armazenamento
+func (uc *DownloadUseCase) open(ctx context.Context, msg *message.Message) (*DownloadResponse, error) {
+	content, object, err := uc.reader.OpenMedia(ctx, msg.MediaPath)
+	if err != nil {
+		if errors.Is(err, media.ErrObjectNotFound) {
+			return nil, media.ErrObjectNotFound
+		}
+		uc.logger.Error().Err(err).Str("object_path", msg.MediaPath).Msg("Erro ao abrir mídia do armazenamento")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	contentType := msg.MediaMimeType
+	if contentType == "" {
+		contentType = object.ContentType
+	}
+	fileName := msg.MediaFileName
+	if fileName == "" {
+		fileName = path.Base(msg.MediaPath)
+	}
+
+	return &DownloadResponse{
+		Content:      content,
+		FileName:     fileName,
+		ContentType:  contentType,
+		Size:         object.Size,
+		ETag:         object.ETag,
+		LastModified: object.LastModified,
+	}, nil
+}
+
+// fetch baixa a mídia do WhatsApp e grava o caminho na mensagem; o fetcher agrupa os downloads
+// simultâneos da mesma mensagem
+func (uc *DownloadUseCase) fetch(ctx context.Context, msg *message.Message) error {
+	if err := uc.fetcher.FetchMedia(ctx, msg); err != nil {
+		return err
+	}
+	return uc.messageRepo.UpdateMediaInfo(ctx, msg)
+}
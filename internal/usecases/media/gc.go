@@ -0,0 +1,247 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"zapcore/internal/domain/media"
+	"zapcore/pkg/logger"
+)
+
+// DefaultGCMinAge protege uploads recentes cujo registro ainda não foi gravado no banco
+const DefaultGCMinAge = 24 * time.Hour
+
+// GCUseCase remove do armazenamento as mídias que nenhum registro do banco referencia
+type GCUseCase struct {
+	store   media.Store
+	sources []media.ReferenceSource
+	blobs   media.BlobRepository
+	missing media.MissingMarker
+	logger  *logger.Logger
+}
+
+// NewGCUseCase cria uma nova instância do caso de uso
+func NewGCUseCase(store media.Store, sources ...media.ReferenceSource) *GCUseCase {
+	return &GCUseCase{
+		store:   store,
+		sources: sources,
+		logger:  logger.Get(),
+	}
+}
+
+// SetBlobRepository inclui na coleta os conteúdos deduplicados: as referências são recalculadas
+// pelas mensagens e os conteúdos sem referências há mais de MinAge são removidos
+func (uc *GCUseCase) SetBlobRepository(blobs media.BlobRepository) {
+	uc.blobs = blobs
+	uc.sources = append(uc.sources, blobs)
+}
+
+// SetMissingMarker habilita a reconciliação: as mensagens cujo objeto não existe mais no
+// armazenamento são desvinculadas dele e voltam ao download das mídias pendentes
+func (uc *GCUseCase) SetMissingMarker(marker media.MissingMarker) {
+	uc.missing = marker
+}
+
+// GCRequest representa a requisição de coleta de mídias órfãs
+type GCRequest struct {
+	Prefix    string        `json:"prefix,omitempty"`
+	MinAge    time.Duration `json:"minAge,omitempty"`
+	DryRun    bool          `json:"dryRun,omitempty"`
+	BlobsOnly bool          `json:"blobsOnly,omitempty"` // Coleta apenas os conteúdos deduplicados, sem percorrer o armazenamento
+	Reconcile bool          `json:"reconcile,omitempty"` // Procura também registros cujo objeto não existe mais
+}
+
+// GCResponse representa o resultado da coleta
+type GCResponse struct {
+	Scanned    int      `json:"scanned"`
+	Orphans    []string `json:"orphans"`
+	Missing    []string `json:"missing,omitempty"`  // Objetos referenciados que não existem no armazenamento
+	Unlinked   int      `json:"unlinked,omitempty"` // Mensagens desvinculadas dos objetos ausentes
+	Deleted    int      `json:"deleted"`
+	FreedBytes int64    `json:"freedBytes"`
+	Message    string   `json:"message"`
+}
+
+// Execute executa o caso de uso de coleta de mídias órfãs
+func (uc *GCUseCase) Execute(ctx context.Context, req *GCRequest) (*GCResponse, error) {
+	minAge := req.MinAge
+	if minAge <= 0 {
+		minAge = DefaultGCMinAge
+	}
+	cutoff := time.Now().Add(-minAge)
+
+	if req.BlobsOnly {
+		response := &GCResponse{Orphans: []string{}}
+		if uc.blobs != nil {
+			if err := uc.collectBlobs(ctx, req.DryRun, cutoff, response); err != nil {
+				return nil, err
+			}
+		}
+		response.Message = fmt.Sprintf("%d conteúdo(s) sem referências removido(s)", response.Deleted)
+		return response, nil
+	}
+
+	// Os conteúdos são coletados antes da listagem das referências: um caminho recém-coletado
+	// listado como referenciado seria dado como ausente na reconciliação
+	response := &GCResponse{Orphans: []string{}}
+	if uc.blobs != nil {
+		if err := uc.collectBlobs(ctx, req.DryRun, cutoff, response); err != nil {
+			return nil, err
+		}
+	}
+
+	referenced := make(map[string]struct{})
+	for _, source := range uc.sources {
+		paths, err := source.ListMediaPaths(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			referenced[path] = struct{}{}
+		}
+	}
+
+	// Os caminhos foram listados antes da varredura e cada objeto é gravado antes do registro que o
+	// referencia, então um caminho não visto na varredura é de fato um objeto ausente
+	existing := make(map[string]struct{})
+	err := uc.store.WalkMedia(ctx, req.Prefix, func(object *media.Object) error {
+		response.Scanned++
+		if req.Reconcile {
+			existing[object.Path] = struct{}{}
+		}
+
+		if _, ok := referenced[object.Path]; ok {
+			return nil
+		}
+		if object.LastModified.After(cutoff) {
+			return nil
+		}
+
+		response.Orphans = append(response.Orphans, object.Path)
+		if req.DryRun {
+			return nil
+		}
+
+		if err := uc.store.DeleteMedia(ctx, object.Path); err != nil {
+			// Uma falha isolada não deve interromper a coleta
+			uc.logger.Warn().Err(err).Str("object_path", object.Path).Msg("Erro ao remover mídia órfã")
+			return nil
+		}
+		response.Deleted++
+		response.FreedBytes += object.Size
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao percorrer mídias: %w", err)
+	}
+
+	if req.Reconcile {
+		if err := uc.reconcile(ctx, req, referenced, existing, response); err != nil {
+			return nil, err
+		}
+	}
+
+	response.Message = fmt.Sprintf("%d mídia(s) órfã(s) removida(s)", response.Deleted)
+	if req.DryRun {
+		response.Message = fmt.Sprintf("%d mídia(s) órfã(s) encontrada(s), nenhuma removida", len(response.Orphans))
+	}
+	if req.Reconcile {
+		response.Message += fmt.Sprintf("; %d mídia(s) ausente(s), %d mensagem(ns) desvinculada(s)", len(response.Missing), response.Unlinked)
+	}
+
+	uc.logger.Info().
+		Int("scanned", response.Scanned).
+		Int("orphans", len(response.Orphans)).
+		Int("deleted", response.Deleted).
+		Int("missing", len(response.Missing)).
+		Int("unlinked", response.Unlinked).
+		Int64("freed_bytes", response.FreedBytes).
+		Bool("dry_run", req.DryRun).
+		Msg("Coleta de mídias órfãs concluída")
+
+	return response, nil
+}
+
+// reconcile procura os caminhos referenciados no prefixo percorrido cujo objeto não existe. As
+// mensagens são desvinculadas para que o download das mídias pendentes as baixe de novo, e os
+// registros dos conteúdos deduplicados ausentes são removidos para que voltem a ser gravados.
+func (uc *GCUseCase) reconcile(ctx context.Context, req *GCRequest, referenced, existing map[string]struct{}, response *GCResponse) error {
+	for path := range referenced {
+		if !strings.HasPrefix(path, req.Prefix) {
+			continue
+		}
+		if _, ok := existing[path]; !ok {
+			response.Missing = append(response.Missing, path)
+		}
+	}
+	sort.Strings(response.Missing)
+
+	if req.DryRun || len(response.Missing) == 0 {
+		return nil
+	}
+
+	if uc.blobs != nil {
+		if _, err := uc.blobs.DeleteByPaths(ctx, response.Missing); err != nil {
+			return err
+		}
+	}
+	if uc.missing != nil {
+		unlinked, err := uc.missing.MarkMediaMissing(ctx, response.Missing)
+		if err != nil {
+			return err
+		}
+		response.Unlinked = unlinked
+	}
+	return nil
+}
+
+// collectBlobs remove os conteúdos deduplicados que nenhuma mensagem referencia mais. O registro é
+// reservado antes da remoção do objeto e só é apagado depois dela, para que um novo envio do mesmo
+// conteúdo não passe a referenciar um objeto em remoção. No modo simulação as referências não são
+// recalculadas.
+func (uc *GCUseCase) collectBlobs(ctx context.Context, dryRun bool, cutoff time.Time, response *GCResponse) error {
+	if !dryRun {
+		if err := uc.blobs.RecountReferences(ctx); err != nil {
+			return err
+		}
+	}
+
+	blobs, err := uc.blobs.ListUnreferenced(ctx, cutoff, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, blob := range blobs {
+		response.Orphans = append(response.Orphans, blob.ObjectPath)
+		if dryRun {
+			continue
+		}
+
+		claimed, err := uc.blobs.ClaimUnreferenced(ctx, blob.ID)
+		if err != nil {
+			uc.logger.Warn().Err(err).Str("object_path", blob.ObjectPath).Msg("Erro ao reservar conteúdo de mídia sem referências")
+			continue
+		}
+		if !claimed {
+			continue // Voltou a ser referenciado desde a listagem
+		}
+
+		// Em caso de falha o registro continua reservado e a próxima coleta tenta de novo
+		if err := uc.store.DeleteMedia(ctx, blob.ObjectPath); err != nil {
+			uc.logger.Warn().Err(err).Str("object_path", blob.ObjectPath).Msg("Erro ao remover mídia sem referências")
+			continue
+		}
+
+		if err := uc.blobs.DeleteClaimed(ctx, blob.ID); err != nil {
+			uc.logger.Warn().Err(err).Str("object_path", blob.ObjectPath).Msg("Erro ao remover conteúdo de mídia sem referências")
+			continue
+		}
+		response.Deleted++
+		response.FreedBytes += blob.Size
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRetentionBatch limita as mídias removidas em cada execução da retenção
+const DefaultRetentionBatch = 500
+
+// RetentionUseCase remove do armazenamento as mídias vencidas pela política de retenção de cada
+// sessão (a própria, a do tenant ou os padrões da configuração). As mensagens são mantidas, sem o
+// caminho da mídia e marcadas como expiradas para não voltarem ao download das mídias pendentes.
+type RetentionUseCase struct {
+	messageRepo message.Repository
+	sessionRepo session.Repository
+	tenantRepo  tenant.Repository
+	store       media.Store
+	blobs       media.BlobRepository
+	collector   *GCUseCase
+	defaults    *media.RetentionPolicy
+	logger      *logger.Logger
+}
+
+// NewRetentionUseCase cria uma nova instância do caso de uso
+func NewRetentionUseCase(messageRepo message.Repository, sessionRepo session.Repository, tenantRepo tenant.Repository, store media.Store, defaults *media.RetentionPolicy) *RetentionUseCase {
+	return &RetentionUseCase{
+		messageRepo: messageRepo,
+		sessionRepo: sessionRepo,
+		tenantRepo:  tenantRepo,
+		store:       store,
+		defaults:    defaults,
+		logger:      logger.Get().WithField("component", "media_retention"),
+	}
+}
+
+// SetBlobRepository trata os conteúdos deduplicados: a mensagem vencida apenas libera sua
+// referência e o objeto é removido pela coleta quando nenhuma outra mensagem o referencia. Com
+// collector, a execução periódica também coleta os conteúdos sem referências.
+func (uc *RetentionUseCase) SetBlobRepository(blobs media.BlobRepository, collector *GCUseCase) {
+	uc.blobs = blobs
+	uc.collector = collector
+}
+
+// RetentionRequest representa uma execução da retenção; valores zerados usam os padrões
+type RetentionRequest struct {
+	SessionID *uuid.UUID `json:"sessionId,omitempty"`
+	Limit     int        `json:"limit,omitempty"`
+	DryRun    bool       `json:"dryRun,omitempty"`
+}
+
+// RetentionResponse representa o resultado da retenção
+type RetentionResponse struct {
+	Sessions   int    `json:"sessions"`
+	Expired    int    `json:"expired"`
+	Deleted    int    `json:"deleted"`
+	Released   int    `json:"released"` // Referências a conteúdos deduplicados liberadas para a coleta
+	Failed     int    `json:"failed"`
+	FreedBytes int64  `json:"freedBytes"`
+	Message    string `json:"message"`
+}
+
+// Execute remove um lote de mídias vencidas, das mensagens mais antigas para as mais recentes
+func (uc *RetentionUseCase) Execute(ctx context.Context, req *RetentionRequest) (*RetentionResponse, error) {
+	limit := valueOrDefault(req.Limit, DefaultRetentionBatch)
+
+	filters := session.ListFilters{}
+	if req.SessionID != nil {
+		filters.IDs = []uuid.UUID{*req.SessionID}
+	}
+	sessions, err := uc.sessionRepo.List(ctx, filters)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao listar sessões")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	tenantPolicies, err := uc.tenantPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RetentionResponse{}
+	now := time.Now()
+
+sessions:
+	for _, sess := range sessions {
+		policy, source := media.ResolveRetention(sess.MediaRetention, tenantPolicies[sess.TenantID], uc.defaults)
+		targets := policy.Targets()
+		if len(targets) == 0 {
+			continue
+		}
+		response.Sessions++
+
+		for _, target := range targets {
+			remaining := limit - response.Expired
+			if remaining <= 0 || ctx.Err() != nil {
+				break sessions
+			}
+
+			messages, err := uc.messageRepo.ListStoredMedia(ctx, message.StoredMediaFilter{
+				SessionID:    sess.ID,
+				MessageTypes: target.MessageTypes,
+				Direction:    target.Direction,
+				Before:       now.Add(-target.MaxAge),
+				Limit:        remaining,
+			})
+			if err != nil {
+				uc.logger.Error().Err(err).Str("session_id", sess.ID.String()).Msg("Erro ao buscar mídias vencidas")
+				return nil, fmt.Errorf("erro interno do servidor")
+			}
+
+			for _, msg := range messages {
+				response.Expired++
+				if req.DryRun {
+					response.FreedBytes += msg.MediaSize
+					continue
+				}
+				uc.expire(ctx, msg, source, response)
+			}
+		}
+	}
+
+	response.Message = fmt.Sprintf("%d mídia(s) vencida(s) removida(s), %d byte(s) liberado(s)", response.Deleted+response.Released, response.FreedBytes)
+	if req.DryRun {
+		response.Message = fmt.Sprintf("%d mídia(s) vencida(s) encontrada(s), nenhuma removida", response.Expired)
+	}
+
+	return response, nil
+}
+
+// Run executa a retenção a cada intervalo até o contexto ser cancelado
+func (uc *RetentionUseCase) Run(ctx context.Context, interval time.Duration, req RetentionRequest) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		response, err := uc.Execute(ctx, &req)
+		if err != nil {
+			continue
+		}
+		if uc.collector != nil && !req.DryRun {
+			collected, err := uc.collector.Execute(ctx, &GCRequest{BlobsOnly: true})
+			if err != nil {
+				uc.logger.Warn().Err(err).Msg("Erro ao coletar conteúdos de mídia sem referências")
+			} else {
+				response.FreedBytes += collected.FreedBytes
+			}
+		}
+		if response.Expired > 0 || response.FreedBytes > 0 {
+			uc.logger.Info().
+				Int("sessions", response.Sessions).
+				Int("expired", response.Expired).
+				Int("deleted", response.Deleted).
+				Int("released", response.Released).
+				Int("failed", response.Failed).
+				Int64("freed_bytes", response.FreedBytes).
+				Msg("Retenção de mídias concluída")
+		}
+	}
+}
+
+// expire desvincula a mídia vencida da mensagem e remove o objeto. A mensagem é gravada antes da
+// remoção: se a remoção falhar, o objeto sem referências é recolhido pela coleta de órfãos.
+func (uc *RetentionUseCase) expire(ctx context.Context, msg *message.Message, source string, response *RetentionResponse) {
+	objectPath := msg.MediaPath
+	blobID := msg.MediaID
+
+	msg.ExpireMedia()
+	if err := uc.messageRepo.UpdateMediaInfo(ctx, msg); err != nil {
+		uc.logger.Error().Err(err).Str("message_id", msg.MsgID).Msg("Erro ao marcar mídia como expirada")
+		response.Failed++
+		return
+	}
+
+	if blobID != nil && uc.blobs != nil {
+		if err := uc.blobs.ReleaseReference(ctx, *blobID); err != nil {
+			// A coleta recalcula as referências pelas mensagens
+			uc.logger.Warn().Err(err).Str("object_path", objectPath).Msg("Erro ao liberar conteúdo de mídia")
+		}
+		response.Released++
+		return
+	}
+
+	if err := uc.store.DeleteMedia(ctx, objectPath); err != nil {
+		uc.logger.Warn().Err(err).Str("object_path", objectPath).Msg("Erro ao remover mídia vencida")
+		response.Failed++
+		return
+	}
+
+	response.Deleted++
+	response.FreedBytes += msg.MediaSize
+
+	uc.logger.Debug().
+		Str("session_id", msg.SessionID.String()).
+		Str("message_id", msg.MsgID).
+		Str("object_path", objectPath).
+		Str("policy", source).
+		Msg("Mídia vencida removida")
+}
+
+// tenantPolicies retorna as políticas de retenção definidas pelos tenants
+func (uc *RetentionUseCase) tenantPolicies(ctx context.Context) (map[string]*media.RetentionPolicy, error) {
+	tenants, err := uc.tenantRepo.List(ctx)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao listar tenants")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	policies := make(map[string]*media.RetentionPolicy, len(tenants))
+	for _, t := range tenants {
+		if t.MediaRetention != nil {
+			policies[t.ID] = t.MediaRetention
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,135 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/message"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// RetryUseCase baixa novamente do WhatsApp a mídia de uma mensagem, pedindo o reenvio ao
+// aparelho do remetente quando o arquivo já expirou na CDN
+type RetryUseCase struct {
+	messageRepo message.Repository
+	reader      media.Reader
+	fetcher     media.Fetcher
+	logger      *logger.Logger
+}
+
+// NewRetryUseCase cria uma nova instância do caso de uso; sem reader o armazenamento está desativado
+func NewRetryUseCase(messageRepo message.Repository, reader media.Reader, fetcher media.Fetcher) *RetryUseCase {
+	return &RetryUseCase{
+		messageRepo: messageRepo,
+		reader:      reader,
+		fetcher:     fetcher,
+		logger:      logger.Get(),
+	}
+}
+
+// RetryRequest representa a requisição de novo download da mídia
+type RetryRequest struct {
+	SessionID uuid.UUID `json:"-"`
+	MsgID     string    `json:"-"`
+	Force     bool      `json:"force,omitempty"` // Baixa mesmo que a mídia já esteja armazenada
+}
+
+// RetryResponse representa a mídia da mensagem após o novo download
+type RetryResponse struct {
+	MessageID  string `json:"messageId"`
+	MediaPath  string `json:"mediaPath"`
+	MediaSize  int64  `json:"mediaSize"`
+	MimeType   string `json:"mimeType,omitempty"`
+	FileName   string `json:"fileName,omitempty"`
+	Attempts   int    `json:"attempts"`
+	Downloaded bool   `json:"downloaded"`
+	Message    string `json:"message"`
+}
+
+// Execute executa o caso de uso de novo download da mídia
+func (uc *RetryUseCase) Execute(ctx context.Context, req *RetryRequest) (*RetryResponse, error) {
+	msg, err := uc.messageRepo.GetBySessionMsgID(ctx, req.SessionID, req.MsgID)
+	if err != nil {
+		if errors.Is(err, message.ErrMessageNotFound) {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", req.SessionID.String()).Str("message_id", req.MsgID).Msg("Erro ao buscar mensagem")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if !msg.IsMediaMessage() {
+		return nil, message.ErrMediaNotFound
+	}
+	if uc.reader == nil {
+		return nil, media.ErrStorageDisabled
+	}
+
+	if !req.Force && msg.MediaPath != "" {
+		stored, err := uc.isStored(ctx, msg.MediaPath)
+		if err != nil {
+			return nil, err
+		}
+		if stored {
+			return retryResponse(msg, false, "Mídia já armazenada"), nil
+		}
+	}
+
+	if err := uc.fetcher.FetchMedia(ctx, msg); err != nil {
+		msg.RecordMediaFailure(err.Error())
+		if recordErr := uc.messageRepo.RecordMediaAttempt(ctx, msg); recordErr != nil {
+			uc.logger.Error().Err(recordErr).Str("message_id", msg.MsgID).Msg("Erro ao registrar tentativa de download da mídia")
+		}
+
+		if errors.Is(err, media.ErrMediaUnavailable) || errors.Is(err, media.ErrStorageDisabled) {
+			uc.logger.Warn().Err(err).Str("session_id", req.SessionID.String()).Str("message_id", req.MsgID).Msg("Não foi possível baixar a mídia do WhatsApp")
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", req.SessionID.String()).Str("message_id", req.MsgID).Msg("Erro ao baixar mídia do WhatsApp")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if err := uc.messageRepo.UpdateMediaInfo(ctx, msg); err != nil {
+		uc.logger.Error().Err(err).Str("message_id", msg.MsgID).Msg("Erro ao gravar mídia da mensagem")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	uc.logger.Info().
+		Str("session_id", req.SessionID.String()).
+		Str("message_id", req.MsgID).
+		Str("object_path", msg.MediaPath).
+		Msg("Mídia baixada novamente do WhatsApp")
+
+	return retryResponse(msg, true, "Mídia baixada novamente do WhatsApp"), nil
+}
+
+// isStored verifica se o objeto da mídia existe no armazenamento
+func (uc *RetryUseCase) isStored(ctx context.Context, objectPath string) (bool, error) {
+	content, _, err := uc.reader.OpenMedia(ctx, objectPath)
+	if err != nil {
+		if errors.Is(err, media.ErrObjectNotFound) {
+			return false, nil
+		}
+		uc.logger.Error().Err(err).Str("object_path", objectPath).Msg("Erro ao verificar mídia no armazenamento")
+		return false, fmt.Errorf("erro interno do servidor")
+	}
+	content.Close()
+	return true, nil
+}
+
+// retryResponse monta a resposta com as informações de mídia da mensagem
+func retryResponse(msg *message.Message, downloaded bool, text string) *RetryResponse {
+	return &RetryResponse{
+		MessageID:  msg.MsgID,
+		MediaPath:  msg.MediaPath,
+		MediaSize:  msg.MediaSize,
+		MimeType:   msg.MediaMimeType,
+		FileName:   msg.MediaFileName,
+		Attempts:   msg.MediaAttempts,
+		Downloaded: downloaded,
+		Message:    text,
+	}
+}
@@ -0,0 +1,123 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/message"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// GetReceiptsUseCase representa o caso de uso para consultar as confirmações de uma mensagem
+type GetReceiptsUseCase struct {
+	messageRepo message.Repository
+	receiptRepo message.ReceiptRepository
+	readRule    message.ReadRule
+	logger      *logger.Logger
+}
+
+// NewGetReceiptsUseCase cria uma nova instância do caso de uso
+func NewGetReceiptsUseCase(
+	messageRepo message.Repository,
+	receiptRepo message.ReceiptRepository,
+	readRule message.ReadRule,
+) *GetReceiptsUseCase {
+	return &GetReceiptsUseCase{
+		messageRepo: messageRepo,
+		receiptRepo: receiptRepo,
+		readRule:    readRule,
+		logger:      logger.Get(),
+	}
+}
+
+// RecipientReceipts representa as confirmações de um destinatário
+type RecipientReceipts struct {
+	JID         string     `json:"jid"`
+	DeliveredAt *time.Time `json:"deliveredAt,omitempty"`
+	ReadAt      *time.Time `json:"readAt,omitempty"`
+	PlayedAt    *time.Time `json:"playedAt,omitempty"`
+}
+
+// ReceiptsResponse representa a resposta com as confirmações da mensagem
+type ReceiptsResponse struct {
+	SessionID  uuid.UUID              `json:"sessionId"`
+	MsgID      string                 `json:"msgId"`
+	ChatJID    string                 `json:"chatJid"`
+	Status     message.MessageStatus  `json:"status"`
+	ReadRule   string                 `json:"readRule"`
+	Summary    message.ReceiptSummary `json:"summary"`
+	Recipients []*RecipientReceipts   `json:"recipients"`
+}
+
+// Execute retorna quem recebeu, leu ou ouviu a mensagem
+func (uc *GetReceiptsUseCase) Execute(ctx context.Context, sessionID uuid.UUID, msgID string) (*ReceiptsResponse, error) {
+	msg, err := uc.messageRepo.GetBySessionMsgID(ctx, sessionID, msgID)
+	if err != nil {
+		if errors.Is(err, message.ErrMessageNotFound) {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Str("message_id", msgID).Msg("Erro ao buscar mensagem")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	receipts, err := uc.receiptRepo.ListByMessage(ctx, sessionID, msgID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Str("message_id", msgID).Msg("Erro ao listar confirmações")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	recipients, summary := groupReceipts(receipts)
+	summary.Expected = message.ExpectedRecipients(msg.ChatJID, msg.RecipientCount)
+
+	return &ReceiptsResponse{
+		SessionID:  sessionID,
+		MsgID:      msg.MsgID,
+		ChatJID:    msg.ChatJID,
+		Status:     msg.Status,
+		ReadRule:   uc.readRule.String(),
+		Summary:    summary,
+		Recipients: recipients,
+	}, nil
+}
+
+// groupReceipts agrupa as confirmações por destinatário, na ordem da primeira confirmação,
+// contando os destinatários como o repositório: ouvir implica ler e ler implica receber
+func groupReceipts(receipts []*message.Receipt) ([]*RecipientReceipts, message.ReceiptSummary) {
+	recipients := make([]*RecipientReceipts, 0)
+	byJID := make(map[string]*RecipientReceipts)
+
+	for _, receipt := range receipts {
+		recipient, ok := byJID[receipt.RecipientJID]
+		if !ok {
+			recipient = &RecipientReceipts{JID: receipt.RecipientJID}
+			byJID[receipt.RecipientJID] = recipient
+			recipients = append(recipients, recipient)
+		}
+
+		timestamp := receipt.Timestamp
+		switch receipt.Type {
+		case message.ReceiptTypeDelivered:
+			recipient.DeliveredAt = &timestamp
+		case message.ReceiptTypeRead:
+			recipient.ReadAt = &timestamp
+		case message.ReceiptTypePlayed:
+			recipient.PlayedAt = &timestamp
+		}
+	}
+
+	summary := message.ReceiptSummary{Recipients: len(recipients), Delivered: len(recipients)}
+	for _, recipient := range recipients {
+		if recipient.ReadAt != nil || recipient.PlayedAt != nil {
+			summary.Read++
+		}
+		if recipient.PlayedAt != nil {
+			summary.Played++
+		}
+	}
+
+	return recipients, summary
+}
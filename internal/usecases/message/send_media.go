@@ -2,6 +2,7 @@ package message
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
@@ -10,11 +11,16 @@ import (
 	"time"
 
 	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/sendlimit"
 	"zapcore/internal/domain/session"
 	"zapcore/internal/domain/whatsapp"
+	templateUseCase "zapcore/internal/usecases/template"
+	tenantUseCase "zapcore/internal/usecases/tenant"
 	"zapcore/pkg/logger"
+	"zapcore/pkg/tracing"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Constantes para limites de tamanho de mídia (em bytes)
@@ -33,6 +39,7 @@ var (
 	}
 	SupportedVideoMimes = []string{
 		"video/mp4", "video/avi", "video/mov", "video/mkv", "video/webm",
+		"video/quicktime", "video/3gpp", "video/x-matroska", "video/x-msvideo",
 	}
 	SupportedAudioMimes = []string{
 		"audio/mpeg", "audio/mp3", "audio/wav", "audio/ogg", "audio/aac", "audio/m4a",
@@ -44,7 +51,7 @@ var (
 		"text/plain", "application/zip", "application/rar",
 	}
 	SupportedStickerMimes = []string{
-		"image/webp", "image/png",
+		"image/webp", "image/png", "image/jpeg",
 	}
 )
 
@@ -53,6 +60,8 @@ type SendMediaUseCase struct {
 	messageRepo    message.Repository
 	sessionRepo    session.Repository
 	whatsappClient whatsapp.Client
+	templates      *templateUseCase.RenderUseCase
+	quotas         *tenantUseCase.QuotaUseCase
 	logger         *logger.Logger
 }
 
@@ -61,11 +70,15 @@ func NewSendMediaUseCase(
 	messageRepo message.Repository,
 	sessionRepo session.Repository,
 	whatsappClient whatsapp.Client,
+	templates *templateUseCase.RenderUseCase,
+	quotas *tenantUseCase.QuotaUseCase,
 ) *SendMediaUseCase {
 	return &SendMediaUseCase{
 		messageRepo:    messageRepo,
 		sessionRepo:    sessionRepo,
 		whatsappClient: whatsappClient,
+		templates:      templates,
+		quotas:         quotas,
 		logger:         logger.Get(),
 	}
 }
@@ -82,6 +95,15 @@ type SendMediaRequest struct {
 	FileName   string              `json:"file_name,omitempty"`
 	MimeType   string              `json:"mime_type,omitempty"`
 	ReplyToID  string              `json:"reply_to_id,omitempty"`
+	PTT        bool                `json:"ptt,omitempty"` // Áudio como mensagem de voz
+
+	// Normalização da mídia antes do envio; nil segue o padrão do servidor
+	Normalize *whatsapp.NormalizeOptions `json:"normalize,omitempty"`
+
+	// Envio via template: legenda e mídia vêm do template quando não informadas
+	TemplateID *uuid.UUID        `json:"templateId,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	Language   string            `json:"language,omitempty"`
 }
 
 // SendMediaResponse representa a resposta do envio de mídia
@@ -90,10 +112,24 @@ type SendMediaResponse struct {
 	Status     message.MessageStatus `json:"status"`
 	Timestamp  string                `json:"timestamp"`
 	Message    string                `json:"message"`
+	PreviewURL string                `json:"preview_url,omitempty"`
+	PTT        bool                  `json:"ptt,omitempty"`        // Áudio entregue como mensagem de voz
+	Normalized []string              `json:"normalized,omitempty"` // Alterações feitas na mídia antes do envio
 }
 
 // Execute executa o caso de uso de envio de mídia
 func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest) (*SendMediaResponse, error) {
+	ctx, span := tracing.Start(ctx, "SendMediaUseCase.Execute",
+		attribute.String("session.id", req.SessionID.String()),
+		attribute.String("message.type", string(req.Type)),
+	)
+	response, err := uc.execute(ctx, req)
+	tracing.End(span, err)
+	return response, err
+}
+
+// execute valida a sessão, resolve o template e envia a mídia
+func (uc *SendMediaUseCase) execute(ctx context.Context, req *SendMediaRequest) (*SendMediaResponse, error) {
 	// Verificar se a sessão existe e está conectada
 	sess, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
 	if err != nil {
@@ -112,6 +148,17 @@ func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest)
 		return nil, session.ErrSessionNotConnected
 	}
 
+	// Resolver template, se informado
+	if req.TemplateID != nil {
+		closer, err := uc.applyTemplate(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if closer != nil {
+			defer closer.Close()
+		}
+	}
+
 	// Validar tipo de mídia
 	if !isValidMediaType(req.Type) {
 		return nil, message.ErrInvalidMediaType
@@ -145,6 +192,11 @@ func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest)
 		Str("media_type", string(req.Type)).
 		Msg("Preparando envio de mídia")
 
+	// Contabilizar o envio na cota diária do tenant
+	if err := uc.quotas.ReserveMessage(ctx, sess); err != nil {
+		return nil, err
+	}
+
 	// Enviar via WhatsApp baseado no tipo
 	var whatsappResp *whatsapp.MessageResponse
 
@@ -160,6 +212,7 @@ func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest)
 			ReplyToID:  req.ReplyToID,
 			MimeType:   req.MimeType,
 			FileName:   req.FileName,
+			Normalize:  req.Normalize,
 		}
 		whatsappResp, err = uc.whatsappClient.SendImageMessage(ctx, whatsappReq)
 
@@ -173,6 +226,7 @@ func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest)
 			ReplyToID:  req.ReplyToID,
 			MimeType:   req.MimeType,
 			FileName:   req.FileName,
+			PTT:        req.PTT,
 		}
 		whatsappResp, err = uc.whatsappClient.SendAudioMessage(ctx, whatsappReq)
 
@@ -187,6 +241,7 @@ func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest)
 			ReplyToID:  req.ReplyToID,
 			MimeType:   req.MimeType,
 			FileName:   req.FileName,
+			Normalize:  req.Normalize,
 		}
 		whatsappResp, err = uc.whatsappClient.SendVideoMessage(ctx, whatsappReq)
 
@@ -213,14 +268,24 @@ func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest)
 			Base64Data:  req.Base64Data,
 			ReplyToID:   req.ReplyToID,
 			MimeType:    req.MimeType,
+			Normalize:   req.Normalize,
 		}
 		whatsappResp, err = uc.whatsappClient.SendStickerMessage(ctx, whatsappReq)
 
 	default:
+		uc.quotas.ReleaseMessage(ctx, sess)
 		return nil, message.ErrInvalidMediaType
 	}
 
 	if err != nil {
+		uc.quotas.ReleaseMessage(ctx, sess)
+
+		// Envio recusado pelos limites de envio da sessão
+		var throttled *sendlimit.ThrottledError
+		if errors.As(err, &throttled) {
+			return nil, throttled
+		}
+
 		uc.logger.Error().
 			Err(err).
 			Str("session_id", req.SessionID.String()).
@@ -262,9 +327,44 @@ func (uc *SendMediaUseCase) Execute(ctx context.Context, req *SendMediaRequest)
 		Status:     message.MessageStatusSent,
 		Timestamp:  time.Now().Format("2006-01-02T15:04:05Z07:00"),
 		Message:    "Mídia enviada com sucesso",
+		PreviewURL: whatsappResp.PreviewURL,
+		PTT:        whatsappResp.PTT,
+		Normalized: whatsappResp.Normalized,
 	}, nil
 }
 
+// applyTemplate preenche legenda e mídia da requisição a partir do template
+func (uc *SendMediaUseCase) applyTemplate(ctx context.Context, req *SendMediaRequest) (io.Closer, error) {
+	rendered, err := renderTemplate(ctx, uc.templates, *req.TemplateID, req.Language, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Caption == "" {
+		req.Caption = rendered.Text
+	}
+
+	// Mídia enviada na requisição tem prioridade sobre a mídia do template
+	if req.MediaData != nil || req.MediaURL != "" || req.Base64Data != "" {
+		return nil, nil
+	}
+
+	reader, err := uc.templates.OpenMedia(ctx, rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	req.MediaData = reader
+	if req.MimeType == "" {
+		req.MimeType = rendered.MediaMimeType
+	}
+	if req.FileName == "" {
+		req.FileName = rendered.MediaFileName
+	}
+
+	return reader, nil
+}
+
 // isValidMediaType verifica se o tipo de mídia é válido
 func isValidMediaType(msgType message.MessageType) bool {
 	validTypes := []message.MessageType{
@@ -2,15 +2,21 @@ package message
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/sendlimit"
 	"zapcore/internal/domain/session"
 	"zapcore/internal/domain/whatsapp"
+	templateUseCase "zapcore/internal/usecases/template"
+	tenantUseCase "zapcore/internal/usecases/tenant"
 	"zapcore/pkg/logger"
+	"zapcore/pkg/tracing"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // SendTextUseCase representa o caso de uso para enviar mensagem de texto
@@ -18,6 +24,8 @@ type SendTextUseCase struct {
 	messageRepo    message.Repository
 	sessionRepo    session.Repository
 	whatsappClient whatsapp.Client
+	templates      *templateUseCase.RenderUseCase
+	quotas         *tenantUseCase.QuotaUseCase
 	logger         *logger.Logger
 }
 
@@ -26,11 +34,15 @@ func NewSendTextUseCase(
 	messageRepo message.Repository,
 	sessionRepo session.Repository,
 	whatsappClient whatsapp.Client,
+	templates *templateUseCase.RenderUseCase,
+	quotas *tenantUseCase.QuotaUseCase,
 ) *SendTextUseCase {
 	return &SendTextUseCase{
 		messageRepo:    messageRepo,
 		sessionRepo:    sessionRepo,
 		whatsappClient: whatsappClient,
+		templates:      templates,
+		quotas:         quotas,
 		logger:         logger.Get(),
 	}
 }
@@ -39,8 +51,13 @@ func NewSendTextUseCase(
 type SendTextRequest struct {
 	SessionID uuid.UUID `json:"sessionId" validate:"required"`
 	To        string    `json:"to" validate:"required"`
-	Text      string    `json:"text" validate:"required,min=1,max=4096"`
+	Text      string    `json:"text" validate:"required_without=TemplateID,max=4096"`
 	ReplyID   string    `json:"replyId,omitempty"`
+
+	// Envio via template: substitui o texto pelo template renderizado
+	TemplateID *uuid.UUID        `json:"templateId,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	Language   string            `json:"language,omitempty"`
 }
 
 // SendTextResponse representa a resposta do envio de texto
@@ -53,6 +70,16 @@ type SendTextResponse struct {
 
 // Execute executa o caso de uso de envio de texto
 func (uc *SendTextUseCase) Execute(ctx context.Context, req *SendTextRequest) (*SendTextResponse, error) {
+	ctx, span := tracing.Start(ctx, "SendTextUseCase.Execute",
+		attribute.String("session.id", req.SessionID.String()),
+	)
+	response, err := uc.execute(ctx, req)
+	tracing.End(span, err)
+	return response, err
+}
+
+// execute valida a sessão, resolve o template e envia a mensagem de texto
+func (uc *SendTextUseCase) execute(ctx context.Context, req *SendTextRequest) (*SendTextResponse, error) {
 	// Verificar se a sessão existe e está conectada
 	sess, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
 	if err != nil {
@@ -71,6 +98,19 @@ func (uc *SendTextUseCase) Execute(ctx context.Context, req *SendTextRequest) (*
 		return nil, session.ErrSessionNotConnected
 	}
 
+	// Resolver template, se informado
+	if req.TemplateID != nil {
+		rendered, err := renderTemplate(ctx, uc.templates, *req.TemplateID, req.Language, req.Variables)
+		if err != nil {
+			return nil, err
+		}
+		req.Text = rendered.Text
+	}
+
+	if req.Text == "" {
+		return nil, message.ErrInvalidContent
+	}
+
 	uc.logger.Debug().
 		Str("session_id", req.SessionID.String()).
 		Str("to", req.To).
@@ -85,9 +125,22 @@ func (uc *SendTextUseCase) Execute(ctx context.Context, req *SendTextRequest) (*
 		ReplyToID: req.ReplyID,
 	}
 
+	// Contabilizar o envio na cota diária do tenant
+	if err := uc.quotas.ReserveMessage(ctx, sess); err != nil {
+		return nil, err
+	}
+
 	// Enviar via WhatsApp
 	whatsappResp, err := uc.whatsappClient.SendTextMessage(ctx, whatsappReq)
 	if err != nil {
+		uc.quotas.ReleaseMessage(ctx, sess)
+
+		// Envio recusado pelos limites de envio da sessão
+		var throttled *sendlimit.ThrottledError
+		if errors.As(err, &throttled) {
+			return nil, throttled
+		}
+
 		uc.logger.Error().Err(err).Msg("Erro ao enviar mensagem via WhatsApp")
 		return nil, fmt.Errorf("erro ao enviar mensagem: %w", err)
 	}
@@ -0,0 +1,29 @@
+package message
+
+import (
+	"context"
+	"fmt"
+
+	templateUseCase "zapcore/internal/usecases/template"
+
+	"github.com/google/uuid"
+)
+
+// renderTemplate renderiza o template informado na requisição de envio
+func renderTemplate(
+	ctx context.Context,
+	renderer *templateUseCase.RenderUseCase,
+	templateID uuid.UUID,
+	language string,
+	variables map[string]string,
+) (*templateUseCase.RenderResponse, error) {
+	if renderer == nil {
+		return nil, fmt.Errorf("templates não estão habilitados")
+	}
+
+	return renderer.Execute(ctx, &templateUseCase.RenderRequest{
+		TemplateID: templateID,
+		Language:   language,
+		Variables:  variables,
+	})
+}
@@ -5,27 +5,32 @@ import (
 	"fmt"
 
 	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
+	tenantUseCase "zapcore/internal/usecases/tenant"
 	"zapcore/pkg/logger"
 )
 
 // CreateUseCase representa o caso de uso para criar sessão
 type CreateUseCase struct {
 	sessionRepo session.Repository
+	quotas      *tenantUseCase.QuotaUseCase
 	logger      *logger.Logger
 }
 
 // NewCreateUseCase cria uma nova instância do caso de uso
-func NewCreateUseCase(sessionRepo session.Repository) *CreateUseCase {
+func NewCreateUseCase(sessionRepo session.Repository, quotas *tenantUseCase.QuotaUseCase) *CreateUseCase {
 	return &CreateUseCase{
 		sessionRepo: sessionRepo,
+		quotas:      quotas,
 		logger:      logger.Get(),
 	}
 }
 
 // CreateRequest representa a requisição para criar sessão
 type CreateRequest struct {
-	Name    string `json:"name" validate:"required,min=3,max=50"`
-	Webhook string `json:"webhook,omitempty" validate:"omitempty,url"`
+	TenantID string `json:"tenantId,omitempty"` // Ignorado fora da chave mestre: a sessão pertence ao tenant da chave
+	Name     string `json:"name" validate:"required,min=3,max=50"`
+	Webhook  string `json:"webhook,omitempty" validate:"omitempty,url"`
 }
 
 // CreateResponse representa a resposta da criação de sessão
@@ -36,20 +41,33 @@ type CreateResponse struct {
 
 // Execute executa o caso de uso de criação de sessão
 func (uc *CreateUseCase) Execute(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
-	// Validar se já existe uma sessão com o mesmo nome
-	existingSession, err := uc.sessionRepo.GetByName(ctx, req.Name)
-	if err != nil && err != session.ErrSessionNotFound {
+	tenantID, ok := tenant.IDFromContext(ctx)
+	if !ok {
+		tenantID = req.TenantID
+		if tenantID == "" {
+			tenantID = tenant.DefaultTenantID
+		}
+	}
+
+	// Validar se já existe uma sessão com o mesmo nome (em qualquer tenant)
+	exists, err := uc.sessionRepo.ExistsByName(ctx, req.Name)
+	if err != nil {
 		uc.logger.Error().Err(err).Msg("Erro ao verificar sessão existente")
 		return nil, fmt.Errorf("erro interno do servidor")
 	}
 
-	if existingSession != nil {
+	if exists {
 		uc.logger.Warn().Str("name", req.Name).Msg("Tentativa de criar sessão com nome duplicado")
 		return nil, session.ErrSessionAlreadyExists
 	}
 
+	if err := uc.quotas.CheckSessionLimit(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
 	// Criar nova sessão
 	newSession := session.NewSession(req.Name)
+	newSession.TenantID = tenantID
 
 	// Configurar webhook se fornecido
 	if req.Webhook != "" {
@@ -65,6 +83,7 @@ func (uc *CreateUseCase) Execute(ctx context.Context, req *CreateRequest) (*Crea
 	uc.logger.Info().
 		Str("session_id", newSession.ID.String()).
 		Str("session_name", newSession.Name).
+		Str("tenant_id", newSession.TenantID).
 		Msg("Sessão criada com sucesso")
 
 	return &CreateResponse{
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/whatsapp"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DeleteUseCase representa o caso de uso para remover uma sessão
+type DeleteUseCase struct {
+	sessionRepo    session.Repository
+	whatsappClient whatsapp.Client
+	logger         *logger.Logger
+}
+
+// NewDeleteUseCase cria uma nova instância do caso de uso
+func NewDeleteUseCase(sessionRepo session.Repository, whatsappClient whatsapp.Client) *DeleteUseCase {
+	return &DeleteUseCase{
+		sessionRepo:    sessionRepo,
+		whatsappClient: whatsappClient,
+		logger:         logger.Get(),
+	}
+}
+
+// DeleteRequest representa a requisição para remover sessão
+type DeleteRequest struct {
+	SessionID uuid.UUID `json:"sessionId" validate:"required"`
+	// Logout desvincula o dispositivo antes de remover a sessão
+	Logout bool `json:"logout,omitempty"`
+}
+
+// Execute executa o caso de uso de remoção de sessão
+func (uc *DeleteUseCase) Execute(ctx context.Context, req *DeleteRequest) error {
+	sess, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar sessão")
+		return fmt.Errorf("erro interno do servidor")
+	}
+
+	if req.Logout && sess.JID != "" {
+		if err := uc.whatsappClient.Logout(ctx, sess.ID); err != nil {
+			uc.logger.Error().Err(err).Str("session_id", sess.ID.String()).Msg("Erro ao desvincular sessão antes da remoção")
+			return err
+		}
+	} else if err := uc.whatsappClient.Disconnect(ctx, sess.ID); err != nil {
+		// Continua mesmo com erro: a sessão será removida de qualquer forma
+		uc.logger.Warn().Err(err).Str("session_id", sess.ID.String()).Msg("Erro ao desconectar sessão antes da remoção")
+	}
+
+	if err := uc.sessionRepo.Delete(ctx, sess.ID); err != nil {
+		if err == session.ErrSessionNotFound {
+			return err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao remover sessão")
+		return fmt.Errorf("erro ao remover sessão: %w", err)
+	}
+
+	uc.logger.Info().Str("session_id", sess.ID.String()).Str("session_name", sess.Name).Msg("Sessão removida com sucesso")
+	return nil
+}
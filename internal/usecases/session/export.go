@@ -0,0 +1,150 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"zapcore/internal/domain/chat"
+	"zapcore/internal/domain/contact"
+	"zapcore/internal/domain/message"
+	"zapcore/internal/domain/session"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// exportPageSize é a quantidade de registros lidos por consulta durante a exportação
+const exportPageSize = 500
+
+// Tipos de registro gravados na exportação
+const (
+	ExportKindSession = "session"
+	ExportKindChat    = "chat"
+	ExportKindContact = "contact"
+	ExportKindMessage = "message"
+)
+
+// ExportRecord é uma linha do arquivo NDJSON exportado
+type ExportRecord struct {
+	Kind string `json:"kind"`
+	Data any    `json:"data"`
+}
+
+// ExportUseCase exporta os dados de uma sessão em NDJSON
+type ExportUseCase struct {
+	sessionRepo session.Repository
+	chatRepo    chat.Repository
+	contactRepo contact.Repository
+	messageRepo message.Repository
+	logger      *logger.Logger
+}
+
+// NewExportUseCase cria uma nova instância do caso de uso
+func NewExportUseCase(
+	sessionRepo session.Repository,
+	chatRepo chat.Repository,
+	contactRepo contact.Repository,
+	messageRepo message.Repository,
+) *ExportUseCase {
+	return &ExportUseCase{
+		sessionRepo: sessionRepo,
+		chatRepo:    chatRepo,
+		contactRepo: contactRepo,
+		messageRepo: messageRepo,
+		logger:      logger.Get(),
+	}
+}
+
+// ExportRequest representa a requisição de exportação
+type ExportRequest struct {
+	SessionID uuid.UUID `json:"sessionId" validate:"required"`
+	// SkipMessages exporta apenas sessão, chats e contatos
+	SkipMessages bool      `json:"skipMessages,omitempty"`
+	Writer       io.Writer `json:"-"`
+}
+
+// ExportResponse representa o resumo da exportação
+type ExportResponse struct {
+	SessionID uuid.UUID `json:"sessionId"`
+	Chats     int       `json:"chats"`
+	Contacts  int       `json:"contacts"`
+	Messages  int       `json:"messages"`
+}
+
+// Execute executa o caso de uso de exportação
+func (uc *ExportUseCase) Execute(ctx context.Context, req *ExportRequest) (*ExportResponse, error) {
+	sess, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	encoder := json.NewEncoder(req.Writer)
+	response := &ExportResponse{SessionID: sess.ID}
+
+	if err := encoder.Encode(ExportRecord{Kind: ExportKindSession, Data: sess}); err != nil {
+		return nil, fmt.Errorf("erro ao gravar exportação: %w", err)
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		chats, err := uc.chatRepo.GetBySessionID(ctx, sess.ID, chat.ListFilters{Limit: exportPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chats {
+			if err := encoder.Encode(ExportRecord{Kind: ExportKindChat, Data: c}); err != nil {
+				return nil, fmt.Errorf("erro ao gravar exportação: %w", err)
+			}
+		}
+		response.Chats += len(chats)
+		if len(chats) < exportPageSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		contacts, err := uc.contactRepo.GetBySessionID(ctx, sess.ID, contact.ListFilters{Limit: exportPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range contacts {
+			if err := encoder.Encode(ExportRecord{Kind: ExportKindContact, Data: c}); err != nil {
+				return nil, fmt.Errorf("erro ao gravar exportação: %w", err)
+			}
+		}
+		response.Contacts += len(contacts)
+		if len(contacts) < exportPageSize {
+			break
+		}
+	}
+
+	for offset := 0; !req.SkipMessages; offset += exportPageSize {
+		messages, err := uc.messageRepo.GetBySessionID(ctx, sess.ID, message.ListFilters{Limit: exportPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range messages {
+			if err := encoder.Encode(ExportRecord{Kind: ExportKindMessage, Data: m}); err != nil {
+				return nil, fmt.Errorf("erro ao gravar exportação: %w", err)
+			}
+		}
+		response.Messages += len(messages)
+		if len(messages) < exportPageSize {
+			break
+		}
+	}
+
+	uc.logger.Info().
+		Str("session_id", sess.ID.String()).
+		Int("chats", response.Chats).
+		Int("contacts", response.Contacts).
+		Int("messages", response.Messages).
+		Msg("Sessão exportada com sucesso")
+
+	return response, nil
+}
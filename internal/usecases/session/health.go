@@ -0,0 +1,126 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/whatsapp"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Status agregado do health check das sessões
+const (
+	HealthStatusHealthy  = "healthy"
+	HealthStatusDegraded = "degraded"
+)
+
+// HealthCheckUseCase representa o caso de uso para verificar a saúde das sessões
+type HealthCheckUseCase struct {
+	sessionRepo    session.Repository
+	whatsappClient whatsapp.Client
+	qrStuckAfter   time.Duration
+	logger         *logger.Logger
+}
+
+// NewHealthCheckUseCase cria uma nova instância do caso de uso
+func NewHealthCheckUseCase(sessionRepo session.Repository, whatsappClient whatsapp.Client, qrStuckAfter time.Duration) *HealthCheckUseCase {
+	return &HealthCheckUseCase{
+		sessionRepo:    sessionRepo,
+		whatsappClient: whatsappClient,
+		qrStuckAfter:   qrStuckAfter,
+		logger:         logger.Get(),
+	}
+}
+
+// HealthCheckRequest representa a requisição de health check das sessões
+type HealthCheckRequest struct {
+	SessionIDs    []uuid.UUID `json:"-"` // Sessões permitidas para a chave; vazio verifica todas
+	OnlyUnhealthy bool        `json:"only_unhealthy,omitempty"`
+}
+
+// Execute executa o caso de uso de health check das sessões
+func (uc *HealthCheckUseCase) Execute(ctx context.Context, req *HealthCheckRequest) (*session.HealthStatus, error) {
+	sessions, err := uc.sessionRepo.List(ctx, session.ListFilters{IDs: req.SessionIDs, OrderBy: "name", OrderDir: "ASC"})
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao listar sessões para health check")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	now := time.Now()
+	status := &session.HealthStatus{
+		Status:         HealthStatusHealthy,
+		TotalSessions:  len(sessions),
+		SessionDetails: make(map[uuid.UUID]session.SessionHealth),
+	}
+
+	for _, sess := range sessions {
+		if !sess.IsActive {
+			continue
+		}
+		status.ActiveSessions++
+
+		health := uc.checkSession(ctx, sess, now)
+		if health.Connected {
+			status.ConnectedSessions++
+		}
+		if !health.IsHealthy {
+			status.ErrorSessions++
+			status.Status = HealthStatusDegraded
+		}
+
+		if req.OnlyUnhealthy && health.IsHealthy {
+			continue
+		}
+		status.SessionDetails[sess.ID] = health
+	}
+
+	return status, nil
+}
+
+// checkSession combina o estado persistido com o estado em memória do cliente WhatsApp
+func (uc *HealthCheckUseCase) checkSession(ctx context.Context, sess *session.Session, now time.Time) session.SessionHealth {
+	health := session.SessionHealth{
+		ID:        sess.ID,
+		Name:      sess.Name,
+		Status:    sess.Status,
+		IsHealthy: true,
+		Connected: uc.whatsappClient.IsConnected(ctx, sess.ID),
+		LoggedIn:  uc.whatsappClient.IsLoggedIn(ctx, sess.ID),
+	}
+
+	if sess.LastSeen != nil {
+		health.LastSeen = sess.LastSeen.Format(time.RFC3339)
+	}
+
+	activity := uc.whatsappClient.GetActivity(ctx, sess.ID)
+	if activity.LastEventAt != nil {
+		health.LastEventAt = activity.LastEventAt.Format(time.RFC3339)
+		health.LastEventType = activity.LastEventType
+	}
+	if activity.QRPendingSince != nil {
+		health.QRPendingSince = activity.QRPendingSince.Format(time.RFC3339)
+		health.QRCodesIssued = activity.QRCodesIssued
+		health.QRStuck = now.Sub(*activity.QRPendingSince) > uc.qrStuckAfter
+	}
+
+	switch {
+	case health.QRStuck:
+		health.IsHealthy = false
+		health.ErrorMessage = fmt.Sprintf("QR Code aguardando leitura há mais de %s", uc.qrStuckAfter)
+	case sess.Status == session.WhatsAppStatusConnecting && !health.Connected &&
+		activity.QRPendingSince == nil && now.Sub(sess.UpdatedAt) > uc.qrStuckAfter:
+		// Status "connecting" persistido sem cliente nem QR ativo: a conexão não foi concluída
+		health.IsHealthy = false
+		health.ErrorMessage = "sessão presa em connecting sem cliente ativo"
+	case sess.Status == session.WhatsAppStatusConnected && (!health.Connected || !health.LoggedIn):
+		// Sessões desconectadas pelo usuário não contam; apenas quedas que o banco ainda não refletiu
+		health.IsHealthy = false
+		health.ErrorMessage = "sessão marcada como connected sem conexão ativa com o WhatsApp"
+	}
+
+	return health
+}
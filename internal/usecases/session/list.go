@@ -6,6 +6,8 @@ import (
 
 	"zapcore/internal/domain/session"
 	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
 )
 
 // ListUseCase representa o caso de uso para listar sessões
@@ -24,12 +26,13 @@ func NewListUseCase(sessionRepo session.Repository) *ListUseCase {
 
 // ListRequest representa a requisição para listar sessões
 type ListRequest struct {
-	Status   *session.WhatsAppSessionStatus `json:"status,omitempty"`
-	IsActive *bool                          `json:"is_active,omitempty"`
-	Limit    int                            `json:"limit,omitempty"`
-	Offset   int                            `json:"offset,omitempty"`
-	OrderBy  string                         `json:"order_by,omitempty"`
-	OrderDir string                         `json:"order_dir,omitempty"`
+	SessionIDs []uuid.UUID                    `json:"-"` // Sessões permitidas para a chave; vazio lista todas
+	Status     *session.WhatsAppSessionStatus `json:"status,omitempty"`
+	IsActive   *bool                          `json:"is_active,omitempty"`
+	Limit      int                            `json:"limit,omitempty"`
+	Offset     int                            `json:"offset,omitempty"`
+	OrderBy    string                         `json:"order_by,omitempty"`
+	OrderDir   string                         `json:"order_dir,omitempty"`
 }
 
 // ListResponse representa a resposta da listagem de sessões
@@ -44,6 +47,7 @@ type ListResponse struct {
 func (uc *ListUseCase) Execute(ctx context.Context, req *ListRequest) (*ListResponse, error) {
 	// Preparar filtros
 	filters := session.ListFilters{
+		IDs:      req.SessionIDs,
 		Status:   req.Status,
 		IsActive: req.IsActive,
 		Limit:    req.Limit,
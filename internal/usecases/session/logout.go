@@ -0,0 +1,70 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/whatsapp"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// LogoutUseCase representa o caso de uso para desvincular a sessão da conta do WhatsApp
+type LogoutUseCase struct {
+	sessionRepo    session.Repository
+	whatsappClient whatsapp.Client
+	logger         *logger.Logger
+}
+
+// NewLogoutUseCase cria uma nova instância do caso de uso
+func NewLogoutUseCase(sessionRepo session.Repository, whatsappClient whatsapp.Client) *LogoutUseCase {
+	return &LogoutUseCase{
+		sessionRepo:    sessionRepo,
+		whatsappClient: whatsappClient,
+		logger:         logger.Get(),
+	}
+}
+
+// LogoutRequest representa a requisição de logout
+type LogoutRequest struct {
+	SessionID uuid.UUID `json:"sessionId" validate:"required"`
+}
+
+// LogoutResponse representa a resposta do logout
+type LogoutResponse struct {
+	SessionID uuid.UUID                     `json:"sessionId"`
+	Status    session.WhatsAppSessionStatus `json:"status"`
+	Message   string                        `json:"message"`
+}
+
+// Execute executa o caso de uso de logout
+func (uc *LogoutUseCase) Execute(ctx context.Context, req *LogoutRequest) (*LogoutResponse, error) {
+	sess, err := uc.sessionRepo.GetByID(ctx, req.SessionID)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	// Sem JID a sessão nunca foi pareada: não há dispositivo para desvincular
+	if sess.JID == "" {
+		return nil, session.ErrSessionNotConnected
+	}
+
+	if err := uc.whatsappClient.Logout(ctx, req.SessionID); err != nil {
+		uc.logger.Error().Err(err).Str("session_id", req.SessionID.String()).Msg("Erro ao desvincular sessão")
+		return nil, err
+	}
+
+	uc.logger.Info().Str("session_id", req.SessionID.String()).Msg("Sessão desvinculada com sucesso")
+
+	return &LogoutResponse{
+		SessionID: sess.ID,
+		Status:    session.WhatsAppStatusDisconnected,
+		Message:   "Sessão desvinculada com sucesso",
+	}, nil
+}
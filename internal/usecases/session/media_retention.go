@@ -0,0 +1,107 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// MediaRetentionUseCase representa o caso de uso para consultar e alterar a retenção das mídias da sessão
+type MediaRetentionUseCase struct {
+	sessionRepo session.Repository
+	tenantRepo  tenant.Repository
+	defaults    *media.RetentionPolicy
+	logger      *logger.Logger
+}
+
+// NewMediaRetentionUseCase cria uma nova instância do caso de uso; defaults é a política da
+// configuração, usada quando nem a sessão nem o tenant definem a própria
+func NewMediaRetentionUseCase(sessionRepo session.Repository, tenantRepo tenant.Repository, defaults *media.RetentionPolicy) *MediaRetentionUseCase {
+	return &MediaRetentionUseCase{
+		sessionRepo: sessionRepo,
+		tenantRepo:  tenantRepo,
+		defaults:    defaults,
+		logger:      logger.Get(),
+	}
+}
+
+// MediaRetentionResponse representa a retenção das mídias da sessão
+type MediaRetentionResponse struct {
+	SessionID uuid.UUID              `json:"sessionId"`
+	Policy    *media.RetentionPolicy `json:"policy,omitempty"`    // Política própria da sessão
+	Effective *media.RetentionPolicy `json:"effective,omitempty"` // Política aplicada pela coleta
+	Source    string                 `json:"source"`              // session, tenant, default ou none
+}
+
+// Get retorna a política própria da sessão e a efetiva
+func (uc *MediaRetentionUseCase) Get(ctx context.Context, sessionID uuid.UUID) (*MediaRetentionResponse, error) {
+	sess, err := uc.getSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.response(ctx, sess), nil
+}
+
+// Set define a retenção própria da sessão; nil ou sem regras volta à política herdada
+func (uc *MediaRetentionUseCase) Set(ctx context.Context, sessionID uuid.UUID, policy *media.RetentionPolicy) (*MediaRetentionResponse, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	sess, err := uc.getSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.SetMediaRetention(policy)
+	if err := uc.sessionRepo.Update(ctx, sess); err != nil {
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao salvar retenção das mídias")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	uc.logger.Info().
+		Str("session_id", sessionID.String()).
+		Bool("custom", sess.MediaRetention != nil).
+		Msg("Retenção das mídias da sessão atualizada")
+
+	return uc.response(ctx, sess), nil
+}
+
+// response monta a resposta com a política efetiva da sessão
+func (uc *MediaRetentionUseCase) response(ctx context.Context, sess *session.Session) *MediaRetentionResponse {
+	var tenantPolicy *media.RetentionPolicy
+	owner, err := uc.tenantRepo.GetByID(ctx, sess.TenantID)
+	if err != nil {
+		// Sem o tenant, a resposta mostra a política da sessão ou os padrões
+		uc.logger.Warn().Err(err).Str("tenant_id", sess.TenantID).Msg("Erro ao buscar tenant da sessão")
+	} else {
+		tenantPolicy = owner.MediaRetention
+	}
+
+	effective, source := media.ResolveRetention(sess.MediaRetention, tenantPolicy, uc.defaults)
+	return &MediaRetentionResponse{
+		SessionID: sess.ID,
+		Policy:    sess.MediaRetention,
+		Effective: effective,
+		Source:    source,
+	}
+}
+
+// getSession busca a sessão, restrita ao tenant do contexto
+func (uc *MediaRetentionUseCase) getSession(ctx context.Context, sessionID uuid.UUID) (*session.Session, error) {
+	sess, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+	return sess, nil
+}
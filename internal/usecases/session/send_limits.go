@@ -0,0 +1,86 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/sendlimit"
+	"zapcore/internal/domain/session"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// SendLimitsUseCase representa o caso de uso para consultar e alterar os limites de envio da sessão
+type SendLimitsUseCase struct {
+	sessionRepo session.Repository
+	governor    sendlimit.Governor
+	logger      *logger.Logger
+}
+
+// NewSendLimitsUseCase cria uma nova instância do caso de uso
+func NewSendLimitsUseCase(sessionRepo session.Repository, governor sendlimit.Governor) *SendLimitsUseCase {
+	return &SendLimitsUseCase{
+		sessionRepo: sessionRepo,
+		governor:    governor,
+		logger:      logger.Get(),
+	}
+}
+
+// Usage retorna os limites efetivos da sessão e o consumo atual
+func (uc *SendLimitsUseCase) Usage(ctx context.Context, sessionID uuid.UUID) (*sendlimit.Usage, error) {
+	if _, err := uc.getSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	usage, err := uc.governor.Usage(ctx, sessionID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao consultar uso dos limites de envio")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return usage, nil
+}
+
+// Set define os limites de envio próprios da sessão; nil volta aos padrões da configuração
+func (uc *SendLimitsUseCase) Set(ctx context.Context, sessionID uuid.UUID, limits *sendlimit.Limits) (*sendlimit.Usage, error) {
+	if limits != nil {
+		if err := limits.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sess, err := uc.getSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.SetSendLimits(limits)
+	if err := uc.sessionRepo.Update(ctx, sess); err != nil {
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao salvar limites de envio")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	// Os limites valem a partir do próximo envio
+	uc.governor.Reload(sessionID)
+
+	uc.logger.Info().
+		Str("session_id", sessionID.String()).
+		Bool("custom", limits != nil).
+		Msg("Limites de envio da sessão atualizados")
+
+	return uc.Usage(ctx, sessionID)
+}
+
+// getSession busca a sessão, restrita ao tenant do contexto
+func (uc *SendLimitsUseCase) getSession(ctx context.Context, sessionID uuid.UUID) (*session.Session, error) {
+	sess, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+	return sess, nil
+}
@@ -0,0 +1,106 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zapcore/internal/domain/template"
+	"zapcore/pkg/logger"
+)
+
+// DefaultLanguage é o idioma usado quando o template não informa um
+const DefaultLanguage = "pt-BR"
+
+// CreateUseCase representa o caso de uso para criar template
+type CreateUseCase struct {
+	templateRepo template.Repository
+	logger       *logger.Logger
+}
+
+// NewCreateUseCase cria uma nova instância do caso de uso
+func NewCreateUseCase(templateRepo template.Repository) *CreateUseCase {
+	return &CreateUseCase{
+		templateRepo: templateRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// CreateRequest representa a requisição para criar template
+type CreateRequest struct {
+	TenantID      string                      `json:"tenantId" validate:"required"`
+	Name          string                      `json:"name" validate:"required,min=3,max=100"`
+	Language      string                      `json:"language,omitempty"`
+	Body          string                      `json:"body" validate:"required,max=4096"`
+	MediaPath     string                      `json:"mediaPath,omitempty"`
+	MediaType     string                      `json:"mediaType,omitempty"`
+	MediaMimeType string                      `json:"mediaMimeType,omitempty"`
+	MediaFileName string                      `json:"mediaFileName,omitempty"`
+	Variants      map[string]template.Variant `json:"variants,omitempty"`
+}
+
+// CreateResponse representa a resposta da criação de template
+type CreateResponse struct {
+	Template          *template.Template `json:"template"`
+	RequiredVariables []string           `json:"requiredVariables"`
+	Message           string             `json:"message"`
+}
+
+// Execute executa o caso de uso de criação de template
+func (uc *CreateUseCase) Execute(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, template.ErrInvalidTemplateName
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, template.ErrEmptyTemplateBody
+	}
+
+	// Validar se já existe um template com o mesmo nome no tenant
+	existing, err := uc.templateRepo.GetByName(ctx, req.TenantID, req.Name)
+	if err != nil && err != template.ErrTemplateNotFound {
+		uc.logger.Error().Err(err).Msg("Erro ao verificar template existente")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if existing != nil {
+		uc.logger.Warn().
+			Str("tenant_id", req.TenantID).
+			Str("name", req.Name).
+			Msg("Tentativa de criar template com nome duplicado")
+		return nil, template.ErrTemplateAlreadyExists
+	}
+
+	language := req.Language
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	newTemplate := template.NewTemplate(req.TenantID, req.Name, language, req.Body)
+	newTemplate.MediaPath = req.MediaPath
+	newTemplate.MediaType = req.MediaType
+	newTemplate.MediaMimeType = req.MediaMimeType
+	newTemplate.MediaFileName = req.MediaFileName
+	if req.Variants != nil {
+		newTemplate.Variants = req.Variants
+	}
+	if err := newTemplate.ValidateMediaPaths(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Create(ctx, newTemplate); err != nil {
+		uc.logger.Error().Err(err).Str("template_name", req.Name).Msg("Erro ao criar template")
+		return nil, fmt.Errorf("erro ao criar template: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("template_id", newTemplate.ID.String()).
+		Str("tenant_id", newTemplate.TenantID).
+		Str("template_name", newTemplate.Name).
+		Msg("Template criado com sucesso")
+
+	return &CreateResponse{
+		Template:          newTemplate,
+		RequiredVariables: newTemplate.RequiredVariables(),
+		Message:           "Template criado com sucesso",
+	}, nil
+}
@@ -0,0 +1,35 @@
+package template
+
+import (
+	"context"
+
+	"zapcore/internal/domain/template"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// DeleteUseCase representa o caso de uso para remover template
+type DeleteUseCase struct {
+	templateRepo template.Repository
+	logger       *logger.Logger
+}
+
+// NewDeleteUseCase cria uma nova instância do caso de uso
+func NewDeleteUseCase(templateRepo template.Repository) *DeleteUseCase {
+	return &DeleteUseCase{
+		templateRepo: templateRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de remoção de template
+func (uc *DeleteUseCase) Execute(ctx context.Context, templateID uuid.UUID) error {
+	if err := uc.templateRepo.Delete(ctx, templateID); err != nil {
+		uc.logger.Error().Err(err).Str("template_id", templateID.String()).Msg("Erro ao remover template")
+		return err
+	}
+
+	uc.logger.Info().Str("template_id", templateID.String()).Msg("Template removido com sucesso")
+	return nil
+}
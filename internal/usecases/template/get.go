@@ -0,0 +1,48 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/template"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// GetUseCase representa o caso de uso para obter um template
+type GetUseCase struct {
+	templateRepo template.Repository
+	logger       *logger.Logger
+}
+
+// NewGetUseCase cria uma nova instância do caso de uso
+func NewGetUseCase(templateRepo template.Repository) *GetUseCase {
+	return &GetUseCase{
+		templateRepo: templateRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// GetResponse representa a resposta da busca de template
+type GetResponse struct {
+	Template          *template.Template `json:"template"`
+	RequiredVariables []string           `json:"requiredVariables"`
+}
+
+// Execute executa o caso de uso de busca de template
+func (uc *GetUseCase) Execute(ctx context.Context, templateID uuid.UUID) (*GetResponse, error) {
+	tmpl, err := uc.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		if err == template.ErrTemplateNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar template")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return &GetResponse{
+		Template:          tmpl,
+		RequiredVariables: tmpl.RequiredVariables(),
+	}, nil
+}
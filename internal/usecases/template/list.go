@@ -0,0 +1,68 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/template"
+	"zapcore/pkg/logger"
+)
+
+// ListUseCase representa o caso de uso para listar templates
+type ListUseCase struct {
+	templateRepo template.Repository
+	logger       *logger.Logger
+}
+
+// NewListUseCase cria uma nova instância do caso de uso
+func NewListUseCase(templateRepo template.Repository) *ListUseCase {
+	return &ListUseCase{
+		templateRepo: templateRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// ListRequest representa a requisição para listar templates
+type ListRequest struct {
+	TenantID string `json:"tenantId,omitempty" form:"tenantId"`
+	Language string `json:"language,omitempty" form:"language"`
+	Limit    int    `json:"limit,omitempty" form:"limit"`
+	Offset   int    `json:"offset,omitempty" form:"offset"`
+}
+
+// ListResponse representa a resposta da listagem de templates
+type ListResponse struct {
+	Templates []*template.Template `json:"templates"`
+	Limit     int                  `json:"limit"`
+	Offset    int                  `json:"offset"`
+}
+
+// Execute executa o caso de uso de listagem de templates
+func (uc *ListUseCase) Execute(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	filters := template.ListFilters{
+		TenantID: req.TenantID,
+		Language: req.Language,
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	}
+
+	// Aplicar valores padrão se não fornecidos
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	templates, err := uc.templateRepo.List(ctx, filters)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao listar templates")
+		return nil, fmt.Errorf("erro ao listar templates: %w", err)
+	}
+
+	return &ListResponse{
+		Templates: templates,
+		Limit:     filters.Limit,
+		Offset:    filters.Offset,
+	}, nil
+}
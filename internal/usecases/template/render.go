@@ -0,0 +1,111 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"zapcore/internal/domain/template"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// RenderUseCase representa o caso de uso para renderizar templates
+type RenderUseCase struct {
+	templateRepo template.Repository
+	mediaStorage template.MediaStorage
+	logger       *logger.Logger
+}
+
+// NewRenderUseCase cria uma nova instância do caso de uso
+func NewRenderUseCase(templateRepo template.Repository, mediaStorage template.MediaStorage) *RenderUseCase {
+	return &RenderUseCase{
+		templateRepo: templateRepo,
+		mediaStorage: mediaStorage,
+		logger:       logger.Get(),
+	}
+}
+
+// RenderRequest representa a requisição para renderizar template
+type RenderRequest struct {
+	TemplateID uuid.UUID         `json:"templateId" validate:"required"`
+	Language   string            `json:"language,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+}
+
+// RenderResponse representa o template renderizado
+type RenderResponse struct {
+	TemplateID    uuid.UUID `json:"templateId"`
+	TenantID      string    `json:"tenantId"`
+	Text          string    `json:"text"`
+	MediaPath     string    `json:"mediaPath,omitempty"`
+	MediaType     string    `json:"mediaType,omitempty"`
+	MediaMimeType string    `json:"mediaMimeType,omitempty"`
+	MediaFileName string    `json:"mediaFileName,omitempty"`
+}
+
+// Execute executa o caso de uso de renderização de template
+func (uc *RenderUseCase) Execute(ctx context.Context, req *RenderRequest) (*RenderResponse, error) {
+	tmpl, err := uc.templateRepo.GetByID(ctx, req.TemplateID)
+	if err != nil {
+		if err == template.ErrTemplateNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar template")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	text, mediaPath, err := tmpl.Render(req.Language, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Debug().
+		Str("template_id", tmpl.ID.String()).
+		Str("language", req.Language).
+		Int("text_length", len(text)).
+		Msg("Template renderizado")
+
+	return &RenderResponse{
+		TemplateID:    tmpl.ID,
+		TenantID:      tmpl.TenantID,
+		Text:          text,
+		MediaPath:     mediaPath,
+		MediaType:     tmpl.MediaType,
+		MediaMimeType: tmpl.MediaMimeType,
+		MediaFileName: tmpl.MediaFileName,
+	}, nil
+}
+
+// OpenMedia abre a mídia anexada a um template renderizado; mídias fora do armazenamento do
+// tenant do template são recusadas
+func (uc *RenderUseCase) OpenMedia(ctx context.Context, rendered *RenderResponse) (io.ReadCloser, error) {
+	if rendered.MediaPath == "" {
+		return nil, template.ErrTemplateHasNoMedia
+	}
+	if !tenant.OwnsObjectPath(rendered.TenantID, rendered.MediaPath) {
+		uc.logger.Warn().
+			Str("template_id", rendered.TemplateID.String()).
+			Str("tenant_id", rendered.TenantID).
+			Str("media_path", rendered.MediaPath).
+			Msg("Mídia do template fora do armazenamento do tenant")
+		return nil, template.ErrInvalidMediaPath
+	}
+	if uc.mediaStorage == nil {
+		return nil, template.ErrMediaStorageDisabled
+	}
+
+	reader, err := uc.mediaStorage.GetMedia(ctx, rendered.MediaPath)
+	if err != nil {
+		uc.logger.Error().
+			Err(err).
+			Str("template_id", rendered.TemplateID.String()).
+			Str("media_path", rendered.MediaPath).
+			Msg("Erro ao abrir mídia do template")
+		return nil, fmt.Errorf("erro ao abrir mídia do template: %w", err)
+	}
+
+	return reader, nil
+}
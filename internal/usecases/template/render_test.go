@@ -0,0 +1,79 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"zapcore/internal/domain/template"
+
+	"github.com/google/uuid"
+)
+
+// recordingStorage registra os paths abertos
+type recordingStorage struct {
+	opened []string
+}
+
+func (s *recordingStorage) GetMedia(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	s.opened = append(s.opened, objectPath)
+	return io.NopCloser(strings.NewReader("mídia")), nil
+}
+
+func TestValidateMediaPathsRejectsOtherTenant(t *testing.T) {
+	cases := map[string]string{
+		"outro tenant":     "tenant-b/blobs/ab/abcdef.jpg",
+		"travessia":        "tenant-a/../tenant-b/blobs/ab/abcdef.jpg",
+		"absoluto":         "/tenant-a/blobs/ab/abcdef.jpg",
+		"prefixo parecido": "tenant-a2/blobs/ab/abcdef.jpg",
+		"quarentena":       "_quarantine/tenant-a/session/chat/inbound/msg.jpg",
+	}
+	for name, mediaPath := range cases {
+		tmpl := template.NewTemplate("tenant-a", "boas-vindas", DefaultLanguage, "Olá")
+		tmpl.MediaPath = mediaPath
+		if err := tmpl.ValidateMediaPaths(); !errors.Is(err, template.ErrInvalidMediaPath) {
+			t.Errorf("%s: esperado ErrInvalidMediaPath para %q, obtido %v", name, mediaPath, err)
+		}
+
+		tmpl.MediaPath = ""
+		tmpl.Variants["en"] = template.Variant{Body: "Hello", MediaPath: mediaPath}
+		if err := tmpl.ValidateMediaPaths(); !errors.Is(err, template.ErrInvalidMediaPath) {
+			t.Errorf("%s: esperado ErrInvalidMediaPath na variante para %q, obtido %v", name, mediaPath, err)
+		}
+	}
+
+	tmpl := template.NewTemplate("tenant-a", "boas-vindas", DefaultLanguage, "Olá")
+	tmpl.MediaPath = "tenant-a/blobs/ab/abcdef.jpg"
+	if err := tmpl.ValidateMediaPaths(); err != nil {
+		t.Errorf("mídia do próprio tenant deveria ser aceita: %v", err)
+	}
+}
+
+func TestOpenMediaRejectsOtherTenant(t *testing.T) {
+	storage := &recordingStorage{}
+	uc := NewRenderUseCase(nil, storage)
+
+	_, err := uc.OpenMedia(context.Background(), &RenderResponse{
+		TemplateID: uuid.New(),
+		TenantID:   "tenant-a",
+		MediaPath:  "tenant-b/blobs/ab/abcdef.jpg",
+	})
+	if !errors.Is(err, template.ErrInvalidMediaPath) {
+		t.Fatalf("esperado ErrInvalidMediaPath, obtido %v", err)
+	}
+	if len(storage.opened) > 0 {
+		t.Errorf("mídia de outro tenant não deveria ser aberta: %v", storage.opened)
+	}
+
+	reader, err := uc.OpenMedia(context.Background(), &RenderResponse{
+		TemplateID: uuid.New(),
+		TenantID:   "tenant-a",
+		MediaPath:  "tenant-a/blobs/ab/abcdef.jpg",
+	})
+	if err != nil {
+		t.Fatalf("mídia do próprio tenant deveria abrir: %v", err)
+	}
+	reader.Close()
+}
@@ -0,0 +1,97 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zapcore/internal/domain/template"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// UpdateUseCase representa o caso de uso para atualizar template
+type UpdateUseCase struct {
+	templateRepo template.Repository
+	logger       *logger.Logger
+}
+
+// NewUpdateUseCase cria uma nova instância do caso de uso
+func NewUpdateUseCase(templateRepo template.Repository) *UpdateUseCase {
+	return &UpdateUseCase{
+		templateRepo: templateRepo,
+		logger:       logger.Get(),
+	}
+}
+
+// UpdateRequest representa a requisição para atualizar template
+type UpdateRequest struct {
+	TemplateID    uuid.UUID                   `json:"-"`
+	Language      *string                     `json:"language,omitempty"`
+	Body          *string                     `json:"body,omitempty"`
+	MediaPath     *string                     `json:"mediaPath,omitempty"`
+	MediaType     *string                     `json:"mediaType,omitempty"`
+	MediaMimeType *string                     `json:"mediaMimeType,omitempty"`
+	MediaFileName *string                     `json:"mediaFileName,omitempty"`
+	Variants      map[string]template.Variant `json:"variants,omitempty"`
+}
+
+// UpdateResponse representa a resposta da atualização de template
+type UpdateResponse struct {
+	Template          *template.Template `json:"template"`
+	RequiredVariables []string           `json:"requiredVariables"`
+	Message           string             `json:"message"`
+}
+
+// Execute executa o caso de uso de atualização de template
+func (uc *UpdateUseCase) Execute(ctx context.Context, req *UpdateRequest) (*UpdateResponse, error) {
+	tmpl, err := uc.templateRepo.GetByID(ctx, req.TemplateID)
+	if err != nil {
+		if err == template.ErrTemplateNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Msg("Erro ao buscar template")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	// Aplicar apenas os campos informados
+	if req.Language != nil && *req.Language != "" {
+		tmpl.Language = *req.Language
+	}
+	if req.Body != nil {
+		if strings.TrimSpace(*req.Body) == "" {
+			return nil, template.ErrEmptyTemplateBody
+		}
+		tmpl.Body = *req.Body
+	}
+	if req.MediaPath != nil {
+		tmpl.MediaPath = *req.MediaPath
+	}
+	if req.MediaType != nil {
+		tmpl.MediaType = *req.MediaType
+	}
+	if req.MediaMimeType != nil {
+		tmpl.MediaMimeType = *req.MediaMimeType
+	}
+	if req.MediaFileName != nil {
+		tmpl.MediaFileName = *req.MediaFileName
+	}
+	if req.Variants != nil {
+		tmpl.Variants = req.Variants
+	}
+	if err := tmpl.ValidateMediaPaths(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Update(ctx, tmpl); err != nil {
+		uc.logger.Error().Err(err).Str("template_id", tmpl.ID.String()).Msg("Erro ao atualizar template")
+		return nil, fmt.Errorf("erro ao atualizar template: %w", err)
+	}
+
+	return &UpdateResponse{
+		Template:          tmpl,
+		RequiredVariables: tmpl.RequiredVariables(),
+		Message:           "Template atualizado com sucesso",
+	}, nil
+}
@@ -0,0 +1,74 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+)
+
+// CreateTenantUseCase representa o caso de uso para criar tenant
+type CreateTenantUseCase struct {
+	tenantRepo tenant.Repository
+	logger     *logger.Logger
+}
+
+// NewCreateTenantUseCase cria uma nova instância do caso de uso
+func NewCreateTenantUseCase(tenantRepo tenant.Repository) *CreateTenantUseCase {
+	return &CreateTenantUseCase{
+		tenantRepo: tenantRepo,
+		logger:     logger.Get(),
+	}
+}
+
+// CreateTenantRequest representa a requisição para criar tenant
+type CreateTenantRequest struct {
+	ID                string `json:"id" validate:"required,max=100"`
+	Name              string `json:"name" validate:"required,max=100"`
+	MaxSessions       int    `json:"maxSessions,omitempty"`
+	MaxMessagesPerDay int    `json:"maxMessagesPerDay,omitempty"`
+	MaxStorageBytes   int64  `json:"maxStorageBytes,omitempty"`
+
+	MediaRetention *media.RetentionPolicy `json:"mediaRetention,omitempty"`
+}
+
+// TenantResponse representa a resposta das operações sobre um tenant
+type TenantResponse struct {
+	Tenant  *tenant.Tenant `json:"tenant"`
+	Message string         `json:"message"`
+}
+
+// Execute executa o caso de uso de criação de tenant
+func (uc *CreateTenantUseCase) Execute(ctx context.Context, req *CreateTenantRequest) (*TenantResponse, error) {
+	newTenant := tenant.NewTenant(req.ID, req.Name)
+	newTenant.MaxSessions = req.MaxSessions
+	newTenant.MaxMessagesPerDay = req.MaxMessagesPerDay
+	newTenant.MaxStorageBytes = req.MaxStorageBytes
+	if !req.MediaRetention.IsEmpty() {
+		newTenant.MediaRetention = req.MediaRetention
+	}
+
+	if err := newTenant.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.tenantRepo.Create(ctx, newTenant); err != nil {
+		if err == tenant.ErrTenantAlreadyExists {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("tenant_id", newTenant.ID).Msg("Erro ao criar tenant")
+		return nil, fmt.Errorf("erro ao criar tenant: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("tenant_id", newTenant.ID).
+		Str("tenant_name", newTenant.Name).
+		Msg("Tenant criado com sucesso")
+
+	return &TenantResponse{
+		Tenant:  newTenant,
+		Message: "Tenant criado com sucesso",
+	}, nil
+}
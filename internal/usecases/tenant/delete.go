@@ -0,0 +1,41 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+)
+
+// DeleteTenantUseCase representa o caso de uso para remover tenant
+type DeleteTenantUseCase struct {
+	tenantRepo tenant.Repository
+	logger     *logger.Logger
+}
+
+// NewDeleteTenantUseCase cria uma nova instância do caso de uso
+func NewDeleteTenantUseCase(tenantRepo tenant.Repository) *DeleteTenantUseCase {
+	return &DeleteTenantUseCase{
+		tenantRepo: tenantRepo,
+		logger:     logger.Get(),
+	}
+}
+
+// Execute executa o caso de uso de remoção; tenants com sessões ou chaves são recusados
+func (uc *DeleteTenantUseCase) Execute(ctx context.Context, tenantID string) error {
+	if tenantID == tenant.DefaultTenantID {
+		return tenant.ErrDefaultTenant
+	}
+
+	if err := uc.tenantRepo.Delete(ctx, tenantID); err != nil {
+		if err == tenant.ErrTenantNotFound || err == tenant.ErrTenantNotEmpty {
+			return err
+		}
+		uc.logger.Error().Err(err).Str("tenant_id", tenantID).Msg("Erro ao deletar tenant")
+		return fmt.Errorf("erro ao deletar tenant: %w", err)
+	}
+
+	uc.logger.Info().Str("tenant_id", tenantID).Msg("Tenant removido com sucesso")
+	return nil
+}
@@ -0,0 +1,57 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+)
+
+// ListTenantsUseCase representa o caso de uso para listar e consultar tenants
+type ListTenantsUseCase struct {
+	tenantRepo tenant.Repository
+	logger     *logger.Logger
+}
+
+// NewListTenantsUseCase cria uma nova instância do caso de uso
+func NewListTenantsUseCase(tenantRepo tenant.Repository) *ListTenantsUseCase {
+	return &ListTenantsUseCase{
+		tenantRepo: tenantRepo,
+		logger:     logger.Get(),
+	}
+}
+
+// ListTenantsResponse representa a resposta da listagem de tenants
+type ListTenantsResponse struct {
+	Tenants []*tenant.Tenant `json:"tenants"`
+	Total   int              `json:"total"`
+}
+
+// Execute executa o caso de uso de listagem de tenants
+func (uc *ListTenantsUseCase) Execute(ctx context.Context) (*ListTenantsResponse, error) {
+	tenants, err := uc.tenantRepo.List(ctx)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Erro ao listar tenants")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return &ListTenantsResponse{
+		Tenants: tenants,
+		Total:   len(tenants),
+	}, nil
+}
+
+// Get busca um tenant pelo ID
+func (uc *ListTenantsUseCase) Get(ctx context.Context, tenantID string) (*tenant.Tenant, error) {
+	t, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if err == tenant.ErrTenantNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("tenant_id", tenantID).Msg("Erro ao buscar tenant")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return t, nil
+}
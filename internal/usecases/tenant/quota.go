@@ -0,0 +1,212 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zapcore/internal/domain/session"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// QuotaUseCase aplica os limites de sessões, mensagens diárias e armazenamento dos tenants
+type QuotaUseCase struct {
+	tenantRepo  tenant.Repository
+	usageRepo   tenant.UsageRepository
+	sessionRepo session.Repository
+	logger      *logger.Logger
+}
+
+// NewQuotaUseCase cria uma nova instância do caso de uso
+func NewQuotaUseCase(tenantRepo tenant.Repository, usageRepo tenant.UsageRepository, sessionRepo session.Repository) *QuotaUseCase {
+	return &QuotaUseCase{
+		tenantRepo:  tenantRepo,
+		usageRepo:   usageRepo,
+		sessionRepo: sessionRepo,
+		logger:      logger.Get(),
+	}
+}
+
+// Usage retorna o consumo atual do tenant frente aos seus limites
+func (uc *QuotaUseCase) Usage(ctx context.Context, tenantID string) (*tenant.Usage, error) {
+	owner, err := uc.getTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.usageRepo.CountSessions(ctx, tenantID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("tenant_id", tenantID).Msg("Erro ao contar sessões do tenant")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	messages, err := uc.usageRepo.MessagesSent(ctx, tenantID, tenant.UsageDay(time.Now()))
+	if err != nil {
+		uc.logger.Error().Err(err).Str("tenant_id", tenantID).Msg("Erro ao buscar mensagens enviadas pelo tenant")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	storage, err := uc.usageRepo.StorageBytes(ctx, tenantID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("tenant_id", tenantID).Msg("Erro ao calcular armazenamento do tenant")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	return &tenant.Usage{
+		TenantID:          owner.ID,
+		Sessions:          sessions,
+		MaxSessions:       owner.MaxSessions,
+		MessagesToday:     messages,
+		MaxMessagesPerDay: owner.MaxMessagesPerDay,
+		StorageBytes:      storage,
+		MaxStorageBytes:   owner.MaxStorageBytes,
+	}, nil
+}
+
+// CheckSessionLimit verifica se o tenant pode criar mais uma sessão
+func (uc *QuotaUseCase) CheckSessionLimit(ctx context.Context, tenantID string) error {
+	owner, err := uc.getTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if !owner.IsActive {
+		return tenant.ErrTenantInactive
+	}
+	if owner.MaxSessions == 0 {
+		return nil
+	}
+
+	sessions, err := uc.usageRepo.CountSessions(ctx, tenantID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("tenant_id", tenantID).Msg("Erro ao contar sessões do tenant")
+		return fmt.Errorf("erro interno do servidor")
+	}
+
+	if sessions >= owner.MaxSessions {
+		uc.logger.Warn().
+			Str("tenant_id", tenantID).
+			Int("sessions", sessions).
+			Int("max_sessions", owner.MaxSessions).
+			Msg("Limite de sessões do tenant atingido")
+		return tenant.ErrSessionLimitReached
+	}
+
+	return nil
+}
+
+// ReserveMessage contabiliza um envio da sessão na cota diária do seu tenant.
+// O contador é incrementado antes da verificação para que envios concorrentes não ultrapassem o limite.
+func (uc *QuotaUseCase) ReserveMessage(ctx context.Context, sess *session.Session) error {
+	owner, err := uc.getTenant(ctx, sess.TenantID)
+	if err != nil {
+		return err
+	}
+	if !owner.IsActive {
+		return tenant.ErrTenantInactive
+	}
+
+	day := tenant.UsageDay(time.Now())
+	total, err := uc.usageRepo.IncrementMessages(ctx, owner.ID, day, 1)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("tenant_id", owner.ID).Msg("Erro ao contabilizar mensagem do tenant")
+		return fmt.Errorf("erro interno do servidor")
+	}
+
+	if owner.MaxMessagesPerDay > 0 && total > owner.MaxMessagesPerDay {
+		uc.release(ctx, owner.ID, day)
+		uc.logger.Warn().
+			Str("tenant_id", owner.ID).
+			Str("session_id", sess.ID.String()).
+			Int("max_messages_per_day", owner.MaxMessagesPerDay).
+			Msg("Cota diária de mensagens do tenant esgotada")
+		return tenant.ErrMessageQuotaExceeded
+	}
+
+	return nil
+}
+
+// ReserveSessionMessage resolve a sessão pelo ID e contabiliza um envio na cota do seu tenant,
+// para os envios automáticos que não passam pelos casos de uso de mensagens. A sessão retornada
+// é usada em ReleaseMessage quando o envio não chega a ser feito.
+func (uc *QuotaUseCase) ReserveSessionMessage(ctx context.Context, sessionID uuid.UUID) (*session.Session, error) {
+	sess, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao buscar sessão")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	if err := uc.ReserveMessage(ctx, sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// ReleaseMessage devolve à cota um envio reservado que não chegou a ser feito
+func (uc *QuotaUseCase) ReleaseMessage(ctx context.Context, sess *session.Session) {
+	uc.release(ctx, sess.TenantID, tenant.UsageDay(time.Now()))
+}
+
+// CheckStorage resolve o tenant da sessão e verifica se a mídia cabe na sua cota de armazenamento
+func (uc *QuotaUseCase) CheckStorage(ctx context.Context, sessionID uuid.UUID, size int64) (string, error) {
+	sess, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if err == session.ErrSessionNotFound {
+			return "", err
+		}
+		uc.logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Erro ao buscar sessão")
+		return "", fmt.Errorf("erro interno do servidor")
+	}
+
+	owner, err := uc.getTenant(ctx, sess.TenantID)
+	if err != nil {
+		return "", err
+	}
+	if owner.MaxStorageBytes == 0 {
+		return owner.ID, nil
+	}
+
+	used, err := uc.usageRepo.StorageBytes(ctx, owner.ID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("tenant_id", owner.ID).Msg("Erro ao calcular armazenamento do tenant")
+		return "", fmt.Errorf("erro interno do servidor")
+	}
+
+	if used+size > owner.MaxStorageBytes {
+		uc.logger.Warn().
+			Str("tenant_id", owner.ID).
+			Str("session_id", sessionID.String()).
+			Int64("storage_bytes", used).
+			Int64("media_bytes", size).
+			Int64("max_storage_bytes", owner.MaxStorageBytes).
+			Msg("Cota de armazenamento do tenant esgotada")
+		return owner.ID, tenant.ErrStorageQuotaExceeded
+	}
+
+	return owner.ID, nil
+}
+
+// getTenant busca o tenant, mapeando erros de infraestrutura para erro interno
+func (uc *QuotaUseCase) getTenant(ctx context.Context, tenantID string) (*tenant.Tenant, error) {
+	owner, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if err == tenant.ErrTenantNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("tenant_id", tenantID).Msg("Erro ao buscar tenant")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+	return owner, nil
+}
+
+// release desfaz um incremento do contador diário
+func (uc *QuotaUseCase) release(ctx context.Context, tenantID string, day time.Time) {
+	if _, err := uc.usageRepo.IncrementMessages(ctx, tenantID, day, -1); err != nil {
+		uc.logger.Warn().Err(err).Str("tenant_id", tenantID).Msg("Erro ao devolver mensagem à cota do tenant")
+	}
+}
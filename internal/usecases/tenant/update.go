@@ -0,0 +1,95 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zapcore/internal/domain/media"
+	"zapcore/internal/domain/tenant"
+	"zapcore/pkg/logger"
+)
+
+// UpdateTenantUseCase representa o caso de uso para atualizar tenant
+type UpdateTenantUseCase struct {
+	tenantRepo tenant.Repository
+	logger     *logger.Logger
+}
+
+// NewUpdateTenantUseCase cria uma nova instância do caso de uso
+func NewUpdateTenantUseCase(tenantRepo tenant.Repository) *UpdateTenantUseCase {
+	return &UpdateTenantUseCase{
+		tenantRepo: tenantRepo,
+		logger:     logger.Get(),
+	}
+}
+
+// UpdateTenantRequest representa a requisição para atualizar tenant
+type UpdateTenantRequest struct {
+	TenantID          string  `json:"-"`
+	Name              *string `json:"name,omitempty"`
+	MaxSessions       *int    `json:"maxSessions,omitempty"`
+	MaxMessagesPerDay *int    `json:"maxMessagesPerDay,omitempty"`
+	MaxStorageBytes   *int64  `json:"maxStorageBytes,omitempty"`
+	IsActive          *bool   `json:"isActive,omitempty"`
+
+	// Regras vazias removem a política do tenant, que volta aos padrões da configuração
+	MediaRetention *media.RetentionPolicy `json:"mediaRetention,omitempty"`
+}
+
+// Execute executa o caso de uso de atualização de tenant
+func (uc *UpdateTenantUseCase) Execute(ctx context.Context, req *UpdateTenantRequest) (*TenantResponse, error) {
+	t, err := uc.tenantRepo.GetByID(ctx, req.TenantID)
+	if err != nil {
+		if err == tenant.ErrTenantNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("tenant_id", req.TenantID).Msg("Erro ao buscar tenant")
+		return nil, fmt.Errorf("erro interno do servidor")
+	}
+
+	// Aplicar apenas os campos informados
+	if req.Name != nil {
+		t.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.MaxSessions != nil {
+		t.MaxSessions = *req.MaxSessions
+	}
+	if req.MaxMessagesPerDay != nil {
+		t.MaxMessagesPerDay = *req.MaxMessagesPerDay
+	}
+	if req.MaxStorageBytes != nil {
+		t.MaxStorageBytes = *req.MaxStorageBytes
+	}
+	if req.IsActive != nil {
+		t.IsActive = *req.IsActive
+	}
+	if req.MediaRetention != nil {
+		t.MediaRetention = req.MediaRetention
+		if req.MediaRetention.IsEmpty() {
+			t.MediaRetention = nil
+		}
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.tenantRepo.Update(ctx, t); err != nil {
+		if err == tenant.ErrTenantNotFound {
+			return nil, err
+		}
+		uc.logger.Error().Err(err).Str("tenant_id", t.ID).Msg("Erro ao atualizar tenant")
+		return nil, fmt.Errorf("erro ao atualizar tenant: %w", err)
+	}
+
+	uc.logger.Info().
+		Str("tenant_id", t.ID).
+		Bool("is_active", t.IsActive).
+		Msg("Tenant atualizado com sucesso")
+
+	return &TenantResponse{
+		Tenant:  t,
+		Message: "Tenant atualizado com sucesso",
+	}, nil
+}
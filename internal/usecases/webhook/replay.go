@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"zapcore/internal/domain/eventstream"
+	"zapcore/pkg/logger"
+)
+
+const (
+	// replayBatchSize é a quantidade de eventos lidos do log por consulta
+	replayBatchSize = 100
+
+	// DefaultReplayTimeout limita cada requisição ao webhook
+	DefaultReplayTimeout = 15 * time.Second
+)
+
+// ReplayUseCase reenvia para uma URL os eventos persistidos no log de eventos
+type ReplayUseCase struct {
+	eventLog   eventstream.Log
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewReplayUseCase cria uma nova instância do caso de uso
+func NewReplayUseCase(eventLog eventstream.Log, httpClient *http.Client) *ReplayUseCase {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultReplayTimeout}
+	}
+	return &ReplayUseCase{
+		eventLog:   eventLog,
+		httpClient: httpClient,
+		logger:     logger.Get(),
+	}
+}
+
+// ReplayRequest representa a requisição de reenvio de eventos
+type ReplayRequest struct {
+	URL      string             `json:"url" validate:"required,url"`
+	Filter   eventstream.Filter `json:"filter"`
+	AfterSeq int64              `json:"afterSeq"`
+	Limit    int                `json:"limit,omitempty"`
+	DryRun   bool               `json:"dryRun,omitempty"`
+}
+
+// ReplayResponse representa o resultado do reenvio.
+// LastSeq é o último evento entregue e pode ser usado como AfterSeq para retomar.
+type ReplayResponse struct {
+	Sent    int    `json:"sent"`
+	LastSeq int64  `json:"lastSeq"`
+	Message string `json:"message"`
+}
+
+// Execute reenvia os eventos em ordem, parando na primeira falha de entrega
+func (uc *ReplayUseCase) Execute(ctx context.Context, req *ReplayRequest) (*ReplayResponse, error) {
+	response := &ReplayResponse{LastSeq: req.AfterSeq}
+
+	for req.Limit <= 0 || response.Sent < req.Limit {
+		batch := replayBatchSize
+		if req.Limit > 0 && req.Limit-response.Sent < batch {
+			batch = req.Limit - response.Sent
+		}
+
+		events, err := uc.eventLog.Since(ctx, response.LastSeq, req.Filter, batch)
+		if err != nil {
+			return response, err
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			if !req.DryRun {
+				if err := uc.deliver(ctx, req.URL, event); err != nil {
+					uc.logger.Error().Err(err).Int64("seq", event.Seq).Str("url", req.URL).Msg("Falha ao reenviar evento")
+					response.Message = fmt.Sprintf("Reenvio interrompido no evento %d", event.Seq)
+					return response, err
+				}
+			}
+			response.Sent++
+			response.LastSeq = event.Seq
+		}
+	}
+
+	response.Message = "Eventos reenviados com sucesso"
+	if req.DryRun {
+		response.Message = "Simulação concluída, nenhum evento enviado"
+	}
+
+	uc.logger.Info().
+		Int("sent", response.Sent).
+		Int64("last_seq", response.LastSeq).
+		Bool("dry_run", req.DryRun).
+		Msg("Reenvio de eventos concluído")
+
+	return response, nil
+}
+
+// deliver envia um evento para o webhook
+func (uc *ReplayUseCase) deliver(ctx context.Context, url string, event *eventstream.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar evento: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Zapcore-Event", event.Type)
+	httpReq.Header.Set("X-Zapcore-Event-Id", strconv.FormatInt(event.Seq, 10))
+	httpReq.Header.Set("X-Zapcore-Replay", "true")
+
+	resp, err := uc.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook respondeu com status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -9,8 +10,31 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Campos de correlação com o tracing
+const (
+	TraceIDField = "trace_id"
+	SpanIDField  = "span_id"
+)
+
+// TraceHook adiciona trace_id e span_id aos eventos que carregam um contexto com span
+type TraceHook struct{}
+
+// Run implementa zerolog.Hook
+func (TraceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	ctx := e.GetCtx()
+	if ctx == nil {
+		return
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return
+	}
+	e.Str(TraceIDField, spanContext.TraceID().String()).Str(SpanIDField, spanContext.SpanID().String())
+}
+
 // Logger encapsula o zerolog com configurações padronizadas
 type Logger struct {
 	logger zerolog.Logger
@@ -52,6 +76,9 @@ func New(config Config) *Logger {
 		}
 	}
 
+	// Eventos com contexto (.Ctx(ctx)) recebem trace_id/span_id do span ativo
+	zeroLogger = zeroLogger.Hook(TraceHook{})
+
 	return &Logger{
 		logger: zeroLogger,
 	}
@@ -192,6 +219,20 @@ func (l *Logger) WithStatus(status string) *Logger {
 	}
 }
 
+// WithTrace adiciona trace_id e span_id do span presente no contexto
+func (l *Logger) WithTrace(ctx context.Context) *Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return l
+	}
+	return &Logger{
+		logger: l.logger.With().
+			Str(TraceIDField, spanContext.TraceID().String()).
+			Str(SpanIDField, spanContext.SpanID().String()).
+			Logger(),
+	}
+}
+
 // WithError adiciona erro ao contexto do logger
 func (l *Logger) WithError(err error) *Logger {
 	return &Logger{
@@ -289,6 +330,11 @@ func WithStatus(status string) *Logger {
 	return Get().WithStatus(status)
 }
 
+// WithTrace adiciona trace_id e span_id ao logger global
+func WithTrace(ctx context.Context) *Logger {
+	return Get().WithTrace(ctx)
+}
+
 // WithError adiciona erro ao logger global
 func WithError(err error) *Logger {
 	return Get().WithError(err)
@@ -0,0 +1,166 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName é o nome do tracer usado por toda a aplicação
+const TracerName = "zapcore"
+
+// Exportadores suportados
+const (
+	ExporterNone     = "none"
+	ExporterStdout   = "stdout"
+	ExporterFile     = "file"
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+)
+
+// Config representa as configurações de tracing
+type Config struct {
+	Exporter       string  // none, stdout, file, otlp-grpc, otlp-http
+	Endpoint       string  // host:porta do coletor OTLP
+	Insecure       bool    // OTLP sem TLS
+	FilePath       string  // arquivo do exportador file (JSON por linha)
+	SampleRatio    float64 // fração de traces raiz amostrados (0 a 1)
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+}
+
+// ShutdownFunc descarrega os spans pendentes e encerra o exportador
+type ShutdownFunc func(ctx context.Context) error
+
+// Init configura o TracerProvider global e a propagação W3C (traceparent/baggage).
+// Com o exportador "none" apenas a propagação é configurada e os spans não são gravados.
+func Init(ctx context.Context, config Config) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter := strings.ToLower(strings.TrimSpace(config.Exporter))
+	if exporter == "" || exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	spanExporter, closer, err := newExporter(ctx, exporter, config)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+		semconv.ServiceVersion(config.ServiceVersion),
+		semconv.DeploymentEnvironmentName(config.Environment),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar resource de tracing: %w", err)
+	}
+
+	ratio := config.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// newExporter cria o exportador de spans configurado
+func newExporter(ctx context.Context, exporter string, config Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch exporter {
+	case ExporterStdout:
+		spanExporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return spanExporter, nil, err
+
+	case ExporterFile:
+		if err := os.MkdirAll(filepath.Dir(config.FilePath), 0755); err != nil {
+			return nil, nil, fmt.Errorf("erro ao criar diretório de traces: %w", err)
+		}
+		file, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao abrir arquivo de traces: %w", err)
+		}
+		spanExporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return spanExporter, file, nil
+
+	case ExporterOTLPGRPC:
+		options := []otlptracegrpc.Option{}
+		if config.Endpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		spanExporter, err := otlptracegrpc.New(ctx, options...)
+		return spanExporter, nil, err
+
+	case ExporterOTLPHTTP:
+		options := []otlptracehttp.Option{}
+		if config.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		spanExporter, err := otlptracehttp.New(ctx, options...)
+		return spanExporter, nil, err
+
+	default:
+		return nil, nil, fmt.Errorf("exportador de tracing desconhecido: %s", exporter)
+	}
+}
+
+// Tracer retorna o tracer da aplicação
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Start inicia um span filho do span presente no contexto
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End registra o erro, se houver, e finaliza o span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}